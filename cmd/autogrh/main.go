@@ -15,6 +15,9 @@ func main() {
 	_ = godotenv.Load()
 
 	app := Bootstrap.Load()
+	if err := Bootstrap.ConfigurarCriptografia(app); err != nil {
+		log.Fatal(err)
+	}
 	if err := Bootstrap.ConnectDB(); err != nil {
 		log.Fatal(err)
 	}
@@ -32,11 +35,23 @@ func main() {
 	folhaCtl := Bootstrap.BuildFolhaPagamentoService(auth)
 	pagamentoCtl := Bootstrap.BuildPagamentoService(auth)
 	avisoSvc := Bootstrap.BuildAvisoService(auth)
+	pagamentoFeriasSvc := Bootstrap.BuildPagamentoFeriasService(auth)
+	regraAusenciaSvc := Bootstrap.BuildRegraAusenciaService(auth)
+	calendarioICSSvc := Bootstrap.BuildCalendarioICSService(auth)
+	calendarioSvc := Bootstrap.BuildCalendarioService(auth)
+	pontoSvc := Bootstrap.BuildPontoService(auth)
+	jornadaSvc := Bootstrap.BuildJornadaService(auth)
+	bancoHorasSvc := Bootstrap.BuildBancoHorasService(auth)
+	cargoSvc := Bootstrap.BuildCargoService(auth)
+	centroCustoSvc := Bootstrap.BuildCentroCustoService(auth)
+	buscaSvc := Bootstrap.BuildBuscaService(auth)
+	importacaoSvc := Bootstrap.BuildImportacaoService(auth)
+	dadosPessoaisSvc := Bootstrap.BuildDadosPessoaisService(auth, app)
 
 	// Inicializar workers
-	Bootstrap.InitWorkers(feriasSvc, descansoSvc, salarioRealSvc, funcSvc, faltaSvc, folhaCtl, avisoSvc)
+	Bootstrap.InitWorkers(feriasSvc, descansoSvc, salarioRealSvc, funcSvc, faltaSvc, folhaCtl, avisoSvc, pagamentoFeriasSvc, dadosPessoaisSvc)
 
-	routes := router.New(auth, pessoaSvc, funcSvc, documentoSvc, faltaSvc, feriasSvc, descansoSvc, salarioSvc, salarioRealSvc, valeCtl, folhaCtl, pagamentoCtl, avisoSvc)
+	routes := router.New(auth, pessoaSvc, funcSvc, documentoSvc, faltaSvc, feriasSvc, descansoSvc, salarioSvc, salarioRealSvc, valeCtl, folhaCtl, pagamentoCtl, avisoSvc, pagamentoFeriasSvc, regraAusenciaSvc, calendarioICSSvc, calendarioSvc, pontoSvc, jornadaSvc, bancoHorasSvc, cargoSvc, centroCustoSvc, buscaSvc, importacaoSvc, dadosPessoaisSvc)
 
 	cors := middleware.NewCORS(middleware.CORSConfig{
 
@@ -0,0 +1,34 @@
+// Comando criptografar leva os dados pessoais já gravados ao estado configurado em CRIPTO_*: cifra o
+// texto puro, recifra com a chave atual e grava os índices cegos. Pode ser rodado mais de uma vez.
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/joho/godotenv"
+
+	"AutoGRH/pkg/bootstrap"
+	"AutoGRH/pkg/repository"
+)
+
+func main() {
+	_ = godotenv.Load()
+
+	app := Bootstrap.Load()
+	if err := Bootstrap.ConfigurarCriptografia(app); err != nil {
+		log.Fatal(err)
+	}
+	if err := Bootstrap.ConnectDB(); err != nil {
+		log.Fatal(err)
+	}
+
+	resultado, err := repository.MigrarCriptografia()
+	for _, m := range resultado {
+		fmt.Printf("%-28s cifradas: %d  recifradas: %d  decifradas: %d\n", m.Coluna, m.Cifradas, m.Recifradas, m.Decifradas)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Migração da criptografia concluída")
+}
@@ -2,6 +2,7 @@ package Adapter
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 )
 
 type ValeRepositoryAdapter struct {
@@ -14,6 +15,7 @@ type ValeRepositoryAdapter struct {
 	listPendentes         func() ([]entity.Vale, error)
 	listAprovadosNaoPagos func() ([]entity.Vale, error)
 	listAll               func() ([]entity.Vale, error)
+	buscar                func(q consulta.Consulta) (consulta.Pagina[entity.Vale], error)
 }
 
 // Construtor
@@ -27,6 +29,7 @@ func NewValeRepositoryAdapter(
 	listPendentes func() ([]entity.Vale, error),
 	listAprovadosNaoPagos func() ([]entity.Vale, error),
 	listAll func() ([]entity.Vale, error),
+	buscar func(q consulta.Consulta) (consulta.Pagina[entity.Vale], error),
 ) *ValeRepositoryAdapter {
 	return &ValeRepositoryAdapter{
 		create:                create,
@@ -38,6 +41,7 @@ func NewValeRepositoryAdapter(
 		listPendentes:         listPendentes,
 		listAprovadosNaoPagos: listAprovadosNaoPagos,
 		listAll:               listAll,
+		buscar:                buscar,
 	}
 }
 
@@ -77,3 +81,7 @@ func (a *ValeRepositoryAdapter) ListAprovadosNaoPagos() ([]entity.Vale, error) {
 func (a *ValeRepositoryAdapter) ListAll() ([]entity.Vale, error) {
 	return a.listAll()
 }
+
+func (a *ValeRepositoryAdapter) Buscar(q consulta.Consulta) (consulta.Pagina[entity.Vale], error) {
+	return a.buscar(q)
+}
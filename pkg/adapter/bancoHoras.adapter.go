@@ -0,0 +1,54 @@
+package Adapter
+
+import "AutoGRH/pkg/entity"
+
+type BancoHorasRepositoryAdapter struct {
+	create            func(l *entity.BancoHorasLancamento) error
+	getByID           func(id int64) (*entity.BancoHorasLancamento, error)
+	delete            func(id int64) error
+	listByFuncionario func(funcionarioID int64) ([]*entity.BancoHorasLancamento, error)
+	getConfig         func(funcionarioID int64) (*entity.BancoHorasConfig, error)
+	saveConfig        func(c *entity.BancoHorasConfig) error
+}
+
+func NewBancoHorasRepositoryAdapter(
+	create func(l *entity.BancoHorasLancamento) error,
+	getByID func(id int64) (*entity.BancoHorasLancamento, error),
+	delete func(id int64) error,
+	listByFuncionario func(funcionarioID int64) ([]*entity.BancoHorasLancamento, error),
+	getConfig func(funcionarioID int64) (*entity.BancoHorasConfig, error),
+	saveConfig func(c *entity.BancoHorasConfig) error,
+) *BancoHorasRepositoryAdapter {
+	return &BancoHorasRepositoryAdapter{
+		create:            create,
+		getByID:           getByID,
+		delete:            delete,
+		listByFuncionario: listByFuncionario,
+		getConfig:         getConfig,
+		saveConfig:        saveConfig,
+	}
+}
+
+func (a *BancoHorasRepositoryAdapter) Create(l *entity.BancoHorasLancamento) error {
+	return a.create(l)
+}
+
+func (a *BancoHorasRepositoryAdapter) GetByID(id int64) (*entity.BancoHorasLancamento, error) {
+	return a.getByID(id)
+}
+
+func (a *BancoHorasRepositoryAdapter) Delete(id int64) error {
+	return a.delete(id)
+}
+
+func (a *BancoHorasRepositoryAdapter) ListByFuncionario(funcionarioID int64) ([]*entity.BancoHorasLancamento, error) {
+	return a.listByFuncionario(funcionarioID)
+}
+
+func (a *BancoHorasRepositoryAdapter) GetConfig(funcionarioID int64) (*entity.BancoHorasConfig, error) {
+	return a.getConfig(funcionarioID)
+}
+
+func (a *BancoHorasRepositoryAdapter) SaveConfig(c *entity.BancoHorasConfig) error {
+	return a.saveConfig(c)
+}
@@ -0,0 +1,43 @@
+package Adapter
+
+import (
+	"AutoGRH/pkg/entity"
+	"time"
+)
+
+type CalendarioTokenRepositoryAdapter struct {
+	save              func(t *entity.CalendarioToken) error
+	getByHash         func(hash string) (*entity.CalendarioToken, error)
+	deleteByUsuarioID func(usuarioID int64) error
+	touch             func(id int64, quando time.Time) error
+}
+
+func NewCalendarioTokenRepositoryAdapter(
+	save func(t *entity.CalendarioToken) error,
+	getByHash func(hash string) (*entity.CalendarioToken, error),
+	deleteByUsuarioID func(usuarioID int64) error,
+	touch func(id int64, quando time.Time) error,
+) *CalendarioTokenRepositoryAdapter {
+	return &CalendarioTokenRepositoryAdapter{
+		save:              save,
+		getByHash:         getByHash,
+		deleteByUsuarioID: deleteByUsuarioID,
+		touch:             touch,
+	}
+}
+
+func (a *CalendarioTokenRepositoryAdapter) Save(t *entity.CalendarioToken) error {
+	return a.save(t)
+}
+
+func (a *CalendarioTokenRepositoryAdapter) GetByHash(hash string) (*entity.CalendarioToken, error) {
+	return a.getByHash(hash)
+}
+
+func (a *CalendarioTokenRepositoryAdapter) DeleteByUsuarioID(usuarioID int64) error {
+	return a.deleteByUsuarioID(usuarioID)
+}
+
+func (a *CalendarioTokenRepositoryAdapter) Touch(id int64, quando time.Time) error {
+	return a.touch(id, quando)
+}
@@ -0,0 +1,75 @@
+package Adapter
+
+import "AutoGRH/pkg/entity"
+
+type CargoRepositoryAdapter struct {
+	create            func(c *entity.Cargo) error
+	getByID           func(id int64) (*entity.Cargo, error)
+	getByTitulo       func(titulo string) (*entity.Cargo, error)
+	update            func(c *entity.Cargo) error
+	delete            func(id int64) error
+	list              func() ([]*entity.Cargo, error)
+	emUso             func(id int64) (bool, error)
+	registrar         func(fc *entity.FuncionarioCargo) error
+	listByFuncionario func(funcionarioID int64) ([]*entity.FuncionarioCargo, error)
+}
+
+func NewCargoRepositoryAdapter(
+	create func(c *entity.Cargo) error,
+	getByID func(id int64) (*entity.Cargo, error),
+	getByTitulo func(titulo string) (*entity.Cargo, error),
+	update func(c *entity.Cargo) error,
+	delete func(id int64) error,
+	list func() ([]*entity.Cargo, error),
+	emUso func(id int64) (bool, error),
+	registrar func(fc *entity.FuncionarioCargo) error,
+	listByFuncionario func(funcionarioID int64) ([]*entity.FuncionarioCargo, error),
+) *CargoRepositoryAdapter {
+	return &CargoRepositoryAdapter{
+		create:            create,
+		getByID:           getByID,
+		getByTitulo:       getByTitulo,
+		update:            update,
+		delete:            delete,
+		list:              list,
+		emUso:             emUso,
+		registrar:         registrar,
+		listByFuncionario: listByFuncionario,
+	}
+}
+
+func (a *CargoRepositoryAdapter) Create(c *entity.Cargo) error {
+	return a.create(c)
+}
+
+func (a *CargoRepositoryAdapter) GetByID(id int64) (*entity.Cargo, error) {
+	return a.getByID(id)
+}
+
+func (a *CargoRepositoryAdapter) GetByTitulo(titulo string) (*entity.Cargo, error) {
+	return a.getByTitulo(titulo)
+}
+
+func (a *CargoRepositoryAdapter) Update(c *entity.Cargo) error {
+	return a.update(c)
+}
+
+func (a *CargoRepositoryAdapter) Delete(id int64) error {
+	return a.delete(id)
+}
+
+func (a *CargoRepositoryAdapter) List() ([]*entity.Cargo, error) {
+	return a.list()
+}
+
+func (a *CargoRepositoryAdapter) EmUso(id int64) (bool, error) {
+	return a.emUso(id)
+}
+
+func (a *CargoRepositoryAdapter) Registrar(fc *entity.FuncionarioCargo) error {
+	return a.registrar(fc)
+}
+
+func (a *CargoRepositoryAdapter) ListByFuncionario(funcionarioID int64) ([]*entity.FuncionarioCargo, error) {
+	return a.listByFuncionario(funcionarioID)
+}
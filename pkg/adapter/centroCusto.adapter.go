@@ -0,0 +1,120 @@
+package Adapter
+
+import (
+	"AutoGRH/pkg/entity"
+	"time"
+)
+
+type CentroCustoRepositoryAdapter struct {
+	createCentro        func(c *entity.CentroCusto) error
+	getCentroByID       func(id int64) (*entity.CentroCusto, error)
+	listCentros         func() ([]*entity.CentroCusto, error)
+	updateCentro        func(c *entity.CentroCusto) error
+	deleteCentro        func(id int64) error
+	centroEmUso         func(id int64) (bool, error)
+	createDepartamento  func(d *entity.Departamento) error
+	getDepartamentoByID func(id int64) (*entity.Departamento, error)
+	listDepartamentos   func() ([]*entity.Departamento, error)
+	updateDepartamento  func(d *entity.Departamento) error
+	deleteDepartamento  func(id int64) error
+	departamentoEmUso   func(id int64) (bool, error)
+	saveAlocacoes       func(funcionarioID int64, inicio time.Time, alocacoes []*entity.AlocacaoFuncionario) error
+	deleteAlocacoes     func(funcionarioID int64, inicio time.Time) error
+	listAlocacoes       func(funcionarioID int64) ([]*entity.AlocacaoFuncionario, error)
+}
+
+func NewCentroCustoRepositoryAdapter(
+	createCentro func(c *entity.CentroCusto) error,
+	getCentroByID func(id int64) (*entity.CentroCusto, error),
+	listCentros func() ([]*entity.CentroCusto, error),
+	updateCentro func(c *entity.CentroCusto) error,
+	deleteCentro func(id int64) error,
+	centroEmUso func(id int64) (bool, error),
+	createDepartamento func(d *entity.Departamento) error,
+	getDepartamentoByID func(id int64) (*entity.Departamento, error),
+	listDepartamentos func() ([]*entity.Departamento, error),
+	updateDepartamento func(d *entity.Departamento) error,
+	deleteDepartamento func(id int64) error,
+	departamentoEmUso func(id int64) (bool, error),
+	saveAlocacoes func(funcionarioID int64, inicio time.Time, alocacoes []*entity.AlocacaoFuncionario) error,
+	deleteAlocacoes func(funcionarioID int64, inicio time.Time) error,
+	listAlocacoes func(funcionarioID int64) ([]*entity.AlocacaoFuncionario, error),
+) *CentroCustoRepositoryAdapter {
+	return &CentroCustoRepositoryAdapter{
+		createCentro:        createCentro,
+		getCentroByID:       getCentroByID,
+		listCentros:         listCentros,
+		updateCentro:        updateCentro,
+		deleteCentro:        deleteCentro,
+		centroEmUso:         centroEmUso,
+		createDepartamento:  createDepartamento,
+		getDepartamentoByID: getDepartamentoByID,
+		listDepartamentos:   listDepartamentos,
+		updateDepartamento:  updateDepartamento,
+		deleteDepartamento:  deleteDepartamento,
+		departamentoEmUso:   departamentoEmUso,
+		saveAlocacoes:       saveAlocacoes,
+		deleteAlocacoes:     deleteAlocacoes,
+		listAlocacoes:       listAlocacoes,
+	}
+}
+
+func (a *CentroCustoRepositoryAdapter) CreateCentro(c *entity.CentroCusto) error {
+	return a.createCentro(c)
+}
+
+func (a *CentroCustoRepositoryAdapter) GetCentroByID(id int64) (*entity.CentroCusto, error) {
+	return a.getCentroByID(id)
+}
+
+func (a *CentroCustoRepositoryAdapter) ListCentros() ([]*entity.CentroCusto, error) {
+	return a.listCentros()
+}
+
+func (a *CentroCustoRepositoryAdapter) UpdateCentro(c *entity.CentroCusto) error {
+	return a.updateCentro(c)
+}
+
+func (a *CentroCustoRepositoryAdapter) DeleteCentro(id int64) error {
+	return a.deleteCentro(id)
+}
+
+func (a *CentroCustoRepositoryAdapter) CentroEmUso(id int64) (bool, error) {
+	return a.centroEmUso(id)
+}
+
+func (a *CentroCustoRepositoryAdapter) CreateDepartamento(d *entity.Departamento) error {
+	return a.createDepartamento(d)
+}
+
+func (a *CentroCustoRepositoryAdapter) GetDepartamentoByID(id int64) (*entity.Departamento, error) {
+	return a.getDepartamentoByID(id)
+}
+
+func (a *CentroCustoRepositoryAdapter) ListDepartamentos() ([]*entity.Departamento, error) {
+	return a.listDepartamentos()
+}
+
+func (a *CentroCustoRepositoryAdapter) UpdateDepartamento(d *entity.Departamento) error {
+	return a.updateDepartamento(d)
+}
+
+func (a *CentroCustoRepositoryAdapter) DeleteDepartamento(id int64) error {
+	return a.deleteDepartamento(id)
+}
+
+func (a *CentroCustoRepositoryAdapter) DepartamentoEmUso(id int64) (bool, error) {
+	return a.departamentoEmUso(id)
+}
+
+func (a *CentroCustoRepositoryAdapter) SaveAlocacoes(funcionarioID int64, inicio time.Time, alocacoes []*entity.AlocacaoFuncionario) error {
+	return a.saveAlocacoes(funcionarioID, inicio, alocacoes)
+}
+
+func (a *CentroCustoRepositoryAdapter) DeleteAlocacoes(funcionarioID int64, inicio time.Time) error {
+	return a.deleteAlocacoes(funcionarioID, inicio)
+}
+
+func (a *CentroCustoRepositoryAdapter) ListAlocacoes(funcionarioID int64) ([]*entity.AlocacaoFuncionario, error) {
+	return a.listAlocacoes(funcionarioID)
+}
@@ -2,6 +2,7 @@ package Adapter
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 	"context"
 )
 
@@ -11,6 +12,7 @@ type DocumentoRepositoryAdapter struct {
 	getByID   func(ctx context.Context, id int64) (*entity.Documento, error)
 	list     func(ctx context.Context) ([]*entity.Documento, error)
 	delete   func(ctx context.Context, id int64) error
+	buscar   func(ctx context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Documento], error)
 }
 
 func NewDocumentoRepositoryAdapter(
@@ -19,6 +21,7 @@ func NewDocumentoRepositoryAdapter(
 	getByID func(ctx context.Context, id int64) (*entity.Documento, error),
 	list func(ctx context.Context) ([]*entity.Documento, error),
 	delete func(ctx context.Context, id int64) error,
+	buscar func(ctx context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Documento], error),
 ) *DocumentoRepositoryAdapter {
 	return &DocumentoRepositoryAdapter{
 		create:    create,
@@ -26,6 +29,7 @@ func NewDocumentoRepositoryAdapter(
 		getByID:   getByID,
 		list:      list,
 		delete:    delete,
+		buscar:    buscar,
 	}
 }
 
@@ -50,3 +54,7 @@ func (a *DocumentoRepositoryAdapter) List(ctx context.Context) ([]*entity.Docume
 func (a *DocumentoRepositoryAdapter) Delete(ctx context.Context, id int64) error {
 	return a.delete(ctx, id)
 }
+
+func (a *DocumentoRepositoryAdapter) Buscar(ctx context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Documento], error) {
+	return a.buscar(ctx, q)
+}
@@ -2,6 +2,7 @@ package Adapter
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 )
 
 // FaltaRepositoryAdapter adapta funções do repository para a interface service.FaltaRepository
@@ -12,6 +13,7 @@ type FaltaRepositoryAdapter struct {
 	getByID   func(id int64) (*entity.Falta, error)
 	getByFunc func(funcionarioID int64) ([]*entity.Falta, error)
 	listAll   func() ([]*entity.Falta, error)
+	buscar    func(q consulta.Consulta) (consulta.Pagina[*entity.Falta], error)
 }
 
 func NewFaltaRepositoryAdapter(
@@ -21,6 +23,7 @@ func NewFaltaRepositoryAdapter(
 	getByID func(id int64) (*entity.Falta, error),
 	getByFunc func(funcionarioID int64) ([]*entity.Falta, error),
 	listAll func() ([]*entity.Falta, error),
+	buscar func(q consulta.Consulta) (consulta.Pagina[*entity.Falta], error),
 ) *FaltaRepositoryAdapter {
 	return &FaltaRepositoryAdapter{
 		create:    create,
@@ -29,6 +32,7 @@ func NewFaltaRepositoryAdapter(
 		getByID:   getByID,
 		getByFunc: getByFunc,
 		listAll:   listAll,
+		buscar:    buscar,
 	}
 }
 
@@ -57,3 +61,7 @@ func (a *FaltaRepositoryAdapter) GetFaltasByFuncionarioID(funcionarioID int64) (
 func (a *FaltaRepositoryAdapter) ListAll() ([]*entity.Falta, error) {
 	return a.listAll()
 }
+
+func (a *FaltaRepositoryAdapter) Buscar(q consulta.Consulta) (consulta.Pagina[*entity.Falta], error) {
+	return a.buscar(q)
+}
@@ -0,0 +1,50 @@
+package Adapter
+
+import (
+	"AutoGRH/pkg/entity"
+	"time"
+)
+
+type FeriadoRepositoryAdapter struct {
+	create    func(f *entity.Feriado) error
+	getByID   func(id int64) (*entity.Feriado, error)
+	update    func(f *entity.Feriado) error
+	delete    func(id int64) error
+	listEntre func(inicio, fim time.Time) ([]*entity.Feriado, error)
+}
+
+func NewFeriadoRepositoryAdapter(
+	create func(f *entity.Feriado) error,
+	getByID func(id int64) (*entity.Feriado, error),
+	update func(f *entity.Feriado) error,
+	delete func(id int64) error,
+	listEntre func(inicio, fim time.Time) ([]*entity.Feriado, error),
+) *FeriadoRepositoryAdapter {
+	return &FeriadoRepositoryAdapter{
+		create:    create,
+		getByID:   getByID,
+		update:    update,
+		delete:    delete,
+		listEntre: listEntre,
+	}
+}
+
+func (a *FeriadoRepositoryAdapter) Create(f *entity.Feriado) error {
+	return a.create(f)
+}
+
+func (a *FeriadoRepositoryAdapter) GetByID(id int64) (*entity.Feriado, error) {
+	return a.getByID(id)
+}
+
+func (a *FeriadoRepositoryAdapter) Update(f *entity.Feriado) error {
+	return a.update(f)
+}
+
+func (a *FeriadoRepositoryAdapter) Delete(id int64) error {
+	return a.delete(id)
+}
+
+func (a *FeriadoRepositoryAdapter) ListEntre(inicio, fim time.Time) ([]*entity.Feriado, error) {
+	return a.listEntre(inicio, fim)
+}
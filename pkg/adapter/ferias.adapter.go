@@ -2,6 +2,7 @@ package Adapter
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 	"context"
 )
 
@@ -13,6 +14,7 @@ type FeriasRepositoryAdapter struct {
 	update                   func(f *entity.Ferias) error
 	delete                   func(id int64) error
 	list                     func() ([]*entity.Ferias, error)
+	buscar                   func(q consulta.Consulta) (consulta.Pagina[*entity.Ferias], error)
 }
 
 func NewFeriasRepositoryAdapter(
@@ -22,6 +24,7 @@ func NewFeriasRepositoryAdapter(
 	update func(f *entity.Ferias) error,
 	delete func(id int64) error,
 	list func() ([]*entity.Ferias, error),
+	buscar func(q consulta.Consulta) (consulta.Pagina[*entity.Ferias], error),
 ) *FeriasRepositoryAdapter {
 	return &FeriasRepositoryAdapter{
 		create:                   create,
@@ -30,6 +33,7 @@ func NewFeriasRepositoryAdapter(
 		update:                   update,
 		delete:                   delete,
 		list:                     list,
+		buscar:                   buscar,
 	}
 }
 
@@ -58,3 +62,7 @@ func (a *FeriasRepositoryAdapter) Delete(_ context.Context, id int64) error {
 func (a *FeriasRepositoryAdapter) List(_ context.Context) ([]*entity.Ferias, error) {
 	return a.list()
 }
+
+func (a *FeriasRepositoryAdapter) Buscar(_ context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Ferias], error) {
+	return a.buscar(q)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 )
 
 type FuncionarioRepositoryAdapter struct {
@@ -14,6 +15,7 @@ type FuncionarioRepositoryAdapter struct {
 	listAtivos   func() ([]*entity.Funcionario, error)
 	listInativos func() ([]*entity.Funcionario, error)
 	listTodos    func() ([]*entity.Funcionario, error)
+	buscar       func(q consulta.Consulta) (consulta.Pagina[*entity.Funcionario], error)
 }
 
 func NewFuncionarioRepositoryAdapter(
@@ -24,6 +26,7 @@ func NewFuncionarioRepositoryAdapter(
 	listAtivos func() ([]*entity.Funcionario, error),
 	listInativos func() ([]*entity.Funcionario, error),
 	listTodos func() ([]*entity.Funcionario, error),
+	buscar func(q consulta.Consulta) (consulta.Pagina[*entity.Funcionario], error),
 ) *FuncionarioRepositoryAdapter {
 	return &FuncionarioRepositoryAdapter{
 		create:       create,
@@ -33,6 +36,7 @@ func NewFuncionarioRepositoryAdapter(
 		listAtivos:   listAtivos,
 		listInativos: listInativos,
 		listTodos:    listTodos,
+		buscar:       buscar,
 	}
 }
 
@@ -64,3 +68,7 @@ func (a *FuncionarioRepositoryAdapter) ListInativos(ctx context.Context) ([]*ent
 func (a *FuncionarioRepositoryAdapter) ListTodos(ctx context.Context) ([]*entity.Funcionario, error) {
 	return a.listTodos()
 }
+
+func (a *FuncionarioRepositoryAdapter) Buscar(ctx context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Funcionario], error) {
+	return a.buscar(q)
+}
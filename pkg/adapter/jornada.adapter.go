@@ -0,0 +1,82 @@
+package Adapter
+
+import "AutoGRH/pkg/entity"
+
+type JornadaRepositoryAdapter struct {
+	create            func(j *entity.Jornada) error
+	getByID           func(id int64) (*entity.Jornada, error)
+	update            func(j *entity.Jornada) error
+	delete            func(id int64) error
+	list              func() ([]*entity.Jornada, error)
+	emUso             func(id int64) (bool, error)
+	atribuir          func(a *entity.FuncionarioJornada) error
+	getAtribuicaoByID func(id int64) (*entity.FuncionarioJornada, error)
+	removerAtribuicao func(id int64) error
+	listByFuncionario func(funcionarioID int64) ([]*entity.FuncionarioJornada, error)
+}
+
+func NewJornadaRepositoryAdapter(
+	create func(j *entity.Jornada) error,
+	getByID func(id int64) (*entity.Jornada, error),
+	update func(j *entity.Jornada) error,
+	delete func(id int64) error,
+	list func() ([]*entity.Jornada, error),
+	emUso func(id int64) (bool, error),
+	atribuir func(a *entity.FuncionarioJornada) error,
+	getAtribuicaoByID func(id int64) (*entity.FuncionarioJornada, error),
+	removerAtribuicao func(id int64) error,
+	listByFuncionario func(funcionarioID int64) ([]*entity.FuncionarioJornada, error),
+) *JornadaRepositoryAdapter {
+	return &JornadaRepositoryAdapter{
+		create:            create,
+		getByID:           getByID,
+		update:            update,
+		delete:            delete,
+		list:              list,
+		emUso:             emUso,
+		atribuir:          atribuir,
+		getAtribuicaoByID: getAtribuicaoByID,
+		removerAtribuicao: removerAtribuicao,
+		listByFuncionario: listByFuncionario,
+	}
+}
+
+func (a *JornadaRepositoryAdapter) Create(j *entity.Jornada) error {
+	return a.create(j)
+}
+
+func (a *JornadaRepositoryAdapter) GetByID(id int64) (*entity.Jornada, error) {
+	return a.getByID(id)
+}
+
+func (a *JornadaRepositoryAdapter) Update(j *entity.Jornada) error {
+	return a.update(j)
+}
+
+func (a *JornadaRepositoryAdapter) Delete(id int64) error {
+	return a.delete(id)
+}
+
+func (a *JornadaRepositoryAdapter) List() ([]*entity.Jornada, error) {
+	return a.list()
+}
+
+func (a *JornadaRepositoryAdapter) EmUso(id int64) (bool, error) {
+	return a.emUso(id)
+}
+
+func (a *JornadaRepositoryAdapter) Atribuir(fj *entity.FuncionarioJornada) error {
+	return a.atribuir(fj)
+}
+
+func (a *JornadaRepositoryAdapter) GetAtribuicaoByID(id int64) (*entity.FuncionarioJornada, error) {
+	return a.getAtribuicaoByID(id)
+}
+
+func (a *JornadaRepositoryAdapter) RemoverAtribuicao(id int64) error {
+	return a.removerAtribuicao(id)
+}
+
+func (a *JornadaRepositoryAdapter) ListByFuncionario(funcionarioID int64) ([]*entity.FuncionarioJornada, error) {
+	return a.listByFuncionario(funcionarioID)
+}
@@ -0,0 +1,50 @@
+package Adapter
+
+import (
+	"AutoGRH/pkg/entity"
+	"time"
+)
+
+type PagamentoFeriasRepositoryAdapter struct {
+	getByID         func(id int64) (*entity.PagamentoFerias, error)
+	getByDescansoID func(descansoID int64) (*entity.PagamentoFerias, error)
+	list            func() ([]*entity.PagamentoFerias, error)
+	listByFunc      func(funcionarioID int64) ([]*entity.PagamentoFerias, error)
+	marcarComoPago  func(id int64, data time.Time) error
+}
+
+func NewPagamentoFeriasRepositoryAdapter(
+	getByID func(id int64) (*entity.PagamentoFerias, error),
+	getByDescansoID func(descansoID int64) (*entity.PagamentoFerias, error),
+	list func() ([]*entity.PagamentoFerias, error),
+	listByFunc func(funcionarioID int64) ([]*entity.PagamentoFerias, error),
+	marcarComoPago func(id int64, data time.Time) error,
+) *PagamentoFeriasRepositoryAdapter {
+	return &PagamentoFeriasRepositoryAdapter{
+		getByID:         getByID,
+		getByDescansoID: getByDescansoID,
+		list:            list,
+		listByFunc:      listByFunc,
+		marcarComoPago:  marcarComoPago,
+	}
+}
+
+func (a *PagamentoFeriasRepositoryAdapter) GetByID(id int64) (*entity.PagamentoFerias, error) {
+	return a.getByID(id)
+}
+
+func (a *PagamentoFeriasRepositoryAdapter) GetByDescansoID(descansoID int64) (*entity.PagamentoFerias, error) {
+	return a.getByDescansoID(descansoID)
+}
+
+func (a *PagamentoFeriasRepositoryAdapter) List() ([]*entity.PagamentoFerias, error) {
+	return a.list()
+}
+
+func (a *PagamentoFeriasRepositoryAdapter) ListByFuncionarioID(funcionarioID int64) ([]*entity.PagamentoFerias, error) {
+	return a.listByFunc(funcionarioID)
+}
+
+func (a *PagamentoFeriasRepositoryAdapter) MarcarComoPago(id int64, data time.Time) error {
+	return a.marcarComoPago(id, data)
+}
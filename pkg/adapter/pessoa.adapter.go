@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 )
 
 type PessoaRepositoryAdapter struct {
@@ -16,6 +17,7 @@ type PessoaRepositoryAdapter struct {
 	existsRG   func(rg string) (bool, error)
 	searchNome func(nome string) ([]*entity.Pessoa, error)
 	listAll    func() ([]*entity.Pessoa, error)
+	buscar     func(q consulta.Consulta) (consulta.Pagina[*entity.Pessoa], error)
 }
 
 func NewPessoaRepositoryAdapter(
@@ -28,6 +30,7 @@ func NewPessoaRepositoryAdapter(
 	existsRG func(rg string) (bool, error),
 	searchNome func(nome string) ([]*entity.Pessoa, error),
 	listAll func() ([]*entity.Pessoa, error),
+	buscar func(q consulta.Consulta) (consulta.Pagina[*entity.Pessoa], error),
 ) *PessoaRepositoryAdapter {
 	return &PessoaRepositoryAdapter{
 		create:     create,
@@ -39,6 +42,7 @@ func NewPessoaRepositoryAdapter(
 		existsRG:   existsRG,
 		searchNome: searchNome,
 		listAll:    listAll,
+		buscar:     buscar,
 	}
 }
 
@@ -78,3 +82,7 @@ func (a *PessoaRepositoryAdapter) SearchByNome(ctx context.Context, nome string)
 func (a *PessoaRepositoryAdapter) ListAll(ctx context.Context) ([]*entity.Pessoa, error) {
 	return a.listAll()
 }
+
+func (a *PessoaRepositoryAdapter) Buscar(ctx context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Pessoa], error) {
+	return a.buscar(q)
+}
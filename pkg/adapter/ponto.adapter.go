@@ -0,0 +1,36 @@
+package Adapter
+
+import (
+	"AutoGRH/pkg/entity"
+	"time"
+)
+
+type PontoRepositoryAdapter struct {
+	upsert      func(p *entity.PontoDia) error
+	getDia      func(funcionarioID int64, dia time.Time) (*entity.PontoDia, error)
+	listPeriodo func(funcionarioID int64, inicio, fim time.Time) ([]*entity.PontoDia, error)
+}
+
+func NewPontoRepositoryAdapter(
+	upsert func(p *entity.PontoDia) error,
+	getDia func(funcionarioID int64, dia time.Time) (*entity.PontoDia, error),
+	listPeriodo func(funcionarioID int64, inicio, fim time.Time) ([]*entity.PontoDia, error),
+) *PontoRepositoryAdapter {
+	return &PontoRepositoryAdapter{
+		upsert:      upsert,
+		getDia:      getDia,
+		listPeriodo: listPeriodo,
+	}
+}
+
+func (a *PontoRepositoryAdapter) Upsert(p *entity.PontoDia) error {
+	return a.upsert(p)
+}
+
+func (a *PontoRepositoryAdapter) GetDia(funcionarioID int64, dia time.Time) (*entity.PontoDia, error) {
+	return a.getDia(funcionarioID, dia)
+}
+
+func (a *PontoRepositoryAdapter) ListPeriodo(funcionarioID int64, inicio, fim time.Time) ([]*entity.PontoDia, error) {
+	return a.listPeriodo(funcionarioID, inicio, fim)
+}
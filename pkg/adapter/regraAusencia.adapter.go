@@ -0,0 +1,49 @@
+package Adapter
+
+import (
+	"AutoGRH/pkg/entity"
+)
+
+type RegraAusenciaRepositoryAdapter struct {
+	create  func(r *entity.RegraAusencia) error
+	getByID func(id int64) (*entity.RegraAusencia, error)
+	update  func(r *entity.RegraAusencia) error
+	delete  func(id int64) error
+	list    func() ([]*entity.RegraAusencia, error)
+}
+
+func NewRegraAusenciaRepositoryAdapter(
+	create func(r *entity.RegraAusencia) error,
+	getByID func(id int64) (*entity.RegraAusencia, error),
+	update func(r *entity.RegraAusencia) error,
+	delete func(id int64) error,
+	list func() ([]*entity.RegraAusencia, error),
+) *RegraAusenciaRepositoryAdapter {
+	return &RegraAusenciaRepositoryAdapter{
+		create:  create,
+		getByID: getByID,
+		update:  update,
+		delete:  delete,
+		list:    list,
+	}
+}
+
+func (a *RegraAusenciaRepositoryAdapter) Create(r *entity.RegraAusencia) error {
+	return a.create(r)
+}
+
+func (a *RegraAusenciaRepositoryAdapter) GetByID(id int64) (*entity.RegraAusencia, error) {
+	return a.getByID(id)
+}
+
+func (a *RegraAusenciaRepositoryAdapter) Update(r *entity.RegraAusencia) error {
+	return a.update(r)
+}
+
+func (a *RegraAusenciaRepositoryAdapter) Delete(id int64) error {
+	return a.delete(id)
+}
+
+func (a *RegraAusenciaRepositoryAdapter) List() ([]*entity.RegraAusencia, error) {
+	return a.list()
+}
@@ -4,6 +4,7 @@ import (
 	"AutoGRH/pkg/controller"
 	"AutoGRH/pkg/worker"
 	"context"
+	"fmt"
 	"os"
 	"sort"
 	"strconv"
@@ -15,12 +16,23 @@ import (
 	"AutoGRH/pkg/repository"
 	"AutoGRH/pkg/service"
 	jwtm "AutoGRH/pkg/service/jwt"
+	"AutoGRH/pkg/utils/cripto"
 )
 
 type AppConfig struct {
 	JWTSecret string
 	Auth      service.AuthConfig
 	Perms     service.PermissionMap
+	Retencao  service.RetencaoConfig
+	Cripto    CriptoConfig
+}
+
+// CriptoConfig traz a configuração da criptografia de dados pessoais, validada em ConfigurarCriptografia
+type CriptoConfig struct {
+	Chaves      string   // "1:<base64>,2:<base64>"; vazio desliga a criptografia
+	ChaveAtual  int      // versão usada para cifrar; 0 usa a maior
+	ChaveIndice string   // base64 da chave dos índices cegos
+	Colunas     []string // tabela.coluna; vazio cifra todas as cifráveis
 }
 
 func getenvDefault(k, def string) string {
@@ -125,13 +137,70 @@ func Load() AppConfig {
 		},
 	}
 
+	// LGPD: anos de guarda além do prazo legal e se o worker anonimiza sozinho
+	retencao := service.RetencaoConfig{
+		AnosAdicionais: getenvIntDefault("LGPD_RETENCAO_ANOS_ADICIONAIS", 0),
+		Automatica:     strings.EqualFold(getenvDefault("LGPD_RETENCAO_AUTOMATICA", "false"), "true"),
+	}
+
+	// Criptografia dos dados pessoais no banco
+	criptoCfg := CriptoConfig{
+		Chaves:      os.Getenv("CRIPTO_CHAVES"),
+		ChaveAtual:  getenvIntDefault("CRIPTO_CHAVE_ATUAL", 0),
+		ChaveIndice: os.Getenv("CRIPTO_CHAVE_INDICE"),
+	}
+	for _, col := range strings.Split(os.Getenv("CRIPTO_COLUNAS"), ",") {
+		if col = strings.TrimSpace(col); col != "" {
+			criptoCfg.Colunas = append(criptoCfg.Colunas, col)
+		}
+	}
+
 	return AppConfig{
 		JWTSecret: os.Getenv("JWT_SECRET"),
 		Auth:      cfg,
 		Perms:     perms,
+		Retencao:  retencao,
+		Cripto:    criptoCfg,
 	}
 }
 
+// ConfigurarCriptografia liga a criptografia dos dados pessoais; sem chaves, fica desligada.
+// Deve ser chamada antes de ConnectDB.
+func ConfigurarCriptografia(app AppConfig) error {
+	cc := app.Cripto
+	if strings.TrimSpace(cc.Chaves) == "" {
+		return repository.ConfigurarCriptografia(nil, nil)
+	}
+	chaves, err := cripto.LerChaves(cc.Chaves)
+	if err != nil {
+		return fmt.Errorf("CRIPTO_CHAVES: %w", err)
+	}
+	atual := cc.ChaveAtual
+	if atual == 0 {
+		for v := range chaves {
+			if v > atual {
+				atual = v
+			}
+		}
+	}
+	if cc.ChaveIndice == "" {
+		return fmt.Errorf("CRIPTO_CHAVE_INDICE não definida")
+	}
+	chaveIndice, err := cripto.LerChave(cc.ChaveIndice)
+	if err != nil {
+		return fmt.Errorf("CRIPTO_CHAVE_INDICE: %w", err)
+	}
+	c, err := cripto.NovoCifrador(chaves, atual, chaveIndice)
+	if err != nil {
+		return fmt.Errorf("criptografia: %w", err)
+	}
+	colunas := cc.Colunas
+	if len(colunas) == 0 {
+		colunas = repository.ColunasCifraveis()
+	}
+	return repository.ConfigurarCriptografia(c, colunas)
+}
+
 func ConnectDB() error {
 	// adapte para a sua função concreta, se necessário
 	if repository.DB == nil {
@@ -148,10 +217,13 @@ func InitWorkers(
 	faltaSvc *service.FaltaService,
 	folhaSvc *service.FolhaPagamentoService,
 	avisoSvc *service.AvisoService,
+	pagamentoFeriasSvc *service.PagamentoFeriasService,
+	dadosPessoaisSvc *service.DadosPessoaisService,
 ) {
 	feriasWorker := worker.NewFeriasWorker(
 		feriasSvc,
 		descansoSvc,
+		pagamentoFeriasSvc,
 		salarioRealSvc,
 		funcionarioSvc,
 		faltaSvc,
@@ -163,6 +235,11 @@ func InitWorkers(
 	folhaWorker.Start()
 	feriasWorker.Start()
 	avisosWorker.Start()
+
+	// a anonimização automática só roda se ligada na configuração
+	if dadosPessoaisSvc.Retencao().Automatica {
+		worker.NewRetencaoWorker(dadosPessoaisSvc).Start()
+	}
 }
 
 func BuildAuth(app AppConfig) *service.AuthService {
@@ -189,6 +266,8 @@ func BuildPessoaService(auth *service.AuthService) *service.PessoaService {
 		repository.ExistsPessoaByRG,
 		repository.SearchPessoaByNome,
 		repository.ListPessoas,
+
+		repository.BuscarPessoas,
 	)
 
 	return service.NewPessoaService(auth, logRepo, pessoaRepo)
@@ -208,6 +287,8 @@ func BuildFuncionarioService(auth *service.AuthService) *service.FuncionarioServ
 		repository.ListFuncionariosAtivos,
 		repository.ListFuncionariosInativos,
 		repository.ListTodosFuncionarios,
+
+		repository.BuscarFuncionarios,
 	)
 
 	return service.NewFuncionarioService(auth, logRepo, funcRepo)
@@ -226,6 +307,8 @@ func BuildDocumentoService(auth *service.AuthService) *service.DocumentoService
 		repository.GetByID,
 		repository.ListDocumentos,
 		repository.DeleteDocumento,
+
+		repository.BuscarDocumentos,
 	)
 
 	return service.NewDocumentoService(auth, logRepo, docRepo)
@@ -244,6 +327,8 @@ func BuildFaltaService(auth *service.AuthService) *service.FaltaService {
 		repository.GetFaltaByID,
 		repository.GetFaltasByFuncionarioID,
 		repository.ListAllFaltas,
+
+		repository.BuscarFaltas,
 	)
 
 	return service.NewFaltaService(auth, logRepo, faltaRepo)
@@ -262,6 +347,8 @@ func BuildFeriasService(auth *service.AuthService) *service.FeriasService {
 		repository.UpdateFerias,
 		repository.DeleteFerias,
 		repository.ListFerias,
+
+		repository.BuscarFerias,
 	)
 
 	return service.NewFeriasService(auth, logRepo, repo)
@@ -287,6 +374,135 @@ func BuildDescansoService(auth *service.AuthService) *service.DescansoService {
 	return service.NewDescansoService(auth, logRepo, repo)
 }
 
+// BuildPagamentoFeriasService constrói o serviço de pagamentos de férias (aviso e recibo)
+func BuildPagamentoFeriasService(auth *service.AuthService) *service.PagamentoFeriasService {
+	createLog := func(ctx context.Context, l *entity.Log) (int64, error) {
+		return 0, repository.CreateLog(l)
+	}
+	logRepo := Adapter.NewLogRepositoryAdapter(createLog)
+
+	repo := Adapter.NewPagamentoFeriasRepositoryAdapter(
+		repository.GetPagamentoFeriasByID,
+		repository.GetPagamentoFeriasByDescansoID,
+		repository.ListPagamentosFerias,
+		repository.ListPagamentosFeriasByFuncionarioID,
+		repository.MarcarPagamentoFeriasComoPago,
+	)
+
+	return service.NewPagamentoFeriasService(auth, logRepo, repo)
+}
+
+// BuildRegraAusenciaService constrói o serviço de regras de limite de ausências
+func BuildRegraAusenciaService(auth *service.AuthService) *service.RegraAusenciaService {
+	createLog := func(ctx context.Context, l *entity.Log) (int64, error) {
+		return 0, repository.CreateLog(l)
+	}
+	logRepo := Adapter.NewLogRepositoryAdapter(createLog)
+
+	repo := Adapter.NewRegraAusenciaRepositoryAdapter(
+		repository.CreateRegraAusencia,
+		repository.GetRegraAusenciaByID,
+		repository.UpdateRegraAusencia,
+		repository.DeleteRegraAusencia,
+		repository.ListRegrasAusencia,
+	)
+
+	return service.NewRegraAusenciaService(auth, logRepo, repo)
+}
+
+// BuildCalendarioICSService constrói o serviço de feeds iCalendar (.ics)
+func BuildCalendarioICSService(auth *service.AuthService) *service.CalendarioICSService {
+	createLog := func(ctx context.Context, l *entity.Log) (int64, error) {
+		return 0, repository.CreateLog(l)
+	}
+	logRepo := Adapter.NewLogRepositoryAdapter(createLog)
+
+	repo := Adapter.NewCalendarioTokenRepositoryAdapter(
+		repository.SaveCalendarioToken,
+		repository.GetCalendarioTokenByHash,
+		repository.DeleteCalendarioTokenByUsuarioID,
+		repository.TouchCalendarioToken,
+	)
+
+	return service.NewCalendarioICSService(auth, logRepo, repo)
+}
+
+// BuildCalendarioService constrói o serviço de feriados e dias úteis
+func BuildCalendarioService(auth *service.AuthService) *service.CalendarioService {
+	createLog := func(ctx context.Context, l *entity.Log) (int64, error) {
+		return 0, repository.CreateLog(l)
+	}
+	logRepo := Adapter.NewLogRepositoryAdapter(createLog)
+
+	repo := Adapter.NewFeriadoRepositoryAdapter(
+		repository.CreateFeriado,
+		repository.GetFeriadoByID,
+		repository.UpdateFeriado,
+		repository.DeleteFeriado,
+		repository.ListFeriadosEntre,
+	)
+
+	return service.NewCalendarioService(auth, logRepo, repo)
+}
+
+// BuildPontoService constrói o serviço de importação e apuração do ponto
+func BuildPontoService(auth *service.AuthService) *service.PontoService {
+	createLog := func(ctx context.Context, l *entity.Log) (int64, error) {
+		return 0, repository.CreateLog(l)
+	}
+	logRepo := Adapter.NewLogRepositoryAdapter(createLog)
+
+	repo := Adapter.NewPontoRepositoryAdapter(
+		repository.UpsertPontoDia,
+		repository.GetPontoDia,
+		repository.ListPontoDiasByFuncionarioPeriodo,
+	)
+
+	return service.NewPontoService(auth, logRepo, repo)
+}
+
+// BuildJornadaService constrói o serviço de jornadas de trabalho
+func BuildJornadaService(auth *service.AuthService) *service.JornadaService {
+	createLog := func(ctx context.Context, l *entity.Log) (int64, error) {
+		return 0, repository.CreateLog(l)
+	}
+	logRepo := Adapter.NewLogRepositoryAdapter(createLog)
+
+	repo := Adapter.NewJornadaRepositoryAdapter(
+		repository.CreateJornada,
+		repository.GetJornadaByID,
+		repository.UpdateJornada,
+		repository.DeleteJornada,
+		repository.ListJornadas,
+		repository.JornadaEmUso,
+		repository.CreateFuncionarioJornada,
+		repository.GetFuncionarioJornadaByID,
+		repository.DeleteFuncionarioJornada,
+		repository.ListJornadasByFuncionarioID,
+	)
+
+	return service.NewJornadaService(auth, logRepo, repo)
+}
+
+// BuildBancoHorasService constrói o serviço de banco de horas
+func BuildBancoHorasService(auth *service.AuthService) *service.BancoHorasService {
+	createLog := func(ctx context.Context, l *entity.Log) (int64, error) {
+		return 0, repository.CreateLog(l)
+	}
+	logRepo := Adapter.NewLogRepositoryAdapter(createLog)
+
+	repo := Adapter.NewBancoHorasRepositoryAdapter(
+		repository.CreateLancamentoBancoHoras,
+		repository.GetLancamentoBancoHorasByID,
+		repository.DeleteLancamentoBancoHoras,
+		repository.ListLancamentosBancoHorasByFuncionario,
+		repository.GetBancoHorasConfig,
+		repository.SaveBancoHorasConfig,
+	)
+
+	return service.NewBancoHorasService(auth, logRepo, repo)
+}
+
 func BuildSalarioService(auth *service.AuthService) *service.SalarioService {
 	createLog := func(ctx context.Context, l *entity.Log) (int64, error) {
 		return 0, repository.CreateLog(l)
@@ -337,6 +553,7 @@ func BuildValeService(auth *service.AuthService) *service.ValeService {
 		repository.ListValesPendentes,
 		repository.ListValesAprovadosNaoPagos,
 		repository.ListAllVales,
+		repository.BuscarVales,
 	)
 	return service.NewValeService(valeRepo, auth, logRepo)
 }
@@ -391,5 +608,80 @@ func BuildPagamentoController(auth *service.AuthService) *controller.PagamentoCo
 }
 
 func BuildAvisoService(auth *service.AuthService) *service.AvisoService {
-	return service.NewAvisoService(auth)
+	return service.NewAvisoService(auth, getenvIntDefault("AVISO_EXPERIENCIA_DIAS", 10))
+}
+
+// BuildCargoService constrói o serviço do catálogo de cargos
+func BuildCargoService(auth *service.AuthService) *service.CargoService {
+	createLog := func(ctx context.Context, l *entity.Log) (int64, error) {
+		return 0, repository.CreateLog(l)
+	}
+	logRepo := Adapter.NewLogRepositoryAdapter(createLog)
+
+	repo := Adapter.NewCargoRepositoryAdapter(
+		repository.CreateCargo,
+		repository.GetCargoByID,
+		repository.GetCargoByTitulo,
+		repository.UpdateCargo,
+		repository.DeleteCargo,
+		repository.ListCargos,
+		repository.CargoEmUso,
+		repository.CreateFuncionarioCargo,
+		repository.ListCargosByFuncionarioID,
+	)
+
+	return service.NewCargoService(auth, logRepo, repo)
+}
+
+// BuildCentroCustoService constrói o serviço de centros de custo, departamentos e alocações
+func BuildCentroCustoService(auth *service.AuthService) *service.CentroCustoService {
+	createLog := func(ctx context.Context, l *entity.Log) (int64, error) {
+		return 0, repository.CreateLog(l)
+	}
+	logRepo := Adapter.NewLogRepositoryAdapter(createLog)
+
+	repo := Adapter.NewCentroCustoRepositoryAdapter(
+		repository.CreateCentroCusto,
+		repository.GetCentroCustoByID,
+		repository.ListCentrosCusto,
+		repository.UpdateCentroCusto,
+		repository.DeleteCentroCusto,
+		repository.CentroCustoEmUso,
+		repository.CreateDepartamento,
+		repository.GetDepartamentoByID,
+		repository.ListDepartamentos,
+		repository.UpdateDepartamento,
+		repository.DeleteDepartamento,
+		repository.DepartamentoEmUso,
+		repository.SaveAlocacoes,
+		repository.DeleteAlocacoes,
+		repository.ListAlocacoesByFuncionarioID,
+	)
+
+	return service.NewCentroCustoService(auth, logRepo, repo)
+}
+
+// BuildBuscaService constrói o serviço de busca global
+func BuildBuscaService(auth *service.AuthService) *service.BuscaService {
+	return service.NewBuscaService(auth)
+}
+
+// BuildImportacaoService constrói o serviço de importação em lote
+func BuildImportacaoService(auth *service.AuthService) *service.ImportacaoService {
+	createLog := func(ctx context.Context, l *entity.Log) (int64, error) {
+		return 0, repository.CreateLog(l)
+	}
+	logRepo := Adapter.NewLogRepositoryAdapter(createLog)
+
+	return service.NewImportacaoService(auth, logRepo)
+}
+
+// BuildDadosPessoaisService constrói o serviço de dados pessoais (LGPD) com a política de retenção
+func BuildDadosPessoaisService(auth *service.AuthService, app AppConfig) *service.DadosPessoaisService {
+	createLog := func(ctx context.Context, l *entity.Log) (int64, error) {
+		return 0, repository.CreateLog(l)
+	}
+	logRepo := Adapter.NewLogRepositoryAdapter(createLog)
+
+	return service.NewDadosPessoaisService(auth, logRepo, app.Retencao)
 }
@@ -5,6 +5,8 @@ import (
 	mw "AutoGRH/pkg/controller/middleware"
 	"AutoGRH/pkg/service"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -127,6 +129,8 @@ func (c *PagamentoController) MarcarComoPago(w http.ResponseWriter, r *http.Requ
 	httpjson.WriteJSON(w, http.StatusOK, map[string]string{"message": "Pagamento marcado como pago"})
 }
 
+// ListarPagamentosDaFolha lista os pagamentos da folha; com ?formato=csv|xlsx, baixa a planilha
+// GET /folhas/{id}/pagamentos
 func (c *PagamentoController) ListarPagamentosDaFolha(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	folhaID, err := strconv.ParseInt(idStr, 10, 64)
@@ -140,6 +144,23 @@ func (c *PagamentoController) ListarPagamentosDaFolha(w http.ResponseWriter, r *
 		httpjson.Unauthorized(w, "UNAUTHORIZED", "não autenticado")
 		return
 	}
+	formato, ok := lerFormatoExportacao(w, r)
+	if !ok {
+		return
+	}
+
+	if formato != "" {
+		tabela, err := c.pagamentoService.ExportarPagamentosDaFolha(r.Context(), claims, folhaID)
+		switch {
+		case errors.Is(err, service.ErrFolhaNaoEncontrada):
+			httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "Folha não encontrada", Code: "NOT_FOUND"})
+		case err != nil:
+			httpjson.Internal(w, err.Error())
+		default:
+			escreverPlanilha(w, formato, fmt.Sprintf("folha-%d", folhaID), tabela)
+		}
+		return
+	}
 
 	rows, err := c.pagamentoService.ListarPagamentosDaFolha(r.Context(), claims, folhaID)
 	if err != nil {
@@ -77,21 +77,29 @@ func (c *ValeController) GetVale(w http.ResponseWriter, r *http.Request) {
 	httpjson.WriteJSON(w, http.StatusOK, v)
 }
 
-// ListarVales (todos)
+// ListarVales (todos, paginado)
 func (c *ValeController) ListarVales(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middleware.GetClaims(r.Context())
 	if !ok {
 		httpjson.Unauthorized(w, "NO_CLAIMS", "sem claims")
 		return
 	}
-
-	vales, err := c.valeService.ListarVales(r.Context(), claims)
-	if err != nil {
-		httpjson.Internal(w, err.Error())
+	formato, ok := lerFormatoExportacao(w, r)
+	if !ok {
+		return
+	}
+	q, ok := lerConsulta(w, r)
+	if !ok {
 		return
 	}
+	q.Todos = formato != ""
 
-	httpjson.WriteJSON(w, http.StatusOK, vales)
+	pagina, err := c.valeService.BuscarVales(r.Context(), claims, q)
+	if formato != "" {
+		exportarPagina(w, formato, "vales", pagina, err, service.TabelaVales)
+		return
+	}
+	escreverPagina(w, pagina, err)
 }
 
 // ListarValesFuncionario
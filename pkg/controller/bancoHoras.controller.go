@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type BancoHorasController struct {
+	bancoHorasService *service.BancoHorasService
+}
+
+func NewBancoHorasController(s *service.BancoHorasService) *BancoHorasController {
+	return &BancoHorasController{bancoHorasService: s}
+}
+
+// GET /funcionarios/{id}/banco-horas?ate=YYYY-MM-DD (ate opcional, padrão hoje)
+func (c *BancoHorasController) Extrato(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	var ate time.Time
+	if v := r.URL.Query().Get("ate"); v != "" {
+		if ate, err = dateStringToTime.DateStringToTime(v); err != nil {
+			httpjson.BadRequest(w, "data 'ate' inválida: "+err.Error())
+			return
+		}
+	}
+	extrato, err := c.bancoHorasService.Extrato(r.Context(), claims, funcionarioID, ate)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	if extrato == nil {
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "funcionário não encontrado", Code: "NOT_FOUND"})
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, extrato)
+}
+
+// POST /funcionarios/{id}/banco-horas  (admin)
+func (c *BancoHorasController) Lancar(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	var req struct {
+		Data      string `json:"data"`
+		Tipo      string `json:"tipo"`
+		Minutos   int    `json:"minutos"`
+		Descricao string `json:"descricao"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	data, err := dateStringToTime.DateStringToTime(req.Data)
+	if err != nil {
+		httpjson.BadRequest(w, "data inválida: "+err.Error())
+		return
+	}
+	l := entity.NewBancoHorasLancamento(funcionarioID, data, req.Tipo, req.Minutos, req.Descricao)
+	if err := c.bancoHorasService.Lancar(r.Context(), claims, l); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, l)
+}
+
+// DELETE /funcionarios/{id}/banco-horas/{lancamentoID}  (admin)
+func (c *BancoHorasController) Excluir(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	lancamentoID, err := strconv.ParseInt(chi.URLParam(r, "lancamentoID"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "lancamentoID inválido")
+		return
+	}
+	if err := c.bancoHorasService.ExcluirLancamento(r.Context(), claims, funcionarioID, lancamentoID); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /funcionarios/{id}/banco-horas/folga  (admin)
+func (c *BancoHorasController) Folga(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	var req struct {
+		Data      string `json:"data"`
+		Descricao string `json:"descricao"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	data, err := dateStringToTime.DateStringToTime(req.Data)
+	if err != nil {
+		httpjson.BadRequest(w, "data inválida: "+err.Error())
+		return
+	}
+	l, err := c.bancoHorasService.ConcederFolga(r.Context(), claims, funcionarioID, data, req.Descricao)
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, l)
+}
+
+// PUT /funcionarios/{id}/banco-horas/config  (admin)
+func (c *BancoHorasController) Configurar(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	var cfg entity.BancoHorasConfig
+	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	cfg.FuncionarioID = funcionarioID
+	if err := c.bancoHorasService.Configurar(r.Context(), claims, &cfg); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, cfg)
+}
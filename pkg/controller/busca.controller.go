@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/service"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+type BuscaController struct {
+	svc *service.BuscaService
+}
+
+func NewBuscaController(s *service.BuscaService) *BuscaController {
+	return &BuscaController{svc: s}
+}
+
+// Buscar faz a busca global
+// GET /busca?q=silva&limit=20
+func (c *BuscaController) Buscar(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+
+	limite := service.LimiteBuscaPadrao
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > service.LimiteBuscaMaximo {
+			httpjson.BadRequest(w, fmt.Sprintf("limit deve ser um inteiro entre 1 e %d", service.LimiteBuscaMaximo))
+			return
+		}
+		limite = n
+	}
+
+	resultados, err := c.svc.Buscar(r.Context(), claims, r.URL.Query().Get("q"), limite)
+	if err != nil {
+		if erroValidacao(w, err) {
+			return
+		}
+		httpjson.Internal(w, err.Error())
+		return
+	}
+
+	httpjson.WritePage(w, httpjson.Page{Data: resultados, Total: len(resultados), Limit: limite})
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CalendarioController struct {
+	calendarioService *service.CalendarioService
+}
+
+func NewCalendarioController(s *service.CalendarioService) *CalendarioController {
+	return &CalendarioController{calendarioService: s}
+}
+
+type feriadoRequest struct {
+	Data       string `json:"data"` // YYYY-MM-DD
+	Nome       string `json:"nome"`
+	Tipo       string `json:"tipo"`
+	Localidade string `json:"localidade"`
+	Recorrente bool   `json:"recorrente"`
+}
+
+func (req feriadoRequest) toEntity() (*entity.Feriado, error) {
+	data, err := dateStringToTime.DateStringToTime(req.Data)
+	if err != nil {
+		return nil, err
+	}
+	f := entity.NewFeriado(data, req.Nome, req.Tipo, req.Recorrente)
+	f.Localidade = req.Localidade
+	return f, nil
+}
+
+// GET /calendario?ano=2026 — feriados nacionais, pontos facultativos e cadastrados do ano
+func (c *CalendarioController) ListarAno(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	ano := time.Now().Year()
+	if v := r.URL.Query().Get("ano"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			httpjson.BadRequest(w, "ano inválido")
+			return
+		}
+		ano = n
+	}
+
+	lista, err := c.calendarioService.ListarAno(r.Context(), claims, ano)
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// GET /calendario/dias-uteis?inicio=YYYY-MM-DD&fim=YYYY-MM-DD
+func (c *CalendarioController) DiasUteis(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	q := r.URL.Query()
+	if q.Get("inicio") == "" || q.Get("fim") == "" {
+		httpjson.BadRequest(w, "parâmetros 'inicio' e 'fim' são obrigatórios")
+		return
+	}
+	ini, err := dateStringToTime.DateStringToTime(q.Get("inicio"))
+	if err != nil {
+		httpjson.BadRequest(w, "data 'inicio' inválida: "+err.Error())
+		return
+	}
+	fim, err := dateStringToTime.DateStringToTime(q.Get("fim"))
+	if err != nil {
+		httpjson.BadRequest(w, "data 'fim' inválida: "+err.Error())
+		return
+	}
+
+	dto, err := c.calendarioService.ConsultarDiasUteis(r.Context(), claims, ini, fim)
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, dto)
+}
+
+// POST /calendario  (admin)
+func (c *CalendarioController) Create(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	var req feriadoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	f, err := req.toEntity()
+	if err != nil {
+		httpjson.BadRequest(w, "data inválida: "+err.Error())
+		return
+	}
+
+	if err := c.calendarioService.Criar(r.Context(), claims, f); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, f)
+}
+
+// PUT /calendario/{id}  (admin)
+func (c *CalendarioController) Update(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	var req feriadoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	f, err := req.toEntity()
+	if err != nil {
+		httpjson.BadRequest(w, "data inválida: "+err.Error())
+		return
+	}
+	f.ID = id
+
+	if err := c.calendarioService.Atualizar(r.Context(), claims, f); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, f)
+}
+
+// DELETE /calendario/{id}  (admin)
+func (c *CalendarioController) Delete(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	if err := c.calendarioService.Excluir(r.Context(), claims, id); err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
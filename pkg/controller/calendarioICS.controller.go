@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/service"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CalendarioICSController struct {
+	calendarioService *service.CalendarioICSService
+}
+
+func NewCalendarioICSController(s *service.CalendarioICSService) *CalendarioICSController {
+	return &CalendarioICSController{calendarioService: s}
+}
+
+type calendarioTokenResponse struct {
+	Token string            `json:"token"`
+	Feeds map[string]string `json:"feeds"`
+}
+
+// POST /ics/token — gera (ou substitui) o token de assinatura do usuário logado
+func (c *CalendarioICSController) GerarToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+
+	token, err := c.calendarioService.GerarToken(r.Context(), claims)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+
+	feeds := make(map[string]string, len(service.FeedsCalendario))
+	for _, f := range service.FeedsCalendario {
+		feeds[f] = fmt.Sprintf("/ics/%s/%s.ics", token, f)
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, calendarioTokenResponse{Token: token, Feeds: feeds})
+}
+
+// DELETE /ics/token — revoga o token de assinatura do usuário logado
+func (c *CalendarioICSController) RevogarToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+
+	if err := c.calendarioService.RevogarToken(r.Context(), claims); err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /ics/{token}/{feed}.ics — feed público, autenticado pelo token da URL
+func (c *CalendarioICSController) Feed(w http.ResponseWriter, r *http.Request) {
+	claims, err := c.calendarioService.ClaimsPorToken(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		if errors.Is(err, service.ErrTokenCalendarioInvalido) {
+			httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "Calendário não encontrado", Code: "NOT_FOUND"})
+			return
+		}
+		httpjson.Internal(w, err.Error())
+		return
+	}
+
+	feed := chi.URLParam(r, "feed")
+	ics, err := c.calendarioService.Feed(r.Context(), claims, feed)
+	if err != nil {
+		if errors.Is(err, service.ErrFeedCalendarioInvalido) {
+			httpjson.BadRequest(w, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			httpjson.Forbidden(w, "não autorizado")
+			return
+		}
+		httpjson.Internal(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="autogrh-%s.ics"`, feed))
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(ics)
+}
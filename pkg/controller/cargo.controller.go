@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CargoController struct {
+	cargoService *service.CargoService
+}
+
+func NewCargoController(s *service.CargoService) *CargoController {
+	return &CargoController{cargoService: s}
+}
+
+type cargoRequest struct {
+	Titulo       string  `json:"titulo"`
+	CBO          string  `json:"cbo"`
+	SalarioMin   float64 `json:"salario_min"`
+	SalarioMax   float64 `json:"salario_max"`
+	Departamento string  `json:"departamento"`
+	Ativo        *bool   `json:"ativo"` // só na atualização; padrão: ativo
+}
+
+func (req cargoRequest) toEntity() *entity.Cargo {
+	c := entity.NewCargo(req.Titulo, req.CBO, req.SalarioMin, req.SalarioMax, req.Departamento)
+	if req.Ativo != nil {
+		c.Ativo = *req.Ativo
+	}
+	return c
+}
+
+// GET /cargos
+func (c *CargoController) List(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	lista, err := c.cargoService.ListarCargos(r.Context(), claims)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	if lista == nil {
+		lista = []*entity.Cargo{}
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// GET /cargos/{id}
+func (c *CargoController) Get(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	cargo, err := c.cargoService.BuscarCargo(r.Context(), claims, id)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	if cargo == nil {
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "cargo não encontrado", Code: "NOT_FOUND"})
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, cargo)
+}
+
+// POST /cargos  (admin)
+func (c *CargoController) Create(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	var req cargoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	cargo := req.toEntity()
+	if err := c.cargoService.CriarCargo(r.Context(), claims, cargo); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, cargo)
+}
+
+// PUT /cargos/{id}  (admin)
+func (c *CargoController) Update(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	var req cargoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	cargo := req.toEntity()
+	cargo.ID = id
+	if err := c.cargoService.AtualizarCargo(r.Context(), claims, cargo); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, cargo)
+}
+
+// DELETE /cargos/{id}  (admin)
+func (c *CargoController) Delete(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	if err := c.cargoService.ExcluirCargo(r.Context(), claims, id); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /funcionarios/{id}/cargos — histórico de cargos
+func (c *CargoController) Historico(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	lista, err := c.cargoService.ListarHistorico(r.Context(), claims, funcionarioID)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// POST /funcionarios/{id}/cargos  (admin) — promoção ou mudança de cargo
+// {"cargo_id": 2, "data": "2025-06-01", "salario": 4500, "salario_real": 4500, "observacao": "promoção"}
+func (c *CargoController) Promover(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	var req struct {
+		CargoID     int64    `json:"cargo_id"`
+		Data        string   `json:"data"` // opcional: hoje
+		Observacao  string   `json:"observacao"`
+		Salario     *float64 `json:"salario"`
+		SalarioReal *float64 `json:"salario_real"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	var data time.Time
+	if req.Data != "" {
+		if data, err = dateStringToTime.DateStringToTime(req.Data); err != nil {
+			httpjson.BadRequest(w, "data inválida: "+err.Error())
+			return
+		}
+	}
+	res, err := c.cargoService.PromoverFuncionario(r.Context(), claims, funcionarioID, service.PromocaoInput{
+		CargoID:     req.CargoID,
+		Data:        data,
+		Observacao:  req.Observacao,
+		Salario:     req.Salario,
+		SalarioReal: req.SalarioReal,
+	})
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, res)
+}
@@ -0,0 +1,320 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CentroCustoController struct {
+	centroCustoService *service.CentroCustoService
+}
+
+func NewCentroCustoController(s *service.CentroCustoService) *CentroCustoController {
+	return &CentroCustoController{centroCustoService: s}
+}
+
+type centroCustoRequest struct {
+	Codigo string `json:"codigo"`
+	Nome   string `json:"nome"`
+	Ativo  *bool  `json:"ativo"` // só na atualização; padrão: ativo
+}
+
+type departamentoRequest struct {
+	Nome          string `json:"nome"`
+	CentroCustoID *int64 `json:"centro_custo_id"`
+	Ativo         *bool  `json:"ativo"` // só na atualização; padrão: ativo
+}
+
+// GET /centros-de-custo
+func (c *CentroCustoController) ListCentros(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	lista, err := c.centroCustoService.ListarCentrosCusto(r.Context(), claims)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	if lista == nil {
+		lista = []*entity.CentroCusto{}
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// POST /centros-de-custo  (admin)
+func (c *CentroCustoController) CreateCentro(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	var req centroCustoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	centro := entity.NewCentroCusto(req.Codigo, req.Nome)
+	if err := c.centroCustoService.CriarCentroCusto(r.Context(), claims, centro); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, centro)
+}
+
+// PUT /centros-de-custo/{id}  (admin)
+func (c *CentroCustoController) UpdateCentro(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	var req centroCustoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	centro := entity.NewCentroCusto(req.Codigo, req.Nome)
+	centro.ID = id
+	if req.Ativo != nil {
+		centro.Ativo = *req.Ativo
+	}
+	if err := c.centroCustoService.AtualizarCentroCusto(r.Context(), claims, centro); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, centro)
+}
+
+// DELETE /centros-de-custo/{id}  (admin)
+func (c *CentroCustoController) DeleteCentro(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	if err := c.centroCustoService.ExcluirCentroCusto(r.Context(), claims, id); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /departamentos
+func (c *CentroCustoController) ListDepartamentos(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	lista, err := c.centroCustoService.ListarDepartamentos(r.Context(), claims)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	if lista == nil {
+		lista = []*entity.Departamento{}
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// POST /departamentos  (admin)
+func (c *CentroCustoController) CreateDepartamento(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	var req departamentoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	d := entity.NewDepartamento(req.Nome, req.CentroCustoID)
+	if err := c.centroCustoService.CriarDepartamento(r.Context(), claims, d); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, d)
+}
+
+// PUT /departamentos/{id}  (admin)
+func (c *CentroCustoController) UpdateDepartamento(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	var req departamentoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	d := entity.NewDepartamento(req.Nome, req.CentroCustoID)
+	d.ID = id
+	if req.Ativo != nil {
+		d.Ativo = *req.Ativo
+	}
+	if err := c.centroCustoService.AtualizarDepartamento(r.Context(), claims, d); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, d)
+}
+
+// DELETE /departamentos/{id}  (admin)
+func (c *CentroCustoController) DeleteDepartamento(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	if err := c.centroCustoService.ExcluirDepartamento(r.Context(), claims, id); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /funcionarios/{id}/alocacoes — histórico de alocações
+func (c *CentroCustoController) ListAlocacoes(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	lista, err := c.centroCustoService.ListarAlocacoes(r.Context(), claims, funcionarioID)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// PUT /funcionarios/{id}/alocacoes  (admin)
+// {"inicio": "2025-01-01", "itens": [{"departamento_id": 1, "percentual": 60}, {"departamento_id": 2, "centro_custo_id": 3, "percentual": 40}]}
+func (c *CentroCustoController) DefinirAlocacao(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	var req struct {
+		Inicio string `json:"inicio"` // "YYYY-MM-DD"
+		Itens  []struct {
+			DepartamentoID int64   `json:"departamento_id"`
+			CentroCustoID  *int64  `json:"centro_custo_id"` // opcional: o do departamento
+			Percentual     float64 `json:"percentual"`      // opcional com um único item
+		} `json:"itens"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	inicio, err := dateStringToTime.DateStringToTime(req.Inicio)
+	if err != nil {
+		httpjson.BadRequest(w, "data 'inicio' inválida: "+err.Error())
+		return
+	}
+	itens := make([]service.AlocacaoItem, 0, len(req.Itens))
+	for _, it := range req.Itens {
+		itens = append(itens, service.AlocacaoItem{
+			DepartamentoID: it.DepartamentoID,
+			CentroCustoID:  it.CentroCustoID,
+			Percentual:     it.Percentual,
+		})
+	}
+	alocacoes, err := c.centroCustoService.DefinirAlocacao(r.Context(), claims, funcionarioID, inicio, itens)
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, alocacoes)
+}
+
+// DELETE /funcionarios/{id}/alocacoes?inicio=YYYY-MM-DD  (admin)
+func (c *CentroCustoController) RemoverAlocacao(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	inicio, err := dateStringToTime.DateStringToTime(r.URL.Query().Get("inicio"))
+	if err != nil {
+		httpjson.BadRequest(w, "parâmetro 'inicio' inválido: "+err.Error())
+		return
+	}
+	if err := c.centroCustoService.RemoverAlocacao(r.Context(), claims, funcionarioID, inicio); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /folhas/{id}/centros-de-custo — custo da folha de salário por centro de custo
+func (c *CentroCustoController) ResumoFolha(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	folhaID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	resumo, err := c.centroCustoService.ResumoFolha(r.Context(), claims, folhaID)
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	if resumo == nil {
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "folha não encontrada", Code: "NOT_FOUND"})
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, resumo)
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/utils/consulta"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// lerConsulta lê paginação, ordenação e filtros da query string; responde 400 e devolve ok=false se inválidos
+func lerConsulta(w http.ResponseWriter, r *http.Request) (consulta.Consulta, bool) {
+	q, err := consulta.Parse(r.URL.Query())
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return q, false
+	}
+	return q, true
+}
+
+// escreverPagina responde a página no envelope padrão das listagens, ou o erro da consulta
+func escreverPagina[T any](w http.ResponseWriter, p consulta.Pagina[T], err error) {
+	if err != nil {
+		if errors.Is(err, consulta.ErrConsultaInvalida) {
+			httpjson.BadRequest(w, err.Error())
+			return
+		}
+		httpjson.Internal(w, err.Error())
+		return
+	}
+
+	page := httpjson.Page{Data: p.Itens, Total: p.Total, Page: p.Pagina, Limit: p.Limite}
+	if p.ProximoCursor > 0 {
+		page.NextCursor = strconv.FormatInt(p.ProximoCursor, 10)
+	}
+	httpjson.WritePage(w, page)
+}
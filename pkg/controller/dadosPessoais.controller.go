@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/service"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type DadosPessoaisController struct {
+	svc *service.DadosPessoaisService
+}
+
+func NewDadosPessoaisController(svc *service.DadosPessoaisService) *DadosPessoaisController {
+	return &DadosPessoaisController{svc: svc}
+}
+
+// ExportarDadosPessoais baixa o ZIP com os dados pessoais e documentos do titular
+// GET /pessoas/{id}/dados-pessoais
+func (c *DadosPessoaisController) ExportarDadosPessoais(w http.ResponseWriter, r *http.Request) {
+	id, claims, ok := pessoaIDClaims(w, r)
+	if !ok {
+		return
+	}
+	conteudo, err := c.svc.ExportarDadosPessoais(r.Context(), claims, id)
+	if err != nil {
+		erroLGPD(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="dados-pessoais-%d.zip"`, id))
+	_, _ = w.Write(conteudo)
+}
+
+// AnonimizarPessoa apaga os dados pessoais do titular, mantendo o histórico de vínculos e pagamentos
+// POST /pessoas/{id}/anonimizar
+func (c *DadosPessoaisController) AnonimizarPessoa(w http.ResponseWriter, r *http.Request) {
+	id, claims, ok := pessoaIDClaims(w, r)
+	if !ok {
+		return
+	}
+	a, err := c.svc.AnonimizarPessoa(r.Context(), claims, id)
+	if err != nil {
+		erroLGPD(w, err)
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, a)
+}
+
+// retencaoResponse descreve a política de guarda e os titulares alcançados por ela
+type retencaoResponse struct {
+	PrazoAnos    int                       `json:"prazo_anos"`
+	Automatica   bool                      `json:"automatica"`
+	Vencidas     []*entity.RetencaoVencida `json:"vencidas,omitempty"`
+	Anonimizadas []*entity.Anonimizacao    `json:"anonimizadas,omitempty"`
+	Erro         string                    `json:"erro,omitempty"`
+}
+
+// ListarRetencao lista os ex-funcionários com prazo de guarda vencido
+// GET /admin/lgpd/retencao
+func (c *DadosPessoaisController) ListarRetencao(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	vencidas, err := c.svc.RetencoesVencidas(r.Context(), claims, time.Now())
+	if err != nil {
+		erroLGPD(w, err)
+		return
+	}
+	ret := c.svc.Retencao()
+	httpjson.WriteJSON(w, http.StatusOK, retencaoResponse{PrazoAnos: ret.Anos(), Automatica: ret.Automatica, Vencidas: vencidas})
+}
+
+// AplicarRetencao anonimiza agora os ex-funcionários com prazo de guarda vencido
+// POST /admin/lgpd/retencao
+func (c *DadosPessoaisController) AplicarRetencao(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	feitas, err := c.svc.AplicarRetencao(r.Context(), claims, time.Now())
+	if err != nil && feitas == nil {
+		erroLGPD(w, err)
+		return
+	}
+	ret := c.svc.Retencao()
+	resp := retencaoResponse{PrazoAnos: ret.Anos(), Automatica: ret.Automatica, Anonimizadas: feitas}
+	if err != nil {
+		resp.Erro = err.Error()
+	}
+	httpjson.WriteJSON(w, http.StatusOK, resp)
+}
+
+// erroLGPD responde 404, 409, 403 ou 500 conforme o erro do service
+func erroLGPD(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrPessoaNaoEncontrada):
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "Pessoa não encontrada", Code: "NOT_FOUND"})
+	case errors.Is(err, service.ErrPessoaAnonimizada), errors.Is(err, service.ErrPessoaComVinculoAtivo):
+		httpjson.WriteError(w, http.StatusConflict, "CONFLICT", err.Error(), nil)
+	case errors.Is(err, service.ErrUnauthorized):
+		httpjson.Forbidden(w, "não autorizado")
+	default:
+		httpjson.Internal(w, err.Error())
+	}
+}
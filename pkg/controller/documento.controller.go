@@ -72,20 +72,29 @@ func (c *DocumentoController) GetDocumentosByFuncionarioID(w http.ResponseWriter
 	httpjson.WriteJSON(w, http.StatusOK, docs)
 }
 
-// ListDocumentos - lista todos
+// ListDocumentos - lista todos, paginado
 func (c *DocumentoController) ListDocumentos(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middleware.GetClaims(r.Context())
 	if !ok {
 		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
 		return
 	}
+	formato, ok := lerFormatoExportacao(w, r)
+	if !ok {
+		return
+	}
+	q, ok := lerConsulta(w, r)
+	if !ok {
+		return
+	}
+	q.Todos = formato != ""
 
-	docs, err := c.documentoService.ListDocumentos(r.Context(), claims)
-	if err != nil {
-		httpjson.Internal(w, err.Error())
+	pagina, err := c.documentoService.BuscarDocumentos(r.Context(), claims, q)
+	if formato != "" {
+		exportarPagina(w, formato, "documentos", pagina, err, service.TabelaDocumentos)
 		return
 	}
-	httpjson.WriteJSON(w, http.StatusOK, docs)
+	escreverPagina(w, pagina, err)
 }
 
 // DownloadDocumento - baixa arquivo físico
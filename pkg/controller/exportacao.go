@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/utils/consulta"
+	"AutoGRH/pkg/utils/planilha"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// lerFormatoExportacao indica se a listagem deve sair em planilha: ?formato=csv|xlsx tem prioridade sobre
+// o cabeçalho Accept (text/csv ou o tipo do XLSX); vazio = JSON. O parâmetro é retirado da query para não
+// ser lido como filtro. Responde 400 e devolve ok=false para formato desconhecido.
+func lerFormatoExportacao(w http.ResponseWriter, r *http.Request) (string, bool) {
+	valores := r.URL.Query()
+	if _, ok := valores["formato"]; ok {
+		formato := strings.ToLower(strings.TrimSpace(valores.Get("formato")))
+		valores.Del("formato")
+		r.URL.RawQuery = valores.Encode()
+		switch formato {
+		case "", "json":
+			return "", true
+		case "csv":
+			return planilha.FormatoCSV, true
+		case "xlsx":
+			return planilha.FormatoXLSX, true
+		}
+		httpjson.BadRequest(w, "formato inválido: use json, csv ou xlsx")
+		return "", false
+	}
+
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, planilha.TipoXLSX):
+		return planilha.FormatoXLSX, true
+	case strings.Contains(accept, "text/csv"):
+		return planilha.FormatoCSV, true
+	}
+	return "", true
+}
+
+// exportarPagina responde todos os itens da consulta como planilha, ou o erro da consulta
+func exportarPagina[T any](w http.ResponseWriter, formato, arquivo string, p consulta.Pagina[T], err error,
+	tabela func([]T) (*planilha.Tabela, error)) {
+	if err != nil {
+		escreverPagina(w, p, err)
+		return
+	}
+	t, err := tabela(p.Itens)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	escreverPlanilha(w, formato, arquivo, t)
+}
+
+// escreverPlanilha envia a tabela para download; o nome do arquivo leva a data da exportação
+func escreverPlanilha(w http.ResponseWriter, formato, arquivo string, t *planilha.Tabela) {
+	conteudo, err := planilha.Escrever(t, formato)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	nome := fmt.Sprintf("%s-%s.%s", arquivo, time.Now().Format("2006-01-02"), strings.ToLower(formato))
+	w.Header().Set("Content-Type", planilha.TipoMIME(formato))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, nome))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(conteudo)
+}
@@ -33,6 +33,15 @@ func (c *FaltaController) CreateFalta(w http.ResponseWriter, r *http.Request) {
 		httpjson.BadRequest(w, "JSON inválido")
 		return
 	}
+	// POST /funcionarios/{id}/faltas: o funcionário vem da rota
+	if idStr := chi.URLParam(r, "id"); idStr != "" {
+		funcionarioID, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			httpjson.BadRequest(w, "funcionarioID inválido")
+			return
+		}
+		f.FuncionarioID = funcionarioID
+	}
 
 	if err := c.faltaService.CreateFalta(r.Context(), claims, &f); err != nil {
 		httpjson.Internal(w, err.Error())
@@ -143,21 +152,29 @@ func (c *FaltaController) GetFaltasByFuncionarioID(w http.ResponseWriter, r *htt
 	httpjson.WriteJSON(w, http.StatusOK, lista)
 }
 
-// Listar todas as faltas
+// Listar todas as faltas, paginado
 func (c *FaltaController) ListAllFaltas(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middleware.GetClaims(r.Context())
 	if !ok {
 		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
 		return
 	}
-
-	lista, err := c.faltaService.ListAllFaltas(r.Context(), claims)
-	if err != nil {
-		httpjson.Internal(w, err.Error())
+	formato, ok := lerFormatoExportacao(w, r)
+	if !ok {
 		return
 	}
+	q, ok := lerConsulta(w, r)
+	if !ok {
+		return
+	}
+	q.Todos = formato != ""
 
-	httpjson.WriteJSON(w, http.StatusOK, lista)
+	pagina, err := c.faltaService.BuscarFaltas(r.Context(), claims, q)
+	if formato != "" {
+		exportarPagina(w, formato, "faltas", pagina, err, service.TabelaFaltas)
+		return
+	}
+	escreverPagina(w, pagina, err)
 }
 
 func (c *FaltaController) UpsertMensal(w http.ResponseWriter, r *http.Request) {
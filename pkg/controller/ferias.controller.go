@@ -4,8 +4,11 @@ import (
 	"AutoGRH/pkg/controller/httpjson"
 	"AutoGRH/pkg/controller/middleware"
 	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -18,20 +21,29 @@ func NewFeriasController(s *service.FeriasService) *FeriasController {
 	return &FeriasController{feriasService: s}
 }
 
-// Listar todas as férias
+// Listar todas as férias, paginado
 func (c *FeriasController) ListFerias(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middleware.GetClaims(r.Context())
 	if !ok {
 		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
 		return
 	}
+	formato, ok := lerFormatoExportacao(w, r)
+	if !ok {
+		return
+	}
+	q, ok := lerConsulta(w, r)
+	if !ok {
+		return
+	}
+	q.Todos = formato != ""
 
-	ferias, err := c.feriasService.ListFerias(r.Context(), claims)
-	if err != nil {
-		httpjson.Internal(w, err.Error())
+	pagina, err := c.feriasService.BuscarFerias(r.Context(), claims, q)
+	if formato != "" {
+		exportarPagina(w, formato, "ferias", pagina, err, service.TabelaFerias)
 		return
 	}
-	httpjson.WriteJSON(w, http.StatusOK, ferias)
+	escreverPagina(w, pagina, err)
 }
 
 // Buscar férias por ID
@@ -192,6 +204,40 @@ func (c *FeriasController) Garantir(w http.ResponseWriter, r *http.Request) {
 	httpjson.WriteJSON(w, http.StatusOK, lista)
 }
 
+// GET /funcionarios/{id}/ferias/projecao?data=YYYY-MM-DD (padrão: hoje)
+func (c *FeriasController) Projecao(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+
+	funcID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || funcID <= 0 {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+
+	data := time.Now()
+	if v := r.URL.Query().Get("data"); v != "" {
+		if data, err = dateStringToTime.DateStringToTime(v); err != nil {
+			httpjson.BadRequest(w, "data inválida: "+err.Error())
+			return
+		}
+	}
+
+	proj, err := c.feriasService.ProjecaoFerias(r.Context(), claims, funcID, data)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	if proj == nil {
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "Funcionário não encontrado", Code: "NOT_FOUND"})
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, proj)
+}
+
 // PUT /ferias/{id}/pagar
 func (c *FeriasController) MarcarComoPago(w http.ResponseWriter, r *http.Request) {
 	claims, ok := middleware.GetClaims(r.Context())
@@ -251,3 +297,84 @@ func (c *FeriasController) DesmarcarPago(w http.ResponseWriter, r *http.Request)
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// POST /ferias-coletivas  (admin)
+// Lança o mesmo período de descanso para todos os ativos, um cargo, um departamento ou uma lista de funcionários
+func (c *FeriasController) CriarColetivas(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	var in struct {
+		Inicio         string  `json:"inicio"` // "YYYY-MM-DD"
+		Fim            string  `json:"fim"`    // "YYYY-MM-DD"
+		Escopo         string  `json:"escopo"` // TODOS | CARGO | DEPARTAMENTO | FUNCIONARIOS
+		Cargo          string  `json:"cargo"`
+		DepartamentoID int64   `json:"departamento_id"`
+		FuncionarioIDs []int64 `json:"funcionario_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	if in.Inicio == "" || in.Fim == "" || in.Escopo == "" {
+		httpjson.BadRequest(w, "campos 'inicio', 'fim' e 'escopo' são obrigatórios")
+		return
+	}
+	ini, err := dateStringToTime.DateStringToTime(in.Inicio)
+	if err != nil {
+		httpjson.BadRequest(w, "data 'inicio' inválida: "+err.Error())
+		return
+	}
+	fim, err := dateStringToTime.DateStringToTime(in.Fim)
+	if err != nil {
+		httpjson.BadRequest(w, "data 'fim' inválida: "+err.Error())
+		return
+	}
+
+	resultados, err := c.feriasService.CriarFeriasColetivas(r.Context(), claims, service.FeriasColetivasInput{
+		Inicio:         ini,
+		Fim:            fim,
+		Escopo:         in.Escopo,
+		Cargo:          in.Cargo,
+		DepartamentoID: in.DepartamentoID,
+		FuncionarioIDs: in.FuncionarioIDs,
+	})
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, resultados)
+}
+
+// GET /ferias/calendario?inicio=YYYY-MM-DD&fim=YYYY-MM-DD
+func (c *FeriasController) Calendario(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	q := r.URL.Query()
+	if q.Get("inicio") == "" || q.Get("fim") == "" {
+		httpjson.BadRequest(w, "parâmetros 'inicio' e 'fim' são obrigatórios")
+		return
+	}
+	ini, err := dateStringToTime.DateStringToTime(q.Get("inicio"))
+	if err != nil {
+		httpjson.BadRequest(w, "data 'inicio' inválida: "+err.Error())
+		return
+	}
+	fim, err := dateStringToTime.DateStringToTime(q.Get("fim"))
+	if err != nil {
+		httpjson.BadRequest(w, "data 'fim' inválida: "+err.Error())
+		return
+	}
+
+	cal, err := c.feriasService.CalendarioFerias(r.Context(), claims, ini, fim)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, cal)
+}
@@ -6,10 +6,13 @@ import (
 	"AutoGRH/pkg/entity"
 	"AutoGRH/pkg/service"
 	"AutoGRH/pkg/utils/dateStringToTime"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -31,8 +34,13 @@ type funcionarioRequest struct {
 	Nascimento        string  `json:"nascimento"`
 	Admissao          string  `json:"admissao"`
 	Cargo             string  `json:"cargo"`
+	CargoID           *int64  `json:"cargoID"` // opcional: cargo do catálogo (o título substitui "cargo")
 	SalarioInicial    float64 `json:"salarioInicial"`
 	FeriasDisponiveis int     `json:"feriasDisponiveis"`
+	PrazoContrato     string  `json:"prazoContrato"`  // INDETERMINADO (padrão) ou EXPERIENCIA
+	FimExperiencia    string  `json:"fimExperiencia"` // opcional: padrão 45 dias após a admissão
+	FimProrrogacao    string  `json:"fimProrrogacao"`
+	TipoContrato      string  `json:"tipoContrato"` // CLT (padrão), ESTAGIO, APRENDIZ, TEMPORARIO ou PJ
 }
 
 func (r *funcionarioRequest) ToEntity() (*entity.Funcionario, error) {
@@ -41,8 +49,11 @@ func (r *funcionarioRequest) ToEntity() (*entity.Funcionario, error) {
 	f.PIS = r.PIS
 	f.CTPF = r.CTPF
 	f.Cargo = r.Cargo
+	f.CargoID = r.CargoID
 	f.SalarioInicial = r.SalarioInicial
 	f.FeriasDisponiveis = r.FeriasDisponiveis
+	f.PrazoContrato = r.PrazoContrato
+	f.TipoContrato = r.TipoContrato
 
 	if r.Nascimento != "" {
 		d, err := dateStringToTime.DateStringToTime(r.Nascimento)
@@ -58,6 +69,20 @@ func (r *funcionarioRequest) ToEntity() (*entity.Funcionario, error) {
 		}
 		f.Admissao = d
 	}
+	if r.FimExperiencia != "" {
+		d, err := dateStringToTime.DateStringToTime(r.FimExperiencia)
+		if err != nil {
+			return nil, fmt.Errorf("fim da experiência inválido: %w", err)
+		}
+		f.FimExperiencia = &d
+	}
+	if r.FimProrrogacao != "" {
+		d, err := dateStringToTime.DateStringToTime(r.FimProrrogacao)
+		if err != nil {
+			return nil, fmt.Errorf("fim da prorrogação inválido: %w", err)
+		}
+		f.FimProrrogacao = &d
+	}
 
 	return &f, nil
 }
@@ -83,6 +108,9 @@ func (c *FuncionarioController) CreateFuncionario(w http.ResponseWriter, r *http
 	}
 
 	if err := c.funcionarioService.CreateFuncionario(r.Context(), claims, f); err != nil {
+		if erroValidacao(w, err) {
+			return
+		}
 		httpjson.Internal(w, err.Error())
 		return
 	}
@@ -118,6 +146,48 @@ func (c *FuncionarioController) GetFuncionarioByID(w http.ResponseWriter, r *htt
 	httpjson.WriteJSON(w, http.StatusOK, f)
 }
 
+// Dossie retorna o perfil do funcionário numa chamada; ?incluir= escolhe as seções (padrão: todas)
+// GET /funcionarios/{id}/dossie?incluir=ferias,vales
+func (c *FuncionarioController) Dossie(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		httpjson.BadRequest(w, "ID inválido")
+		return
+	}
+
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "não autenticado")
+		return
+	}
+
+	incluir := "todos"
+	if r.URL.Query().Has("incluir") {
+		incluir = r.URL.Query().Get("incluir")
+	}
+	secoes, err := service.SecoesDossie(incluir)
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+
+	d, err := c.funcionarioService.DossieFuncionario(r.Context(), claims, id, secoes, time.Now())
+	if err != nil {
+		if errors.Is(err, service.ErrUnauthorized) {
+			httpjson.Forbidden(w, "não autorizado")
+			return
+		}
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	if d == nil {
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "Funcionário não encontrado", Code: "NOT_FOUND"})
+		return
+	}
+
+	httpjson.WriteJSON(w, http.StatusOK, d)
+}
+
 // UpdateFuncionario atualiza um funcionário existente
 func (c *FuncionarioController) UpdateFuncionario(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -147,6 +217,9 @@ func (c *FuncionarioController) UpdateFuncionario(w http.ResponseWriter, r *http
 	}
 
 	if err := c.funcionarioService.UpdateFuncionario(r.Context(), claims, f); err != nil {
+		if erroValidacao(w, err) {
+			return
+		}
 		httpjson.Internal(w, err.Error())
 		return
 	}
@@ -211,19 +284,105 @@ func (c *FuncionarioController) ListFuncionariosInativos(w http.ResponseWriter,
 	httpjson.WriteJSON(w, http.StatusOK, list)
 }
 
-// ListTodosFuncionarios retorna todos os funcionários, ativos e inativos
+// ListTodosFuncionarios retorna os funcionários, ativos e inativos, paginados e filtrados
+// GET /funcionarios?nome=ana&status=ativo&admissao_de=2024-01-01&sort=-admissao&page=2
 func (c *FuncionarioController) ListTodosFuncionarios(w http.ResponseWriter, r *http.Request) {
 	claims, ok := mw.GetClaims(r.Context())
 	if !ok {
 		httpjson.Unauthorized(w, "UNAUTHORIZED", "não autenticado")
 		return
 	}
+	formato, ok := lerFormatoExportacao(w, r)
+	if !ok {
+		return
+	}
+	q, ok := lerConsulta(w, r)
+	if !ok {
+		return
+	}
+	q.Todos = formato != ""
 
-	list, err := c.funcionarioService.ListTodosFuncionarios(r.Context(), claims)
+	pagina, err := c.funcionarioService.BuscarFuncionarios(r.Context(), claims, q)
+	if formato != "" {
+		exportarPagina(w, formato, "funcionarios", pagina, err, service.TabelaFuncionarios)
+		return
+	}
+	escreverPagina(w, pagina, err)
+}
+
+// ProrrogarExperiencia prorroga o contrato de experiência
+// PUT /funcionarios/{id}/experiencia/prorrogar  body opcional: {"fim": "YYYY-MM-DD"}
+func (c *FuncionarioController) ProrrogarExperiencia(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
-		httpjson.Internal(w, err.Error())
+		httpjson.BadRequest(w, "ID inválido")
 		return
 	}
 
-	httpjson.WriteJSON(w, http.StatusOK, list)
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "não autenticado")
+		return
+	}
+
+	var input struct {
+		Fim string `json:"fim"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			httpjson.BadRequest(w, "JSON inválido")
+			return
+		}
+	}
+	var fim time.Time
+	if input.Fim != "" {
+		if fim, err = dateStringToTime.DateStringToTime(input.Fim); err != nil {
+			httpjson.BadRequest(w, "fim inválido: "+err.Error())
+			return
+		}
+	}
+
+	f, err := c.funcionarioService.ProrrogarExperiencia(r.Context(), claims, id, fim)
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, f)
+}
+
+// EfetivarContrato converte a experiência em contrato por prazo indeterminado
+// PUT /funcionarios/{id}/experiencia/efetivar
+func (c *FuncionarioController) EfetivarContrato(w http.ResponseWriter, r *http.Request) {
+	c.acaoExperiencia(w, r, c.funcionarioService.EfetivarContrato)
+}
+
+// EncerrarExperiencia encerra o contrato no término da experiência
+// PUT /funcionarios/{id}/experiencia/encerrar
+func (c *FuncionarioController) EncerrarExperiencia(w http.ResponseWriter, r *http.Request) {
+	c.acaoExperiencia(w, r, c.funcionarioService.EncerrarExperiencia)
+}
+
+func (c *FuncionarioController) acaoExperiencia(
+	w http.ResponseWriter,
+	r *http.Request,
+	acao func(ctx context.Context, claims service.Claims, id int64) (*entity.Funcionario, error),
+) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "ID inválido")
+		return
+	}
+
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "não autenticado")
+		return
+	}
+
+	f, err := acao(r.Context(), claims, id)
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, f)
 }
@@ -25,6 +25,11 @@ func BadRequest(w http.ResponseWriter, message string) {
 	WriteError(w, http.StatusBadRequest, "BAD_REQUEST", message, nil)
 }
 
+// ValidationError responde 400 com a lista de campos inválidos em details
+func ValidationError(w http.ResponseWriter, message string, details interface{}) {
+	WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", message, details)
+}
+
 func Unauthorized(w http.ResponseWriter, code, message string) {
 	WriteError(w, http.StatusUnauthorized, code, message, nil)
 }
@@ -36,3 +41,16 @@ func Forbidden(w http.ResponseWriter, message string) {
 func Internal(w http.ResponseWriter, message string) {
 	WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", message, nil)
 }
+
+// Page é o envelope das listagens paginadas: itens da página e total de itens que atendem aos filtros
+type Page struct {
+	Data       interface{} `json:"data"`
+	Total      int         `json:"total"`
+	Page       int         `json:"page,omitempty"`
+	Limit      int         `json:"limit"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+func WritePage(w http.ResponseWriter, p Page) {
+	WriteJSON(w, http.StatusOK, p)
+}
@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/utils/planilha"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// tamanhoMaximoImportacao limita o arquivo enviado para importação
+const tamanhoMaximoImportacao = 10 << 20
+
+type ImportacaoController struct {
+	svc *service.ImportacaoService
+}
+
+func NewImportacaoController(s *service.ImportacaoService) *ImportacaoController {
+	return &ImportacaoController{svc: s}
+}
+
+// ImportarFuncionarios valida a planilha de funcionários (multipart: file e, opcional, mapeamento em JSON)
+// POST /importacoes/funcionarios
+func (c *ImportacaoController) ImportarFuncionarios(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, tamanhoMaximoImportacao+1<<20)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		httpjson.BadRequest(w, "arquivo não enviado (esperado campo 'file')")
+		return
+	}
+	defer file.Close()
+
+	conteudo, err := io.ReadAll(io.LimitReader(file, tamanhoMaximoImportacao+1))
+	if err != nil {
+		httpjson.BadRequest(w, "erro ao ler arquivo enviado")
+		return
+	}
+	if len(conteudo) > tamanhoMaximoImportacao {
+		httpjson.BadRequest(w, fmt.Sprintf("arquivo maior que %d MB", tamanhoMaximoImportacao>>20))
+		return
+	}
+
+	var mapeamento map[string]string
+	if v := strings.TrimSpace(r.FormValue("mapeamento")); v != "" {
+		if err := json.Unmarshal([]byte(v), &mapeamento); err != nil {
+			httpjson.BadRequest(w, `mapeamento inválido: esperado JSON {"campo": "coluna"}`)
+			return
+		}
+	}
+
+	imp, err := c.svc.ValidarFuncionarios(r.Context(), claims, filepath.Base(header.Filename), conteudo, mapeamento)
+	if err != nil {
+		erroImportacao(w, err)
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, imp)
+}
+
+// ConfirmarImportacao cria os registros de uma importação validada
+// POST /importacoes/{id}/confirmar
+func (c *ImportacaoController) ConfirmarImportacao(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+
+	imp, err := c.svc.ConfirmarImportacao(r.Context(), claims, id)
+	switch {
+	case errors.Is(err, service.ErrImportacaoComErros):
+		httpjson.WriteError(w, http.StatusUnprocessableEntity, "IMPORT_ERRORS", err.Error(), imp)
+		return
+	case errors.Is(err, service.ErrImportacaoConcluida):
+		httpjson.WriteError(w, http.StatusConflict, "CONFLICT", err.Error(), nil)
+		return
+	case err != nil:
+		erroImportacao(w, err)
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, imp)
+}
+
+// ListImportacoes lista as importações
+// GET /importacoes
+func (c *ImportacaoController) ListImportacoes(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+
+	lista, err := c.svc.ListImportacoes(r.Context(), claims)
+	if err != nil {
+		erroImportacao(w, err)
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// GetImportacao devolve a importação com o resultado de cada linha
+// GET /importacoes/{id}
+func (c *ImportacaoController) GetImportacao(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+
+	imp, err := c.svc.GetImportacao(r.Context(), claims, id)
+	if err != nil {
+		erroImportacao(w, err)
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, imp)
+}
+
+// DownloadArquivo baixa a planilha enviada
+// GET /importacoes/{id}/arquivo
+func (c *ImportacaoController) DownloadArquivo(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+
+	imp, err := c.svc.GetImportacao(r.Context(), claims, id)
+	if err != nil {
+		erroImportacao(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", planilha.TipoMIME(imp.Formato))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": imp.Arquivo}))
+	_, _ = w.Write(imp.Conteudo)
+}
+
+// DownloadRelatorio baixa o relatório CSV com a situação de cada linha
+// GET /importacoes/{id}/relatorio
+func (c *ImportacaoController) DownloadRelatorio(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+
+	relatorio, err := c.svc.RelatorioImportacao(r.Context(), claims, id)
+	if err != nil {
+		erroImportacao(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="importacao-%d.csv"`, id))
+	_, _ = w.Write(relatorio)
+}
+
+// erroImportacao responde 404, 403, 400 ou 500 conforme o erro do service
+func erroImportacao(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrImportacaoNaoEncontrada):
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "Importação não encontrada", Code: "NOT_FOUND"})
+	case errors.Is(err, service.ErrUnauthorized):
+		httpjson.Forbidden(w, "não autorizado")
+	case erroValidacao(w, err):
+	default:
+		httpjson.Internal(w, err.Error())
+	}
+}
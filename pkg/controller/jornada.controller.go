@@ -0,0 +1,297 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type JornadaController struct {
+	jornadaService *service.JornadaService
+}
+
+func NewJornadaController(s *service.JornadaService) *JornadaController {
+	return &JornadaController{jornadaService: s}
+}
+
+type jornadaRequest struct {
+	Modelo           string              `json:"modelo"` // opcional: parte de um modelo (ex.: SEMANAL_44H)
+	Nome             string              `json:"nome"`
+	Tipo             string              `json:"tipo"`
+	CicloDias        int                 `json:"ciclo_dias"`
+	TrabalhaFeriados *bool               `json:"trabalha_feriados"`
+	Dias             []entity.DiaJornada `json:"dias"`
+}
+
+func (req jornadaRequest) toEntity() (*entity.Jornada, error) {
+	j := entity.NewJornada(req.Nome, req.Tipo, req.CicloDias, false)
+	if req.Modelo != "" {
+		base, err := service.NovaJornadaDeModelo(req.Modelo)
+		if err != nil {
+			return nil, err
+		}
+		j = base
+		if req.Nome != "" {
+			j.Nome = req.Nome
+		}
+	}
+	if req.TrabalhaFeriados != nil {
+		j.TrabalhaFeriados = *req.TrabalhaFeriados
+	}
+	if req.Dias != nil {
+		j.Dias = req.Dias
+	}
+	return j, nil
+}
+
+// GET /jornadas
+func (c *JornadaController) List(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	lista, err := c.jornadaService.ListarJornadas(r.Context(), claims)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	if lista == nil {
+		lista = []*entity.Jornada{}
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// GET /jornadas/modelos
+func (c *JornadaController) Modelos(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	lista, err := c.jornadaService.ListarModelos(r.Context(), claims)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// GET /jornadas/{id}
+func (c *JornadaController) Get(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	j, err := c.jornadaService.BuscarJornada(r.Context(), claims, id)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	if j == nil {
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "jornada não encontrada", Code: "NOT_FOUND"})
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, j)
+}
+
+// POST /jornadas  (admin)
+func (c *JornadaController) Create(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	var req jornadaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	j, err := req.toEntity()
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	if err := c.jornadaService.CriarJornada(r.Context(), claims, j); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, j)
+}
+
+// PUT /jornadas/{id}  (admin)
+func (c *JornadaController) Update(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	var req jornadaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	j, err := req.toEntity()
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	j.ID = id
+	if err := c.jornadaService.AtualizarJornada(r.Context(), claims, j); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, j)
+}
+
+// DELETE /jornadas/{id}  (admin)
+func (c *JornadaController) Delete(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	if err := c.jornadaService.ExcluirJornada(r.Context(), claims, id); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /funcionarios/{id}/jornadas — histórico de jornadas atribuídas
+func (c *JornadaController) ListAtribuicoes(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	lista, err := c.jornadaService.ListarAtribuicoes(r.Context(), claims, funcionarioID)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// POST /funcionarios/{id}/jornadas  (admin) — {"jornada_id": 1, "inicio": "2025-01-01"}
+func (c *JornadaController) Atribuir(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	var req struct {
+		JornadaID int64  `json:"jornada_id"`
+		Inicio    string `json:"inicio"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+	inicio, err := dateStringToTime.DateStringToTime(req.Inicio)
+	if err != nil {
+		httpjson.BadRequest(w, "data 'inicio' inválida: "+err.Error())
+		return
+	}
+	a, err := c.jornadaService.AtribuirJornada(r.Context(), claims, funcionarioID, req.JornadaID, inicio)
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, a)
+}
+
+// DELETE /funcionarios/{id}/jornadas/{atribuicaoID}  (admin)
+func (c *JornadaController) RemoverAtribuicao(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	atribuicaoID, err := strconv.ParseInt(chi.URLParam(r, "atribuicaoID"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "atribuicaoID inválido")
+		return
+	}
+	if err := c.jornadaService.RemoverAtribuicao(r.Context(), claims, funcionarioID, atribuicaoID); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /funcionarios/{id}/jornada-prevista?inicio=YYYY-MM-DD&fim=YYYY-MM-DD (fim opcional)
+func (c *JornadaController) Prevista(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+	q := r.URL.Query()
+	if q.Get("inicio") == "" {
+		httpjson.BadRequest(w, "parâmetro 'inicio' é obrigatório")
+		return
+	}
+	ini, err := dateStringToTime.DateStringToTime(q.Get("inicio"))
+	if err != nil {
+		httpjson.BadRequest(w, "data 'inicio' inválida: "+err.Error())
+		return
+	}
+	fim := ini
+	if q.Get("fim") != "" {
+		if fim, err = dateStringToTime.DateStringToTime(q.Get("fim")); err != nil {
+			httpjson.BadRequest(w, "data 'fim' inválida: "+err.Error())
+			return
+		}
+	}
+
+	lista, err := c.jornadaService.ConsultarHorasPrevistas(r.Context(), claims, funcionarioID, ini, fim)
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
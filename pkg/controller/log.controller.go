@@ -2,44 +2,40 @@ package controller
 
 import (
 	"AutoGRH/pkg/repository"
-	"encoding/json"
+	"AutoGRH/pkg/service"
 	"net/http"
-	"strconv"
 )
 
 type LogController struct{}
 
 func NewLogController() *LogController { return &LogController{} }
 
-// GET /admin/logs?limit=200&usuarioId=123
+// GET /admin/logs?limit=200&usuario_id=123&evento=CRIAR&data_de=2025-01-01
+// usuarioId continua aceito como sinônimo de usuario_id
 func (c *LogController) List(w http.ResponseWriter, r *http.Request) {
-	q := r.URL.Query()
-
-	limit := 200
-	if v := q.Get("limit"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
-			limit = n
-		}
+	valores := r.URL.Query()
+	if uid := valores.Get("usuarioId"); uid != "" {
+		valores.Del("usuarioId")
+		valores.Set("usuario_id", uid)
 	}
-
-	if uidStr := q.Get("usuarioId"); uidStr != "" {
-		if uid, err := strconv.ParseInt(uidStr, 10, 64); err == nil && uid > 0 {
-			logs, err := repository.GetLogsByUsuarioID(uid)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			w.Header().Set("Content-Type", "application/json; charset=utf-8")
-			_ = json.NewEncoder(w).Encode(logs)
-			return
-		}
+	if valores.Get("limit") == "" {
+		valores.Set("limit", "200")
 	}
+	r.URL.RawQuery = valores.Encode()
 
-	logs, err := repository.ListAllLogsView(limit)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	formato, ok := lerFormatoExportacao(w, r)
+	if !ok {
+		return
+	}
+	q, ok := lerConsulta(w, r)
+	if !ok {
+		return
+	}
+	q.Todos = formato != ""
+	pagina, err := repository.BuscarLogs(q)
+	if formato != "" {
+		exportarPagina(w, formato, "logs", pagina, err, service.TabelaLogs)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	_ = json.NewEncoder(w).Encode(logs)
+	escreverPagina(w, pagina, err)
 }
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PagamentoFeriasController struct {
+	pagamentoFeriasService *service.PagamentoFeriasService
+}
+
+func NewPagamentoFeriasController(s *service.PagamentoFeriasService) *PagamentoFeriasController {
+	return &PagamentoFeriasController{pagamentoFeriasService: s}
+}
+
+// GET /pagamentos-ferias?funcionario_id=
+func (c *PagamentoFeriasController) List(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+
+	if s := r.URL.Query().Get("funcionario_id"); s != "" {
+		funcID, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || funcID <= 0 {
+			httpjson.BadRequest(w, "funcionario_id inválido")
+			return
+		}
+		lista, err := c.pagamentoFeriasService.ListarPorFuncionario(r.Context(), claims, funcID)
+		if err != nil {
+			httpjson.Internal(w, err.Error())
+			return
+		}
+		httpjson.WriteJSON(w, http.StatusOK, lista)
+		return
+	}
+
+	lista, err := c.pagamentoFeriasService.Listar(r.Context(), claims)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// GET /pagamentos-ferias/{id}
+func (c *PagamentoFeriasController) GetByID(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+
+	p, err := c.pagamentoFeriasService.GetByID(r.Context(), claims, id)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	if p == nil {
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "Pagamento de férias não encontrado", Code: "NOT_FOUND"})
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, p)
+}
+
+// GET /descansos/{id}/pagamento
+func (c *PagamentoFeriasController) GetByDescanso(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	descansoID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "descansoID inválido")
+		return
+	}
+
+	p, err := c.pagamentoFeriasService.GetByDescansoID(r.Context(), claims, descansoID)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	if p == nil {
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "Pagamento de férias não encontrado", Code: "NOT_FOUND"})
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, p)
+}
+
+// POST /descansos/{id}/pagamento  (admin) — gera ou recalcula o pagamento de um descanso aprovado
+func (c *PagamentoFeriasController) Gerar(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	descansoID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "descansoID inválido")
+		return
+	}
+
+	p, err := c.pagamentoFeriasService.GerarParaDescanso(r.Context(), claims, descansoID)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, p)
+}
+
+// PUT /pagamentos-ferias/{id}/pagar  (admin) — body opcional {"data": "YYYY-MM-DD"}; padrão = hoje
+func (c *PagamentoFeriasController) Pagar(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+
+	var in struct {
+		Data string `json:"data"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			httpjson.BadRequest(w, "JSON inválido")
+			return
+		}
+	}
+	data := time.Now()
+	if in.Data != "" {
+		if data, err = dateStringToTime.DateStringToTime(in.Data); err != nil {
+			httpjson.BadRequest(w, "data inválida: "+err.Error())
+			return
+		}
+	}
+
+	if err := c.pagamentoFeriasService.MarcarComoPago(r.Context(), claims, id, data); err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /pagamentos-ferias/{id}/recibo — PDF de aviso e recibo de férias
+func (c *PagamentoFeriasController) Recibo(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+
+	pdf, err := c.pagamentoFeriasService.GerarAvisoRecibo(r.Context(), claims, id)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	if pdf == nil {
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "Pagamento de férias não encontrado", Code: "NOT_FOUND"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="recibo-ferias-%d.pdf"`, id))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(pdf)
+}
@@ -6,6 +6,7 @@ import (
 	"AutoGRH/pkg/entity"
 	"AutoGRH/pkg/service"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -21,23 +22,30 @@ func NewPessoaController(p *service.PessoaService) *PessoaController {
 }
 
 // struct auxiliar para request em camelCase
+// endereço e contatos têm rotas próprias
 type pessoaRequest struct {
-	Nome              string `json:"nome"`
-	CPF               string `json:"cpf"`
-	RG                string `json:"rg"`
-	Endereco          string `json:"endereco"`
-	Contato           string `json:"contato"`
-	ContatoEmergencia string `json:"contatoEmergencia"`
+	Nome             string `json:"nome"`
+	CPF              string `json:"cpf"`
+	RG               string `json:"rg"`
+	NomeSocial       string `json:"nomeSocial"`
+	Sexo             string `json:"sexo"`
+	IdentidadeGenero string `json:"identidadeGenero"`
+	EstadoCivil      int    `json:"estadoCivil"`
+	GrauInstrucao    int    `json:"grauInstrucao"`
+	RacaCor          int    `json:"racaCor"`
 }
 
 func (r *pessoaRequest) ToEntity() *entity.Pessoa {
 	return &entity.Pessoa{
-		Nome:              r.Nome,
-		CPF:               r.CPF,
-		RG:                r.RG,
-		Endereco:          r.Endereco,
-		Contato:           r.Contato,
-		ContatoEmergencia: r.ContatoEmergencia,
+		Nome:             r.Nome,
+		CPF:              r.CPF,
+		RG:               r.RG,
+		NomeSocial:       r.NomeSocial,
+		Sexo:             r.Sexo,
+		IdentidadeGenero: r.IdentidadeGenero,
+		EstadoCivil:      r.EstadoCivil,
+		GrauInstrucao:    r.GrauInstrucao,
+		RacaCor:          r.RacaCor,
 	}
 }
 
@@ -57,6 +65,9 @@ func (c *PessoaController) CreatePessoa(w http.ResponseWriter, r *http.Request)
 
 	p := input.ToEntity()
 	if err := c.pessoaService.CreatePessoa(r.Context(), claims, p); err != nil {
+		if erroValidacao(w, err) {
+			return
+		}
 		httpjson.Internal(w, err.Error())
 		return
 	}
@@ -117,6 +128,13 @@ func (c *PessoaController) UpdatePessoa(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := c.pessoaService.UpdatePessoa(r.Context(), claims, p); err != nil {
+		if errors.Is(err, service.ErrPessoaAnonimizada) {
+			httpjson.WriteError(w, http.StatusConflict, "CONFLICT", err.Error(), nil)
+			return
+		}
+		if erroValidacao(w, err) {
+			return
+		}
 		httpjson.Internal(w, err.Error())
 		return
 	}
@@ -140,6 +158,10 @@ func (c *PessoaController) DeletePessoa(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := c.pessoaService.DeletePessoa(r.Context(), claims, id); err != nil {
+		if errors.Is(err, service.ErrPessoaComVinculos) {
+			httpjson.WriteError(w, http.StatusConflict, "CONFLICT", err.Error(), nil)
+			return
+		}
 		httpjson.Internal(w, err.Error())
 		return
 	}
@@ -147,19 +169,27 @@ func (c *PessoaController) DeletePessoa(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// ListPessoas retorna todas as pessoas
+// ListPessoas retorna as pessoas, paginadas e filtradas
 func (c *PessoaController) ListPessoas(w http.ResponseWriter, r *http.Request) {
 	claims, ok := mw.GetClaims(r.Context())
 	if !ok {
 		httpjson.Unauthorized(w, "UNAUTHORIZED", "não autenticado")
 		return
 	}
-
-	list, err := c.pessoaService.ListPessoas(r.Context(), claims)
-	if err != nil {
-		httpjson.Internal(w, err.Error())
+	formato, ok := lerFormatoExportacao(w, r)
+	if !ok {
 		return
 	}
+	q, ok := lerConsulta(w, r)
+	if !ok {
+		return
+	}
+	q.Todos = formato != ""
 
-	httpjson.WriteJSON(w, http.StatusOK, list)
+	pagina, err := c.pessoaService.BuscarPessoas(r.Context(), claims, q)
+	if formato != "" {
+		exportarPagina(w, formato, "pessoas", pagina, err, service.TabelaPessoas)
+		return
+	}
+	escreverPagina(w, pagina, err)
 }
@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	mw "AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/service"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type enderecoRequest struct {
+	CEP         string `json:"cep"`
+	Logradouro  string `json:"logradouro"`
+	Numero      string `json:"numero"`
+	Complemento string `json:"complemento"`
+	Bairro      string `json:"bairro"`
+	Cidade      string `json:"cidade"`
+	UF          string `json:"uf"`
+	CodigoIBGE  string `json:"codigoIBGE"`
+}
+
+type contatoRequest struct {
+	Tipo      string `json:"tipo"`
+	Valor     string `json:"valor"`
+	Principal bool   `json:"principal"`
+}
+
+type contatoEmergenciaRequest struct {
+	Nome       string `json:"nome"`
+	Parentesco string `json:"parentesco"`
+	Telefone   string `json:"telefone"`
+}
+
+// pessoaIDClaims lê o ID da pessoa na rota e as claims; responde o erro e devolve ok=false se faltar algum
+func pessoaIDClaims(w http.ResponseWriter, r *http.Request) (int64, service.Claims, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "ID inválido")
+		return 0, service.Claims{}, false
+	}
+	claims, ok := mw.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "não autenticado")
+		return 0, service.Claims{}, false
+	}
+	return id, claims, true
+}
+
+// erroDadosPessoa responde 404, 400 ou 500 conforme o erro do service
+func erroDadosPessoa(w http.ResponseWriter, err error) {
+	if errors.Is(err, service.ErrPessoaNaoEncontrada) {
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "Pessoa não encontrada", Code: "NOT_FOUND"})
+		return
+	}
+	if erroValidacao(w, err) {
+		return
+	}
+	httpjson.Internal(w, err.Error())
+}
+
+// GetEndereco retorna o endereço da pessoa
+func (c *PessoaController) GetEndereco(w http.ResponseWriter, r *http.Request) {
+	id, claims, ok := pessoaIDClaims(w, r)
+	if !ok {
+		return
+	}
+
+	e, err := c.pessoaService.GetEndereco(r.Context(), claims, id)
+	if err != nil {
+		erroDadosPessoa(w, err)
+		return
+	}
+	if e == nil {
+		httpjson.WriteJSON(w, http.StatusNotFound, httpjson.ErrorResponse{Error: "Endereço não cadastrado", Code: "NOT_FOUND"})
+		return
+	}
+
+	httpjson.WriteJSON(w, http.StatusOK, e)
+}
+
+// SalvarEndereco grava o endereço da pessoa
+func (c *PessoaController) SalvarEndereco(w http.ResponseWriter, r *http.Request) {
+	id, claims, ok := pessoaIDClaims(w, r)
+	if !ok {
+		return
+	}
+
+	var input enderecoRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+
+	e := &entity.Endereco{
+		CEP:         input.CEP,
+		Logradouro:  input.Logradouro,
+		Numero:      input.Numero,
+		Complemento: input.Complemento,
+		Bairro:      input.Bairro,
+		Cidade:      input.Cidade,
+		UF:          input.UF,
+		CodigoIBGE:  input.CodigoIBGE,
+	}
+	if err := c.pessoaService.SalvarEndereco(r.Context(), claims, id, e); err != nil {
+		erroDadosPessoa(w, err)
+		return
+	}
+
+	httpjson.WriteJSON(w, http.StatusOK, e)
+}
+
+// RemoverEndereco apaga o endereço da pessoa
+func (c *PessoaController) RemoverEndereco(w http.ResponseWriter, r *http.Request) {
+	id, claims, ok := pessoaIDClaims(w, r)
+	if !ok {
+		return
+	}
+
+	if err := c.pessoaService.RemoverEndereco(r.Context(), claims, id); err != nil {
+		erroDadosPessoa(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListarContatos lista os contatos da pessoa
+func (c *PessoaController) ListarContatos(w http.ResponseWriter, r *http.Request) {
+	id, claims, ok := pessoaIDClaims(w, r)
+	if !ok {
+		return
+	}
+
+	lista, err := c.pessoaService.ListarContatos(r.Context(), claims, id)
+	if err != nil {
+		erroDadosPessoa(w, err)
+		return
+	}
+	if lista == nil {
+		lista = []entity.Contato{}
+	}
+
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// SalvarContatos substitui os contatos da pessoa
+func (c *PessoaController) SalvarContatos(w http.ResponseWriter, r *http.Request) {
+	id, claims, ok := pessoaIDClaims(w, r)
+	if !ok {
+		return
+	}
+
+	var input []contatoRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+
+	contatos := make([]entity.Contato, 0, len(input))
+	for _, in := range input {
+		contatos = append(contatos, entity.Contato{Tipo: in.Tipo, Valor: in.Valor, Principal: in.Principal})
+	}
+	if err := c.pessoaService.SalvarContatos(r.Context(), claims, id, contatos); err != nil {
+		erroDadosPessoa(w, err)
+		return
+	}
+
+	httpjson.WriteJSON(w, http.StatusOK, contatos)
+}
+
+// ListarContatosEmergencia lista os contatos de emergência da pessoa
+func (c *PessoaController) ListarContatosEmergencia(w http.ResponseWriter, r *http.Request) {
+	id, claims, ok := pessoaIDClaims(w, r)
+	if !ok {
+		return
+	}
+
+	lista, err := c.pessoaService.ListarContatosEmergencia(r.Context(), claims, id)
+	if err != nil {
+		erroDadosPessoa(w, err)
+		return
+	}
+	if lista == nil {
+		lista = []entity.ContatoEmergencia{}
+	}
+
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// SalvarContatosEmergencia substitui os contatos de emergência da pessoa
+func (c *PessoaController) SalvarContatosEmergencia(w http.ResponseWriter, r *http.Request) {
+	id, claims, ok := pessoaIDClaims(w, r)
+	if !ok {
+		return
+	}
+
+	var input []contatoEmergenciaRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+
+	contatos := make([]entity.ContatoEmergencia, 0, len(input))
+	for _, in := range input {
+		contatos = append(contatos, entity.ContatoEmergencia{Nome: in.Nome, Parentesco: in.Parentesco, Telefone: in.Telefone})
+	}
+	if err := c.pessoaService.SalvarContatosEmergencia(r.Context(), claims, id, contatos); err != nil {
+		erroDadosPessoa(w, err)
+		return
+	}
+
+	httpjson.WriteJSON(w, http.StatusOK, contatos)
+}
+
+// Dominios devolve as tabelas de códigos dos dados pessoais
+func (c *PessoaController) Dominios(w http.ResponseWriter, r *http.Request) {
+	httpjson.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"sexo":              []string{entity.SexoMasculino, entity.SexoFeminino},
+		"identidade_genero": entity.IdentidadesGenero,
+		"estado_civil":      entity.EstadosCivis,
+		"grau_instrucao":    entity.GrausInstrucao,
+		"raca_cor":          entity.RacasCor,
+		"tipo_contato":      []string{entity.ContatoTelefone, entity.ContatoCelular, entity.ContatoEmail, entity.ContatoWhatsApp},
+		"parentesco":        entity.Parentescos,
+	})
+}
+
+// ListarVinculos devolve a linha do tempo dos contratos da pessoa
+func (c *PessoaController) ListarVinculos(w http.ResponseWriter, r *http.Request) {
+	id, claims, ok := pessoaIDClaims(w, r)
+	if !ok {
+		return
+	}
+
+	lista, err := c.pessoaService.ListarVinculos(r.Context(), claims, id)
+	if err != nil {
+		erroDadosPessoa(w, err)
+		return
+	}
+
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
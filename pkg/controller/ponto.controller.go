@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/service"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PontoController struct {
+	pontoService *service.PontoService
+}
+
+func NewPontoController(s *service.PontoService) *PontoController {
+	return &PontoController{pontoService: s}
+}
+
+// POST /ponto/importar — upload multipart do AFD (campo 'file')
+func (c *PontoController) Importar(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		httpjson.BadRequest(w, "arquivo não enviado (esperado campo 'file')")
+		return
+	}
+	defer file.Close()
+
+	res, err := c.pontoService.Importar(r.Context(), claims, file)
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, res)
+}
+
+// GET /funcionarios/{id}/ponto?mes=10&ano=2025 — apuração diária do mês (padrão: mês atual)
+func (c *PontoController) ListarMes(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	funcionarioID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "funcionarioID inválido")
+		return
+	}
+
+	agora := time.Now()
+	mes, ano := int(agora.Month()), agora.Year()
+	if v := r.URL.Query().Get("mes"); v != "" {
+		if mes, err = strconv.Atoi(v); err != nil {
+			httpjson.BadRequest(w, "mes inválido")
+			return
+		}
+	}
+	if v := r.URL.Query().Get("ano"); v != "" {
+		if ano, err = strconv.Atoi(v); err != nil {
+			httpjson.BadRequest(w, "ano inválido")
+			return
+		}
+	}
+
+	dias, err := c.pontoService.ListarMes(r.Context(), claims, funcionarioID, mes, ano)
+	if err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, dias)
+}
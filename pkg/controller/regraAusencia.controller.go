@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/service"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type RegraAusenciaController struct {
+	regraService *service.RegraAusenciaService
+}
+
+func NewRegraAusenciaController(s *service.RegraAusenciaService) *RegraAusenciaController {
+	return &RegraAusenciaController{regraService: s}
+}
+
+type regraAusenciaRequest struct {
+	Escopo      string `json:"escopo"`
+	Referencia  string `json:"referencia"`
+	MaxAusentes int    `json:"max_ausentes"`
+	Modo        string `json:"modo"`
+	Ativo       *bool  `json:"ativo"`
+}
+
+// GET /ferias/regras-ausencia
+func (c *RegraAusenciaController) List(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	lista, err := c.regraService.Listar(r.Context(), claims)
+	if err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, lista)
+}
+
+// POST /ferias/regras-ausencia  (admin)
+func (c *RegraAusenciaController) Create(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	var req regraAusenciaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+
+	regra := entity.NewRegraAusencia(req.Escopo, req.Referencia, req.MaxAusentes, req.Modo)
+	if err := c.regraService.Criar(r.Context(), claims, regra); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusCreated, regra)
+}
+
+// PUT /ferias/regras-ausencia/{id}  (admin)
+func (c *RegraAusenciaController) Update(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	var req regraAusenciaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpjson.BadRequest(w, "JSON inválido")
+		return
+	}
+
+	regra := entity.NewRegraAusencia(req.Escopo, req.Referencia, req.MaxAusentes, req.Modo)
+	regra.ID = id
+	if req.Ativo != nil {
+		regra.Ativo = *req.Ativo
+	}
+	if err := c.regraService.Atualizar(r.Context(), claims, regra); err != nil {
+		httpjson.BadRequest(w, err.Error())
+		return
+	}
+	httpjson.WriteJSON(w, http.StatusOK, regra)
+}
+
+// DELETE /ferias/regras-ausencia/{id}  (admin)
+func (c *RegraAusenciaController) Delete(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaims(r.Context())
+	if !ok {
+		httpjson.Unauthorized(w, "UNAUTHORIZED", "usuário não autenticado")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		httpjson.BadRequest(w, "id inválido")
+		return
+	}
+	if err := c.regraService.Excluir(r.Context(), claims, id); err != nil {
+		httpjson.Internal(w, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -49,7 +49,11 @@ func (c *SalarioController) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpjson.WriteJSON(w, http.StatusCreated, salario)
+	// aviso (não bloqueia) quando o valor foge da faixa do cargo
+	httpjson.WriteJSON(w, http.StatusCreated, struct {
+		*entity.Salario
+		Aviso string `json:"aviso,omitempty"`
+	}{salario, c.salarioService.AvisoFaixaSalarial(funcID, req.Valor)})
 }
 
 // GET /funcionarios/{id}/salarios
@@ -3,6 +3,7 @@ package controller
 import (
 	"AutoGRH/pkg/controller/httpjson"
 	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/entity"
 	"AutoGRH/pkg/service"
 	"encoding/json"
 	"net/http"
@@ -49,7 +50,11 @@ func (c *SalarioRealController) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpjson.WriteJSON(w, http.StatusCreated, criado)
+	// aviso (não bloqueia) quando o valor foge da faixa do cargo
+	httpjson.WriteJSON(w, http.StatusCreated, struct {
+		*entity.SalarioReal
+		Aviso string `json:"aviso,omitempty"`
+	}{criado, c.salarioRealService.AvisoFaixaSalarial(funcID, req.Valor)})
 }
 
 // GET /funcionarios/{id}/salarios-reais
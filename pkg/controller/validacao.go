@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"AutoGRH/pkg/controller/httpjson"
+	"AutoGRH/pkg/service"
+	"errors"
+	"net/http"
+)
+
+// erroValidacao responde 400 com os campos inválidos quando err é um *service.ErroValidacao
+func erroValidacao(w http.ResponseWriter, err error) bool {
+	var verr *service.ErroValidacao
+	if !errors.As(err, &verr) {
+		return false
+	}
+	httpjson.ValidationError(w, verr.Error(), verr.Campos)
+	return true
+}
@@ -0,0 +1,60 @@
+package entity
+
+import "time"
+
+// Tipos de lançamento do banco de horas
+const (
+	BancoHorasCredito   = "CREDITO"   // horas trabalhadas a mais, a compensar
+	BancoHorasDebito    = "DEBITO"    // horas não trabalhadas compensadas pelo saldo
+	BancoHorasPagamento = "PAGAMENTO" // saldo pago como hora extra em folha
+)
+
+// Origens de lançamento do banco de horas. Referencia aponta para o registro de origem
+// (ponto_dia, falta ou folha), o que permite refazer o lançamento sem duplicar.
+const (
+	BancoHorasOrigemManual = "MANUAL"
+	BancoHorasOrigemPonto  = "PONTO"
+	BancoHorasOrigemFolga  = "FOLGA"
+	BancoHorasOrigemFolha  = "FOLHA"
+)
+
+// BancoHorasLancamento é uma movimentação do banco de horas de um funcionário. Minutos é sempre positivo;
+// o tipo define o sinal. Créditos vencem em Vencimento e, se não compensados, são pagos na folha seguinte.
+type BancoHorasLancamento struct {
+	ID            int64      `json:"id"`
+	FuncionarioID int64      `json:"funcionario_id"`
+	Data          time.Time  `json:"data"`
+	Tipo          string     `json:"tipo"`
+	Minutos       int        `json:"minutos"`
+	Vencimento    *time.Time `json:"vencimento,omitempty"`
+	Origem        string     `json:"origem"`
+	Referencia    *int64     `json:"referencia,omitempty"`
+	Descricao     string     `json:"descricao"`
+}
+
+// NewBancoHorasLancamento cria um lançamento manual
+func NewBancoHorasLancamento(funcionarioID int64, data time.Time, tipo string, minutos int, descricao string) *BancoHorasLancamento {
+	return &BancoHorasLancamento{
+		FuncionarioID: funcionarioID,
+		Data:          data,
+		Tipo:          tipo,
+		Minutos:       minutos,
+		Origem:        BancoHorasOrigemManual,
+		Descricao:     descricao,
+	}
+}
+
+// Sinal devolve os minutos com sinal: positivo para créditos, negativo para débitos e pagamentos
+func (l *BancoHorasLancamento) Sinal() int {
+	if l.Tipo == BancoHorasCredito {
+		return l.Minutos
+	}
+	return -l.Minutos
+}
+
+// BancoHorasConfig indica se o funcionário compensa horas extras pelo banco e em quantos meses os créditos vencem
+type BancoHorasConfig struct {
+	FuncionarioID int64 `json:"funcionario_id"`
+	Ativo         bool  `json:"ativo"`
+	MesesValidade int   `json:"meses_validade"`
+}
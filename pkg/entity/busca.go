@@ -0,0 +1,21 @@
+package entity
+
+// Tipos de resultado da busca global
+const (
+	BuscaPessoa      = "PESSOA"
+	BuscaFuncionario = "FUNCIONARIO"
+	BuscaCargo       = "CARGO"
+	BuscaDocumento   = "DOCUMENTO"
+	BuscaVale        = "VALE"
+	BuscaFolha       = "FOLHA"
+)
+
+// ResultadoBusca é um item encontrado pela busca global, com o link do recurso dono
+type ResultadoBusca struct {
+	Tipo       string  `json:"tipo"`
+	ID         int64   `json:"id"`
+	Titulo     string  `json:"titulo"`
+	Detalhe    string  `json:"detalhe,omitempty"`
+	Link       string  `json:"link"`
+	Relevancia float64 `json:"relevancia"` // de 0 a 1
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// CalendarioToken dá acesso, sem login, aos feeds iCalendar (.ics) de um usuário.
+// Apenas o hash do token é armazenado; o valor original é exibido uma única vez.
+type CalendarioToken struct {
+	ID           int64      `json:"id"`
+	UsuarioID    int64      `json:"usuario_id"`
+	TokenHash    string     `json:"-"`
+	CriadoEm     time.Time  `json:"criado_em"`
+	UltimoAcesso *time.Time `json:"ultimo_acesso,omitempty"`
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+// Cargo é uma posição do catálogo de cargos, com o código CBO e a faixa salarial prevista
+type Cargo struct {
+	ID           int64   `json:"id"`
+	Titulo       string  `json:"titulo"`
+	CBO          string  `json:"cbo"` // Classificação Brasileira de Ocupações: 6 dígitos, sem hífen
+	SalarioMin   float64 `json:"salario_min"`
+	SalarioMax   float64 `json:"salario_max"` // 0 = sem teto
+	Departamento string  `json:"departamento"`
+	Ativo        bool    `json:"ativo"`
+}
+
+// NewCargo cria um cargo ativo
+func NewCargo(titulo, cbo string, salarioMin, salarioMax float64, departamento string) *Cargo {
+	return &Cargo{
+		Titulo:       titulo,
+		CBO:          cbo,
+		SalarioMin:   salarioMin,
+		SalarioMax:   salarioMax,
+		Departamento: departamento,
+		Ativo:        true,
+	}
+}
+
+// ForaDaFaixa indica se o valor está abaixo do mínimo ou acima do máximo do cargo
+func (c *Cargo) ForaDaFaixa(valor float64) bool {
+	return valor < c.SalarioMin || (c.SalarioMax > 0 && valor > c.SalarioMax)
+}
+
+// FuncionarioCargo registra o cargo ocupado pelo funcionário a partir de Inicio; vale até o início do seguinte
+type FuncionarioCargo struct {
+	ID            int64     `json:"id"`
+	FuncionarioID int64     `json:"funcionario_id"`
+	CargoID       int64     `json:"cargo_id"`
+	Inicio        time.Time `json:"inicio"`
+	Observacao    string    `json:"observacao"`
+	Cargo         *Cargo    `json:"cargo,omitempty"`
+}
+
+// NewFuncionarioCargo cria um registro do histórico de cargos
+func NewFuncionarioCargo(funcionarioID, cargoID int64, inicio time.Time, observacao string) *FuncionarioCargo {
+	return &FuncionarioCargo{
+		FuncionarioID: funcionarioID,
+		CargoID:       cargoID,
+		Inicio:        inicio,
+		Observacao:    observacao,
+	}
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+// CentroCusto agrupa os custos de folha para o financeiro
+type CentroCusto struct {
+	ID     int64  `json:"id"`
+	Codigo string `json:"codigo"`
+	Nome   string `json:"nome"`
+	Ativo  bool   `json:"ativo"`
+}
+
+// NewCentroCusto cria um centro de custo ativo
+func NewCentroCusto(codigo, nome string) *CentroCusto {
+	return &CentroCusto{Codigo: codigo, Nome: nome, Ativo: true}
+}
+
+// Departamento é uma área da empresa; seu centro de custo é o padrão de quem é alocado nele
+type Departamento struct {
+	ID            int64  `json:"id"`
+	Nome          string `json:"nome"`
+	CentroCustoID *int64 `json:"centro_custo_id,omitempty"`
+	Ativo         bool   `json:"ativo"`
+}
+
+// NewDepartamento cria um departamento ativo
+func NewDepartamento(nome string, centroCustoID *int64) *Departamento {
+	return &Departamento{Nome: nome, CentroCustoID: centroCustoID, Ativo: true}
+}
+
+// AlocacaoFuncionario é a parte do funcionário alocada em um departamento e centro de custo a partir
+// de Inicio. As alocações com o mesmo início formam a distribuição vigente e somam 100%.
+type AlocacaoFuncionario struct {
+	ID             int64     `json:"id"`
+	FuncionarioID  int64     `json:"funcionario_id"`
+	DepartamentoID int64     `json:"departamento_id"`
+	CentroCustoID  int64     `json:"centro_custo_id"`
+	Inicio         time.Time `json:"inicio"`
+	Percentual     float64   `json:"percentual"`
+}
+
+// RateioPagamento é a parcela de um pagamento da folha atribuída a um centro de custo
+type RateioPagamento struct {
+	ID             int64   `json:"id"`
+	PagamentoID    int64   `json:"pagamento_id"`
+	CentroCustoID  int64   `json:"centro_custo_id"`
+	DepartamentoID int64   `json:"departamento_id"`
+	Percentual     float64 `json:"percentual"`
+	Valor          float64 `json:"valor"` // parcela do valor final
+	FGTS           float64 `json:"fgts"`  // parcela do depósito de FGTS
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// Anonimizacao é o resultado da anonimização de um titular (LGPD art. 16): os dados pessoais são
+// apagados e os registros de vínculo e pagamento ficam, sem identificação
+type Anonimizacao struct {
+	PessoaID            int64     `json:"pessoa_id"`
+	Vinculos            int       `json:"vinculos"`
+	DocumentosRemovidos int       `json:"documentos_removidos"`
+	AnonimizadoEm       time.Time `json:"anonimizado_em"`
+}
+
+// RetencaoVencida é um ex-funcionário cujo prazo de guarda dos dados pessoais já passou
+type RetencaoVencida struct {
+	PessoaID       int64     `json:"pessoa_id"`
+	Nome           string    `json:"nome"`
+	UltimaDemissao time.Time `json:"ultima_demissao"`
+	Vinculos       int       `json:"vinculos"`
+}
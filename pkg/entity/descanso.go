@@ -13,6 +13,9 @@ type Descanso struct {
 	Aprovado bool      `json:"aprovado"`
 	Pago     bool      `json:"pago"`
 	FeriasID int64     `json:"ferias_id"`
+
+	// Conflitos com regras de ausência em modo AVISO detectados na criação (não persistido)
+	Conflitos []ConflitoAusencia `json:"conflitos,omitempty"`
 }
 
 // NewDescanso cria uma nova instância de Descanso não aprovado nem pago
@@ -0,0 +1,59 @@
+package entity
+
+import "time"
+
+// Seções opcionais do dossiê do funcionário (?incluir=)
+const (
+	DossiePessoa        = "pessoa"
+	DossieCargos        = "cargos"
+	DossieSalarios      = "salarios"
+	DossieSalariosReais = "salarios_reais"
+	DossieDocumentos    = "documentos"
+	DossieFerias        = "ferias"
+	DossieFaltas        = "faltas"
+	DossiePagamentos    = "pagamentos"
+	DossieVales         = "vales"
+)
+
+// SecoesDossie lista todas as seções do dossiê, na ordem em que são carregadas
+var SecoesDossie = []string{
+	DossiePessoa, DossieCargos, DossieSalarios, DossieSalariosReais, DossieDocumentos,
+	DossieFerias, DossieFaltas, DossiePagamentos, DossieVales,
+}
+
+// DossieFuncionario reúne numa resposta o vínculo, as seções pedidas e os totais calculados.
+// As coleções do vínculo vêm nos campos de Funcionario; Incluido diz quais seções foram carregadas.
+type DossieFuncionario struct {
+	Funcionario   *Funcionario        `json:"funcionario"`
+	Pessoa        *Pessoa             `json:"pessoa,omitempty"`
+	Cargos        []*FuncionarioCargo `json:"cargos,omitempty"`
+	TempoDeCasa   TempoDeCasa         `json:"tempo_de_casa"`
+	SaldoFerias   SaldoFerias         `json:"saldo_ferias"`
+	ValesEmAberto ValesEmAberto       `json:"vales_em_aberto"`
+	Incluido      []string            `json:"incluido"`
+}
+
+// TempoDeCasa é a duração do vínculo, da admissão até a demissão ou a data de referência
+type TempoDeCasa struct {
+	Anos      int       `json:"anos"`
+	Meses     int       `json:"meses"`
+	Dias      int       `json:"dias"`
+	TotalDias int       `json:"total_dias"`
+	Ate       time.Time `json:"ate"`
+}
+
+// SaldoFerias soma os dias ainda não gozados nem pagos dos períodos de férias
+type SaldoFerias struct {
+	Dias              int        `json:"dias"`
+	Periodos          int        `json:"periodos"`
+	PeriodosVencidos  int        `json:"periodos_vencidos"`            // vencidos com saldo: pagamento em dobro (CLT art. 137)
+	ProximoVencimento *time.Time `json:"proximo_vencimento,omitempty"` // do período com saldo que vence primeiro
+}
+
+// ValesEmAberto resume os vales ativos ainda não pagos
+type ValesEmAberto struct {
+	Quantidade          int     `json:"quantidade"`
+	Total               float64 `json:"total"`
+	AguardandoAprovacao int     `json:"aguardando_aprovacao"`
+	AprovadosTotal      float64 `json:"aprovados_total"` // a descontar na próxima folha de vale
+}
@@ -1,6 +1,28 @@
 package entity
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Tipos de falta. MENSAL é o total consolidado do mês (lançamento antigo, sem dia exato);
+// os demais são registros individuais, em que Mes guarda o dia da ausência.
+const (
+	FaltaTipoMensal        = "MENSAL"
+	FaltaTipoInjustificada = "INJUSTIFICADA"
+	FaltaTipoAtestado      = "ATESTADO"
+	FaltaTipoLicenca       = "LICENCA"
+	FaltaTipoAbonada       = "ABONADA"
+	FaltaTipoAtraso        = "ATRASO"
+)
+
+// Origens do registro de falta. As faltas e atrasos lançados pela importação do ponto (PONTO) são
+// refeitos quando o dia é apurado de novo; os lançados ou editados à mão (MANUAL) são mantidos.
+const (
+	FaltaOrigemManual = "MANUAL"
+	FaltaOrigemPonto  = "PONTO"
+)
 
 // Falta representa a quantidade de faltas de um funcionário em um determinado mês
 // Usado para cálculo de descontos, controle de presença e geração da folha
@@ -8,8 +30,12 @@ import "time"
 type Falta struct {
 	ID            int64     `json:"id"`
 	FuncionarioID int64     `json:"funcionario_id"`
-	Quantidade    int       `json:"quantidade"`
-	Mes           time.Time `json:"mes"`
+	Quantidade    int       `json:"quantidade"` // dias de ausência (0 para atrasos)
+	Mes           time.Time `json:"mes"`        // mês de referência (MENSAL) ou dia da ausência
+	Tipo          string    `json:"tipo"`
+	Minutos       int       `json:"minutos,omitempty"` // duração do atraso
+	DocumentoID   *int64    `json:"documento_id,omitempty"`
+	Origem        string    `json:"origem"`
 }
 
 // NewFalta cria uma nova instância de Falta para um funcionário em um determinado mês
@@ -18,5 +44,70 @@ func NewFalta(qtd int, mes time.Time, funcionarioID int64) *Falta {
 		Quantidade:    qtd,
 		Mes:           mes,
 		FuncionarioID: funcionarioID,
+		Tipo:          FaltaTipoMensal,
+		Origem:        FaltaOrigemManual,
+	}
+}
+
+// NewAusencia cria um registro individual de ausência em um dia
+func NewAusencia(tipo string, dia time.Time, dias int, funcionarioID int64) *Falta {
+	return &Falta{
+		Quantidade:    dias,
+		Mes:           dia,
+		FuncionarioID: funcionarioID,
+		Tipo:          tipo,
+		Origem:        FaltaOrigemManual,
 	}
 }
+
+// NewAtraso cria um registro de atraso em minutos
+func NewAtraso(dia time.Time, minutos int, funcionarioID int64) *Falta {
+	return &Falta{
+		Mes:           dia,
+		FuncionarioID: funcionarioID,
+		Tipo:          FaltaTipoAtraso,
+		Minutos:       minutos,
+		Origem:        FaltaOrigemManual,
+	}
+}
+
+// TipoFaltaValido indica se o tipo informado é conhecido
+func TipoFaltaValido(tipo string) bool {
+	switch strings.ToUpper(tipo) {
+	case FaltaTipoMensal, FaltaTipoInjustificada, FaltaTipoAtestado,
+		FaltaTipoLicenca, FaltaTipoAbonada, FaltaTipoAtraso:
+		return true
+	}
+	return false
+}
+
+// TiposFaltaDescontoDias são as ausências descontadas do salário por dia (1/30 do salário cada);
+// a folha soma as faltas desses tipos. O atraso também desconta, pelos minutos.
+var TiposFaltaDescontoDias = []string{FaltaTipoMensal, FaltaTipoInjustificada}
+
+// DescontaSalario indica se a ausência reduz o salário do mês. Atestado, licença e falta
+// abonada são justificadas (CLT art. 473) e não geram desconto.
+func (f *Falta) DescontaSalario() bool {
+	return f.Tipo == FaltaTipoAtraso || slices.Contains(TiposFaltaDescontoDias, f.Tipo)
+}
+
+// Justificada indica se a ausência está justificada (atestado, licença ou abono): o dia não tem
+// jornada prevista
+func (f *Falta) Justificada() bool {
+	switch f.Tipo {
+	case FaltaTipoAtestado, FaltaTipoLicenca, FaltaTipoAbonada:
+		return true
+	}
+	return false
+}
+
+// ReduzFerias indica se a ausência conta na tabela do art. 130 da CLT. Só faltas
+// injustificadas contam (art. 131); atrasos não são faltas.
+func (f *Falta) ReduzFerias() bool {
+	return f.Tipo == FaltaTipoMensal || f.Tipo == FaltaTipoInjustificada
+}
+
+// Individual indica se o registro corresponde a um dia específico
+func (f *Falta) Individual() bool {
+	return f.Tipo != FaltaTipoMensal
+}
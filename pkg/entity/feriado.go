@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// Tipos de Feriado. NACIONAL e FACULTATIVO são calculados a cada ano e não ficam no banco;
+// os demais são cadastrados pela empresa.
+const (
+	FeriadoTipoNacional    = "NACIONAL"
+	FeriadoTipoFacultativo = "FACULTATIVO" // ponto facultativo nacional: é dia útil, salvo folga cadastrada
+	FeriadoTipoEstadual    = "ESTADUAL"
+	FeriadoTipoMunicipal   = "MUNICIPAL"
+	FeriadoTipoEmpresa     = "EMPRESA" // dia sem expediente definido pela empresa (ex.: emenda de feriado)
+)
+
+// Feriado é um feriado ou dia sem expediente do calendário da empresa
+type Feriado struct {
+	ID         int64     `json:"id,omitempty"`
+	Data       time.Time `json:"data"`
+	Nome       string    `json:"nome"`
+	Tipo       string    `json:"tipo"`
+	Localidade string    `json:"localidade,omitempty"` // UF ou município, para estaduais e municipais
+	Recorrente bool      `json:"recorrente"`           // repete todo ano no mesmo dia e mês
+}
+
+// NewFeriado cria um feriado cadastrado
+func NewFeriado(data time.Time, nome, tipo string, recorrente bool) *Feriado {
+	return &Feriado{
+		Data:       data,
+		Nome:       nome,
+		Tipo:       tipo,
+		Recorrente: recorrente,
+	}
+}
+
+// SemExpediente indica se o dia deixa de ser útil (pontos facultativos não deixam)
+func (f *Feriado) SemExpediente() bool {
+	return f.Tipo != FeriadoTipoFacultativo
+}
+
+// Cadastrado indica se o tipo é mantido pela empresa (e não calculado)
+func (f *Feriado) Cadastrado() bool {
+	return f.Tipo == FeriadoTipoEstadual || f.Tipo == FeriadoTipoMunicipal || f.Tipo == FeriadoTipoEmpresa
+}
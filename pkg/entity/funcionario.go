@@ -2,6 +2,21 @@ package entity
 
 import "time"
 
+// Prazo do contrato de trabalho
+const (
+	ContratoPrazoIndeterminado = "INDETERMINADO"
+	ContratoExperiencia        = "EXPERIENCIA" // contrato de experiência (CLT art. 445), até 90 dias
+)
+
+// Tipos de contrato (vínculo) do funcionário
+const (
+	TipoContratoCLT        = "CLT"
+	TipoContratoEstagio    = "ESTAGIO"    // Lei 11.788/2008: sem INSS nem FGTS, recesso de 30 dias
+	TipoContratoAprendiz   = "APRENDIZ"   // CLT art. 428: FGTS de 2%
+	TipoContratoTemporario = "TEMPORARIO" // Lei 6.019/1974
+	TipoContratoPJ         = "PJ"         // prestador de serviço: sem folha e sem férias
+)
+
 // Funcionario representa um vínculo contratual com uma pessoa
 // Dados pessoais são referenciados via PessoaID; este modelo armazena dados contratuais
 
@@ -15,10 +30,23 @@ type Funcionario struct {
 	Admissao          time.Time  `json:"admissao"`
 	Demissao          *time.Time `json:"demissao,omitempty"`
 	Cargo             string     `json:"cargo"`
+	CargoID           *int64     `json:"cargo_id,omitempty"` // cargo do catálogo; Cargo guarda o título
 	SalarioInicial    float64    `json:"salario_inicial"`
 	FeriasDisponiveis int        `json:"ferias_disponiveis"`
 	Ativo             bool       `json:"ativo"`
 
+	// InicioAquisitivo substitui a admissão como data-base dos períodos aquisitivos
+	// (ex.: reiniciada por férias coletivas de quem tinha menos de 12 meses)
+	InicioAquisitivo *time.Time `json:"inicio_aquisitivo,omitempty"`
+
+	TipoContrato string `json:"tipo_contrato"`
+
+	// Prazo do contrato: durante a experiência, FimExperiencia é o término do primeiro período e
+	// FimProrrogacao o da única prorrogação admitida
+	PrazoContrato  string     `json:"prazo_contrato"`
+	FimExperiencia *time.Time `json:"fim_experiencia,omitempty"`
+	FimProrrogacao *time.Time `json:"fim_prorrogacao,omitempty"`
+
 	SalarioRegistradoAtual *Salario     `json:"salario_registrado_atual,omitempty"`
 	SalarioRealAtual       *SalarioReal `json:"salario_real_atual,omitempty"`
 
@@ -31,3 +59,58 @@ type Funcionario struct {
 	Pagamentos []Pagamento `json:"pagamentos,omitempty"`
 	Vales      []Vale      `json:"vales,omitempty"`
 }
+
+// EmExperiencia indica se o contrato ainda está no período de experiência
+func (f *Funcionario) EmExperiencia() bool {
+	return f.PrazoContrato == ContratoExperiencia && f.FimExperiencia != nil
+}
+
+// TerminoExperiencia devolve o término vigente da experiência: o da prorrogação, se houver
+func (f *Funcionario) TerminoExperiencia() *time.Time {
+	if f.FimProrrogacao != nil {
+		return f.FimProrrogacao
+	}
+	return f.FimExperiencia
+}
+
+// EntraNaFolha indica se o funcionário recebe pela folha de salário
+func (f *Funcionario) EntraNaFolha() bool {
+	return f.TipoContrato != TipoContratoPJ
+}
+
+// TemFerias indica se o contrato gera períodos de férias (ou recesso, no estágio)
+func (f *Funcionario) TemFerias() bool {
+	return f.TipoContrato != TipoContratoPJ
+}
+
+// RecolheINSS indica se há contribuição previdenciária descontada em folha
+func (f *Funcionario) RecolheINSS() bool {
+	return f.TipoContrato != TipoContratoEstagio && f.TipoContrato != TipoContratoPJ
+}
+
+// AliquotaFGTS devolve a alíquota de FGTS depositada pelo empregador sobre a remuneração
+func (f *Funcionario) AliquotaFGTS() float64 {
+	switch f.TipoContrato {
+	case TipoContratoEstagio, TipoContratoPJ:
+		return 0
+	case TipoContratoAprendiz:
+		return 0.02
+	default:
+		return 0.08
+	}
+}
+
+// LimiteContrato devolve o último dia permitido pela lei para contratos com duração máxima:
+// estágio e aprendizagem até 2 anos, temporário até 180 dias. Nil quando não há limite.
+func (f *Funcionario) LimiteContrato() *time.Time {
+	var limite time.Time
+	switch f.TipoContrato {
+	case TipoContratoEstagio, TipoContratoAprendiz:
+		limite = f.Admissao.AddDate(2, 0, -1)
+	case TipoContratoTemporario:
+		limite = f.Admissao.AddDate(0, 0, 179)
+	default:
+		return nil
+	}
+	return &limite
+}
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// Tipos de importação em lote
+const (
+	ImportacaoFuncionarios = "FUNCIONARIOS"
+)
+
+// Situação de uma importação
+const (
+	ImportacaoValidada  = "VALIDADA"  // todas as linhas válidas, aguardando confirmação
+	ImportacaoComErros  = "COM_ERROS" // há linhas inválidas; corrija a planilha e envie de novo
+	ImportacaoConcluida = "CONCLUIDA" // registros criados
+)
+
+// Importacao é o registro de uma planilha enviada para cadastro em lote. O arquivo original fica
+// guardado para download e para ser lido de novo na confirmação.
+type Importacao struct {
+	ID            int64             `json:"id"`
+	Tipo          string            `json:"tipo"`
+	Arquivo       string            `json:"arquivo"`
+	Formato       string            `json:"formato"` // CSV ou XLSX
+	Status        string            `json:"status"`
+	Mapeamento    map[string]string `json:"mapeamento"` // campo -> coluna da planilha
+	TotalLinhas   int               `json:"total_linhas"`
+	LinhasComErro int               `json:"linhas_com_erro"`
+	Linhas        []LinhaImportacao `json:"linhas,omitempty"`
+	UsuarioID     int64             `json:"usuario_id"`
+	CriadoEm      time.Time         `json:"criado_em"`
+	ConcluidoEm   *time.Time        `json:"concluido_em,omitempty"`
+	Conteudo      []byte            `json:"-"`
+}
+
+// LinhaImportacao é o resultado de uma linha da planilha; Linha conta o cabeçalho como linha 1
+type LinhaImportacao struct {
+	Linha           int              `json:"linha"`
+	Nome            string           `json:"nome"`
+	CPF             string           `json:"cpf"`
+	PessoaExistente bool             `json:"pessoa_existente,omitempty"` // CPF já cadastrado: readmissão
+	PessoaID        int64            `json:"pessoa_id,omitempty"`
+	FuncionarioID   int64            `json:"funcionario_id,omitempty"`
+	Erros           []ErroImportacao `json:"erros,omitempty"`
+}
+
+// ErroImportacao é um campo inválido de uma linha
+type ErroImportacao struct {
+	Campo    string `json:"campo"`
+	Mensagem string `json:"mensagem"`
+}
@@ -0,0 +1,148 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// Tipos de Jornada
+const (
+	JornadaTipoSemanal       = "SEMANAL"       // mesma escala toda semana (ex.: 44h de segunda a sexta)
+	JornadaTipo12x36         = "12X36"         // 12 horas de trabalho por 36 de descanso (CLT art. 59-A)
+	JornadaTipoParcial       = "PARCIAL"       // tempo parcial, até 30h semanais (CLT art. 58-A)
+	JornadaTipoPersonalizada = "PERSONALIZADA" // turnos livres em um ciclo de dias
+)
+
+// PeriodoJornada é um trecho contínuo de trabalho; os intervalos são os vãos entre períodos do mesmo dia.
+// Fim menor que Inicio indica turno que atravessa a meia-noite.
+type PeriodoJornada struct {
+	Inicio string `json:"inicio"` // hh:mm
+	Fim    string `json:"fim"`    // hh:mm
+}
+
+// DiaJornada são os períodos de um dia do ciclo. Em ciclos de 7 dias Dia é o dia da semana
+// (0 = domingo ... 6 = sábado); nos demais, a posição no ciclo a partir do início da atribuição.
+type DiaJornada struct {
+	Dia      int              `json:"dia"`
+	Periodos []PeriodoJornada `json:"periodos"`
+}
+
+// Jornada é um modelo de escala de trabalho que pode ser atribuído a vários funcionários
+type Jornada struct {
+	ID               int64        `json:"id"`
+	Nome             string       `json:"nome"`
+	Tipo             string       `json:"tipo"`
+	CicloDias        int          `json:"ciclo_dias"`        // 7 nas semanais, 2 na 12x36
+	TrabalhaFeriados bool         `json:"trabalha_feriados"` // escalas como a 12x36 não folgam em feriados
+	Dias             []DiaJornada `json:"dias"`
+}
+
+// NewJornada cria uma jornada sem dias de trabalho
+func NewJornada(nome, tipo string, cicloDias int, trabalhaFeriados bool) *Jornada {
+	return &Jornada{
+		Nome:             nome,
+		Tipo:             tipo,
+		CicloDias:        cicloDias,
+		TrabalhaFeriados: trabalhaFeriados,
+		Dias:             []DiaJornada{},
+	}
+}
+
+// minutosDoDia converte "hh:mm" em minutos desde a meia-noite
+func minutosDoDia(h string) (int, error) {
+	t, err := time.Parse("15:04", h)
+	if err != nil {
+		return 0, fmt.Errorf("horário inválido: %q", h)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Intervalo devolve início e fim em minutos desde a meia-noite do dia (fim pode passar de 24h)
+func (p PeriodoJornada) Intervalo() (inicio, fim int, err error) {
+	if inicio, err = minutosDoDia(p.Inicio); err != nil {
+		return 0, 0, err
+	}
+	if fim, err = minutosDoDia(p.Fim); err != nil {
+		return 0, 0, err
+	}
+	if fim <= inicio {
+		fim += 24 * 60
+	}
+	return inicio, fim, nil
+}
+
+// Minutos devolve a duração do período
+func (p PeriodoJornada) Minutos() int {
+	ini, fim, err := p.Intervalo()
+	if err != nil {
+		return 0
+	}
+	return fim - ini
+}
+
+// PeriodosDoDia devolve os períodos de trabalho na posição do ciclo (vazio em folgas)
+func (j *Jornada) PeriodosDoDia(indice int) []PeriodoJornada {
+	for _, d := range j.Dias {
+		if d.Dia == indice {
+			return d.Periodos
+		}
+	}
+	return nil
+}
+
+// MinutosDoDia soma os períodos de trabalho na posição do ciclo
+func (j *Jornada) MinutosDoDia(indice int) int {
+	total := 0
+	for _, p := range j.PeriodosDoDia(indice) {
+		total += p.Minutos()
+	}
+	return total
+}
+
+// MinutosCiclo soma os minutos de trabalho de um ciclo completo
+func (j *Jornada) MinutosCiclo() int {
+	total := 0
+	for _, d := range j.Dias {
+		for _, p := range d.Periodos {
+			total += p.Minutos()
+		}
+	}
+	return total
+}
+
+// FuncionarioJornada atribui uma jornada a um funcionário a partir de uma data; vale até a próxima atribuição
+type FuncionarioJornada struct {
+	ID            int64     `json:"id"`
+	FuncionarioID int64     `json:"funcionario_id"`
+	JornadaID     int64     `json:"jornada_id"`
+	Inicio        time.Time `json:"inicio"`
+	Jornada       *Jornada  `json:"jornada,omitempty"`
+}
+
+// NewFuncionarioJornada cria a atribuição de uma jornada
+func NewFuncionarioJornada(funcionarioID, jornadaID int64, inicio time.Time) *FuncionarioJornada {
+	return &FuncionarioJornada{
+		FuncionarioID: funcionarioID,
+		JornadaID:     jornadaID,
+		Inicio:        inicio,
+	}
+}
+
+// IndiceNoCiclo devolve a posição do dia no ciclo da jornada: o dia da semana em ciclos de 7 dias, ou os
+// dias corridos desde o início da atribuição módulo o ciclo nos demais
+func (a *FuncionarioJornada) IndiceNoCiclo(dia time.Time) int {
+	if a.Jornada == nil || a.Jornada.CicloDias <= 0 {
+		return 0
+	}
+	if a.Jornada.CicloDias == 7 {
+		return int(dia.Weekday())
+	}
+	ini := time.Date(a.Inicio.Year(), a.Inicio.Month(), a.Inicio.Day(), 0, 0, 0, 0, time.UTC)
+	d := time.Date(dia.Year(), dia.Month(), dia.Day(), 0, 0, 0, 0, time.UTC)
+	n := int(d.Sub(ini).Hours() / 24)
+	n %= a.Jornada.CicloDias
+	if n < 0 {
+		n += a.Jornada.CicloDias
+	}
+	return n
+}
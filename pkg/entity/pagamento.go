@@ -9,8 +9,14 @@ type Pagamento struct {
 	DescontoINSS   float64 `json:"descontoINSS"`
 	SalarioFamilia float64 `json:"salarioFamilia"`
 	DescontoVales  float64 `json:"descontoVales"`
+	DescontoDSR    float64 `json:"descontoDSR"` // repouso semanal perdido por faltas injustificadas
+	HorasExtras    float64 `json:"horasExtras"` // horas extras apuradas no ponto
+	FGTS           float64 `json:"fgts"`        // depósito do empregador; não altera o valor final
 	ValorFinal     float64 `json:"valorFinal"`
 	Pago           bool    `json:"pago"`
+
+	// Rateio por centro de custo, gravado na geração da folha de salário
+	Rateio []RateioPagamento `json:"rateio,omitempty"`
 }
 
 func NewPagamento(funcionarioID, folhaID int64, salarioBase float64) *Pagamento {
@@ -22,6 +28,9 @@ func NewPagamento(funcionarioID, folhaID int64, salarioBase float64) *Pagamento
 		DescontoINSS:   0,
 		SalarioFamilia: 0,
 		DescontoVales:  0,
+		DescontoDSR:    0,
+		HorasExtras:    0,
+		FGTS:           0,
 		ValorFinal:     salarioBase,
 		Pago:           false,
 	}
@@ -30,8 +39,10 @@ func NewPagamento(funcionarioID, folhaID int64, salarioBase float64) *Pagamento
 func (p *Pagamento) RecalcularValorFinal(descontoFaltas float64) {
 	p.ValorFinal = p.SalarioBase +
 		p.Adicional +
+		p.HorasExtras +
 		p.SalarioFamilia -
 		p.DescontoINSS -
 		p.DescontoVales -
+		p.DescontoDSR -
 		descontoFaltas
 }
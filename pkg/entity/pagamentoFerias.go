@@ -0,0 +1,56 @@
+package entity
+
+import "time"
+
+// PagamentoFerias representa o pagamento das férias referente a um descanso aprovado.
+// A CLT (art. 145) exige o pagamento até 2 dias antes do início do descanso;
+// os descontos de INSS e IRRF incidem sobre férias + 1/3.
+type PagamentoFerias struct {
+	ID            int64      `json:"id"`
+	DescansoID    int64      `json:"descanso_id"`
+	FuncionarioID int64      `json:"funcionario_id"`
+	Dias          int        `json:"dias"`
+	DataPrevista  time.Time  `json:"data_prevista"`
+	DataPagamento *time.Time `json:"data_pagamento,omitempty"`
+	ValorFerias   float64    `json:"valor_ferias"`
+	ValorTerco    float64    `json:"valor_terco"`
+	ValorBruto    float64    `json:"valor_bruto"`
+	DescontoINSS  float64    `json:"desconto_inss"`
+	DescontoIRRF  float64    `json:"desconto_irrf"`
+	ValorLiquido  float64    `json:"valor_liquido"`
+	Pago          bool       `json:"pago"`
+}
+
+// DiasAntecedenciaPagamentoFerias é o prazo legal, em dias úteis, entre o pagamento e o início do descanso
+const DiasAntecedenciaPagamentoFerias = 2
+
+// NewPagamentoFerias cria o pagamento de um descanso separando férias e terço a partir do valor total
+// do descanso (que já inclui o 1/3); descontos devem ser aplicados com AplicarDescontos.
+// dataPrevista é o prazo legal, contado em dias úteis no calendário da empresa.
+func NewPagamentoFerias(d *Descanso, funcionarioID int64, dataPrevista time.Time) *PagamentoFerias {
+	p := &PagamentoFerias{
+		DescansoID:    d.ID,
+		FuncionarioID: funcionarioID,
+		Dias:          d.DuracaoEmDias(),
+		DataPrevista:  dataPrevista,
+		ValorBruto:    d.Valor,
+		Pago:          false,
+	}
+	// Valor do descanso = base + base/3  =>  base = 3/4 do total
+	p.ValorFerias = d.Valor * 3 / 4
+	p.ValorTerco = d.Valor - p.ValorFerias
+	p.ValorLiquido = p.ValorBruto
+	return p
+}
+
+// AplicarDescontos registra INSS e IRRF e recalcula o líquido
+func (p *PagamentoFerias) AplicarDescontos(inss, irrf float64) {
+	p.DescontoINSS = inss
+	p.DescontoIRRF = irrf
+	p.ValorLiquido = p.ValorBruto - inss - irrf
+}
+
+// EmAtraso indica se o pagamento passou da data prevista sem ser efetuado
+func (p *PagamentoFerias) EmAtraso(ref time.Time) bool {
+	return !p.Pago && ref.After(p.DataPrevista.AddDate(0, 0, 1))
+}
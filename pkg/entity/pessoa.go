@@ -1,24 +1,132 @@
 package entity
 
+import "time"
+
 // Pessoa representa uma pessoa física única, independente do vínculo empregatício
 
 type Pessoa struct {
-	ID                int64  `json:"id"`
-	Nome              string `json:"nome"`
-	CPF               string `json:"cpf"`
-	RG                string `json:"rg"`
-	Endereco          string `json:"endereco"`
-	Contato           string `json:"contato"`
-	ContatoEmergencia string `json:"contato_emergencia"`
+	ID   int64  `json:"id"`
+	Nome string `json:"nome"`
+	CPF  string `json:"cpf"`
+	RG   string `json:"rg"`
+
+	// Dados pessoais nas tabelas do eSocial (S-2200); 0 ou vazio = não informado
+	NomeSocial       string `json:"nome_social,omitempty"`
+	Sexo             string `json:"sexo,omitempty"` // M ou F
+	IdentidadeGenero string `json:"identidade_genero,omitempty"`
+	EstadoCivil      int    `json:"estado_civil,omitempty"`
+	GrauInstrucao    int    `json:"grau_instrucao,omitempty"`
+	RacaCor          int    `json:"raca_cor,omitempty"`
+
+	Endereco           *Endereco           `json:"endereco,omitempty"`
+	Contatos           []Contato           `json:"contatos,omitempty"`
+	ContatosEmergencia []ContatoEmergencia `json:"contatos_emergencia,omitempty"`
+
+	// AnonimizadoEm marca o titular cujos dados pessoais foram apagados (LGPD); o cadastro fica só
+	// para manter o histórico de vínculos e pagamentos
+	AnonimizadoEm *time.Time `json:"anonimizado_em,omitempty"`
 }
 
-func NewPessoa(nome, cpf, rg, endereco, contato, contatoEmergencia string) *Pessoa {
+func NewPessoa(nome, cpf, rg string) *Pessoa {
 	return &Pessoa{
-		Nome:              nome,
-		CPF:               cpf,
-		RG:                rg,
-		Endereco:          endereco,
-		Contato:           contato,
-		ContatoEmergencia: contatoEmergencia,
+		Nome: nome,
+		CPF:  cpf,
+		RG:   rg,
 	}
 }
+
+// Sexo (eSocial)
+const (
+	SexoMasculino = "M"
+	SexoFeminino  = "F"
+)
+
+// Identidade de gênero, informada pela própria pessoa
+const (
+	GeneroCisgenero    = "CISGENERO"
+	GeneroTransgenero  = "TRANSGENERO"
+	GeneroNaoBinario   = "NAO_BINARIO"
+	GeneroOutra        = "OUTRA"
+	GeneroNaoInformado = "NAO_INFORMADO"
+)
+
+// IdentidadesGenero lista as identidades de gênero aceitas
+var IdentidadesGenero = []string{GeneroCisgenero, GeneroTransgenero, GeneroNaoBinario, GeneroOutra, GeneroNaoInformado}
+
+// EstadosCivis é a tabela de estado civil do eSocial
+var EstadosCivis = map[int]string{
+	1: "Solteiro",
+	2: "Casado",
+	3: "Divorciado",
+	4: "Separado",
+	5: "Viúvo",
+}
+
+// GrausInstrucao é a tabela de grau de instrução do eSocial
+var GrausInstrucao = map[int]string{
+	1:  "Analfabeto, inclusive o que, embora tenha recebido instrução, não se alfabetizou",
+	2:  "Até o 5º ano incompleto do ensino fundamental",
+	3:  "5º ano completo do ensino fundamental",
+	4:  "Do 6º ao 9º ano do ensino fundamental incompleto",
+	5:  "Ensino fundamental completo",
+	6:  "Ensino médio incompleto",
+	7:  "Ensino médio completo",
+	8:  "Educação superior incompleta",
+	9:  "Educação superior completa",
+	10: "Pós-graduação completa",
+	11: "Mestrado completo",
+	12: "Doutorado completo",
+}
+
+// RacasCor é a tabela de raça e cor do eSocial
+var RacasCor = map[int]string{
+	1: "Branca",
+	2: "Preta",
+	3: "Parda",
+	4: "Amarela",
+	5: "Indígena",
+	6: "Não informado",
+}
+
+// Endereco é o endereço residencial da pessoa; CEP só com dígitos e município pelo código do IBGE
+type Endereco struct {
+	ID          int64  `json:"id"`
+	PessoaID    int64  `json:"pessoa_id"`
+	CEP         string `json:"cep"`
+	Logradouro  string `json:"logradouro"`
+	Numero      string `json:"numero"` // "S/N" quando não houver
+	Complemento string `json:"complemento,omitempty"`
+	Bairro      string `json:"bairro,omitempty"`
+	Cidade      string `json:"cidade"`
+	UF          string `json:"uf"`
+	CodigoIBGE  string `json:"codigo_ibge,omitempty"`
+}
+
+// Tipos de contato
+const (
+	ContatoTelefone = "TELEFONE"
+	ContatoCelular  = "CELULAR"
+	ContatoEmail    = "EMAIL"
+	ContatoWhatsApp = "WHATSAPP"
+)
+
+// Contato é um meio de contato da pessoa; telefones só com dígitos (DDD + número)
+type Contato struct {
+	ID        int64  `json:"id"`
+	PessoaID  int64  `json:"pessoa_id"`
+	Tipo      string `json:"tipo"`
+	Valor     string `json:"valor"`
+	Principal bool   `json:"principal"`
+}
+
+// Parentescos aceitos nos contatos de emergência
+var Parentescos = []string{"CONJUGE", "COMPANHEIRO", "PAI", "MAE", "FILHO", "IRMAO", "AVO", "NETO", "TIO", "SOBRINHO", "PRIMO", "AMIGO", "OUTRO"}
+
+// ContatoEmergencia é quem avisar em caso de emergência
+type ContatoEmergencia struct {
+	ID         int64  `json:"id"`
+	PessoaID   int64  `json:"pessoa_id"`
+	Nome       string `json:"nome"`
+	Parentesco string `json:"parentesco"`
+	Telefone   string `json:"telefone"`
+}
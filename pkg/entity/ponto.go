@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// PontoDia é a apuração do ponto de um funcionário em um dia, a partir das marcações importadas do relógio
+type PontoDia struct {
+	ID                 int64     `json:"id"`
+	FuncionarioID      int64     `json:"funcionario_id"`
+	Data               time.Time `json:"data"`
+	Marcacoes          []string  `json:"marcacoes"` // horários "hh:mm" em ordem: entrada, saída, entrada, saída... ("hh:mm+1" é da madrugada seguinte)
+	MinutosPrevistos   int       `json:"minutos_previstos"`
+	MinutosTrabalhados int       `json:"minutos_trabalhados"`
+	MinutosAtraso      int       `json:"minutos_atraso"`    // jornada não cumprida (entrada tardia ou saída antecipada)
+	MinutosExtra       int       `json:"minutos_extra"`     // horas extras a 50%
+	MinutosExtra100    int       `json:"minutos_extra_100"` // trabalho em domingo ou feriado, a 100%
+	Falta              bool      `json:"falta"`             // dia útil sem nenhuma marcação
+	Inconsistente      bool      `json:"inconsistente"`     // número ímpar de marcações
+}
+
+// NewPontoDia cria a apuração vazia de um dia
+func NewPontoDia(funcionarioID int64, data time.Time) *PontoDia {
+	return &PontoDia{
+		FuncionarioID: funcionarioID,
+		Data:          data,
+		Marcacoes:     []string{},
+	}
+}
@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+// Escopos e modos de RegraAusencia
+const (
+	RegraAusenciaEscopoCargo        = "CARGO"
+	RegraAusenciaEscopoDepartamento = "DEPARTAMENTO" // pela alocação vigente em cada dia
+
+	RegraAusenciaModoAviso    = "AVISO"    // permite o descanso, mas gera aviso
+	RegraAusenciaModoBloqueio = "BLOQUEIO" // impede a criação do descanso
+)
+
+// RegraAusencia limita quantas pessoas de um mesmo grupo (cargo ou departamento) podem estar de descanso ao mesmo tempo
+type RegraAusencia struct {
+	ID          int64  `json:"id"`
+	Escopo      string `json:"escopo"`
+	Referencia  string `json:"referencia"` // nome do cargo ou do departamento
+	MaxAusentes int    `json:"max_ausentes"`
+	Modo        string `json:"modo"`
+	Ativo       bool   `json:"ativo"`
+}
+
+// NewRegraAusencia cria uma regra ativa
+func NewRegraAusencia(escopo, referencia string, maxAusentes int, modo string) *RegraAusencia {
+	return &RegraAusencia{
+		Escopo:      escopo,
+		Referencia:  referencia,
+		MaxAusentes: maxAusentes,
+		Modo:        modo,
+		Ativo:       true,
+	}
+}
+
+// Bloqueia indica se a regra impede a criação de descansos acima do limite
+func (r *RegraAusencia) Bloqueia() bool {
+	return r.Modo == RegraAusenciaModoBloqueio
+}
+
+// ConflitoAusencia descreve um intervalo em que uma regra de ausência é excedida
+type ConflitoAusencia struct {
+	RegraID    int64     `json:"regra_id"`
+	Escopo     string    `json:"escopo"`
+	Referencia string    `json:"referencia"`
+	Modo       string    `json:"modo"`
+	Inicio     time.Time `json:"inicio"`
+	Fim        time.Time `json:"fim"`
+	Ausentes   int       `json:"ausentes"` // pico de ausentes no intervalo
+	Limite     int       `json:"limite"`
+}
+
+// DescansoCalendario é um descanso com os dados do funcionário, usado no calendário de férias
+type DescansoCalendario struct {
+	DescansoID    int64     `json:"descanso_id"`
+	FeriasID      int64     `json:"ferias_id"`
+	FuncionarioID int64     `json:"funcionario_id"`
+	Nome          string    `json:"nome"`
+	Cargo         string    `json:"cargo"`
+	Inicio        time.Time `json:"inicio"`
+	Fim           time.Time `json:"fim"`
+	Aprovado      bool      `json:"aprovado"`
+	Pago          bool      `json:"pago"`
+}
@@ -24,6 +24,18 @@ func New(
 	folhaSvc *service.FolhaPagamentoService,
 	pagamentoSvc *service.PagamentoService,
 	avisoSvc *service.AvisoService,
+	pagamentoFeriasSvc *service.PagamentoFeriasService,
+	regraAusenciaSvc *service.RegraAusenciaService,
+	calendarioICSSvc *service.CalendarioICSService,
+	calendarioSvc *service.CalendarioService,
+	pontoSvc *service.PontoService,
+	jornadaSvc *service.JornadaService,
+	bancoHorasSvc *service.BancoHorasService,
+	cargoSvc *service.CargoService,
+	centroCustoSvc *service.CentroCustoService,
+	buscaSvc *service.BuscaService,
+	importacaoSvc *service.ImportacaoService,
+	dadosPessoaisSvc *service.DadosPessoaisService,
 
 ) http.Handler {
 	r := chi.NewRouter()
@@ -44,11 +56,26 @@ func New(
 	pagamentoCtl := controller.NewPagamentoController(pagamentoSvc)
 	logCtl := controller.NewLogController()
 	avisoCtl := controller.NewAvisoController(avisoSvc)
+	pagamentoFeriasCtl := controller.NewPagamentoFeriasController(pagamentoFeriasSvc)
+	regraAusenciaCtl := controller.NewRegraAusenciaController(regraAusenciaSvc)
+	calendarioICSCtl := controller.NewCalendarioICSController(calendarioICSSvc)
+	calendarioCtl := controller.NewCalendarioController(calendarioSvc)
+	pontoCtl := controller.NewPontoController(pontoSvc)
+	jornadaCtl := controller.NewJornadaController(jornadaSvc)
+	bancoHorasCtl := controller.NewBancoHorasController(bancoHorasSvc)
+	cargoCtl := controller.NewCargoController(cargoSvc)
+	centroCustoCtl := controller.NewCentroCustoController(centroCustoSvc)
+	buscaCtl := controller.NewBuscaController(buscaSvc)
+	importacaoCtl := controller.NewImportacaoController(importacaoSvc)
+	dadosPessoaisCtl := controller.NewDadosPessoaisController(dadosPessoaisSvc)
 
 	// Rota pública
 	r.Post("/auth/login", authCtl.Login)
 	r.Post("/auth/logout", authCtl.Logout)
 
+	// Feeds iCalendar: públicos, autenticados pelo token na URL
+	r.Get("/ics/{token}/{feed}.ics", calendarioICSCtl.Feed)
+
 	// Rota autenticada básica
 	r.Group(func(r chi.Router) {
 		r.Use(middleware.RequireAuth(auth))
@@ -63,8 +90,30 @@ func New(
 
 	r.With(middleware.RequireAuth(auth)).Get("/avisos", avisoCtl.List)
 
+	// Busca global em pessoas, funcionários, cargos, documentos, vales e folhas
+	r.With(middleware.RequireAuth(auth)).Get("/busca", buscaCtl.Buscar)
+
+	// Importação de funcionários em lote: validação (dry-run), confirmação e downloads
+	r.Route("/importacoes", func(r chi.Router) {
+		r.Use(middleware.RequirePerm(auth, "funcionario:create"))
+		r.Get("/", importacaoCtl.ListImportacoes)
+		r.Post("/funcionarios", importacaoCtl.ImportarFuncionarios)
+		r.Get("/{id}", importacaoCtl.GetImportacao)
+		r.Post("/{id}/confirmar", importacaoCtl.ConfirmarImportacao)
+		r.Get("/{id}/arquivo", importacaoCtl.DownloadArquivo)
+		r.Get("/{id}/relatorio", importacaoCtl.DownloadRelatorio)
+	})
+
+	// Token de assinatura dos feeds .ics do usuário logado
+	r.With(middleware.RequireAuth(auth)).Post("/ics/token", calendarioICSCtl.GerarToken)
+	r.With(middleware.RequireAuth(auth)).Delete("/ics/token", calendarioICSCtl.RevogarToken)
+
 	r.Route("/admin", func(r chi.Router) {
 		r.With(middleware.RequirePerm(auth, "usuario:list")).Get("/logs", logCtl.List)
+
+		// Retenção LGPD: ex-funcionários com prazo de guarda vencido
+		r.With(middleware.RequirePerm(auth, "lgpd:retencao")).Get("/lgpd/retencao", dadosPessoaisCtl.ListarRetencao)
+		r.With(middleware.RequirePerm(auth, "lgpd:retencao")).Post("/lgpd/retencao", dadosPessoaisCtl.AplicarRetencao)
 	})
 
 	// Rotas com permissão para gerenciar usuários
@@ -89,6 +138,20 @@ func New(
 		r.With(middleware.RequireAuth(auth)).Put("/{id}", pessoaCtl.UpdatePessoa)
 		r.With(middleware.RequirePerm(auth, "pessoa:delete")).Delete("/{id}", pessoaCtl.DeletePessoa)
 		r.With(middleware.RequireAuth(auth)).Get("/{id}", pessoaCtl.GetPessoaByID)
+		r.With(middleware.RequireAuth(auth)).Get("/dominios", pessoaCtl.Dominios)
+
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/endereco", pessoaCtl.GetEndereco)
+		r.With(middleware.RequireAuth(auth)).Put("/{id}/endereco", pessoaCtl.SalvarEndereco)
+		r.With(middleware.RequireAuth(auth)).Delete("/{id}/endereco", pessoaCtl.RemoverEndereco)
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/contatos", pessoaCtl.ListarContatos)
+		r.With(middleware.RequireAuth(auth)).Put("/{id}/contatos", pessoaCtl.SalvarContatos)
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/contatos-emergencia", pessoaCtl.ListarContatosEmergencia)
+		r.With(middleware.RequireAuth(auth)).Put("/{id}/contatos-emergencia", pessoaCtl.SalvarContatosEmergencia)
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/vinculos", pessoaCtl.ListarVinculos)
+
+		// LGPD: acesso do titular aos seus dados e anonimização
+		r.With(middleware.RequirePerm(auth, "lgpd:export")).Get("/{id}/dados-pessoais", dadosPessoaisCtl.ExportarDadosPessoais)
+		r.With(middleware.RequirePerm(auth, "lgpd:anonimizar")).Post("/{id}/anonimizar", dadosPessoaisCtl.AnonimizarPessoa)
 	})
 
 	// Rotas de Funcionários
@@ -102,6 +165,14 @@ func New(
 		r.With(middleware.RequirePerm(auth, "funcionario:delete")).Delete("/{id}", funcionarioCtl.DeleteFuncionario)
 		r.With(middleware.RequireAuth(auth)).Get("/{id}", funcionarioCtl.GetFuncionarioByID)
 
+		// Dossiê: vínculo, seções pedidas em ?incluir= e totais (tempo de casa, saldo de férias, vales em aberto)
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/dossie", funcionarioCtl.Dossie)
+
+		// Contrato de experiência
+		r.With(middleware.RequirePerm(auth, "funcionario:update")).Put("/{id}/experiencia/prorrogar", funcionarioCtl.ProrrogarExperiencia)
+		r.With(middleware.RequirePerm(auth, "funcionario:update")).Put("/{id}/experiencia/efetivar", funcionarioCtl.EfetivarContrato)
+		r.With(middleware.RequirePerm(auth, "funcionario:update")).Put("/{id}/experiencia/encerrar", funcionarioCtl.EncerrarExperiencia)
+
 		// Documentos dentro de funcionário
 		r.With(middleware.RequireAuth(auth)).Post("/{id}/documentos", documentoCtl.CreateDocumento)
 		r.With(middleware.RequireAuth(auth)).Get("/{id}/documentos", documentoCtl.GetDocumentosByFuncionarioID)
@@ -111,10 +182,36 @@ func New(
 		r.With(middleware.RequireAuth(auth)).Post("/{id}/faltas", faltaCtl.CreateFalta)
 		r.With(middleware.RequireAuth(auth)).Put("/{id}/faltas/mensal", faltaCtl.UpsertMensal)
 
+		// Ponto apurado a partir do AFD
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/ponto", pontoCtl.ListarMes)
+
+		// Jornadas atribuídas e jornada prevista
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/jornadas", jornadaCtl.ListAtribuicoes)
+		r.With(middleware.RequirePerm(auth, "jornada:update")).Post("/{id}/jornadas", jornadaCtl.Atribuir)
+		r.With(middleware.RequirePerm(auth, "jornada:update")).Delete("/{id}/jornadas/{atribuicaoID}", jornadaCtl.RemoverAtribuicao)
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/jornada-prevista", jornadaCtl.Prevista)
+
+		// Histórico de cargos e promoções
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/cargos", cargoCtl.Historico)
+		r.With(middleware.RequirePerm(auth, "funcionario:update")).Post("/{id}/cargos", cargoCtl.Promover)
+
+		// Alocação em departamentos e centros de custo
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/alocacoes", centroCustoCtl.ListAlocacoes)
+		r.With(middleware.RequirePerm(auth, "centrocusto:update")).Put("/{id}/alocacoes", centroCustoCtl.DefinirAlocacao)
+		r.With(middleware.RequirePerm(auth, "centrocusto:update")).Delete("/{id}/alocacoes", centroCustoCtl.RemoverAlocacao)
+
+		// Banco de horas
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/banco-horas", bancoHorasCtl.Extrato)
+		r.With(middleware.RequirePerm(auth, "bancohoras:update")).Post("/{id}/banco-horas", bancoHorasCtl.Lancar)
+		r.With(middleware.RequirePerm(auth, "bancohoras:update")).Post("/{id}/banco-horas/folga", bancoHorasCtl.Folga)
+		r.With(middleware.RequirePerm(auth, "bancohoras:update")).Put("/{id}/banco-horas/config", bancoHorasCtl.Configurar)
+		r.With(middleware.RequirePerm(auth, "bancohoras:update")).Delete("/{id}/banco-horas/{lancamentoID}", bancoHorasCtl.Excluir)
+
 		// Férias dentro de funcionário
 		r.With(middleware.RequireAuth(auth)).Get("/{id}/ferias", feriasCtl.GetFeriasByFuncionarioID)
 		// NOVO: recompor períodos de férias automaticamente (retroativos a partir da admissão)
 		r.With(middleware.RequireAuth(auth)).Post("/{id}/ferias/garantir", feriasCtl.Garantir)
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/ferias/projecao", feriasCtl.Projecao)
 
 		// Descansos dentro de funcionário
 		r.With(middleware.RequireAuth(auth)).Get("/{id}/descansos", descansoCtl.ListByFuncionario)
@@ -139,6 +236,14 @@ func New(
 	//rotas diretas de Ferias
 	r.Route("/ferias", func(r chi.Router) {
 		r.With(middleware.RequireAuth(auth)).Get("/", feriasCtl.ListFerias)
+		r.With(middleware.RequireAuth(auth)).Get("/calendario", feriasCtl.Calendario)
+
+		// Limites de ausência simultânea (por cargo)
+		r.With(middleware.RequireAuth(auth)).Get("/regras-ausencia", regraAusenciaCtl.List)
+		r.With(middleware.RequirePerm(auth, "ferias:update")).Post("/regras-ausencia", regraAusenciaCtl.Create)
+		r.With(middleware.RequirePerm(auth, "ferias:update")).Put("/regras-ausencia/{id}", regraAusenciaCtl.Update)
+		r.With(middleware.RequirePerm(auth, "ferias:update")).Delete("/regras-ausencia/{id}", regraAusenciaCtl.Delete)
+
 		r.With(middleware.RequireAuth(auth)).Get("/{id}", feriasCtl.GetFeriasByID)
 		r.With(middleware.RequirePerm(auth, "ferias:update")).Put("/{id}/vencida", feriasCtl.MarcarComoVencidas)
 		r.With(middleware.RequireAuth(auth)).Put("/{id}/terco-pago", feriasCtl.MarcarTercoComoPago)
@@ -151,6 +256,17 @@ func New(
 		r.With(middleware.RequireAuth(auth)).Get("/ferias/{id}/descansos", descansoCtl.ListByFerias)
 	})
 
+	// Férias coletivas
+	r.With(middleware.RequirePerm(auth, "ferias:create")).Post("/ferias-coletivas", feriasCtl.CriarColetivas)
+
+	// Pagamentos de férias (devidos 2 dias antes do descanso)
+	r.Route("/pagamentos-ferias", func(r chi.Router) {
+		r.With(middleware.RequireAuth(auth)).Get("/", pagamentoFeriasCtl.List)
+		r.With(middleware.RequireAuth(auth)).Get("/{id}", pagamentoFeriasCtl.GetByID)
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/recibo", pagamentoFeriasCtl.Recibo)
+		r.With(middleware.RequirePerm(auth, "ferias:update")).Put("/{id}/pagar", pagamentoFeriasCtl.Pagar)
+	})
+
 	// Rotas diretas de Descansos
 	r.Route("/descansos", func(r chi.Router) {
 		r.With(middleware.RequireAuth(auth)).Post("/", descansoCtl.Create)
@@ -158,11 +274,58 @@ func New(
 		r.With(middleware.RequirePerm(auth, "descanso:update")).Put("/{id}/pagar", descansoCtl.Pagar)
 		r.With(middleware.RequirePerm(auth, "descanso:update")).Put("/{id}/desmarcar-pago", descansoCtl.DesmarcarPago)
 		r.With(middleware.RequirePerm(auth, "descanso:delete")).Delete("/{id}", descansoCtl.Delete)
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/pagamento", pagamentoFeriasCtl.GetByDescanso)
+		r.With(middleware.RequirePerm(auth, "ferias:update")).Post("/{id}/pagamento", pagamentoFeriasCtl.Gerar)
 
 		r.With(middleware.RequireAuth(auth)).Get("/aprovados", descansoCtl.ListAprovados)
 		r.With(middleware.RequireAuth(auth)).Get("/pendentes", descansoCtl.ListPendentes)
 	})
 
+	// Ponto: importação do AFD do relógio (REP)
+	r.With(middleware.RequireAuth(auth)).Post("/ponto/importar", pontoCtl.Importar)
+
+	// Jornadas (modelos de escala de trabalho)
+	r.Route("/jornadas", func(r chi.Router) {
+		r.With(middleware.RequireAuth(auth)).Get("/", jornadaCtl.List)
+		r.With(middleware.RequireAuth(auth)).Get("/modelos", jornadaCtl.Modelos)
+		r.With(middleware.RequireAuth(auth)).Get("/{id}", jornadaCtl.Get)
+		r.With(middleware.RequirePerm(auth, "jornada:update")).Post("/", jornadaCtl.Create)
+		r.With(middleware.RequirePerm(auth, "jornada:update")).Put("/{id}", jornadaCtl.Update)
+		r.With(middleware.RequirePerm(auth, "jornada:update")).Delete("/{id}", jornadaCtl.Delete)
+	})
+
+	// Catálogo de cargos (CBO e faixa salarial)
+	r.Route("/cargos", func(r chi.Router) {
+		r.With(middleware.RequireAuth(auth)).Get("/", cargoCtl.List)
+		r.With(middleware.RequireAuth(auth)).Get("/{id}", cargoCtl.Get)
+		r.With(middleware.RequirePerm(auth, "cargo:update")).Post("/", cargoCtl.Create)
+		r.With(middleware.RequirePerm(auth, "cargo:update")).Put("/{id}", cargoCtl.Update)
+		r.With(middleware.RequirePerm(auth, "cargo:update")).Delete("/{id}", cargoCtl.Delete)
+	})
+
+	// Centros de custo e departamentos
+	r.Route("/centros-de-custo", func(r chi.Router) {
+		r.With(middleware.RequireAuth(auth)).Get("/", centroCustoCtl.ListCentros)
+		r.With(middleware.RequirePerm(auth, "centrocusto:update")).Post("/", centroCustoCtl.CreateCentro)
+		r.With(middleware.RequirePerm(auth, "centrocusto:update")).Put("/{id}", centroCustoCtl.UpdateCentro)
+		r.With(middleware.RequirePerm(auth, "centrocusto:update")).Delete("/{id}", centroCustoCtl.DeleteCentro)
+	})
+	r.Route("/departamentos", func(r chi.Router) {
+		r.With(middleware.RequireAuth(auth)).Get("/", centroCustoCtl.ListDepartamentos)
+		r.With(middleware.RequirePerm(auth, "centrocusto:update")).Post("/", centroCustoCtl.CreateDepartamento)
+		r.With(middleware.RequirePerm(auth, "centrocusto:update")).Put("/{id}", centroCustoCtl.UpdateDepartamento)
+		r.With(middleware.RequirePerm(auth, "centrocusto:update")).Delete("/{id}", centroCustoCtl.DeleteDepartamento)
+	})
+
+	// Calendário: feriados nacionais calculados, estaduais/municipais e folgas da empresa
+	r.Route("/calendario", func(r chi.Router) {
+		r.With(middleware.RequireAuth(auth)).Get("/", calendarioCtl.ListarAno)
+		r.With(middleware.RequireAuth(auth)).Get("/dias-uteis", calendarioCtl.DiasUteis)
+		r.With(middleware.RequirePerm(auth, "calendario:update")).Post("/", calendarioCtl.Create)
+		r.With(middleware.RequirePerm(auth, "calendario:update")).Put("/{id}", calendarioCtl.Update)
+		r.With(middleware.RequirePerm(auth, "calendario:update")).Delete("/{id}", calendarioCtl.Delete)
+	})
+
 	// Salários (por funcionário e individuais)
 	r.With(middleware.RequireAuth(auth)).Post("/funcionarios/{id}/salarios", salarioCtl.Create)
 	r.With(middleware.RequireAuth(auth)).Get("/funcionarios/{id}/salarios", salarioCtl.ListByFuncionario)
@@ -203,6 +366,7 @@ func New(
 		r.With(middleware.RequirePerm(auth, "folha:update")).Put("/{id}/fechar", folhaCtl.FecharFolha)
 		r.With(middleware.RequirePerm(auth, "folha:delete")).Delete("/{id}", folhaCtl.ExcluirFolha)
 		r.With(middleware.RequireAuth(auth)).Get("/{id}/pagamentos", pagamentoCtl.ListarPagamentosDaFolha)
+		r.With(middleware.RequireAuth(auth)).Get("/{id}/centros-de-custo", centroCustoCtl.ResumoFolha)
 	})
 
 	// Pagamentos
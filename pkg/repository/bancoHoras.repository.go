@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"AutoGRH/pkg/utils/nullStringToTimePtr"
+	"AutoGRH/pkg/utils/timeToDateString"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+const bancoHorasColumns = `bancoHorasID, funcionarioID, data, tipo, minutos, vencimento, origem, referencia, descricao`
+
+func vencimentoParam(l *entity.BancoHorasLancamento) interface{} {
+	if l.Vencimento == nil {
+		return nil
+	}
+	return timeToDateString.TimeToDateString(*l.Vencimento)
+}
+
+// CreateLancamentoBancoHoras insere uma movimentação no banco de horas
+func CreateLancamentoBancoHoras(l *entity.BancoHorasLancamento) error {
+	return insertLancamentoBancoHoras(DB, l)
+}
+
+func insertLancamentoBancoHoras(db executor, l *entity.BancoHorasLancamento) error {
+	query := `INSERT INTO banco_horas (funcionarioID, data, tipo, minutos, vencimento, origem, referencia, descricao)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := db.Exec(query, l.FuncionarioID, timeToDateString.TimeToDateString(l.Data), l.Tipo, l.Minutos,
+		vencimentoParam(l), l.Origem, l.Referencia, l.Descricao)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir lançamento do banco de horas: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID do lançamento do banco de horas: %w", err)
+	}
+	l.ID = id
+	return nil
+}
+
+// CreateFolgaCompensada grava numa única transação a ausência ABONADA da folga e o débito no banco de
+// horas que a referencia
+func CreateFolgaCompensada(falta *entity.Falta, l *entity.BancoHorasLancamento) (err error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação da folga compensada: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				log.Printf("erro ao desfazer transação da folga compensada: %v", rerr)
+			}
+		}
+	}()
+
+	if err = insertFalta(tx, falta); err != nil {
+		return err
+	}
+	l.Referencia = &falta.ID
+	if err = insertLancamentoBancoHoras(tx, l); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar folga compensada: %w", err)
+	}
+	return nil
+}
+
+// UpsertLancamentoBancoHoras grava o lançamento automático de uma origem, substituindo o existente
+// para o mesmo funcionário, tipo, origem e referência
+func UpsertLancamentoBancoHoras(l *entity.BancoHorasLancamento) error {
+	query := `INSERT INTO banco_horas (funcionarioID, data, tipo, minutos, vencimento, origem, referencia, descricao)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			bancoHorasID = LAST_INSERT_ID(bancoHorasID),
+			data = VALUES(data),
+			minutos = VALUES(minutos),
+			vencimento = VALUES(vencimento),
+			descricao = VALUES(descricao)`
+
+	result, err := DB.Exec(query, l.FuncionarioID, timeToDateString.TimeToDateString(l.Data), l.Tipo, l.Minutos,
+		vencimentoParam(l), l.Origem, l.Referencia, l.Descricao)
+	if err != nil {
+		return fmt.Errorf("erro ao gravar lançamento do banco de horas: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID do lançamento do banco de horas: %w", err)
+	}
+	l.ID = id
+	return nil
+}
+
+func scanLancamentoBancoHoras(row rowScanner) (*entity.BancoHorasLancamento, error) {
+	var l entity.BancoHorasLancamento
+	var dataStr string
+	var vencimento sql.NullString
+	var referencia sql.NullInt64
+	if err := row.Scan(&l.ID, &l.FuncionarioID, &dataStr, &l.Tipo, &l.Minutos, &vencimento, &l.Origem, &referencia, &l.Descricao); err != nil {
+		return nil, err
+	}
+	var err error
+	if l.Data, err = dateStringToTime.DateStringToTime(dataStr); err != nil {
+		return nil, fmt.Errorf("erro ao converter data do lançamento: %w", err)
+	}
+	if l.Vencimento, err = nullStringToTimePtr.NullStringToTimePtr(vencimento); err != nil {
+		return nil, fmt.Errorf("erro ao converter vencimento do lançamento: %w", err)
+	}
+	if referencia.Valid {
+		ref := referencia.Int64
+		l.Referencia = &ref
+	}
+	return &l, nil
+}
+
+// GetLancamentoBancoHorasByID busca uma movimentação do banco de horas
+func GetLancamentoBancoHorasByID(id int64) (*entity.BancoHorasLancamento, error) {
+	l, err := scanLancamentoBancoHoras(DB.QueryRow(`SELECT `+bancoHorasColumns+` FROM banco_horas WHERE bancoHorasID = ?`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar lançamento do banco de horas: %w", err)
+	}
+	return l, nil
+}
+
+// ListLancamentosBancoHorasByFuncionario lista as movimentações do funcionário em ordem cronológica
+func ListLancamentosBancoHorasByFuncionario(funcionarioID int64) ([]*entity.BancoHorasLancamento, error) {
+	rows, err := DB.Query(`SELECT `+bancoHorasColumns+` FROM banco_horas WHERE funcionarioID = ? ORDER BY data, bancoHorasID`, funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar banco de horas: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.BancoHorasLancamento
+	for rows.Next() {
+		l, err := scanLancamentoBancoHoras(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler lançamento do banco de horas: %w", err)
+		}
+		lista = append(lista, l)
+	}
+	return lista, rows.Err()
+}
+
+// DeleteLancamentoBancoHoras remove uma movimentação
+func DeleteLancamentoBancoHoras(id int64) error {
+	if _, err := DB.Exec(`DELETE FROM banco_horas WHERE bancoHorasID = ?`, id); err != nil {
+		return fmt.Errorf("erro ao deletar lançamento do banco de horas: %w", err)
+	}
+	return nil
+}
+
+// DeleteLancamentosBancoHorasByReferencia remove os lançamentos automáticos de um registro de origem
+func DeleteLancamentosBancoHorasByReferencia(funcionarioID int64, tipo, origem string, referencia int64) error {
+	if _, err := DB.Exec(`DELETE FROM banco_horas WHERE funcionarioID = ? AND tipo = ? AND origem = ? AND referencia = ?`,
+		funcionarioID, tipo, origem, referencia); err != nil {
+		return fmt.Errorf("erro ao remover lançamentos do banco de horas: %w", err)
+	}
+	return nil
+}
+
+// GetBancoHorasConfig busca a configuração de banco de horas do funcionário (nil se nunca configurada)
+func GetBancoHorasConfig(funcionarioID int64) (*entity.BancoHorasConfig, error) {
+	var c entity.BancoHorasConfig
+	err := DB.QueryRow(`SELECT funcionarioID, ativo, mesesValidade FROM banco_horas_config WHERE funcionarioID = ?`, funcionarioID).
+		Scan(&c.FuncionarioID, &c.Ativo, &c.MesesValidade)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar configuração do banco de horas: %w", err)
+	}
+	return &c, nil
+}
+
+// SaveBancoHorasConfig grava a configuração de banco de horas do funcionário
+func SaveBancoHorasConfig(c *entity.BancoHorasConfig) error {
+	query := `INSERT INTO banco_horas_config (funcionarioID, ativo, mesesValidade) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE ativo = VALUES(ativo), mesesValidade = VALUES(mesesValidade)`
+	if _, err := DB.Exec(query, c.FuncionarioID, c.Ativo, c.MesesValidade); err != nil {
+		return fmt.Errorf("erro ao gravar configuração do banco de horas: %w", err)
+	}
+	return nil
+}
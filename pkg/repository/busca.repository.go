@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// buscaFullText indica se os índices FULLTEXT da busca global foram criados. Sem eles (ou em bases
+// sem suporte), a busca usa LIKE.
+var buscaFullText bool
+
+// tamanhoMinimoFullText é o menor termo indexado pelo InnoDB (innodb_ft_min_token_size)
+const tamanhoMinimoFullText = 3
+
+// criarIndicesBusca cria os índices FULLTEXT dos nomes de pessoas e dos cargos dos funcionários.
+// Falhas não interrompem a inicialização: a busca passa a usar LIKE.
+func criarIndicesBusca() {
+	buscaFullText = addFullTextIfNotExists("pessoa", "ft_pessoa_nome", "nome, nomeSocial") &&
+		addFullTextIfNotExists("funcionario", "ft_funcionario_cargo", "cargo")
+	if !buscaFullText {
+		log.Println("Busca global sem índices FULLTEXT; usando LIKE.")
+	}
+}
+
+func addFullTextIfNotExists(table, index, columns string) bool {
+	var count int
+	if err := DB.QueryRow(`SELECT COUNT(*) FROM information_schema.STATISTICS
+	           WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?`, table, index).Scan(&count); err != nil {
+		log.Printf("erro ao verificar índice %s: %v", index, err)
+		return false
+	}
+	if count > 0 {
+		return true
+	}
+	if _, err := DB.Exec(fmt.Sprintf("CREATE FULLTEXT INDEX %s ON %s (%s)", index, table, columns)); err != nil {
+		log.Printf("erro ao criar índice FULLTEXT %s: %v", index, err)
+		return false
+	}
+	return true
+}
+
+// fonteBusca é uma consulta da busca global. select deve trazer id, título e detalhe.
+type fonteBusca struct {
+	tipo     string
+	sel      string
+	colunas  []string // colunas pesquisadas
+	fullText bool     // colunas cobertas por um índice FULLTEXT (na mesma ordem)
+	filtro   string   // condição fixa adicional
+	cifrada  string   // coluna cifrável (tabela.coluna) trazida no detalhe
+}
+
+var fontesTexto = []fonteBusca{
+	{
+		tipo:     entity.BuscaPessoa,
+		sel:      `SELECT p.pessoaID, p.nome, p.nomeSocial FROM pessoa p`,
+		colunas:  []string{"p.nome", "p.nomeSocial"},
+		fullText: true,
+	},
+	{
+		tipo: entity.BuscaFuncionario,
+		sel: `SELECT f.funcionarioID, p.nome, CONCAT(f.cargo, IF(f.ativo, '', ' (desligado)'))
+		      FROM funcionario f JOIN pessoa p ON p.pessoaID = f.pessoaID`,
+		colunas:  []string{"p.nome", "p.nomeSocial"},
+		fullText: true,
+	},
+	{
+		tipo: entity.BuscaFuncionario,
+		sel: `SELECT f.funcionarioID, p.nome, CONCAT(f.cargo, IF(f.ativo, '', ' (desligado)'))
+		      FROM funcionario f JOIN pessoa p ON p.pessoaID = f.pessoaID`,
+		colunas:  []string{"f.cargo"},
+		fullText: true,
+	},
+	{
+		tipo:    entity.BuscaCargo,
+		sel:     `SELECT c.cargoID, c.titulo, c.cbo FROM cargo c`,
+		colunas: []string{"c.titulo"},
+	},
+	{
+		tipo: entity.BuscaDocumento,
+		sel: `SELECT d.documentoID, d.caminho, COALESCE(p.nome, '')
+		      FROM documento d
+		      LEFT JOIN funcionario f ON f.funcionarioID = d.funcionarioID
+		      LEFT JOIN pessoa p ON p.pessoaID = f.pessoaID`,
+		colunas: []string{"d.caminho"},
+	},
+}
+
+// BuscaTexto procura o termo em nomes, cargos e nomes de arquivo. Cada palavra do termo precisa
+// aparecer em alguma das colunas da fonte; a ordenação por relevância fica com o service.
+func BuscaTexto(termo string, limite int) ([]entity.ResultadoBusca, error) {
+	palavras := strings.Fields(strings.ToLower(termo))
+	if len(palavras) == 0 {
+		return nil, nil
+	}
+	var out []entity.ResultadoBusca
+	for _, f := range fontesTexto {
+		itens, err := buscarFonte(f, palavras, limite)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, itens...)
+	}
+	return out, nil
+}
+
+func buscarFonte(f fonteBusca, palavras []string, limite int) ([]entity.ResultadoBusca, error) {
+	if f.fullText && buscaFullText && fullTextAplicavel(palavras) {
+		cond, args := condicaoFullText(f.colunas, palavras)
+		itens, err := consultarFonte(f, cond, args, limite)
+		if err == nil {
+			return itens, nil
+		}
+		// índice removido ou base sem suporte: segue com LIKE
+		log.Printf("busca FULLTEXT em %s falhou, usando LIKE: %v", f.tipo, err)
+	}
+	cond, args := condicaoLike(f.colunas, palavras)
+	return consultarFonte(f, cond, args, limite)
+}
+
+// fullTextAplicavel indica se todas as palavras têm o tamanho mínimo indexado
+func fullTextAplicavel(palavras []string) bool {
+	for _, p := range palavras {
+		if len([]rune(p)) < tamanhoMinimoFullText {
+			return false
+		}
+	}
+	return true
+}
+
+// condicaoFullText exige todas as palavras, como prefixo, no modo booleano
+func condicaoFullText(colunas, palavras []string) (string, []interface{}) {
+	limpa := strings.NewReplacer("+", "", "-", "", "<", "", ">", "", "(", "", ")", "", "~", "", "*", "", `"`, "", "@", "")
+	termos := make([]string, 0, len(palavras))
+	for _, p := range palavras {
+		if p = limpa.Replace(p); p != "" {
+			termos = append(termos, "+"+p+"*")
+		}
+	}
+	return fmt.Sprintf("MATCH(%s) AGAINST (? IN BOOLEAN MODE)", strings.Join(colunas, ", ")),
+		[]interface{}{strings.Join(termos, " ")}
+}
+
+func condicaoLike(colunas, palavras []string) (string, []interface{}) {
+	escapa := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	var conds []string
+	var args []interface{}
+	for _, p := range palavras {
+		alternativas := make([]string, 0, len(colunas))
+		for _, c := range colunas {
+			alternativas = append(alternativas, "LOWER("+c+") LIKE ?")
+			args = append(args, "%"+escapa.Replace(p)+"%")
+		}
+		conds = append(conds, "("+strings.Join(alternativas, " OR ")+")")
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+func consultarFonte(f fonteBusca, cond string, args []interface{}, limite int) ([]entity.ResultadoBusca, error) {
+	query := f.sel + " WHERE " + cond
+	if f.filtro != "" {
+		query += " AND " + f.filtro
+	}
+	query += " LIMIT ?"
+	rows, err := DB.Query(query, append(args, limite)...)
+	if err != nil {
+		return nil, fmt.Errorf("erro na busca de %s: %w", strings.ToLower(f.tipo), err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			log.Printf("erro ao fechar rows em consultarFonte: %v", cerr)
+		}
+	}()
+
+	var out []entity.ResultadoBusca
+	for rows.Next() {
+		r := entity.ResultadoBusca{Tipo: f.tipo}
+		if err := rows.Scan(&r.ID, &r.Titulo, &r.Detalhe); err != nil {
+			return nil, fmt.Errorf("erro ao ler resultado da busca: %w", err)
+		}
+		if f.cifrada != "" {
+			if r.Detalhe, err = decifrar(f.cifrada, r.Detalhe); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// fontesDigitos procuram trechos de CPF e PIS, gravados só com dígitos
+var fontesDigitos = []fonteBusca{
+	{
+		tipo:    entity.BuscaPessoa,
+		sel:     `SELECT p.pessoaID, p.nome, p.cpf FROM pessoa p`,
+		colunas: []string{"p.cpf"},
+		cifrada: "pessoa.cpf",
+	},
+	{
+		tipo: entity.BuscaFuncionario,
+		sel: `SELECT f.funcionarioID, p.nome, f.pis
+		      FROM funcionario f JOIN pessoa p ON p.pessoaID = f.pessoaID`,
+		colunas: []string{"f.pis"},
+		cifrada: "funcionario.pis",
+	},
+}
+
+// BuscaDigitos procura um trecho de CPF ou PIS. Com a coluna cifrada, só o número completo é achado
+// (pelo índice cego); trechos continuam valendo nas linhas ainda em texto puro.
+func BuscaDigitos(digitos string, limite int) ([]entity.ResultadoBusca, error) {
+	var out []entity.ResultadoBusca
+	for _, f := range fontesDigitos {
+		cond, args := f.colunas[0]+" LIKE ?", []interface{}{"%" + digitos + "%"}
+		if idx := indiceCego(f.cifrada, digitos); idx.Valid {
+			exprIndice := f.colunas[0][:strings.Index(f.colunas[0], ".")+1] + colunaIndice(f.cifrada)
+			cond = fmt.Sprintf("(%s = ? OR (%s IS NULL AND %s))", exprIndice, exprIndice, cond)
+			args = append([]interface{}{idx}, args...)
+		}
+		itens, err := consultarFonte(f, cond, args, limite)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, itens...)
+	}
+	return out, nil
+}
+
+var (
+	fonteVale = fonteBusca{
+		tipo: entity.BuscaVale,
+		sel: `SELECT v.valeID, CONCAT('Vale #', v.valeID), CONCAT(p.nome, ' - ', DATE_FORMAT(v.data, '%d/%m/%Y'))
+		      FROM vale v
+		      JOIN funcionario f ON f.funcionarioID = v.funcionarioID
+		      JOIN pessoa p ON p.pessoaID = f.pessoaID`,
+		filtro: "v.ativo = TRUE",
+	}
+	fonteFolha = fonteBusca{
+		tipo: entity.BuscaFolha,
+		sel: `SELECT fp.folhaID, CONCAT('Folha #', fp.folhaID), CONCAT(fp.tipo, ' ', LPAD(fp.mes, 2, '0'), '/', fp.ano)
+		      FROM folha_pagamento fp`,
+	}
+)
+
+// BuscaValePorID devolve o vale com o ID informado, se existir e estiver ativo
+func BuscaValePorID(id int64) ([]entity.ResultadoBusca, error) {
+	return consultarFonte(fonteVale, "v.valeID = ?", []interface{}{id}, 1)
+}
+
+// BuscaFolhaPorID devolve a folha com o ID informado, se existir
+func BuscaFolhaPorID(id int64) ([]entity.ResultadoBusca, error) {
+	return consultarFonte(fonteFolha, "fp.folhaID = ?", []interface{}{id}, 1)
+}
+
+// BuscaFolhasPorCompetencia devolve as folhas (salário e vale) do mês
+func BuscaFolhasPorCompetencia(mes, ano int) ([]entity.ResultadoBusca, error) {
+	return consultarFonte(fonteFolha, "fp.mes = ? AND fp.ano = ?", []interface{}{mes, ano}, 10)
+}
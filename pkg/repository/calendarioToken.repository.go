@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"AutoGRH/pkg/utils/nullStringToTimePtr"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SaveCalendarioToken cria ou substitui o token de calendário do usuário
+func SaveCalendarioToken(t *entity.CalendarioToken) error {
+	query := `INSERT INTO calendario_token (usuarioID, tokenHash, criadoEm, ultimoAcesso)
+		VALUES (?, ?, ?, NULL)
+		ON DUPLICATE KEY UPDATE tokenHash = VALUES(tokenHash), criadoEm = VALUES(criadoEm), ultimoAcesso = NULL`
+
+	if _, err := DB.Exec(query, t.UsuarioID, t.TokenHash, t.CriadoEm); err != nil {
+		return fmt.Errorf("erro ao salvar token de calendário: %w", err)
+	}
+	err := DB.QueryRow(`SELECT calendarioTokenID FROM calendario_token WHERE usuarioID = ?`, t.UsuarioID).Scan(&t.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID do token de calendário: %w", err)
+	}
+	return nil
+}
+
+// GetCalendarioTokenByHash busca o token pelo hash
+func GetCalendarioTokenByHash(hash string) (*entity.CalendarioToken, error) {
+	query := `SELECT calendarioTokenID, usuarioID, tokenHash, criadoEm, ultimoAcesso
+		FROM calendario_token WHERE tokenHash = ?`
+
+	var t entity.CalendarioToken
+	var criadoEmStr string
+	var ultimoAcesso sql.NullString
+	err := DB.QueryRow(query, hash).Scan(&t.ID, &t.UsuarioID, &t.TokenHash, &criadoEmStr, &ultimoAcesso)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar token de calendário: %w", err)
+	}
+	if t.CriadoEm, err = dateStringToTime.DateStringToTime(criadoEmStr); err != nil {
+		return nil, fmt.Errorf("erro ao converter data de criação do token: %w", err)
+	}
+	if t.UltimoAcesso, err = nullStringToTimePtr.NullStringToTimePtr(ultimoAcesso); err != nil {
+		return nil, fmt.Errorf("erro ao converter último acesso do token: %w", err)
+	}
+	return &t, nil
+}
+
+// DeleteCalendarioTokenByUsuarioID revoga o token de calendário do usuário
+func DeleteCalendarioTokenByUsuarioID(usuarioID int64) error {
+	if _, err := DB.Exec(`DELETE FROM calendario_token WHERE usuarioID = ?`, usuarioID); err != nil {
+		return fmt.Errorf("erro ao revogar token de calendário: %w", err)
+	}
+	return nil
+}
+
+// TouchCalendarioToken registra o último acesso ao feed
+func TouchCalendarioToken(id int64, quando time.Time) error {
+	if _, err := DB.Exec(`UPDATE calendario_token SET ultimoAcesso = ? WHERE calendarioTokenID = ?`, quando, id); err != nil {
+		return fmt.Errorf("erro ao atualizar acesso do token de calendário: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"AutoGRH/pkg/utils/timeToDateString"
+	"database/sql"
+	"fmt"
+)
+
+// cargoColumns lista as colunas lidas por scanCargo
+const cargoColumns = `cargoID, titulo, cbo, salarioMin, salarioMax, departamento, ativo`
+
+func scanCargo(row rowScanner) (*entity.Cargo, error) {
+	var c entity.Cargo
+	if err := row.Scan(&c.ID, &c.Titulo, &c.CBO, &c.SalarioMin, &c.SalarioMax, &c.Departamento, &c.Ativo); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// CreateCargo insere um cargo no catálogo
+func CreateCargo(c *entity.Cargo) error {
+	query := `INSERT INTO cargo (titulo, cbo, salarioMin, salarioMax, departamento, ativo) VALUES (?, ?, ?, ?, ?, ?)`
+
+	result, err := DB.Exec(query, c.Titulo, c.CBO, c.SalarioMin, c.SalarioMax, c.Departamento, c.Ativo)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir cargo: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID do cargo: %w", err)
+	}
+	c.ID = id
+	return nil
+}
+
+// GetCargoByID busca um cargo pelo ID
+func GetCargoByID(id int64) (*entity.Cargo, error) {
+	c, err := scanCargo(DB.QueryRow(`SELECT `+cargoColumns+` FROM cargo WHERE cargoID = ?`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar cargo: %w", err)
+	}
+	return c, nil
+}
+
+// GetCargoByTitulo busca um cargo pelo título (comparação sem caixa)
+func GetCargoByTitulo(titulo string) (*entity.Cargo, error) {
+	c, err := scanCargo(DB.QueryRow(`SELECT `+cargoColumns+` FROM cargo WHERE LOWER(TRIM(titulo)) = LOWER(TRIM(?))`, titulo))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar cargo: %w", err)
+	}
+	return c, nil
+}
+
+// ListCargos lista o catálogo de cargos por título
+func ListCargos() ([]*entity.Cargo, error) {
+	rows, err := DB.Query(`SELECT ` + cargoColumns + ` FROM cargo ORDER BY titulo`)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar cargos: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.Cargo
+	for rows.Next() {
+		c, err := scanCargo(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler cargo: %w", err)
+		}
+		lista = append(lista, c)
+	}
+	return lista, rows.Err()
+}
+
+// UpdateCargo atualiza o cargo e o título gravado nos funcionários que o ocupam
+func UpdateCargo(c *entity.Cargo) error {
+	query := `UPDATE cargo SET titulo = ?, cbo = ?, salarioMin = ?, salarioMax = ?, departamento = ?, ativo = ? WHERE cargoID = ?`
+	if _, err := DB.Exec(query, c.Titulo, c.CBO, c.SalarioMin, c.SalarioMax, c.Departamento, c.Ativo, c.ID); err != nil {
+		return fmt.Errorf("erro ao atualizar cargo: %w", err)
+	}
+	if _, err := DB.Exec(`UPDATE funcionario SET cargo = ? WHERE cargoID = ?`, c.Titulo, c.ID); err != nil {
+		return fmt.Errorf("erro ao atualizar título do cargo nos funcionários: %w", err)
+	}
+	return nil
+}
+
+// DeleteCargo remove um cargo do catálogo
+func DeleteCargo(id int64) error {
+	if _, err := DB.Exec(`DELETE FROM cargo WHERE cargoID = ?`, id); err != nil {
+		return fmt.Errorf("erro ao deletar cargo: %w", err)
+	}
+	return nil
+}
+
+// CargoEmUso indica se o cargo aparece no histórico de algum funcionário
+func CargoEmUso(id int64) (bool, error) {
+	var n int
+	if err := DB.QueryRow(`SELECT COUNT(*) FROM funcionario_cargo WHERE cargoID = ?`, id).Scan(&n); err != nil {
+		return false, fmt.Errorf("erro ao verificar uso do cargo: %w", err)
+	}
+	return n > 0, nil
+}
+
+// CreateFuncionarioCargo registra o cargo do funcionário a partir de uma data
+func CreateFuncionarioCargo(fc *entity.FuncionarioCargo) error {
+	return insertFuncionarioCargo(DB, fc)
+}
+
+func insertFuncionarioCargo(db executor, fc *entity.FuncionarioCargo) error {
+	query := `INSERT INTO funcionario_cargo (funcionarioID, cargoID, inicio, observacao) VALUES (?, ?, ?, ?)`
+
+	result, err := db.Exec(query, fc.FuncionarioID, fc.CargoID, timeToDateString.TimeToDateString(fc.Inicio), fc.Observacao)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar cargo do funcionário: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID do histórico de cargo: %w", err)
+	}
+	fc.ID = id
+	return nil
+}
+
+// ListCargosByFuncionarioID lista o histórico de cargos do funcionário em ordem de início,
+// com o cargo de cada registro carregado
+func ListCargosByFuncionarioID(funcionarioID int64) ([]*entity.FuncionarioCargo, error) {
+	rows, err := DB.Query(`SELECT fc.funcionarioCargoID, fc.funcionarioID, fc.cargoID, fc.inicio, fc.observacao,
+			c.cargoID, c.titulo, c.cbo, c.salarioMin, c.salarioMax, c.departamento, c.ativo
+		FROM funcionario_cargo fc
+		JOIN cargo c ON c.cargoID = fc.cargoID
+		WHERE fc.funcionarioID = ?
+		ORDER BY fc.inicio`, funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar cargos do funcionário: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.FuncionarioCargo
+	for rows.Next() {
+		var fc entity.FuncionarioCargo
+		var c entity.Cargo
+		var inicioStr string
+		if err := rows.Scan(&fc.ID, &fc.FuncionarioID, &fc.CargoID, &inicioStr, &fc.Observacao,
+			&c.ID, &c.Titulo, &c.CBO, &c.SalarioMin, &c.SalarioMax, &c.Departamento, &c.Ativo); err != nil {
+			return nil, fmt.Errorf("erro ao ler histórico de cargo: %w", err)
+		}
+		if fc.Inicio, err = dateStringToTime.DateStringToTime(inicioStr); err != nil {
+			return nil, fmt.Errorf("erro ao converter início do cargo: %w", err)
+		}
+		fc.Cargo = &c
+		lista = append(lista, &fc)
+	}
+	return lista, rows.Err()
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"AutoGRH/pkg/utils/timeToDateString"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// CreateCentroCusto insere um centro de custo
+func CreateCentroCusto(c *entity.CentroCusto) error {
+	result, err := DB.Exec(`INSERT INTO centro_custo (codigo, nome, ativo) VALUES (?, ?, ?)`, c.Codigo, c.Nome, c.Ativo)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir centro de custo: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID do centro de custo: %w", err)
+	}
+	c.ID = id
+	return nil
+}
+
+// GetCentroCustoByID busca um centro de custo pelo ID
+func GetCentroCustoByID(id int64) (*entity.CentroCusto, error) {
+	var c entity.CentroCusto
+	err := DB.QueryRow(`SELECT centroCustoID, codigo, nome, ativo FROM centro_custo WHERE centroCustoID = ?`, id).
+		Scan(&c.ID, &c.Codigo, &c.Nome, &c.Ativo)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar centro de custo: %w", err)
+	}
+	return &c, nil
+}
+
+// ListCentrosCusto lista os centros de custo por código
+func ListCentrosCusto() ([]*entity.CentroCusto, error) {
+	rows, err := DB.Query(`SELECT centroCustoID, codigo, nome, ativo FROM centro_custo ORDER BY codigo`)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar centros de custo: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.CentroCusto
+	for rows.Next() {
+		var c entity.CentroCusto
+		if err := rows.Scan(&c.ID, &c.Codigo, &c.Nome, &c.Ativo); err != nil {
+			return nil, fmt.Errorf("erro ao ler centro de custo: %w", err)
+		}
+		lista = append(lista, &c)
+	}
+	return lista, rows.Err()
+}
+
+// UpdateCentroCusto atualiza um centro de custo
+func UpdateCentroCusto(c *entity.CentroCusto) error {
+	if _, err := DB.Exec(`UPDATE centro_custo SET codigo = ?, nome = ?, ativo = ? WHERE centroCustoID = ?`,
+		c.Codigo, c.Nome, c.Ativo, c.ID); err != nil {
+		return fmt.Errorf("erro ao atualizar centro de custo: %w", err)
+	}
+	return nil
+}
+
+// DeleteCentroCusto remove um centro de custo
+func DeleteCentroCusto(id int64) error {
+	if _, err := DB.Exec(`DELETE FROM centro_custo WHERE centroCustoID = ?`, id); err != nil {
+		return fmt.Errorf("erro ao deletar centro de custo: %w", err)
+	}
+	return nil
+}
+
+// CentroCustoEmUso indica se o centro de custo é usado por departamento, alocação ou rateio de folha
+func CentroCustoEmUso(id int64) (bool, error) {
+	var n int
+	err := DB.QueryRow(`SELECT
+		(SELECT COUNT(*) FROM departamento WHERE centroCustoID = ?) +
+		(SELECT COUNT(*) FROM funcionario_alocacao WHERE centroCustoID = ?) +
+		(SELECT COUNT(*) FROM pagamento_rateio WHERE centroCustoID = ?)`, id, id, id).Scan(&n)
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar uso do centro de custo: %w", err)
+	}
+	return n > 0, nil
+}
+
+// CreateDepartamento insere um departamento
+func CreateDepartamento(d *entity.Departamento) error {
+	result, err := DB.Exec(`INSERT INTO departamento (nome, centroCustoID, ativo) VALUES (?, ?, ?)`, d.Nome, d.CentroCustoID, d.Ativo)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir departamento: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID do departamento: %w", err)
+	}
+	d.ID = id
+	return nil
+}
+
+func scanDepartamento(row rowScanner) (*entity.Departamento, error) {
+	var d entity.Departamento
+	var centroCustoID sql.NullInt64
+	if err := row.Scan(&d.ID, &d.Nome, &centroCustoID, &d.Ativo); err != nil {
+		return nil, err
+	}
+	if centroCustoID.Valid {
+		d.CentroCustoID = &centroCustoID.Int64
+	}
+	return &d, nil
+}
+
+// GetDepartamentoByID busca um departamento pelo ID
+func GetDepartamentoByID(id int64) (*entity.Departamento, error) {
+	d, err := scanDepartamento(DB.QueryRow(`SELECT departamentoID, nome, centroCustoID, ativo FROM departamento WHERE departamentoID = ?`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar departamento: %w", err)
+	}
+	return d, nil
+}
+
+// ListDepartamentos lista os departamentos por nome
+func ListDepartamentos() ([]*entity.Departamento, error) {
+	rows, err := DB.Query(`SELECT departamentoID, nome, centroCustoID, ativo FROM departamento ORDER BY nome`)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar departamentos: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.Departamento
+	for rows.Next() {
+		d, err := scanDepartamento(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler departamento: %w", err)
+		}
+		lista = append(lista, d)
+	}
+	return lista, rows.Err()
+}
+
+// UpdateDepartamento atualiza um departamento
+func UpdateDepartamento(d *entity.Departamento) error {
+	if _, err := DB.Exec(`UPDATE departamento SET nome = ?, centroCustoID = ?, ativo = ? WHERE departamentoID = ?`,
+		d.Nome, d.CentroCustoID, d.Ativo, d.ID); err != nil {
+		return fmt.Errorf("erro ao atualizar departamento: %w", err)
+	}
+	return nil
+}
+
+// DeleteDepartamento remove um departamento
+func DeleteDepartamento(id int64) error {
+	if _, err := DB.Exec(`DELETE FROM departamento WHERE departamentoID = ?`, id); err != nil {
+		return fmt.Errorf("erro ao deletar departamento: %w", err)
+	}
+	return nil
+}
+
+// DepartamentoEmUso indica se o departamento aparece em alguma alocação de funcionário
+func DepartamentoEmUso(id int64) (bool, error) {
+	var n int
+	if err := DB.QueryRow(`SELECT COUNT(*) FROM funcionario_alocacao WHERE departamentoID = ?`, id).Scan(&n); err != nil {
+		return false, fmt.Errorf("erro ao verificar uso do departamento: %w", err)
+	}
+	return n > 0, nil
+}
+
+// SaveAlocacoes substitui a distribuição do funcionário que começa em inicio
+func SaveAlocacoes(funcionarioID int64, inicio time.Time, alocacoes []*entity.AlocacaoFuncionario) error {
+	if err := DeleteAlocacoes(funcionarioID, inicio); err != nil {
+		return err
+	}
+	for _, a := range alocacoes {
+		result, err := DB.Exec(`INSERT INTO funcionario_alocacao (funcionarioID, departamentoID, centroCustoID, inicio, percentual)
+			VALUES (?, ?, ?, ?, ?)`, funcionarioID, a.DepartamentoID, a.CentroCustoID, timeToDateString.TimeToDateString(inicio), a.Percentual)
+		if err != nil {
+			return fmt.Errorf("erro ao inserir alocação: %w", err)
+		}
+		if a.ID, err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("erro ao obter ID da alocação: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteAlocacoes remove a distribuição do funcionário que começa em inicio
+func DeleteAlocacoes(funcionarioID int64, inicio time.Time) error {
+	if _, err := DB.Exec(`DELETE FROM funcionario_alocacao WHERE funcionarioID = ? AND inicio = ?`,
+		funcionarioID, timeToDateString.TimeToDateString(inicio)); err != nil {
+		return fmt.Errorf("erro ao remover alocações: %w", err)
+	}
+	return nil
+}
+
+// ListAlocacoesByFuncionarioID lista as alocações do funcionário em ordem de início
+func ListAlocacoesByFuncionarioID(funcionarioID int64) ([]*entity.AlocacaoFuncionario, error) {
+	rows, err := DB.Query(`SELECT alocacaoID, funcionarioID, departamentoID, centroCustoID, inicio, percentual
+		FROM funcionario_alocacao WHERE funcionarioID = ? ORDER BY inicio, alocacaoID`, funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar alocações: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.AlocacaoFuncionario
+	for rows.Next() {
+		var a entity.AlocacaoFuncionario
+		var inicioStr string
+		if err := rows.Scan(&a.ID, &a.FuncionarioID, &a.DepartamentoID, &a.CentroCustoID, &inicioStr, &a.Percentual); err != nil {
+			return nil, fmt.Errorf("erro ao ler alocação: %w", err)
+		}
+		if a.Inicio, err = dateStringToTime.DateStringToTime(inicioStr); err != nil {
+			return nil, fmt.Errorf("erro ao converter início da alocação: %w", err)
+		}
+		lista = append(lista, &a)
+	}
+	return lista, rows.Err()
+}
+
+// SaveRateioPagamento substitui o rateio por centro de custo de um pagamento
+func SaveRateioPagamento(pagamentoID int64, rateio []entity.RateioPagamento) error {
+	if _, err := DB.Exec(`DELETE FROM pagamento_rateio WHERE pagamentoID = ?`, pagamentoID); err != nil {
+		return fmt.Errorf("erro ao limpar rateio do pagamento: %w", err)
+	}
+	for i := range rateio {
+		r := &rateio[i]
+		r.PagamentoID = pagamentoID
+		result, err := DB.Exec(`INSERT INTO pagamento_rateio (pagamentoID, centroCustoID, departamentoID, percentual, valor, fgts)
+			VALUES (?, ?, ?, ?, ?, ?)`, pagamentoID, r.CentroCustoID, r.DepartamentoID, r.Percentual, r.Valor, r.FGTS)
+		if err != nil {
+			return fmt.Errorf("erro ao inserir rateio do pagamento: %w", err)
+		}
+		if r.ID, err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("erro ao obter ID do rateio: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListRateiosByFolhaID devolve o rateio dos pagamentos da folha, por pagamentoID
+func ListRateiosByFolhaID(folhaID int64) (map[int64][]entity.RateioPagamento, error) {
+	rows, err := DB.Query(`SELECT r.rateioID, r.pagamentoID, r.centroCustoID, r.departamentoID, r.percentual, r.valor, r.fgts
+		FROM pagamento_rateio r
+		JOIN pagamento p ON p.pagamentoID = r.pagamentoID
+		WHERE p.folhaID = ?
+		ORDER BY r.pagamentoID, r.rateioID`, folhaID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar rateio da folha %d: %w", folhaID, err)
+	}
+	defer rows.Close()
+
+	porPagamento := make(map[int64][]entity.RateioPagamento)
+	for rows.Next() {
+		var r entity.RateioPagamento
+		if err := rows.Scan(&r.ID, &r.PagamentoID, &r.CentroCustoID, &r.DepartamentoID, &r.Percentual, &r.Valor, &r.FGTS); err != nil {
+			return nil, fmt.Errorf("erro ao ler rateio: %w", err)
+		}
+		porPagamento[r.PagamentoID] = append(porPagamento[r.PagamentoID], r)
+	}
+	return porPagamento, rows.Err()
+}
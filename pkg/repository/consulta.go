@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"AutoGRH/pkg/utils/consulta"
+	"AutoGRH/pkg/utils/documentos"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// filtroSQL traduz o valor de um filtro da consulta em condição SQL
+type filtroSQL func(valor string) (string, []interface{}, error)
+
+// especConsulta descreve o que uma listagem aceita: só os campos listados aqui chegam ao SQL
+type especConsulta struct {
+	chave   string            // coluna do ID: desempate da ordenação e cursor
+	padrao  string            // ordenação quando a consulta não informa; vazio = pelo ID
+	ordens  map[string]string // campo da API → coluna
+	filtros map[string]filtroSQL
+}
+
+type consultaSQL struct {
+	whereTotal  string // filtros, sem o cursor
+	argsTotal   []interface{}
+	wherePagina string
+	argsPagina  []interface{}
+	ordem       string
+	porChave    bool
+}
+
+func (e especConsulta) montar(q consulta.Consulta, condicoes ...string) (consultaSQL, error) {
+	var m consultaSQL
+	conds := append([]string{}, condicoes...)
+
+	campos := make([]string, 0, len(q.Filtros))
+	for campo := range q.Filtros {
+		campos = append(campos, campo)
+	}
+	sort.Strings(campos)
+	for _, campo := range campos {
+		filtro, ok := e.filtros[campo]
+		if !ok {
+			return m, consulta.Invalida("filtro desconhecido: %s", campo)
+		}
+		cond, args, err := filtro(q.Filtros[campo])
+		if err != nil {
+			return m, consulta.Invalida("%s: %v", campo, err)
+		}
+		conds = append(conds, cond)
+		m.argsTotal = append(m.argsTotal, args...)
+	}
+
+	campo := q.Ordem
+	if campo == "" {
+		campo = e.padrao
+	}
+	desc := strings.HasPrefix(campo, "-")
+	campo = strings.TrimPrefix(campo, "-")
+	coluna := e.chave
+	if campo != "" && campo != "id" {
+		c, ok := e.ordens[campo]
+		if !ok {
+			return m, consulta.Invalida("ordenação desconhecida: %s", campo)
+		}
+		coluna = c
+	}
+	m.porChave = coluna == e.chave
+	dir := "ASC"
+	if desc {
+		dir = "DESC"
+	}
+	m.ordem = fmt.Sprintf(" ORDER BY %s %s", coluna, dir)
+	if !m.porChave {
+		m.ordem += fmt.Sprintf(", %s %s", e.chave, dir)
+	}
+
+	if len(conds) > 0 {
+		m.whereTotal = " WHERE " + strings.Join(conds, " AND ")
+	}
+	m.wherePagina, m.argsPagina = m.whereTotal, m.argsTotal
+	if q.Cursor > 0 {
+		if !m.porChave {
+			return m, consulta.Invalida("cursor só pode ser usado com a ordenação por id")
+		}
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		conds = append(conds, fmt.Sprintf("%s %s ?", e.chave, op))
+		m.wherePagina = " WHERE " + strings.Join(conds, " AND ")
+		m.argsPagina = append(append([]interface{}{}, m.argsTotal...), q.Cursor)
+	}
+	return m, nil
+}
+
+// buscar executa a consulta paginada: conta os itens que atendem aos filtros e lista a página pedida,
+// ou todos eles quando q.Todos.
+// from é o FROM (com joins) e colunas as colunas esperadas por listar.
+func buscar[T any](q consulta.Consulta, e especConsulta, colunas, from string,
+	listar func(query string, args ...interface{}) ([]T, error), id func(T) int64, condicoes ...string,
+) (consulta.Pagina[T], error) {
+	q = q.Normalizada()
+	pagina := consulta.Pagina[T]{Pagina: q.Pagina, Limite: q.Limite}
+	m, err := e.montar(q, condicoes...)
+	if err != nil {
+		return pagina, err
+	}
+	if err := DB.QueryRow(`SELECT COUNT(*) `+from+m.whereTotal, m.argsTotal...).Scan(&pagina.Total); err != nil {
+		return pagina, fmt.Errorf("erro ao contar itens da consulta: %w", err)
+	}
+
+	if q.Todos {
+		if pagina.Total > consulta.LimiteExportacao {
+			return pagina, consulta.Invalida("a consulta tem %d itens, acima do limite de %d da exportação; use filtros",
+				pagina.Total, consulta.LimiteExportacao)
+		}
+		pagina.Pagina, pagina.Limite = 1, pagina.Total
+		pagina.Itens, err = listar(`SELECT `+colunas+` `+from+m.whereTotal+m.ordem, m.argsTotal...)
+		if err == nil && pagina.Itens == nil {
+			pagina.Itens = []T{}
+		}
+		return pagina, err
+	}
+
+	query := `SELECT ` + colunas + ` ` + from + m.wherePagina + m.ordem + ` LIMIT ?`
+	args := append(m.argsPagina, q.Limite)
+	if q.Cursor == 0 {
+		query += ` OFFSET ?`
+		args = append(args, q.Offset())
+	} else {
+		pagina.Pagina = 0
+	}
+	if pagina.Itens, err = listar(query, args...); err != nil {
+		return pagina, err
+	}
+	if pagina.Itens == nil {
+		pagina.Itens = []T{}
+	}
+	if m.porChave && len(pagina.Itens) == q.Limite {
+		pagina.ProximoCursor = id(pagina.Itens[len(pagina.Itens)-1])
+	}
+	return pagina, nil
+}
+
+// prefixar qualifica uma lista de colunas com o alias da tabela
+func prefixar(colunas, alias string) string {
+	partes := strings.Split(colunas, ",")
+	for i, c := range partes {
+		partes[i] = alias + "." + strings.TrimSpace(c)
+	}
+	return strings.Join(partes, ", ")
+}
+
+func filtroIgual(coluna string) filtroSQL {
+	return func(v string) (string, []interface{}, error) {
+		return coluna + " = ?", []interface{}{v}, nil
+	}
+}
+
+// filtroDigitos compara só os dígitos, como CPF e PIS são gravados
+func filtroDigitos(coluna string) filtroSQL {
+	return func(v string) (string, []interface{}, error) {
+		return coluna + " = ?", []interface{}{documentos.SoDigitos(v)}, nil
+	}
+}
+
+func filtroMaiusculo(coluna string) filtroSQL {
+	return func(v string) (string, []interface{}, error) {
+		return coluna + " = ?", []interface{}{strings.ToUpper(v)}, nil
+	}
+}
+
+// filtroContem busca o texto em qualquer parte da coluna, sem diferenciar maiúsculas
+func filtroContem(coluna string) filtroSQL {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return func(v string) (string, []interface{}, error) {
+		return "LOWER(" + coluna + ") LIKE ?", []interface{}{"%" + strings.ToLower(r.Replace(v)) + "%"}, nil
+	}
+}
+
+func filtroID(coluna string) filtroSQL {
+	return func(v string) (string, []interface{}, error) {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return "", nil, fmt.Errorf("ID inválido")
+		}
+		return coluna + " = ?", []interface{}{id}, nil
+	}
+}
+
+func filtroBool(coluna string) filtroSQL {
+	return func(v string) (string, []interface{}, error) {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return "", nil, fmt.Errorf("use true ou false")
+		}
+		return coluna + " = ?", []interface{}{b}, nil
+	}
+}
+
+// filtroDesde e filtroAte recebem datas AAAA-MM-DD; o fim inclui o dia todo, também em colunas com hora
+func filtroDesde(coluna string) filtroSQL {
+	return func(v string) (string, []interface{}, error) {
+		d, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return "", nil, fmt.Errorf("data inválida, use AAAA-MM-DD")
+		}
+		return coluna + " >= ?", []interface{}{d.Format("2006-01-02")}, nil
+	}
+}
+
+func filtroAte(coluna string) filtroSQL {
+	return func(v string) (string, []interface{}, error) {
+		d, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return "", nil, fmt.Errorf("data inválida, use AAAA-MM-DD")
+		}
+		return coluna + " < ?", []interface{}{d.AddDate(0, 0, 1).Format("2006-01-02")}, nil
+	}
+}
+
+// filtroOpcoes aceita só os valores listados, cada um com sua condição fixa
+func filtroOpcoes(opcoes map[string]string) filtroSQL {
+	return func(v string) (string, []interface{}, error) {
+		cond, ok := opcoes[strings.ToLower(v)]
+		if !ok {
+			validas := make([]string, 0, len(opcoes))
+			for o := range opcoes {
+				validas = append(validas, o)
+			}
+			sort.Strings(validas)
+			return "", nil, fmt.Errorf("use %s", strings.Join(validas, ", "))
+		}
+		return cond, nil, nil
+	}
+}
@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"AutoGRH/pkg/utils/cripto"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// colunaCifravel é uma coluna de dado pessoal que pode ser gravada cifrada. As que têm indice ganham
+// uma coluna com o índice cego, usada nas buscas por igualdade.
+type colunaCifravel struct {
+	tabela string
+	chave  string // chave primária, para a migração
+	nome   string
+	indice string
+}
+
+func (c colunaCifravel) id() string { return c.tabela + "." + c.nome }
+
+var colunasCifraveis = []colunaCifravel{
+	{"pessoa", "pessoaID", "cpf", "cpfIndice"},
+	{"pessoa", "pessoaID", "rg", "rgIndice"},
+	{"pessoa", "pessoaID", "endereco", ""}, // texto livre anterior ao endereço estruturado
+	{"funcionario", "funcionarioID", "pis", "pisIndice"},
+	{"funcionario", "funcionarioID", "ctpf", ""},
+	{"pessoa_endereco", "enderecoID", "cep", ""},
+	{"pessoa_endereco", "enderecoID", "logradouro", ""},
+	{"pessoa_endereco", "enderecoID", "numero", ""},
+	{"pessoa_endereco", "enderecoID", "complemento", ""},
+	{"importacao", "importacaoID", "linhas", ""},   // resultado por linha, com nome e CPF
+	{"importacao", "importacaoID", "conteudo", ""}, // planilha enviada
+}
+
+// ColunasCifraveis lista, no formato tabela.coluna, as colunas aceitas em ConfigurarCriptografia
+func ColunasCifraveis() []string {
+	ids := make([]string, 0, len(colunasCifraveis))
+	for _, c := range colunasCifraveis {
+		ids = append(ids, c.id())
+	}
+	return ids
+}
+
+// cifrador é nil com a criptografia desligada; colunasCifradas são as colunas gravadas cifradas.
+// Valores já cifrados são decifrados mesmo em colunas fora da configuração.
+var (
+	cifrador        *cripto.Cifrador
+	colunasCifradas = map[string]bool{}
+)
+
+// ConfigurarCriptografia liga a criptografia das colunas informadas (tabela.coluna); c nil desliga.
+// Deve ser chamada antes de ConnectDB, cujas migrações já leem dados cifrados.
+func ConfigurarCriptografia(c *cripto.Cifrador, colunas []string) error {
+	aceitas := make(map[string]bool, len(colunasCifraveis))
+	for _, cc := range colunasCifraveis {
+		aceitas[cc.id()] = true
+	}
+	selecionadas := make(map[string]bool, len(colunas))
+	for _, col := range colunas {
+		col = strings.TrimSpace(col)
+		if !aceitas[col] {
+			return fmt.Errorf("coluna %q não pode ser cifrada; use uma de %s", col, strings.Join(ColunasCifraveis(), ", "))
+		}
+		selecionadas[col] = true
+	}
+	if c == nil {
+		selecionadas = map[string]bool{}
+	}
+	cifrador, colunasCifradas = c, selecionadas
+	return nil
+}
+
+// cifrar prepara o valor para gravação na coluna (tabela.coluna)
+func cifrar(coluna, valor string) (string, error) {
+	if cifrador == nil || !colunasCifradas[coluna] {
+		return valor, nil
+	}
+	v, err := cifrador.Cifrar(valor, coluna)
+	if err != nil {
+		return "", fmt.Errorf("erro ao cifrar %s: %w", coluna, err)
+	}
+	return v, nil
+}
+
+// decifrar devolve o texto de um valor lido da coluna; texto puro volta como está
+func decifrar(coluna, valor string) (string, error) {
+	if !cripto.Cifrado(valor) {
+		return valor, nil
+	}
+	if cifrador == nil {
+		return "", fmt.Errorf("%s está cifrado, mas a criptografia não foi configurada", coluna)
+	}
+	v, err := cifrador.Decifrar(valor, coluna)
+	if err != nil {
+		return "", fmt.Errorf("erro ao decifrar %s: %w", coluna, err)
+	}
+	return v, nil
+}
+
+// decifrarCampos decifra, no lugar, vários campos lidos de uma linha
+func decifrarCampos(campos map[string]*string) error {
+	for coluna, valor := range campos {
+		v, err := decifrar(coluna, *valor)
+		if err != nil {
+			return err
+		}
+		*valor = v
+	}
+	return nil
+}
+
+// indiceCego devolve o valor da coluna de índice (NULL com a coluna em texto puro ou valor vazio)
+func indiceCego(coluna, valor string) sql.NullString {
+	if cifrador == nil || !colunasCifradas[coluna] || valor == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: cifrador.Indice(valor, coluna), Valid: true}
+}
+
+// condicaoIgual compara a coluna (tabela.coluna, referida no SQL como expr) com o valor. Com a coluna
+// cifrada a comparação é pelo índice cego; linhas ainda sem índice (não migradas) são comparadas pelo
+// texto puro.
+func condicaoIgual(coluna, expr, valor string) (string, []interface{}) {
+	idx := indiceCego(coluna, valor)
+	exprIndice := colunaIndice(coluna)
+	if !idx.Valid || exprIndice == "" {
+		return expr + " = ?", []interface{}{valor}
+	}
+	if i := strings.LastIndex(expr, "."); i >= 0 {
+		exprIndice = expr[:i+1] + exprIndice
+	}
+	return fmt.Sprintf("(%s = ? OR (%s IS NULL AND %s = ?))", exprIndice, exprIndice, expr), []interface{}{idx, valor}
+}
+
+// colunaIndice devolve o nome da coluna de índice cego de tabela.coluna
+func colunaIndice(coluna string) string {
+	for _, c := range colunasCifraveis {
+		if c.id() == coluna {
+			return c.indice
+		}
+	}
+	return ""
+}
+
+// filtroCifrado é o filtro de listagem por igualdade numa coluna que pode estar cifrada
+func filtroCifrado(coluna, expr string, normalizar func(string) string) filtroSQL {
+	return func(v string) (string, []interface{}, error) {
+		if normalizar != nil {
+			v = normalizar(v)
+		}
+		cond, args := condicaoIgual(coluna, expr, v)
+		return cond, args, nil
+	}
+}
+
+// MigracaoCriptografia conta, por coluna, as linhas regravadas por MigrarCriptografia
+type MigracaoCriptografia struct {
+	Coluna     string
+	Cifradas   int // texto puro que passou a cifrado
+	Recifradas int // cifradas com chave antiga, agora com a atual
+	Decifradas int // coluna fora da configuração, voltou a texto puro
+}
+
+// loteMigracaoCriptografia é quantas linhas são lidas por consulta na migração
+const loteMigracaoCriptografia = 500
+
+// MigrarCriptografia leva todas as colunas cifráveis ao estado configurado: cifra o texto puro das
+// colunas configuradas, recifra com a chave atual o que foi cifrado com outra e grava os índices cegos;
+// nas demais colunas, decifra. Pode ser rodada de novo a qualquer momento.
+func MigrarCriptografia() ([]MigracaoCriptografia, error) {
+	var out []MigracaoCriptografia
+	for _, c := range colunasCifraveis {
+		m, err := migrarColuna(c)
+		if err != nil {
+			return out, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func migrarColuna(c colunaCifravel) (MigracaoCriptografia, error) {
+	m := MigracaoCriptografia{Coluna: c.id()}
+	ligada := cifrador != nil && colunasCifradas[c.id()]
+
+	selIndice := "NULL"
+	if c.indice != "" {
+		selIndice = c.indice
+	}
+	query := fmt.Sprintf(`SELECT %s, %s, %s FROM %s WHERE %s > ? ORDER BY %s LIMIT ?`,
+		c.chave, c.nome, selIndice, c.tabela, c.chave, c.chave)
+
+	var ultimo int64
+	for {
+		type linha struct {
+			id            int64
+			valor, indice sql.NullString
+		}
+		rows, err := DB.Query(query, ultimo, loteMigracaoCriptografia)
+		if err != nil {
+			return m, fmt.Errorf("erro ao ler %s: %w", c.id(), err)
+		}
+		var lote []linha
+		for rows.Next() {
+			var l linha
+			if err := rows.Scan(&l.id, &l.valor, &l.indice); err != nil {
+				rows.Close()
+				return m, fmt.Errorf("erro ao ler %s: %w", c.id(), err)
+			}
+			lote = append(lote, l)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return m, fmt.Errorf("erro ao ler %s: %w", c.id(), err)
+		}
+		if len(lote) == 0 {
+			return m, nil
+		}
+
+		for _, l := range lote {
+			ultimo = l.id
+			if !l.valor.Valid {
+				continue
+			}
+			texto, err := decifrar(c.id(), l.valor.String)
+			if err != nil {
+				return m, fmt.Errorf("%s ID=%d: %w", c.id(), l.id, err)
+			}
+
+			// novo é o valor a gravar; contador, o motivo da regravação
+			novo := texto
+			var contador *int
+			versao, cifrado := cripto.Versao(l.valor.String)
+			if ligada {
+				switch {
+				case !cifrado && texto != "":
+					contador = &m.Cifradas
+				case cifrado && versao != cifrador.Atual():
+					contador = &m.Recifradas
+				}
+				novo = l.valor.String
+				if contador != nil {
+					if novo, err = cifrar(c.id(), texto); err != nil {
+						return m, err
+					}
+				}
+			} else if cifrado {
+				contador = &m.Decifradas
+			}
+
+			var indice sql.NullString
+			if c.indice != "" {
+				indice = indiceCego(c.id(), texto)
+			}
+			if contador == nil && indice == l.indice {
+				continue
+			}
+
+			sets, args := c.nome+" = ?", []interface{}{novo}
+			if c.indice != "" {
+				sets += ", " + c.indice + " = ?"
+				args = append(args, indice)
+			}
+			q := fmt.Sprintf(`UPDATE %s SET %s WHERE %s = ?`, c.tabela, sets, c.chave)
+			if _, err := DB.Exec(q, append(args, l.id)...); err != nil {
+				return m, fmt.Errorf("erro ao regravar %s ID=%d: %w", c.id(), l.id, err)
+			}
+			if contador != nil {
+				*contador++
+			}
+		}
+	}
+}
+
+// alargarColuna aumenta colunas curtas demais para o valor cifrado; índices existentes são mantidos
+func alargarColuna(tabela, coluna, definicao string, tamanho int) {
+	var atual sql.NullInt64
+	err := DB.QueryRow(`SELECT CHARACTER_MAXIMUM_LENGTH FROM information_schema.COLUMNS
+	           WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`, tabela, coluna).Scan(&atual)
+	if err != nil {
+		log.Fatalf("Erro ao verificar tamanho de %s.%s: %v", tabela, coluna, err)
+	}
+	if atual.Valid && atual.Int64 < int64(tamanho) {
+		mustExec(DB, fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s", tabela, coluna, definicao))
+	}
+}
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/cripto"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// ErrPessoaJaAnonimizada indica titular cujos dados pessoais já foram apagados
+var ErrPessoaJaAnonimizada = errors.New("pessoa já anonimizada")
+
+// NomeAnonimizado é o nome gravado no lugar do nome do titular anonimizado
+func NomeAnonimizado(pessoaID int64) string {
+	return fmt.Sprintf("Titular anonimizado #%d", pessoaID)
+}
+
+// avisosDoFuncionario relaciona os tipos de aviso (gravados pelo AvisoService e, CONFLITO_AUSENCIA, na
+// criação de descansos) à tabela da referência; as mensagens trazem o nome do funcionário
+var avisosDoFuncionario = []string{
+	`tipo IN ('FERIAS_VENCIDAS', 'FERIAS_VENCENDO') AND referenciaID IN
+		(SELECT feriasID FROM ferias WHERE funcionarioID IN (SELECT funcionarioID FROM funcionario WHERE pessoaID = ?))`,
+	`tipo = 'VALE_PENDENTE' AND referenciaID IN
+		(SELECT valeID FROM vale WHERE funcionarioID IN (SELECT funcionarioID FROM funcionario WHERE pessoaID = ?))`,
+	`tipo IN ('DESCANSO_PENDENTE', 'CONFLITO_AUSENCIA') AND referenciaID IN
+		(SELECT d.descansoID FROM descanso d JOIN ferias fe ON fe.feriasID = d.feriasID
+		 WHERE fe.funcionarioID IN (SELECT funcionarioID FROM funcionario WHERE pessoaID = ?))`,
+	`tipo = 'PAGAMENTO_FERIAS_PENDENTE' AND referenciaID IN
+		(SELECT pagamentoFeriasID FROM pagamento_ferias WHERE funcionarioID IN (SELECT funcionarioID FROM funcionario WHERE pessoaID = ?))`,
+	`tipo IN ('EXPERIENCIA_VENCENDO', 'EXPERIENCIA_INDETERMINADO', 'CONTRATO_LIMITE') AND referenciaID IN
+		(SELECT funcionarioID FROM funcionario WHERE pessoaID = ?)`,
+}
+
+// AnonimizarPessoa apaga numa transação os dados pessoais do titular e de seus vínculos: nome, CPF e RG
+// viram marcadores, endereço, contatos e documentos são removidos, PIS e CTPS apagados e a data de
+// nascimento reduzida ao ano. Salários, faltas, férias, vales e pagamentos ficam como estão.
+// Devolve os caminhos dos documentos removidos, para que os arquivos sejam apagados do disco.
+func AnonimizarPessoa(pessoaID int64, quando time.Time) (caminhos []string, err error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar transação da anonimização: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				log.Printf("erro ao desfazer transação da anonimização: %v", rerr)
+			}
+		}
+	}()
+
+	var cpf sql.NullString
+	var anonimizadoEm sql.NullString
+	err = tx.QueryRow(`SELECT cpf, anonimizadoEm FROM pessoa WHERE pessoaID = ? FOR UPDATE`, pessoaID).Scan(&cpf, &anonimizadoEm)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar pessoa para anonimização: %w", err)
+	}
+	if anonimizadoEm.Valid {
+		err = ErrPessoaJaAnonimizada
+		return nil, err
+	}
+	if cpf.String, err = decifrar("pessoa.cpf", cpf.String); err != nil {
+		return nil, err
+	}
+
+	// documentos: guarda os caminhos antes de apagar os registros
+	var docIDs []interface{}
+	rows, err := tx.Query(`SELECT documentoID, caminho FROM documento
+		WHERE funcionarioID IN (SELECT funcionarioID FROM funcionario WHERE pessoaID = ?)`, pessoaID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar documentos da pessoa: %w", err)
+	}
+	for rows.Next() {
+		var id int64
+		var caminho string
+		if err = rows.Scan(&id, &caminho); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("erro ao ler documento da pessoa: %w", err)
+		}
+		docIDs = append(docIDs, id)
+		caminhos = append(caminhos, caminho)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao iterar documentos da pessoa: %w", err)
+	}
+
+	type comando struct {
+		descricao string
+		query     string
+		args      []interface{}
+	}
+	marcador := fmt.Sprintf("ANON%d", pessoaID)
+	comandos := []comando{
+		{"pessoa", `UPDATE pessoa SET nome = ?, cpf = ?, rg = ?, cpfIndice = NULL, rgIndice = NULL,
+			nomeSocial = '', sexo = '', identidadeGenero = '',
+			estadoCivil = 0, grauInstrucao = 0, racaCor = 0, endereco = NULL, contato = NULL, contatoEmergencia = NULL,
+			anonimizadoEm = ? WHERE pessoaID = ?`,
+			[]interface{}{NomeAnonimizado(pessoaID), marcador, marcador, quando, pessoaID}},
+		{"endereço", `DELETE FROM pessoa_endereco WHERE pessoaID = ?`, []interface{}{pessoaID}},
+		{"contatos", `DELETE FROM pessoa_contato WHERE pessoaID = ?`, []interface{}{pessoaID}},
+		{"contatos de emergência", `DELETE FROM pessoa_contato_emergencia WHERE pessoaID = ?`, []interface{}{pessoaID}},
+		{"vínculos", `UPDATE funcionario SET pis = '', ctpf = '', pisIndice = NULL,
+			nascimento = IF(nascimento IS NULL, NULL, MAKEDATE(YEAR(nascimento), 1)) WHERE pessoaID = ?`,
+			[]interface{}{pessoaID}},
+	}
+	if len(docIDs) > 0 {
+		in := "(" + strings.TrimSuffix(strings.Repeat("?,", len(docIDs)), ",") + ")"
+		comandos = append(comandos,
+			comando{"atestados das faltas", `UPDATE falta SET documentoID = NULL WHERE documentoID IN ` + in, docIDs},
+			comando{"documentos", `DELETE FROM documento WHERE documentoID IN ` + in, docIDs},
+		)
+	}
+	for _, c := range comandos {
+		if _, err = tx.Exec(c.query, c.args...); err != nil {
+			return nil, fmt.Errorf("erro ao anonimizar %s: %w", c.descricao, err)
+		}
+	}
+	for _, cond := range avisosDoFuncionario {
+		if _, err = tx.Exec(`DELETE FROM aviso WHERE `+cond, pessoaID); err != nil {
+			return nil, fmt.Errorf("erro ao remover avisos da pessoa: %w", err)
+		}
+	}
+	if err = anonimizarImportacoes(tx, pessoaID, cpf.String); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("erro ao confirmar anonimização: %w", err)
+	}
+	return caminhos, nil
+}
+
+// anonimizarImportacoes troca nome e CPF do titular no resultado das importações que o citam e
+// descarta a planilha enviada, que não pode ser editada linha a linha
+func anonimizarImportacoes(tx *sql.Tx, pessoaID int64, cpf string) error {
+	if cpf == "" {
+		return nil
+	}
+	type importacao struct {
+		id     int64
+		linhas []entity.LinhaImportacao
+	}
+	// linhas cifradas não podem ser filtradas pelo CPF no SQL: são todas decifradas e conferidas aqui
+	rows, err := tx.Query(`SELECT importacaoID, linhas FROM importacao WHERE linhas LIKE ? OR linhas LIKE ?`,
+		"%"+cpf+"%", cripto.Prefixo+"%")
+	if err != nil {
+		return fmt.Errorf("erro ao buscar importações da pessoa: %w", err)
+	}
+	var lista []importacao
+	for rows.Next() {
+		var i importacao
+		var linhas string
+		if err := rows.Scan(&i.id, &linhas); err != nil {
+			rows.Close()
+			return fmt.Errorf("erro ao ler importação: %w", err)
+		}
+		if linhas, err = decifrar("importacao.linhas", linhas); err != nil {
+			rows.Close()
+			return fmt.Errorf("importação %d: %w", i.id, err)
+		}
+		if err := json.Unmarshal([]byte(linhas), &i.linhas); err != nil {
+			rows.Close()
+			return fmt.Errorf("erro ao ler linhas da importação %d: %w", i.id, err)
+		}
+		lista = append(lista, i)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao iterar importações: %w", err)
+	}
+
+	for _, i := range lista {
+		citada := false
+		for n, l := range i.linhas {
+			if l.CPF == cpf || l.PessoaID == pessoaID {
+				i.linhas[n].Nome, i.linhas[n].CPF = NomeAnonimizado(pessoaID), ""
+				citada = true
+			}
+		}
+		if !citada {
+			continue
+		}
+		linhas, err := json.Marshal(i.linhas)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar linhas da importação %d: %w", i.id, err)
+		}
+		cifradas, err := cifrar("importacao.linhas", string(linhas))
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`UPDATE importacao SET linhas = ?, conteudo = ? WHERE importacaoID = ?`,
+			cifradas, []byte{}, i.id); err != nil {
+			return fmt.Errorf("erro ao anonimizar importação %d: %w", i.id, err)
+		}
+	}
+	return nil
+}
+
+// ListRetencoesVencidas retorna os titulares ainda identificados cujos vínculos estão todos encerrados,
+// com a última demissão até a data de corte
+func ListRetencoesVencidas(corte time.Time) ([]*entity.RetencaoVencida, error) {
+	query := `SELECT p.pessoaID, p.nome, MAX(f.demissao), COUNT(*)
+		FROM pessoa p JOIN funcionario f ON f.pessoaID = p.pessoaID
+		WHERE p.anonimizadoEm IS NULL
+		GROUP BY p.pessoaID, p.nome
+		HAVING SUM(f.ativo) = 0 AND SUM(f.demissao IS NULL) = 0 AND MAX(f.demissao) <= ?
+		ORDER BY MAX(f.demissao), p.pessoaID`
+
+	rows, err := DB.Query(query, corte.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar retenções vencidas: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			log.Printf("erro ao fechar rows em ListRetencoesVencidas: %v", cerr)
+		}
+	}()
+
+	var lista []*entity.RetencaoVencida
+	for rows.Next() {
+		var r entity.RetencaoVencida
+		var nome sql.NullString
+		var demissao string
+		if err := rows.Scan(&r.PessoaID, &nome, &demissao, &r.Vinculos); err != nil {
+			return nil, fmt.Errorf("erro ao ler retenção vencida: %w", err)
+		}
+		r.Nome = nome.String
+		if r.UltimaDemissao, err = dateStringToTime.DateStringToTime(demissao); err != nil {
+			return nil, fmt.Errorf("erro ao converter data de demissão: %w", err)
+		}
+		lista = append(lista, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao iterar retenções vencidas: %w", err)
+	}
+	return lista, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"AutoGRH/pkg/utils/documentos"
 	"database/sql"
 	"fmt"
 	"log"
@@ -11,10 +12,16 @@ import (
 
 var DB *sql.DB
 
+// executor é atendido por *sql.DB e *sql.Tx, para gravações que também rodam dentro de transação
+type executor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
 func ConnectDB() {
 	createDatabaseIfNotExists()
 	connectWithDatabase()
 	createTables()
+	migrateTables()
 	seedDefaultData()
 }
 
@@ -81,17 +88,57 @@ func createTables() {
 		`CREATE TABLE IF NOT EXISTS pessoa (
 			pessoaID BIGINT AUTO_INCREMENT PRIMARY KEY,
 			nome VARCHAR(100),
-			cpf VARCHAR(20) UNIQUE,
-			rg VARCHAR(20) UNIQUE,
+			cpf VARCHAR(255) UNIQUE,
+			rg VARCHAR(255) UNIQUE,
+			cpfIndice CHAR(64) NULL UNIQUE,
+			rgIndice CHAR(64) NULL UNIQUE,
+			nomeSocial VARCHAR(100) NOT NULL DEFAULT '',
+			sexo CHAR(1) NOT NULL DEFAULT '',
+			identidadeGenero VARCHAR(20) NOT NULL DEFAULT '',
+			estadoCivil TINYINT NOT NULL DEFAULT 0,
+			grauInstrucao TINYINT NOT NULL DEFAULT 0,
+			racaCor TINYINT NOT NULL DEFAULT 0,
 			endereco TEXT,
 			contato VARCHAR(100),
-			contatoEmergencia VARCHAR(100)
+			contatoEmergencia VARCHAR(100),
+			contatosMigrados BOOLEAN NOT NULL DEFAULT FALSE,
+			anonimizadoEm DATETIME NULL
+		);`,
+		`CREATE TABLE IF NOT EXISTS pessoa_endereco (
+			enderecoID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			pessoaID BIGINT NOT NULL UNIQUE,
+			cep VARCHAR(255) NOT NULL DEFAULT '',
+			logradouro VARCHAR(1024) NOT NULL DEFAULT '',
+			numero VARCHAR(255) NOT NULL DEFAULT '',
+			complemento VARCHAR(1024) NOT NULL DEFAULT '',
+			bairro VARCHAR(100) NOT NULL DEFAULT '',
+			cidade VARCHAR(100) NOT NULL DEFAULT '',
+			uf CHAR(2) NOT NULL DEFAULT '',
+			codigoIBGE CHAR(7) NOT NULL DEFAULT '',
+			FOREIGN KEY (pessoaID) REFERENCES pessoa(pessoaID) ON DELETE CASCADE
+		);`,
+		`CREATE TABLE IF NOT EXISTS pessoa_contato (
+			contatoID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			pessoaID BIGINT NOT NULL,
+			tipo VARCHAR(20) NOT NULL,
+			valor VARCHAR(150) NOT NULL,
+			principal BOOLEAN NOT NULL DEFAULT FALSE,
+			FOREIGN KEY (pessoaID) REFERENCES pessoa(pessoaID) ON DELETE CASCADE
+		);`,
+		`CREATE TABLE IF NOT EXISTS pessoa_contato_emergencia (
+			contatoEmergenciaID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			pessoaID BIGINT NOT NULL,
+			nome VARCHAR(100) NOT NULL,
+			parentesco VARCHAR(20) NOT NULL,
+			telefone VARCHAR(20) NOT NULL,
+			FOREIGN KEY (pessoaID) REFERENCES pessoa(pessoaID) ON DELETE CASCADE
 		);`,
 		`CREATE TABLE IF NOT EXISTS funcionario (
 			funcionarioID BIGINT AUTO_INCREMENT PRIMARY KEY,
-			pessoaID BIGINT UNIQUE,
-			pis VARCHAR(20),
-			ctpf VARCHAR(20),
+			pessoaID BIGINT, -- uma pessoa pode ter vários vínculos (readmissão)
+			pis VARCHAR(255),
+			ctpf VARCHAR(255),
+			pisIndice CHAR(64) NULL,
 			nascimento DATE,
 			admissao DATE,
 			demissao DATE NULL,
@@ -99,9 +146,37 @@ func createTables() {
 			salarioInicial FLOAT,
 			feriasDisponiveis INT,
 			ativo BOOLEAN NOT NULL DEFAULT TRUE,
+			inicioAquisitivo DATE NULL,
+			tipoContrato VARCHAR(20) NOT NULL DEFAULT 'CLT',
+			prazoContrato VARCHAR(20) NOT NULL DEFAULT 'INDETERMINADO',
+			fimExperiencia DATE NULL,
+			fimProrrogacao DATE NULL,
+			cargoID BIGINT NULL,
+			INDEX idx_funcionario_pisIndice (pisIndice),
 			FOREIGN KEY (pessoaID) REFERENCES pessoa(pessoaID)
 		);`,
 
+		`CREATE TABLE IF NOT EXISTS cargo (
+			cargoID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			titulo VARCHAR(50) NOT NULL UNIQUE,
+			cbo CHAR(6) NOT NULL DEFAULT '',
+			salarioMin DECIMAL(10,2) NOT NULL DEFAULT 0,
+			salarioMax DECIMAL(10,2) NOT NULL DEFAULT 0,
+			departamento VARCHAR(100) NOT NULL DEFAULT '',
+			ativo BOOLEAN NOT NULL DEFAULT TRUE
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS funcionario_cargo (
+			funcionarioCargoID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			funcionarioID BIGINT NOT NULL,
+			cargoID BIGINT NOT NULL,
+			inicio DATE NOT NULL,
+			observacao VARCHAR(255) NOT NULL DEFAULT '',
+			UNIQUE KEY uq_funcionario_cargo (funcionarioID, inicio),
+			FOREIGN KEY (funcionarioID) REFERENCES funcionario(funcionarioID),
+			FOREIGN KEY (cargoID) REFERENCES cargo(cargoID)
+		);`,
+
 		`CREATE TABLE IF NOT EXISTS vale (
 		valeID BIGINT AUTO_INCREMENT PRIMARY KEY,
 		funcionarioID BIGINT NOT NULL,
@@ -134,10 +209,52 @@ func createTables() {
     valorFinal DECIMAL(10,2) NOT NULL,
     pago BOOLEAN NOT NULL DEFAULT FALSE,
     descontoVales DECIMAL(10,2) NOT NULL DEFAULT 0,
+    descontoDSR DECIMAL(10,2) NOT NULL DEFAULT 0,
+    horasExtras DECIMAL(10,2) NOT NULL DEFAULT 0,
+    fgts DECIMAL(10,2) NOT NULL DEFAULT 0,
     FOREIGN KEY (funcionarioID) REFERENCES funcionario(funcionarioID),
     FOREIGN KEY (folhaID) REFERENCES folha_pagamento(folhaID)
 );`,
 
+		`CREATE TABLE IF NOT EXISTS centro_custo (
+			centroCustoID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			codigo VARCHAR(20) NOT NULL UNIQUE,
+			nome VARCHAR(100) NOT NULL,
+			ativo BOOLEAN NOT NULL DEFAULT TRUE
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS departamento (
+			departamentoID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			nome VARCHAR(100) NOT NULL UNIQUE,
+			centroCustoID BIGINT NULL,
+			ativo BOOLEAN NOT NULL DEFAULT TRUE,
+			FOREIGN KEY (centroCustoID) REFERENCES centro_custo(centroCustoID)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS funcionario_alocacao (
+			alocacaoID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			funcionarioID BIGINT NOT NULL,
+			departamentoID BIGINT NOT NULL,
+			centroCustoID BIGINT NOT NULL,
+			inicio DATE NOT NULL,
+			percentual DECIMAL(5,2) NOT NULL,
+			FOREIGN KEY (funcionarioID) REFERENCES funcionario(funcionarioID),
+			FOREIGN KEY (departamentoID) REFERENCES departamento(departamentoID),
+			FOREIGN KEY (centroCustoID) REFERENCES centro_custo(centroCustoID)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS pagamento_rateio (
+			rateioID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			pagamentoID BIGINT NOT NULL,
+			centroCustoID BIGINT NOT NULL,
+			departamentoID BIGINT NOT NULL,
+			percentual DECIMAL(5,2) NOT NULL,
+			valor DECIMAL(10,2) NOT NULL,
+			fgts DECIMAL(10,2) NOT NULL DEFAULT 0,
+			FOREIGN KEY (pagamentoID) REFERENCES pagamento(pagamentoID) ON DELETE CASCADE,
+			FOREIGN KEY (centroCustoID) REFERENCES centro_custo(centroCustoID)
+		);`,
+
 		`CREATE TABLE IF NOT EXISTS salario (
 			salarioID BIGINT AUTO_INCREMENT PRIMARY KEY,
 			funcionarioID BIGINT,
@@ -161,6 +278,10 @@ func createTables() {
 			funcionarioID BIGINT,
 			quantidade INTEGER,
 			data DATE,
+			tipo VARCHAR(20) NOT NULL DEFAULT 'MENSAL',
+			minutos INT NOT NULL DEFAULT 0,
+			documentoID BIGINT NULL,
+			origem VARCHAR(10) NOT NULL DEFAULT 'MANUAL',
 			FOREIGN KEY (funcionarioID) REFERENCES funcionario(funcionarioID)
 		);`,
 
@@ -196,6 +317,132 @@ func createTables() {
 			FOREIGN KEY (feriasID) REFERENCES ferias(feriasID)
 		);`,
 
+		`CREATE TABLE IF NOT EXISTS calendario_token (
+			calendarioTokenID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			usuarioID BIGINT NOT NULL UNIQUE,
+			tokenHash CHAR(64) NOT NULL UNIQUE,
+			criadoEm DATETIME NOT NULL,
+			ultimoAcesso DATETIME NULL,
+			FOREIGN KEY (usuarioID) REFERENCES usuario(usuarioID) ON DELETE CASCADE
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS feriado (
+			feriadoID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			data DATE NOT NULL,
+			nome VARCHAR(100) NOT NULL,
+			tipo VARCHAR(20) NOT NULL,
+			localidade VARCHAR(100) NOT NULL DEFAULT '',
+			recorrente BOOLEAN NOT NULL DEFAULT FALSE
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS jornada (
+			jornadaID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			nome VARCHAR(100) NOT NULL,
+			tipo VARCHAR(20) NOT NULL,
+			cicloDias INT NOT NULL DEFAULT 7,
+			trabalhaFeriados BOOLEAN NOT NULL DEFAULT FALSE
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS jornada_periodo (
+			jornadaPeriodoID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			jornadaID BIGINT NOT NULL,
+			dia INT NOT NULL,
+			inicio CHAR(5) NOT NULL,
+			fim CHAR(5) NOT NULL,
+			FOREIGN KEY (jornadaID) REFERENCES jornada(jornadaID) ON DELETE CASCADE
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS funcionario_jornada (
+			funcionarioJornadaID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			funcionarioID BIGINT NOT NULL,
+			jornadaID BIGINT NOT NULL,
+			inicio DATE NOT NULL,
+			UNIQUE KEY uq_funcionario_jornada (funcionarioID, inicio),
+			FOREIGN KEY (funcionarioID) REFERENCES funcionario(funcionarioID),
+			FOREIGN KEY (jornadaID) REFERENCES jornada(jornadaID)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS banco_horas (
+			bancoHorasID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			funcionarioID BIGINT NOT NULL,
+			data DATE NOT NULL,
+			tipo VARCHAR(20) NOT NULL,
+			minutos INT NOT NULL,
+			vencimento DATE NULL,
+			origem VARCHAR(20) NOT NULL DEFAULT 'MANUAL',
+			referencia BIGINT NULL,
+			descricao VARCHAR(255) NOT NULL DEFAULT '',
+			UNIQUE KEY uq_banco_horas_origem (funcionarioID, tipo, origem, referencia),
+			FOREIGN KEY (funcionarioID) REFERENCES funcionario(funcionarioID)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS banco_horas_config (
+			funcionarioID BIGINT PRIMARY KEY,
+			ativo BOOLEAN NOT NULL DEFAULT TRUE,
+			mesesValidade INT NOT NULL DEFAULT 6,
+			FOREIGN KEY (funcionarioID) REFERENCES funcionario(funcionarioID)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS ponto_dia (
+			pontoDiaID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			funcionarioID BIGINT NOT NULL,
+			data DATE NOT NULL,
+			marcacoes VARCHAR(255) NOT NULL DEFAULT '',
+			minutosPrevistos INT NOT NULL DEFAULT 0,
+			minutosTrabalhados INT NOT NULL DEFAULT 0,
+			minutosAtraso INT NOT NULL DEFAULT 0,
+			minutosExtra INT NOT NULL DEFAULT 0,
+			minutosExtra100 INT NOT NULL DEFAULT 0,
+			falta BOOLEAN NOT NULL DEFAULT FALSE,
+			inconsistente BOOLEAN NOT NULL DEFAULT FALSE,
+			UNIQUE KEY uq_ponto_dia (funcionarioID, data),
+			FOREIGN KEY (funcionarioID) REFERENCES funcionario(funcionarioID)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS regra_ausencia (
+			regraAusenciaID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			escopo VARCHAR(20) NOT NULL,
+			referencia VARCHAR(100) NOT NULL,
+			maxAusentes INT NOT NULL,
+			modo VARCHAR(10) NOT NULL DEFAULT 'AVISO',
+			ativo BOOLEAN NOT NULL DEFAULT TRUE
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS pagamento_ferias (
+			pagamentoFeriasID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			descansoID BIGINT NOT NULL UNIQUE,
+			funcionarioID BIGINT NOT NULL,
+			dias INT NOT NULL,
+			dataPrevista DATE NOT NULL,
+			dataPagamento DATE NULL,
+			valorFerias DECIMAL(10,2) NOT NULL,
+			valorTerco DECIMAL(10,2) NOT NULL,
+			valorBruto DECIMAL(10,2) NOT NULL,
+			descontoINSS DECIMAL(10,2) NOT NULL DEFAULT 0,
+			descontoIRRF DECIMAL(10,2) NOT NULL DEFAULT 0,
+			valorLiquido DECIMAL(10,2) NOT NULL,
+			pago BOOLEAN NOT NULL DEFAULT FALSE,
+			FOREIGN KEY (descansoID) REFERENCES descanso(descansoID) ON DELETE CASCADE,
+			FOREIGN KEY (funcionarioID) REFERENCES funcionario(funcionarioID)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS importacao (
+			importacaoID BIGINT AUTO_INCREMENT PRIMARY KEY,
+			tipo VARCHAR(20) NOT NULL,
+			arquivo VARCHAR(255) NOT NULL,
+			formato VARCHAR(10) NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			mapeamento TEXT NOT NULL,
+			totalLinhas INT NOT NULL DEFAULT 0,
+			linhasComErro INT NOT NULL DEFAULT 0,
+			linhas MEDIUMTEXT NOT NULL,
+			conteudo LONGBLOB NOT NULL,
+			usuarioID BIGINT NOT NULL,
+			criadoEm DATETIME NOT NULL,
+			concluidoEm DATETIME NULL,
+			INDEX idx_importacao_criado (criadoEm)
+		);`,
+
 		`CREATE TABLE IF NOT EXISTS log (
 			logID BIGINT AUTO_INCREMENT PRIMARY KEY,
 			usuarioID BIGINT,
@@ -224,6 +471,169 @@ func createTables() {
 	log.Println("Todas as tabelas foram criadas/verificadas com sucesso.")
 }
 
+// migrateTables inclui colunas novas em bancos criados por versões anteriores
+func migrateTables() {
+	addColumnIfNotExists("funcionario", "inicioAquisitivo", "DATE NULL")
+	addColumnIfNotExists("funcionario", "tipoContrato", "VARCHAR(20) NOT NULL DEFAULT 'CLT'")
+	addColumnIfNotExists("funcionario", "prazoContrato", "VARCHAR(20) NOT NULL DEFAULT 'INDETERMINADO'")
+	addColumnIfNotExists("funcionario", "fimExperiencia", "DATE NULL")
+	addColumnIfNotExists("funcionario", "fimProrrogacao", "DATE NULL")
+	addColumnIfNotExists("funcionario", "cargoID", "BIGINT NULL")
+	addColumnIfNotExists("falta", "tipo", "VARCHAR(20) NOT NULL DEFAULT 'MENSAL'")
+	addColumnIfNotExists("falta", "minutos", "INT NOT NULL DEFAULT 0")
+	addColumnIfNotExists("falta", "documentoID", "BIGINT NULL")
+	addColumnIfNotExists("falta", "origem", "VARCHAR(10) NOT NULL DEFAULT 'MANUAL'")
+	addColumnIfNotExists("pagamento", "descontoDSR", "DECIMAL(10,2) NOT NULL DEFAULT 0")
+	addColumnIfNotExists("pagamento", "horasExtras", "DECIMAL(10,2) NOT NULL DEFAULT 0")
+	addColumnIfNotExists("pagamento", "fgts", "DECIMAL(10,2) NOT NULL DEFAULT 0")
+	addColumnIfNotExists("pessoa", "nomeSocial", "VARCHAR(100) NOT NULL DEFAULT ''")
+	addColumnIfNotExists("pessoa", "sexo", "CHAR(1) NOT NULL DEFAULT ''")
+	addColumnIfNotExists("pessoa", "identidadeGenero", "VARCHAR(20) NOT NULL DEFAULT ''")
+	addColumnIfNotExists("pessoa", "estadoCivil", "TINYINT NOT NULL DEFAULT 0")
+	addColumnIfNotExists("pessoa", "grauInstrucao", "TINYINT NOT NULL DEFAULT 0")
+	addColumnIfNotExists("pessoa", "racaCor", "TINYINT NOT NULL DEFAULT 0")
+	addColumnIfNotExists("pessoa", "contatosMigrados", "BOOLEAN NOT NULL DEFAULT FALSE")
+	addColumnIfNotExists("pessoa", "anonimizadoEm", "DATETIME NULL")
+	// criptografia de campos: colunas com espaço para o valor cifrado e índices cegos
+	alargarColuna("pessoa", "cpf", "VARCHAR(255)", 255)
+	alargarColuna("pessoa", "rg", "VARCHAR(255)", 255)
+	alargarColuna("funcionario", "pis", "VARCHAR(255)", 255)
+	alargarColuna("funcionario", "ctpf", "VARCHAR(255)", 255)
+	alargarColuna("pessoa_endereco", "cep", "VARCHAR(255) NOT NULL DEFAULT ''", 255)
+	alargarColuna("pessoa_endereco", "logradouro", "VARCHAR(1024) NOT NULL DEFAULT ''", 1024)
+	alargarColuna("pessoa_endereco", "numero", "VARCHAR(255) NOT NULL DEFAULT ''", 255)
+	alargarColuna("pessoa_endereco", "complemento", "VARCHAR(1024) NOT NULL DEFAULT ''", 1024)
+	addColumnIfNotExists("pessoa", "cpfIndice", "CHAR(64) NULL UNIQUE")
+	addColumnIfNotExists("pessoa", "rgIndice", "CHAR(64) NULL UNIQUE")
+	addColumnIfNotExists("funcionario", "pisIndice", "CHAR(64) NULL")
+	addIndexIfNotExists("funcionario", "pisIndice")
+	dropUniqueIfExists("funcionario", "pessoaID")
+	normalizarDocumentos()
+	migrarContatosTexto()
+	criarIndicesBusca()
+
+	log.Println("Migrações de colunas verificadas com sucesso.")
+}
+
+// normalizarDocumentos grava só com dígitos os CPF, PIS e CTPS válidos cadastrados com máscara.
+// Valores inválidos ou que colidiriam com outro já normalizado ficam como estão para correção manual;
+// as colisões são registradas no log.
+func normalizarDocumentos() {
+	type doc struct {
+		id    int64
+		valor string
+	}
+	ler := func(q string) []doc {
+		rows, err := DB.Query(q)
+		if err != nil {
+			log.Printf("Erro ao ler documentos para normalização: %v", err)
+			return nil
+		}
+		defer rows.Close()
+		var lista []doc
+		for rows.Next() {
+			var d doc
+			if err := rows.Scan(&d.id, &d.valor); err == nil {
+				lista = append(lista, d)
+			}
+		}
+		return lista
+	}
+	normalizar := func(tabela, chave, coluna string, lista []doc, fn func(string) (string, error)) {
+		for _, d := range lista {
+			v, err := fn(d.valor)
+			if err != nil || v == d.valor {
+				continue
+			}
+			q := fmt.Sprintf("UPDATE IGNORE %s SET %s = ? WHERE %s = ?", tabela, coluna, chave)
+			res, err := DB.Exec(q, v, d.id)
+			if err != nil {
+				log.Printf("Erro ao normalizar %s.%s ID=%d: %v", tabela, coluna, d.id, err)
+				continue
+			}
+			// o IGNORE descarta em silêncio a troca que violaria o UNIQUE: o valor já existe só com dígitos
+			if n, err := res.RowsAffected(); err == nil && n == 0 {
+				var outro int64
+				q = fmt.Sprintf("SELECT %s FROM %s WHERE %s = ? AND %s <> ? LIMIT 1", chave, tabela, coluna, chave)
+				if err := DB.QueryRow(q, v, d.id).Scan(&outro); err == nil {
+					log.Printf("Aviso: %s.%s ID=%d não normalizado: colide com %s ID=%d; corrija manualmente",
+						tabela, coluna, d.id, tabela, outro)
+				} else {
+					log.Printf("Aviso: %s.%s ID=%d não normalizado; corrija manualmente", tabela, coluna, d.id)
+				}
+			}
+		}
+	}
+	normalizar("pessoa", "pessoaID", "cpf",
+		ler("SELECT pessoaID, cpf FROM pessoa WHERE cpf REGEXP '[^0-9]' AND cpf NOT LIKE 'enc:%'"), documentos.NormalizarCPF)
+	normalizar("funcionario", "funcionarioID", "pis",
+		ler("SELECT funcionarioID, pis FROM funcionario WHERE pis REGEXP '[^0-9]' AND pis NOT LIKE 'enc:%'"), documentos.NormalizarPIS)
+	normalizar("funcionario", "funcionarioID", "ctpf",
+		ler("SELECT funcionarioID, ctpf FROM funcionario WHERE ctpf REGEXP '[^0-9]' AND ctpf NOT LIKE 'enc:%'"), documentos.NormalizarCTPS)
+}
+
+func addColumnIfNotExists(table, column, definition string) {
+	const q = `SELECT COUNT(*) FROM information_schema.COLUMNS
+	           WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`
+	var count int
+	if err := DB.QueryRow(q, table, column).Scan(&count); err != nil {
+		log.Fatalf("Erro ao verificar coluna %s.%s: %v", table, column, err)
+	}
+	if count == 0 {
+		mustExec(DB, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	}
+}
+
+// addIndexIfNotExists cria o índice comum idx_<tabela>_<coluna>, se ainda não existir
+func addIndexIfNotExists(table, column string) {
+	idx := fmt.Sprintf("idx_%s_%s", table, column)
+	var existe int
+	if err := DB.QueryRow(`SELECT COUNT(*) FROM information_schema.STATISTICS
+	           WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?`, table, idx).Scan(&existe); err != nil {
+		log.Fatalf("Erro ao verificar índice %s: %v", idx, err)
+	}
+	if existe == 0 {
+		mustExec(DB, fmt.Sprintf("CREATE INDEX %s ON %s (%s)", idx, table, column))
+	}
+}
+
+// dropUniqueIfExists troca o índice UNIQUE de uma coluna por um índice comum, mantendo o índice
+// exigido pela chave estrangeira
+func dropUniqueIfExists(table, column string) {
+	const q = `SELECT DISTINCT s.INDEX_NAME FROM information_schema.STATISTICS s
+	           WHERE s.TABLE_SCHEMA = DATABASE() AND s.TABLE_NAME = ? AND s.COLUMN_NAME = ?
+	             AND s.NON_UNIQUE = 0 AND s.INDEX_NAME <> 'PRIMARY'
+	             AND (SELECT COUNT(*) FROM information_schema.STATISTICS c
+	                  WHERE c.TABLE_SCHEMA = s.TABLE_SCHEMA AND c.TABLE_NAME = s.TABLE_NAME AND c.INDEX_NAME = s.INDEX_NAME) = 1`
+	rows, err := DB.Query(q, table, column)
+	if err != nil {
+		log.Fatalf("Erro ao verificar índices de %s.%s: %v", table, column, err)
+	}
+	var indices []string
+	for rows.Next() {
+		var nome string
+		if err := rows.Scan(&nome); err == nil {
+			indices = append(indices, nome)
+		}
+	}
+	_ = rows.Close()
+	if len(indices) == 0 {
+		return
+	}
+	idx := fmt.Sprintf("idx_%s_%s", table, column)
+	var existe int
+	if err := DB.QueryRow(`SELECT COUNT(*) FROM information_schema.STATISTICS
+	           WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?`, table, idx).Scan(&existe); err != nil {
+		log.Fatalf("Erro ao verificar índice %s: %v", idx, err)
+	}
+	if existe == 0 {
+		mustExec(DB, fmt.Sprintf("CREATE INDEX %s ON %s (%s)", idx, table, column))
+	}
+	for _, nome := range indices {
+		mustExec(DB, fmt.Sprintf("ALTER TABLE %s DROP INDEX `%s`", table, nome))
+	}
+}
+
 func seedDefaultData() {
 
 	eventos := []string{"LOGIN", "LOGOUT", "CRIAR", "ATUALIZAR", "DELETAR", "APROVAR", "NEGAR"}
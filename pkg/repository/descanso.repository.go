@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"time"
 )
 
 // CreateDescanso cria um descanso vinculado a um período de férias
@@ -160,6 +161,55 @@ func UpdateDescanso(d *entity.Descanso) error {
 	return nil
 }
 
+// AprovarDescanso aprova o descanso, consome os dias do período de férias e grava o pagamento de férias
+// (novo sem ID, existente com ID; já efetuado, não é regravado) numa única transação
+func AprovarDescanso(d *entity.Descanso, p *entity.PagamentoFerias) (err error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação da aprovação do descanso: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				log.Printf("erro ao desfazer transação da aprovação do descanso: %v", rerr)
+			}
+		}
+	}()
+
+	res, err := tx.Exec(`UPDATE descanso SET aprovado = TRUE WHERE descansoID = ? AND aprovado = FALSE`, d.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao aprovar descanso: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao aprovar descanso: %w", err)
+	}
+	if n == 0 {
+		err = fmt.Errorf("descanso já está aprovado")
+		return err
+	}
+	dias := d.DuracaoEmDias()
+	if _, err = tx.Exec(`UPDATE ferias SET dias = dias - ? WHERE feriasID = ? AND dias >= ?`, dias, d.FeriasID, dias); err != nil {
+		return fmt.Errorf("erro ao consumir dias de férias: %w", err)
+	}
+	switch {
+	case p.Pago:
+	case p.ID == 0:
+		err = insertPagamentoFerias(tx, p)
+	default:
+		err = updatePagamentoFerias(tx, p)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação da aprovação do descanso: %w", err)
+	}
+	d.Aprovado = true
+	return nil
+}
+
 // DeleteDescanso deleta um descanso (hard delete)
 func DeleteDescanso(id int64) error {
 	query := `DELETE FROM descanso WHERE descansoID = ?`
@@ -300,3 +350,71 @@ func GetDescansosByFuncionarioID(funcionarioID int64) ([]*entity.Descanso, error
 	}
 	return lista, nil
 }
+
+// GetDescansoSobreposto devolve um descanso do funcionário que se sobrepõe a [inicio, fim], ou nil
+func GetDescansoSobreposto(funcionarioID int64, inicio, fim time.Time) (*entity.Descanso, error) {
+	query := `SELECT d.descansoID, d.feriasID, d.inicio, d.fim, d.valor, d.pago, d.aprovado
+			  FROM descanso d
+			  INNER JOIN ferias f ON d.feriasID = f.feriasID
+			  WHERE f.funcionarioID = ? AND d.inicio <= ? AND d.fim >= ?
+			  ORDER BY d.inicio LIMIT 1`
+
+	var d entity.Descanso
+	var inicioStr, fimStr string
+	err := DB.QueryRow(query, funcionarioID, timeToDateString.TimeToDateString(fim), timeToDateString.TimeToDateString(inicio)).
+		Scan(&d.ID, &d.FeriasID, &inicioStr, &fimStr, &d.Valor, &d.Pago, &d.Aprovado)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar descanso sobreposto: %w", err)
+	}
+	if d.Inicio, err = dateStringToTime.DateStringToTime(inicioStr); err != nil {
+		return nil, fmt.Errorf("erro ao converter data de início: %w", err)
+	}
+	if d.Fim, err = dateStringToTime.DateStringToTime(fimStr); err != nil {
+		return nil, fmt.Errorf("erro ao converter data de fim: %w", err)
+	}
+	return &d, nil
+}
+
+// ListDescansosCalendario lista os descansos (aprovados e pendentes) que se sobrepõem a [inicio, fim],
+// com nome e cargo do funcionário
+func ListDescansosCalendario(inicio, fim time.Time) ([]*entity.DescansoCalendario, error) {
+	query := `SELECT d.descansoID, d.feriasID, fu.funcionarioID, p.nome, fu.cargo, d.inicio, d.fim, d.aprovado, d.pago
+		FROM descanso d
+		JOIN ferias f ON f.feriasID = d.feriasID
+		JOIN funcionario fu ON fu.funcionarioID = f.funcionarioID
+		JOIN pessoa p ON p.pessoaID = fu.pessoaID
+		WHERE d.inicio <= ? AND d.fim >= ?
+		ORDER BY d.inicio, p.nome`
+
+	rows, err := DB.Query(query, timeToDateString.TimeToDateString(fim), timeToDateString.TimeToDateString(inicio))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar calendário de descansos: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.DescansoCalendario
+	for rows.Next() {
+		var c entity.DescansoCalendario
+		var inicioStr, fimStr string
+		var cargo sql.NullString
+		if err := rows.Scan(&c.DescansoID, &c.FeriasID, &c.FuncionarioID, &c.Nome, &cargo,
+			&inicioStr, &fimStr, &c.Aprovado, &c.Pago); err != nil {
+			return nil, fmt.Errorf("erro ao ler descanso do calendário: %w", err)
+		}
+		c.Cargo = cargo.String
+		if c.Inicio, err = dateStringToTime.DateStringToTime(inicioStr); err != nil {
+			return nil, fmt.Errorf("erro ao converter data de início: %w", err)
+		}
+		if c.Fim, err = dateStringToTime.DateStringToTime(fimStr); err != nil {
+			return nil, fmt.Errorf("erro ao converter data de fim: %w", err)
+		}
+		lista = append(lista, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao iterar calendário de descansos: %w", err)
+	}
+	return lista, nil
+}
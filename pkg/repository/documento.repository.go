@@ -2,6 +2,7 @@ package repository
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 	"context"
 	"database/sql"
 	"fmt"
@@ -71,15 +72,36 @@ func GetByID(ctx context.Context, id int64) (*entity.Documento, error) {
 
 // ListDocumentos retorna todos os documentos cadastrados
 func ListDocumentos(ctx context.Context) ([]*entity.Documento, error) {
-	query := `SELECT documentoID, funcionarioID, caminho FROM documento`
+	return listDocumentos(ctx, `SELECT documentoID, funcionarioID, caminho FROM documento`)
+}
+
+// consultaDocumentos define filtros e ordenações da listagem de documentos
+var consultaDocumentos = especConsulta{
+	chave:  "documentoID",
+	ordens: map[string]string{"funcionario_id": "funcionarioID", "caminho": "caminho"},
+	filtros: map[string]filtroSQL{
+		"funcionario_id": filtroID("funcionarioID"),
+		"caminho":        filtroContem("caminho"),
+	},
+}
+
+// BuscarDocumentos lista uma página dos documentos
+func BuscarDocumentos(ctx context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Documento], error) {
+	listar := func(query string, args ...interface{}) ([]*entity.Documento, error) {
+		return listDocumentos(ctx, query, args...)
+	}
+	return buscar(q, consultaDocumentos, `documentoID, funcionarioID, caminho`, `FROM documento`,
+		listar, func(d *entity.Documento) int64 { return d.ID })
+}
 
-	rows, err := DB.QueryContext(ctx, query)
+func listDocumentos(ctx context.Context, query string, args ...interface{}) ([]*entity.Documento, error) {
+	rows, err := DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao listar documentos: %w", err)
 	}
 	defer func() {
 		if cerr := rows.Close(); cerr != nil {
-			log.Printf("erro ao fechar rows em ListDocumentos: %v", cerr)
+			log.Printf("erro ao fechar rows em listDocumentos: %v", cerr)
 		}
 	}()
 
@@ -96,6 +118,11 @@ func ListDocumentos(ctx context.Context) ([]*entity.Documento, error) {
 
 // DeleteDocumento remove um documento pelo ID
 func DeleteDocumento(ctx context.Context, id int64) error {
+	// faltas justificadas por este documento perdem o vínculo, mas continuam registradas
+	if _, err := DB.ExecContext(ctx, `UPDATE falta SET documentoID = NULL WHERE documentoID = ?`, id); err != nil {
+		return fmt.Errorf("erro ao desvincular documento das faltas: %w", err)
+	}
+
 	query := `DELETE FROM documento WHERE documentoID = ?`
 	_, err := DB.ExecContext(ctx, query, id)
 	if err != nil {
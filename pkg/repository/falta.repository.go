@@ -2,16 +2,33 @@ package repository
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 	"AutoGRH/pkg/utils/dateStringToTime"
+	"AutoGRH/pkg/utils/timeToDateString"
 	"database/sql"
 	"fmt"
+	"log"
+	"strings"
+	"time"
 )
 
+const faltaColumns = `faltaID, funcionarioID, quantidade, data, tipo, minutos, documentoID, origem`
+
 // CreateFalta cria um registro de falta
 func CreateFalta(f *entity.Falta) error {
-	query := `INSERT INTO falta (funcionarioID, quantidade, data) VALUES (?, ?, ?)`
+	return insertFalta(DB, f)
+}
+
+func insertFalta(db executor, f *entity.Falta) error {
+	if f.Tipo == "" {
+		f.Tipo = entity.FaltaTipoMensal
+	}
+	if f.Origem == "" {
+		f.Origem = entity.FaltaOrigemManual
+	}
+	query := `INSERT INTO falta (funcionarioID, quantidade, data, tipo, minutos, documentoID, origem) VALUES (?, ?, ?, ?, ?, ?, ?)`
 
-	result, err := DB.Exec(query, f.FuncionarioID, f.Quantidade, f.Mes)
+	result, err := db.Exec(query, f.FuncionarioID, f.Quantidade, timeToDateString.TimeToDateString(f.Mes), f.Tipo, f.Minutos, f.DocumentoID, f.Origem)
 	if err != nil {
 		return fmt.Errorf("erro ao inserir falta: %w", err)
 	}
@@ -24,115 +41,148 @@ func CreateFalta(f *entity.Falta) error {
 	return nil
 }
 
-// GetFaltasByFuncionarioID busca todas as faltas de um funcionário
-func GetFaltasByFuncionarioID(funcionarioID int64) ([]*entity.Falta, error) {
-	query := `SELECT faltaID, funcionarioID, quantidade, data FROM falta WHERE funcionarioID = ?`
+func scanFalta(row rowScanner) (*entity.Falta, error) {
+	var f entity.Falta
+	var dataStr string
+	var documentoID sql.NullInt64
+	if err := row.Scan(&f.ID, &f.FuncionarioID, &f.Quantidade, &dataStr, &f.Tipo, &f.Minutos, &documentoID, &f.Origem); err != nil {
+		return nil, err
+	}
 
-	rows, err := DB.Query(query, funcionarioID)
+	var err error
+	f.Mes, err = dateStringToTime.DateStringToTime(dataStr)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao converter data: %w", err)
+	}
+	if documentoID.Valid {
+		id := documentoID.Int64
+		f.DocumentoID = &id
+	}
+	return &f, nil
+}
+
+func listFaltas(query string, args ...interface{}) ([]*entity.Falta, error) {
+	rows, err := DB.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao listar faltas por funcionário: %w", err)
+		return nil, fmt.Errorf("erro ao listar faltas: %w", err)
 	}
 	defer rows.Close()
 
 	var lista []*entity.Falta
 	for rows.Next() {
-		var f entity.Falta
-		var dataStr string
-		if err := rows.Scan(&f.ID, &f.FuncionarioID, &f.Quantidade, &dataStr); err != nil {
+		f, err := scanFalta(rows)
+		if err != nil {
 			return nil, fmt.Errorf("erro ao ler falta: %w", err)
 		}
+		lista = append(lista, f)
+	}
+	return lista, rows.Err()
+}
 
-		f.Mes, err = dateStringToTime.DateStringToTime(dataStr)
-		if err != nil {
-			return nil, fmt.Errorf("erro ao converter data: %w", err)
-		}
+// GetFaltasByFuncionarioID busca todas as faltas de um funcionário
+func GetFaltasByFuncionarioID(funcionarioID int64) ([]*entity.Falta, error) {
+	return listFaltas(`SELECT `+faltaColumns+` FROM falta WHERE funcionarioID = ? ORDER BY data`, funcionarioID)
+}
 
-		lista = append(lista, &f)
-	}
-	return lista, nil
+// GetFaltasByFuncionarioPeriodo busca as faltas de um funcionário com data em [inicio, fim]
+func GetFaltasByFuncionarioPeriodo(funcionarioID int64, inicio, fim time.Time) ([]*entity.Falta, error) {
+	return listFaltas(`SELECT `+faltaColumns+` FROM falta WHERE funcionarioID = ? AND data BETWEEN ? AND ? ORDER BY data`,
+		funcionarioID, timeToDateString.TimeToDateString(inicio), timeToDateString.TimeToDateString(fim))
 }
 
 // GetFaltaByID retorna uma falta pelo ID
 func GetFaltaByID(id int64) (*entity.Falta, error) {
-	query := `SELECT faltaID, funcionarioID, quantidade, data FROM falta WHERE faltaID = ?`
-	row := DB.QueryRow(query, id)
-
-	var f entity.Falta
-	var dataStr string
-	if err := row.Scan(&f.ID, &f.FuncionarioID, &f.Quantidade, &dataStr); err != nil {
+	f, err := scanFalta(DB.QueryRow(`SELECT `+faltaColumns+` FROM falta WHERE faltaID = ?`, id))
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("erro ao buscar falta: %w", err)
 	}
-
-	var err error
-	f.Mes, err = dateStringToTime.DateStringToTime(dataStr)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao converter data: %w", err)
-	}
-
-	return &f, nil
+	return f, nil
 }
 
 // UpdateFalta atualiza um registro de falta
 func UpdateFalta(f *entity.Falta) error {
-	query := `UPDATE falta SET quantidade = ?, data = ? WHERE faltaID = ?`
-	_, err := DB.Exec(query, f.Quantidade, f.Mes, f.ID)
+	if f.Origem == "" {
+		f.Origem = entity.FaltaOrigemManual
+	}
+	query := `UPDATE falta SET quantidade = ?, data = ?, tipo = ?, minutos = ?, documentoID = ?, origem = ? WHERE faltaID = ?`
+	_, err := DB.Exec(query, f.Quantidade, timeToDateString.TimeToDateString(f.Mes), f.Tipo, f.Minutos, f.DocumentoID, f.Origem, f.ID)
 	if err != nil {
 		return fmt.Errorf("erro ao atualizar falta: %w", err)
 	}
 	return nil
 }
 
-// DeleteFalta remove uma falta por ID
-func DeleteFalta(id int64) error {
-	query := `DELETE FROM falta WHERE faltaID = ?`
-	_, err := DB.Exec(query, id)
+// DeleteFalta remove uma falta por ID, junto com o débito de banco de horas de uma folga compensada,
+// numa única transação
+func DeleteFalta(id int64) (err error) {
+	tx, err := DB.Begin()
 	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação da exclusão da falta: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				log.Printf("erro ao desfazer transação da exclusão da falta: %v", rerr)
+			}
+		}
+	}()
+
+	if _, err = tx.Exec(`DELETE FROM banco_horas WHERE origem = 'FOLGA' AND referencia = ?`, id); err != nil {
+		return fmt.Errorf("erro ao remover débito do banco de horas da falta: %w", err)
+	}
+	if _, err = tx.Exec(`DELETE FROM falta WHERE faltaID = ?`, id); err != nil {
 		return fmt.Errorf("erro ao deletar falta: %w", err)
 	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar exclusão da falta: %w", err)
+	}
 	return nil
 }
 
 // ListAllFaltas retorna todas as faltas cadastradas
 func ListAllFaltas() ([]*entity.Falta, error) {
-	query := `SELECT faltaID, funcionarioID, quantidade, data FROM falta`
-
-	rows, err := DB.Query(query)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao listar faltas: %w", err)
-	}
-	defer rows.Close()
-
-	var lista []*entity.Falta
-	for rows.Next() {
-		var f entity.Falta
-		var dataStr string
-		if err := rows.Scan(&f.ID, &f.FuncionarioID, &f.Quantidade, &dataStr); err != nil {
-			return nil, fmt.Errorf("erro ao ler falta: %w", err)
-		}
+	return listFaltas(`SELECT ` + faltaColumns + ` FROM falta ORDER BY data`)
+}
 
-		f.Mes, err = dateStringToTime.DateStringToTime(dataStr)
-		if err != nil {
-			return nil, fmt.Errorf("erro ao converter data: %w", err)
-		}
+// consultaFaltas define filtros e ordenações da listagem de faltas
+var consultaFaltas = especConsulta{
+	chave:  "faltaID",
+	padrao: "data",
+	ordens: map[string]string{"data": "data", "quantidade": "quantidade", "funcionario_id": "funcionarioID"},
+	filtros: map[string]filtroSQL{
+		"funcionario_id": filtroID("funcionarioID"),
+		"tipo":           filtroMaiusculo("tipo"),
+		"data_de":        filtroDesde("data"),
+		"data_ate":       filtroAte("data"),
+	},
+}
 
-		lista = append(lista, &f)
-	}
-	return lista, nil
+// BuscarFaltas lista uma página de faltas, por padrão em ordem de data
+func BuscarFaltas(q consulta.Consulta) (consulta.Pagina[*entity.Falta], error) {
+	return buscar(q, consultaFaltas, faltaColumns, `FROM falta`,
+		listFaltas, func(f *entity.Falta) int64 { return f.ID })
 }
 
-// GetTotalFaltasByFuncionarioMesAno retorna o total de faltas de um funcionário em um mês/ano específico
+// GetTotalFaltasByFuncionarioMesAno retorna o total de dias de falta descontáveis (os tipos de
+// entity.TiposFaltaDescontoDias) de um funcionário em um mês/ano específico
 func GetTotalFaltasByFuncionarioMesAno(funcionarioID int64, mes int, ano int) (int, error) {
+	in := "(" + strings.TrimSuffix(strings.Repeat("?,", len(entity.TiposFaltaDescontoDias)), ",") + ")"
 	query := `
 SELECT COALESCE(SUM(quantidade),0)
 FROM falta
 WHERE funcionarioID = ?
+  AND tipo IN ` + in + `
   AND MONTH(data) = ?
   AND YEAR(data)  = ?`
 
-	row := DB.QueryRow(query, funcionarioID, mes, ano)
+	args := []interface{}{funcionarioID}
+	for _, tipo := range entity.TiposFaltaDescontoDias {
+		args = append(args, tipo)
+	}
+	row := DB.QueryRow(query, append(args, mes, ano)...)
 
 	var total int
 	if err := row.Scan(&total); err != nil {
@@ -143,6 +193,42 @@ WHERE funcionarioID = ?
 	return total, nil
 }
 
+// GetMinutosAtrasoByFuncionarioMesAno retorna o total de minutos de atraso de um funcionário no mês/ano
+func GetMinutosAtrasoByFuncionarioMesAno(funcionarioID int64, mes int, ano int) (int, error) {
+	query := `
+SELECT COALESCE(SUM(minutos),0)
+FROM falta
+WHERE funcionarioID = ?
+  AND tipo = 'ATRASO'
+  AND MONTH(data) = ?
+  AND YEAR(data)  = ?`
+
+	var total int
+	if err := DB.QueryRow(query, funcionarioID, mes, ano).Scan(&total); err != nil {
+		return 0, fmt.Errorf("erro ao somar atrasos do funcionário %d em %02d/%d: %w",
+			funcionarioID, mes, ano, err)
+	}
+	return total, nil
+}
+
+// GetFaltasMensais retorna o total consolidado (tipo MENSAL) lançado no mês
+func GetFaltasMensais(funcionarioID int64, mes int, ano int) (int, error) {
+	var total int
+	if err := DB.QueryRow(`
+		SELECT COALESCE(SUM(quantidade), 0)
+		  FROM falta
+		 WHERE funcionarioID = ?
+		   AND tipo          = 'MENSAL'
+		   AND MONTH(data)   = ?
+		   AND YEAR(data)    = ?`,
+		funcionarioID, mes, ano,
+	).Scan(&total); err != nil {
+		return 0, fmt.Errorf("erro ao buscar faltas mensais: %w", err)
+	}
+	return total, nil
+}
+
+// SetFaltasMensais grava o total consolidado (tipo MENSAL) do mês; registros individuais não são alterados
 func SetFaltasMensais(funcionarioID int64, mes int, ano int, quantidade int) error {
 	if quantidade < 0 {
 		quantidade = 0
@@ -153,6 +239,7 @@ func SetFaltasMensais(funcionarioID int64, mes int, ano int, quantidade int) err
 		UPDATE falta
 		   SET quantidade = ?
 		 WHERE funcionarioID = ?
+		   AND tipo          = 'MENSAL'
 		   AND MONTH(data)   = ?
 		   AND YEAR(data)    = ?`,
 		quantidade, funcionarioID, mes, ano,
@@ -166,8 +253,8 @@ func SetFaltasMensais(funcionarioID int64, mes int, ano int, quantidade int) err
 	if rows == 0 && quantidade > 0 {
 		primeiroDia := fmt.Sprintf("%04d-%02d-01", ano, mes)
 		if _, err := DB.Exec(`
-			INSERT INTO falta (funcionarioID, quantidade, data)
-			VALUES (?, ?, ?)`,
+			INSERT INTO falta (funcionarioID, quantidade, data, tipo)
+			VALUES (?, ?, ?, 'MENSAL')`,
 			funcionarioID, quantidade, primeiroDia,
 		); err != nil {
 			return fmt.Errorf("erro ao inserir faltas mensais: %w", err)
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"AutoGRH/pkg/utils/timeToDateString"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const feriadoColumns = `feriadoID, data, nome, tipo, localidade, recorrente`
+
+// CreateFeriado insere um feriado ou dia sem expediente cadastrado
+func CreateFeriado(f *entity.Feriado) error {
+	query := `INSERT INTO feriado (data, nome, tipo, localidade, recorrente) VALUES (?, ?, ?, ?, ?)`
+
+	result, err := DB.Exec(query, timeToDateString.TimeToDateString(f.Data), f.Nome, f.Tipo, f.Localidade, f.Recorrente)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir feriado: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID do feriado: %w", err)
+	}
+	f.ID = id
+	return nil
+}
+
+func scanFeriado(row rowScanner) (*entity.Feriado, error) {
+	var f entity.Feriado
+	var dataStr string
+	if err := row.Scan(&f.ID, &dataStr, &f.Nome, &f.Tipo, &f.Localidade, &f.Recorrente); err != nil {
+		return nil, err
+	}
+	var err error
+	if f.Data, err = dateStringToTime.DateStringToTime(dataStr); err != nil {
+		return nil, fmt.Errorf("erro ao converter data do feriado: %w", err)
+	}
+	return &f, nil
+}
+
+// GetFeriadoByID busca um feriado cadastrado pelo ID
+func GetFeriadoByID(id int64) (*entity.Feriado, error) {
+	f, err := scanFeriado(DB.QueryRow(`SELECT `+feriadoColumns+` FROM feriado WHERE feriadoID = ?`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar feriado: %w", err)
+	}
+	return f, nil
+}
+
+// UpdateFeriado atualiza um feriado cadastrado
+func UpdateFeriado(f *entity.Feriado) error {
+	query := `UPDATE feriado SET data = ?, nome = ?, tipo = ?, localidade = ?, recorrente = ? WHERE feriadoID = ?`
+	if _, err := DB.Exec(query, timeToDateString.TimeToDateString(f.Data), f.Nome, f.Tipo, f.Localidade, f.Recorrente, f.ID); err != nil {
+		return fmt.Errorf("erro ao atualizar feriado: %w", err)
+	}
+	return nil
+}
+
+// DeleteFeriado remove um feriado cadastrado
+func DeleteFeriado(id int64) error {
+	if _, err := DB.Exec(`DELETE FROM feriado WHERE feriadoID = ?`, id); err != nil {
+		return fmt.Errorf("erro ao deletar feriado: %w", err)
+	}
+	return nil
+}
+
+func listFeriados(query string, args ...interface{}) ([]*entity.Feriado, error) {
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar feriados: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.Feriado
+	for rows.Next() {
+		f, err := scanFeriado(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler feriado: %w", err)
+		}
+		lista = append(lista, f)
+	}
+	return lista, rows.Err()
+}
+
+// ListFeriados lista todos os feriados cadastrados
+func ListFeriados() ([]*entity.Feriado, error) {
+	return listFeriados(`SELECT ` + feriadoColumns + ` FROM feriado ORDER BY data`)
+}
+
+// ListFeriadosEntre lista os feriados cadastrados com data em [inicio, fim] e todos os recorrentes,
+// que o chamador projeta para os anos do intervalo
+func ListFeriadosEntre(inicio, fim time.Time) ([]*entity.Feriado, error) {
+	return listFeriados(`SELECT `+feriadoColumns+` FROM feriado WHERE recorrente = TRUE OR data BETWEEN ? AND ? ORDER BY data`,
+		timeToDateString.TimeToDateString(inicio), timeToDateString.TimeToDateString(fim))
+}
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 	"AutoGRH/pkg/utils/dateStringToTime"
+	"AutoGRH/pkg/utils/nullStringToTimePtr"
 	"database/sql"
 	"fmt"
 	"log"
@@ -130,35 +132,68 @@ func GetFeriasByFuncionarioID(funcionarioID int64) ([]*entity.Ferias, error) {
 			continue
 		}
 
-		// Carrega descansos
-		descansos, derr := GetDescansosByFeriasID(f.ID)
+		lista = append(lista, &f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao iterar férias: %w", err)
+	}
+
+	// Carrega os descansos de todos os períodos numa consulta só
+	if len(lista) > 0 {
+		descansos, derr := GetDescansosByFuncionarioID(funcionarioID)
 		if derr != nil {
 			log.Printf("erro ao carregar descansos: %v", derr)
-		} else {
-			for _, d := range descansos {
+			return lista, nil
+		}
+		porFerias := make(map[int64]*entity.Ferias, len(lista))
+		for _, f := range lista {
+			porFerias[f.ID] = f
+		}
+		for _, d := range descansos {
+			if f := porFerias[d.FeriasID]; f != nil {
 				f.Descansos = append(f.Descansos, *d)
 			}
 		}
-
-		lista = append(lista, &f)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("erro ao iterar férias: %w", err)
 	}
 	return lista, nil
 }
 
 // ListFerias lista todos os registros de férias
 func ListFerias() ([]*entity.Ferias, error) {
-	query := `SELECT feriasID, funcionarioID, dias, inicio, vencimento, vencido, valor, pago, terco, tercoPago FROM ferias`
+	return listFerias(`SELECT ` + feriasColumns + ` FROM ferias`)
+}
+
+const feriasColumns = `feriasID, funcionarioID, dias, inicio, vencimento, vencido, valor, pago, terco, tercoPago`
+
+// consultaFerias define filtros e ordenações da listagem de férias
+var consultaFerias = especConsulta{
+	chave:  "feriasID",
+	ordens: map[string]string{"inicio": "inicio", "vencimento": "vencimento", "dias": "dias"},
+	filtros: map[string]filtroSQL{
+		"funcionario_id": filtroID("funcionarioID"),
+		"vencido":        filtroBool("vencido"),
+		"pago":           filtroBool("pago"),
+		"inicio_de":      filtroDesde("inicio"),
+		"inicio_ate":     filtroAte("inicio"),
+		"vencimento_ate": filtroAte("vencimento"),
+	},
+}
 
-	rows, err := DB.Query(query)
+// BuscarFerias lista uma página dos períodos de férias (sem os descansos)
+func BuscarFerias(q consulta.Consulta) (consulta.Pagina[*entity.Ferias], error) {
+	return buscar(q, consultaFerias, feriasColumns, `FROM ferias`,
+		listFerias, func(f *entity.Ferias) int64 { return f.ID })
+}
+
+// listFerias executa uma consulta no formato de feriasColumns, sem carregar descansos
+func listFerias(query string, args ...interface{}) ([]*entity.Ferias, error) {
+	rows, err := DB.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao listar férias: %w", err)
 	}
 	defer func() {
 		if cerr := rows.Close(); cerr != nil {
-			log.Printf("erro ao fechar rows em ListFerias: %v", cerr)
+			log.Printf("erro ao fechar rows em listFerias: %v", cerr)
 		}
 	}()
 
@@ -321,6 +356,24 @@ func GetFeriasNaoPagasComSaldo(funcionarioID int64) ([]*entity.Ferias, error) {
 	return lista, nil
 }
 
+// GetSaldoFerias resume, numa consulta, os períodos NÃO pagos com saldo do funcionário
+func GetSaldoFerias(funcionarioID int64) (entity.SaldoFerias, error) {
+	query := `SELECT COALESCE(SUM(dias),0), COUNT(*), COALESCE(SUM(vencido),0),
+	                 MIN(CASE WHEN vencido = FALSE THEN vencimento END)
+	          FROM ferias
+			  WHERE funcionarioID = ? AND pago = FALSE AND dias > 0`
+	var saldo entity.SaldoFerias
+	var proximo sql.NullString
+	if err := DB.QueryRow(query, funcionarioID).Scan(&saldo.Dias, &saldo.Periodos, &saldo.PeriodosVencidos, &proximo); err != nil {
+		return saldo, fmt.Errorf("erro ao resumir saldo de férias: %w", err)
+	}
+	var err error
+	if saldo.ProximoVencimento, err = nullStringToTimePtr.NullStringToTimePtr(proximo); err != nil {
+		return saldo, fmt.Errorf("erro ao converter vencimento: %w", err)
+	}
+	return saldo, nil
+}
+
 // Soma o saldo (dias) de todos os períodos NÃO pagos
 func SumSaldoFeriasNaoPagas(funcionarioID int64) (int, error) {
 	query := `SELECT COALESCE(SUM(dias),0)
@@ -154,6 +154,10 @@ func GetFolhaByMesAnoTipo(mes, ano int, tipo string) (*entity.FolhaPagamentos, e
 
 // DeleteFolhaPagamento exclui uma folha de pagamento permanentemente
 func DeleteFolhaPagamento(id int64) error {
+	// o pagamento do banco de horas feito pela folha volta ao saldo vencido
+	if _, err := DB.Exec(`DELETE FROM banco_horas WHERE origem = 'FOLHA' AND referencia = ?`, id); err != nil {
+		return fmt.Errorf("erro ao remover pagamentos do banco de horas da folha: %w", err)
+	}
 	query := `DELETE FROM folha_pagamento WHERE folhaID = ?`
 	_, err := DB.Exec(query, id)
 	if err != nil {
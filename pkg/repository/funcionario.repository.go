@@ -2,30 +2,54 @@ package repository
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 	"AutoGRH/pkg/utils/dateStringToTime"
-	"AutoGRH/pkg/utils/nullTimeToPtr"
+	"AutoGRH/pkg/utils/documentos"
+	"AutoGRH/pkg/utils/nullStringToTimePtr"
 	"AutoGRH/pkg/utils/ptrToNullTime"
+	"AutoGRH/pkg/utils/timeToDateString"
 	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 )
 
 // CreateFuncionario cria um novo funcionário no banco
 func CreateFuncionario(f *entity.Funcionario) error {
+	return insertFuncionario(DB, f)
+}
+
+func insertFuncionario(db executor, f *entity.Funcionario) error {
 	if f.PessoaID == 0 {
 		return fmt.Errorf("pessoa associada ao funcionário é inválida ou inexistente")
 	}
 
+	if f.TipoContrato == "" {
+		f.TipoContrato = entity.TipoContratoCLT
+	}
+	if f.PrazoContrato == "" {
+		f.PrazoContrato = entity.ContratoPrazoIndeterminado
+	}
+
+	pis, ctpf, pisIndice, err := documentosFuncionario(f)
+	if err != nil {
+		return err
+	}
+
 	query := `INSERT INTO funcionario (
-		pessoaID, pis, ctpf, nascimento, admissao, demissao,
-		cargo, salarioInicial, feriasDisponiveis, ativo)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		pessoaID, pis, ctpf, pisIndice, nascimento, admissao, demissao,
+		cargo, salarioInicial, feriasDisponiveis, ativo,
+		tipoContrato, prazoContrato, fimExperiencia, fimProrrogacao, cargoID)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	result, err := DB.Exec(query,
-		f.PessoaID, f.PIS, f.CTPF, f.Nascimento, f.Admissao,
+	result, err := db.Exec(query,
+		f.PessoaID, pis, ctpf, pisIndice, f.Nascimento, f.Admissao,
 		ptrToNullTime.PtrToNullTime(f.Demissao),
 		f.Cargo, f.SalarioInicial, f.FeriasDisponiveis, true,
+		f.TipoContrato, f.PrazoContrato, ptrToNullTime.PtrToNullTime(f.FimExperiencia), ptrToNullTime.PtrToNullTime(f.FimProrrogacao),
+		f.CargoID,
 	)
 	if err != nil {
 		return fmt.Errorf("erro ao inserir funcionário: %w", err)
@@ -39,29 +63,50 @@ func CreateFuncionario(f *entity.Funcionario) error {
 	return nil
 }
 
-// GetFuncionarioByID busca um funcionário pelo ID com todos os relacionamentos
-func GetFuncionarioByID(id int64) (*entity.Funcionario, error) {
-	query := `SELECT funcionarioID, pessoaID, pis, ctpf, nascimento, admissao, demissao,
-		cargo, salarioInicial, feriasDisponiveis FROM funcionario WHERE funcionarioID = ?`
+// documentosFuncionario devolve PIS e CTPS como gravados (cifrados, se configurado) e o índice cego do PIS
+func documentosFuncionario(f *entity.Funcionario) (pis, ctpf string, pisIndice sql.NullString, err error) {
+	if pis, err = cifrar("funcionario.pis", f.PIS); err != nil {
+		return
+	}
+	if ctpf, err = cifrar("funcionario.ctpf", f.CTPF); err != nil {
+		return
+	}
+	return pis, ctpf, indiceCego("funcionario.pis", f.PIS), nil
+}
 
-	row := DB.QueryRow(query, id)
+// funcionarioColumns lista as colunas contratuais lidas por scanFuncionario
+const funcionarioColumns = `funcionarioID, pessoaID, pis, ctpf, nascimento, admissao, demissao,
+		cargo, salarioInicial, feriasDisponiveis, ativo, inicioAquisitivo,
+		tipoContrato, prazoContrato, fimExperiencia, fimProrrogacao, cargoID`
 
+// rowScanner abstrai *sql.Row e *sql.Rows para leitura de uma linha
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanFuncionario lê uma linha no formato de funcionarioColumns (sem relacionamentos)
+func scanFuncionario(row rowScanner) (*entity.Funcionario, error) {
 	var f entity.Funcionario
 	var nascimentoStr, admissaoStr string
-	var demissao sql.NullTime
+	var demissao, inicioAquisitivo, fimExperiencia, fimProrrogacao sql.NullString
+	var cargoID sql.NullInt64
 
-	err := row.Scan(
+	if err := row.Scan(
 		&f.ID, &f.PessoaID, &f.PIS, &f.CTPF,
 		&nascimentoStr, &admissaoStr, &demissao,
-		&f.Cargo, &f.SalarioInicial, &f.FeriasDisponiveis,
-	)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("erro ao buscar funcionário: %w", err)
+		&f.Cargo, &f.SalarioInicial, &f.FeriasDisponiveis, &f.Ativo, &inicioAquisitivo,
+		&f.TipoContrato, &f.PrazoContrato, &fimExperiencia, &fimProrrogacao, &cargoID,
+	); err != nil {
+		return nil, err
+	}
+	if cargoID.Valid {
+		f.CargoID = &cargoID.Int64
+	}
+	if err := decifrarCampos(map[string]*string{"funcionario.pis": &f.PIS, "funcionario.ctpf": &f.CTPF}); err != nil {
+		return nil, err
 	}
 
+	var err error
 	f.Nascimento, err = dateStringToTime.DateStringToTime(nascimentoStr)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao converter nascimento: %w", err)
@@ -70,14 +115,48 @@ func GetFuncionarioByID(id int64) (*entity.Funcionario, error) {
 	if err != nil {
 		return nil, fmt.Errorf("erro ao converter admissão: %w", err)
 	}
-	f.Demissao = nullTimeToPtr.NullTimeToPtr(demissao)
+	if f.Demissao, err = nullStringToTimePtr.NullStringToTimePtr(demissao); err != nil {
+		return nil, fmt.Errorf("erro ao converter demissão: %w", err)
+	}
+	if f.InicioAquisitivo, err = nullStringToTimePtr.NullStringToTimePtr(inicioAquisitivo); err != nil {
+		return nil, fmt.Errorf("erro ao converter início do período aquisitivo: %w", err)
+	}
+	if f.FimExperiencia, err = nullStringToTimePtr.NullStringToTimePtr(fimExperiencia); err != nil {
+		return nil, fmt.Errorf("erro ao converter fim da experiência: %w", err)
+	}
+	if f.FimProrrogacao, err = nullStringToTimePtr.NullStringToTimePtr(fimProrrogacao); err != nil {
+		return nil, fmt.Errorf("erro ao converter fim da prorrogação: %w", err)
+	}
+	return &f, nil
+}
 
-	err = carregarRelacionamentos(&f)
+// GetFuncionarioByID busca um funcionário pelo ID com todos os relacionamentos
+func GetFuncionarioByID(id int64) (*entity.Funcionario, error) {
+	f, err := GetFuncionarioSemRelacionamentos(id)
+	if err != nil || f == nil {
+		return nil, err
+	}
+
+	err = carregarRelacionamentos(f)
 	if err != nil {
 		return nil, err
 	}
 
-	return &f, nil
+	return f, nil
+}
+
+// GetFuncionarioSemRelacionamentos busca só os dados contratuais de um funcionário
+func GetFuncionarioSemRelacionamentos(id int64) (*entity.Funcionario, error) {
+	query := `SELECT ` + funcionarioColumns + ` FROM funcionario WHERE funcionarioID = ?`
+
+	f, err := scanFuncionario(DB.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar funcionário: %w", err)
+	}
+	return f, nil
 }
 
 // carregarRelacionamentos popula dados relacionados ao funcionário
@@ -145,15 +224,22 @@ func carregarRelacionamentos(f *entity.Funcionario) error {
 
 // UpdateFuncionario atualiza os dados de um funcionário
 func UpdateFuncionario(f *entity.Funcionario) error {
+	pis, ctpf, pisIndice, err := documentosFuncionario(f)
+	if err != nil {
+		return err
+	}
 	query := `UPDATE funcionario SET
-		pis = ?, ctpf = ?, nascimento = ?, admissao = ?, demissao = ?,
-		cargo = ?, salarioInicial = ?, feriasDisponiveis = ?
+		pis = ?, ctpf = ?, pisIndice = ?, nascimento = ?, admissao = ?, demissao = ?,
+		cargo = ?, salarioInicial = ?, feriasDisponiveis = ?,
+		tipoContrato = ?, prazoContrato = ?, fimExperiencia = ?, fimProrrogacao = ?
 		WHERE funcionarioID = ?`
 
-	_, err := DB.Exec(query,
-		f.PIS, f.CTPF, f.Nascimento, f.Admissao,
+	_, err = DB.Exec(query,
+		pis, ctpf, pisIndice, f.Nascimento, f.Admissao,
 		ptrToNullTime.PtrToNullTime(f.Demissao),
-		f.Cargo, f.SalarioInicial, f.FeriasDisponiveis, f.ID,
+		f.Cargo, f.SalarioInicial, f.FeriasDisponiveis,
+		f.TipoContrato, f.PrazoContrato, ptrToNullTime.PtrToNullTime(f.FimExperiencia), ptrToNullTime.PtrToNullTime(f.FimProrrogacao),
+		f.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("erro ao atualizar funcionário: %w", err)
@@ -161,6 +247,15 @@ func UpdateFuncionario(f *entity.Funcionario) error {
 	return nil
 }
 
+// SetCargoFuncionario troca o cargo do catálogo do funcionário e o título gravado em cargo.
+// UpdateFuncionario não altera cargoID: a troca passa pelo histórico de cargos.
+func SetCargoFuncionario(funcionarioID, cargoID int64, titulo string) error {
+	if _, err := DB.Exec(`UPDATE funcionario SET cargoID = ?, cargo = ? WHERE funcionarioID = ?`, cargoID, titulo, funcionarioID); err != nil {
+		return fmt.Errorf("erro ao atualizar cargo do funcionário: %w", err)
+	}
+	return nil
+}
+
 // DeleteFuncionario faz soft delete de um funcionário
 func DeleteFuncionario(id int64) error {
 	query := `UPDATE funcionario SET ativo = FALSE WHERE funcionarioID = ?`
@@ -171,11 +266,9 @@ func DeleteFuncionario(id int64) error {
 	return nil
 }
 
-// listFuncionariosByAtivo é uma função auxiliar para consultas com base no status ativo
-func listFuncionariosByAtivo(ativo bool) ([]*entity.Funcionario, error) {
-	query := `SELECT funcionarioID, pessoaID FROM funcionario WHERE ativo = ?`
-
-	rows, err := DB.Query(query, ativo)
+// listFuncionarios executa uma consulta no formato de funcionarioColumns
+func listFuncionarios(query string, args ...interface{}) ([]*entity.Funcionario, error) {
+	rows, err := DB.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao listar funcionários: %w", err)
 	}
@@ -187,15 +280,23 @@ func listFuncionariosByAtivo(ativo bool) ([]*entity.Funcionario, error) {
 
 	var lista []*entity.Funcionario
 	for rows.Next() {
-		var f entity.Funcionario
-		if err := rows.Scan(&f.ID, &f.PessoaID); err != nil {
+		f, err := scanFuncionario(rows)
+		if err != nil {
 			return nil, fmt.Errorf("erro ao ler funcionário: %w", err)
 		}
-		lista = append(lista, &f)
+		lista = append(lista, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao iterar funcionários: %w", err)
 	}
 	return lista, nil
 }
 
+// listFuncionariosByAtivo é uma função auxiliar para consultas com base no status ativo
+func listFuncionariosByAtivo(ativo bool) ([]*entity.Funcionario, error) {
+	return listFuncionarios(`SELECT `+funcionarioColumns+` FROM funcionario WHERE ativo = ?`, ativo)
+}
+
 // ListFuncionariosAtivos retorna lista de funcionários ativos
 func ListFuncionariosAtivos() ([]*entity.Funcionario, error) {
 	return listFuncionariosByAtivo(true)
@@ -208,27 +309,62 @@ func ListFuncionariosInativos() ([]*entity.Funcionario, error) {
 
 // ListTodosFuncionarios retorna todos os funcionários sem filtro
 func ListTodosFuncionarios() ([]*entity.Funcionario, error) {
-	query := `SELECT funcionarioID, pessoaID FROM funcionario`
+	return listFuncionarios(`SELECT ` + funcionarioColumns + ` FROM funcionario`)
+}
+
+// consultaFuncionarios define filtros e ordenações da listagem de funcionários
+var consultaFuncionarios = especConsulta{
+	chave:  "f.funcionarioID",
+	ordens: map[string]string{"nome": "p.nome", "cargo": "f.cargo", "admissao": "f.admissao", "demissao": "f.demissao"},
+	filtros: map[string]filtroSQL{
+		"nome":          filtroContem("p.nome"),
+		"cpf":           filtroCifrado("pessoa.cpf", "p.cpf", documentos.SoDigitos),
+		"cargo":         filtroContem("f.cargo"),
+		"cargo_id":      filtroID("f.cargoID"),
+		"pessoa_id":     filtroID("f.pessoaID"),
+		"tipo_contrato": filtroMaiusculo("f.tipoContrato"),
+		"admissao_de":   filtroDesde("f.admissao"),
+		"admissao_ate":  filtroAte("f.admissao"),
+		"status":        filtroOpcoes(map[string]string{"ativo": "f.ativo = TRUE", "inativo": "f.ativo = FALSE"}),
+	},
+}
+
+// BuscarFuncionarios lista uma página de funcionários, ativos e inativos, com filtros e ordenação
+func BuscarFuncionarios(q consulta.Consulta) (consulta.Pagina[*entity.Funcionario], error) {
+	return buscar(q, consultaFuncionarios, prefixar(funcionarioColumns, "f"),
+		`FROM funcionario f JOIN pessoa p ON p.pessoaID = f.pessoaID`,
+		listFuncionarios, func(f *entity.Funcionario) int64 { return f.ID })
+}
+
+// ListFuncionariosByPessoaID retorna os vínculos de uma pessoa, do mais antigo ao mais recente
+func ListFuncionariosByPessoaID(pessoaID int64) ([]*entity.Funcionario, error) {
+	return listFuncionarios(`SELECT `+funcionarioColumns+` FROM funcionario WHERE pessoaID = ? ORDER BY admissao, funcionarioID`, pessoaID)
+}
 
-	rows, err := DB.Query(query)
+// ListFuncionariosAtivosByCargo retorna os funcionários ativos de um cargo (comparação sem caixa)
+func ListFuncionariosAtivosByCargo(cargo string) ([]*entity.Funcionario, error) {
+	return listFuncionarios(`SELECT `+funcionarioColumns+` FROM funcionario
+		WHERE ativo = TRUE AND LOWER(TRIM(cargo)) = LOWER(TRIM(?))`, cargo)
+}
+
+// SetInicioAquisitivo redefine a data-base dos períodos aquisitivos do funcionário
+func SetInicioAquisitivo(funcionarioID int64, inicio time.Time) error {
+	query := `UPDATE funcionario SET inicioAquisitivo = ? WHERE funcionarioID = ?`
+	_, err := DB.Exec(query, timeToDateString.TimeToDateString(inicio), funcionarioID)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao listar todos os funcionários: %w", err)
+		return fmt.Errorf("erro ao atualizar início aquisitivo: %w", err)
 	}
-	defer func() {
-		if cerr := rows.Close(); cerr != nil {
-			log.Printf("erro ao fechar rows: %v", cerr)
-		}
-	}()
+	return nil
+}
 
-	var lista []*entity.Funcionario
-	for rows.Next() {
-		var f entity.Funcionario
-		if err := rows.Scan(&f.ID, &f.PessoaID); err != nil {
-			return nil, fmt.Errorf("erro ao ler funcionário: %w", err)
-		}
-		lista = append(lista, &f)
+// SetPrazoContrato altera só o prazo do contrato (ex.: experiência que passou a prazo indeterminado)
+func SetPrazoContrato(funcionarioID int64, prazo string) error {
+	query := `UPDATE funcionario SET prazoContrato = ? WHERE funcionarioID = ?`
+	_, err := DB.Exec(query, prazo, funcionarioID)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar prazo do contrato: %w", err)
 	}
-	return lista, nil
+	return nil
 }
 
 // GetFuncionarioNomeByID retorna o nome (pessoa.nome) dado um funcionarioID.
@@ -265,3 +401,54 @@ func GetFuncionarioNomeByFeriasID(feriasID int64) (string, error) {
 	}
 	return nome, nil
 }
+
+// IdentificacaoFuncionario é o nome e o CPF da pessoa de um vínculo, usados em relatórios e exportações
+type IdentificacaoFuncionario struct {
+	Nome string
+	CPF  string
+}
+
+// IdentificarFuncionarios retorna nome e CPF dos funcionários informados numa única consulta.
+// IDs inexistentes ficam fora do mapa.
+func IdentificarFuncionarios(ids []int64) (map[int64]IdentificacaoFuncionario, error) {
+	out := make(map[int64]IdentificacaoFuncionario, len(ids))
+	args := make([]interface{}, 0, len(ids))
+	vistos := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if !vistos[id] {
+			vistos[id] = true
+			args = append(args, id)
+		}
+	}
+	if len(args) == 0 {
+		return out, nil
+	}
+	in := "(" + strings.TrimSuffix(strings.Repeat("?,", len(args)), ",") + ")"
+
+	rows, err := DB.Query(`SELECT f.funcionarioID, p.nome, p.cpf
+		FROM funcionario f JOIN pessoa p ON p.pessoaID = f.pessoaID
+		WHERE f.funcionarioID IN `+in, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar nomes dos funcionários: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			log.Printf("erro ao fechar rows em IdentificarFuncionarios: %v", cerr)
+		}
+	}()
+	for rows.Next() {
+		var id int64
+		var nome, cpf sql.NullString
+		if err := rows.Scan(&id, &nome, &cpf); err != nil {
+			return nil, fmt.Errorf("erro ao ler nome do funcionário: %w", err)
+		}
+		if cpf.String, err = decifrar("pessoa.cpf", cpf.String); err != nil {
+			return nil, err
+		}
+		out[id] = IdentificacaoFuncionario{Nome: nome.String, CPF: cpf.String}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao iterar nomes dos funcionários: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"AutoGRH/pkg/utils/nullStringToTimePtr"
+	"AutoGRH/pkg/utils/ptrToNullTime"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// CreateImportacao grava o registro da importação com o arquivo enviado e o resultado da validação.
+// O arquivo e as linhas, que trazem nome e CPF, são cifrados conforme CRIPTO_COLUNAS.
+func CreateImportacao(i *entity.Importacao) error {
+	mapeamento, linhas, err := importacaoJSON(i)
+	if err != nil {
+		return err
+	}
+	conteudo, err := cifrar("importacao.conteudo", string(i.Conteudo))
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO importacao (tipo, arquivo, formato, status, mapeamento, totalLinhas, linhasComErro,
+			  linhas, conteudo, usuarioID, criadoEm, concluidoEm)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := DB.Exec(query, i.Tipo, i.Arquivo, i.Formato, i.Status, mapeamento, i.TotalLinhas, i.LinhasComErro,
+		linhas, []byte(conteudo), i.UsuarioID, i.CriadoEm, ptrToNullTime.PtrToNullTime(i.ConcluidoEm))
+	if err != nil {
+		return fmt.Errorf("erro ao inserir importação: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID da importação: %w", err)
+	}
+	i.ID = id
+	return nil
+}
+
+// UpdateImportacao atualiza a situação e o resultado por linha; o arquivo não muda
+func UpdateImportacao(i *entity.Importacao) error {
+	_, linhas, err := importacaoJSON(i)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE importacao SET status = ?, totalLinhas = ?, linhasComErro = ?, linhas = ?, concluidoEm = ?
+			  WHERE importacaoID = ?`
+	_, err = DB.Exec(query, i.Status, i.TotalLinhas, i.LinhasComErro, linhas,
+		ptrToNullTime.PtrToNullTime(i.ConcluidoEm), i.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar importação: %w", err)
+	}
+	return nil
+}
+
+func importacaoJSON(i *entity.Importacao) (string, string, error) {
+	mapeamento, err := json.Marshal(i.Mapeamento)
+	if err != nil {
+		return "", "", fmt.Errorf("erro ao serializar mapeamento da importação: %w", err)
+	}
+	if i.Linhas == nil {
+		i.Linhas = []entity.LinhaImportacao{}
+	}
+	linhas, err := json.Marshal(i.Linhas)
+	if err != nil {
+		return "", "", fmt.Errorf("erro ao serializar linhas da importação: %w", err)
+	}
+	cifradas, err := cifrar("importacao.linhas", string(linhas))
+	if err != nil {
+		return "", "", err
+	}
+	return string(mapeamento), cifradas, nil
+}
+
+// GetImportacaoByID retorna a importação completa, com linhas e arquivo
+func GetImportacaoByID(id int64) (*entity.Importacao, error) {
+	query := `SELECT importacaoID, tipo, arquivo, formato, status, mapeamento, totalLinhas, linhasComErro,
+			  usuarioID, criadoEm, concluidoEm, linhas, conteudo
+			  FROM importacao WHERE importacaoID = ?`
+
+	var i entity.Importacao
+	var linhas, conteudo string
+	r := DB.QueryRow(query, id)
+	err := scanImportacao(&i, func(dest ...interface{}) error {
+		return r.Scan(append(dest, &linhas, &conteudo)...)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := decifrarCampos(map[string]*string{
+		"importacao.linhas": &linhas, "importacao.conteudo": &conteudo,
+	}); err != nil {
+		return nil, err
+	}
+	i.Conteudo = []byte(conteudo)
+	if err := json.Unmarshal([]byte(linhas), &i.Linhas); err != nil {
+		return nil, fmt.Errorf("erro ao ler linhas da importação: %w", err)
+	}
+	return &i, nil
+}
+
+// ListImportacoes lista as importações mais recentes primeiro, sem linhas nem arquivo
+func ListImportacoes() ([]*entity.Importacao, error) {
+	query := `SELECT importacaoID, tipo, arquivo, formato, status, mapeamento, totalLinhas, linhasComErro,
+			  usuarioID, criadoEm, concluidoEm
+			  FROM importacao ORDER BY criadoEm DESC, importacaoID DESC`
+
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar importações: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			log.Printf("erro ao fechar rows em ListImportacoes: %v", cerr)
+		}
+	}()
+
+	var lista []*entity.Importacao
+	for rows.Next() {
+		var i entity.Importacao
+		if err := scanImportacao(&i, rows.Scan); err != nil {
+			return nil, err
+		}
+		lista = append(lista, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao iterar importações: %w", err)
+	}
+	return lista, nil
+}
+
+// scanImportacao lê as colunas comuns de GetImportacaoByID e ListImportacoes
+func scanImportacao(i *entity.Importacao, scan func(dest ...interface{}) error) error {
+	var mapeamento, criadoEm string
+	var concluidoEm sql.NullString
+	if err := scan(&i.ID, &i.Tipo, &i.Arquivo, &i.Formato, &i.Status, &mapeamento, &i.TotalLinhas,
+		&i.LinhasComErro, &i.UsuarioID, &criadoEm, &concluidoEm); err != nil {
+		if err == sql.ErrNoRows {
+			return err
+		}
+		return fmt.Errorf("erro ao ler importação: %w", err)
+	}
+	if err := json.Unmarshal([]byte(mapeamento), &i.Mapeamento); err != nil {
+		return fmt.Errorf("erro ao ler mapeamento da importação: %w", err)
+	}
+	var err error
+	if i.CriadoEm, err = dateStringToTime.DateStringToTime(criadoEm); err != nil {
+		return fmt.Errorf("erro ao converter data da importação: %w", err)
+	}
+	if i.ConcluidoEm, err = nullStringToTimePtr.NullStringToTimePtr(concluidoEm); err != nil {
+		return fmt.Errorf("erro ao converter conclusão da importação: %w", err)
+	}
+	return nil
+}
+
+// FuncionarioImportado reúne os registros de uma linha importada. Pessoa com ID preenchido já
+// existe (readmissão) e não é inserida de novo; Cargo é opcional.
+type FuncionarioImportado struct {
+	Pessoa      *entity.Pessoa
+	Funcionario *entity.Funcionario
+	Salario     *entity.Salario
+	SalarioReal *entity.SalarioReal
+	Cargo       *entity.FuncionarioCargo
+}
+
+// CreateFuncionariosImportados grava todos os itens numa única transação: qualquer erro desfaz tudo
+func CreateFuncionariosImportados(itens []*FuncionarioImportado) (err error) {
+	tx, err := DB.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação da importação: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				log.Printf("erro ao desfazer transação da importação: %v", rerr)
+			}
+		}
+	}()
+
+	for _, it := range itens {
+		if it.Pessoa.ID == 0 {
+			if err = insertPessoa(tx, it.Pessoa); err != nil {
+				return err
+			}
+		}
+		it.Funcionario.PessoaID = it.Pessoa.ID
+		if err = insertFuncionario(tx, it.Funcionario); err != nil {
+			return err
+		}
+		it.Salario.FuncionarioID = it.Funcionario.ID
+		if err = insertSalario(tx, it.Salario); err != nil {
+			return err
+		}
+		it.SalarioReal.FuncionarioID = it.Funcionario.ID
+		if err = insertSalarioReal(tx, it.SalarioReal); err != nil {
+			return err
+		}
+		if it.Cargo != nil {
+			it.Cargo.FuncionarioID = it.Funcionario.ID
+			if err = insertFuncionarioCargo(tx, it.Cargo); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação da importação: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"AutoGRH/pkg/utils/timeToDateString"
+	"database/sql"
+	"fmt"
+)
+
+// CreateJornada insere uma jornada e seus períodos
+func CreateJornada(j *entity.Jornada) error {
+	query := `INSERT INTO jornada (nome, tipo, cicloDias, trabalhaFeriados) VALUES (?, ?, ?, ?)`
+
+	result, err := DB.Exec(query, j.Nome, j.Tipo, j.CicloDias, j.TrabalhaFeriados)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir jornada: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID da jornada: %w", err)
+	}
+	j.ID = id
+	return insertPeriodosJornada(j)
+}
+
+func insertPeriodosJornada(j *entity.Jornada) error {
+	for _, d := range j.Dias {
+		for _, p := range d.Periodos {
+			if _, err := DB.Exec(`INSERT INTO jornada_periodo (jornadaID, dia, inicio, fim) VALUES (?, ?, ?, ?)`,
+				j.ID, d.Dia, p.Inicio, p.Fim); err != nil {
+				return fmt.Errorf("erro ao inserir período da jornada: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// carregarPeriodosJornada preenche os dias da jornada a partir dos períodos gravados
+func carregarPeriodosJornada(j *entity.Jornada) error {
+	rows, err := DB.Query(`SELECT dia, inicio, fim FROM jornada_periodo WHERE jornadaID = ? ORDER BY dia, inicio`, j.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar períodos da jornada: %w", err)
+	}
+	defer rows.Close()
+
+	j.Dias = []entity.DiaJornada{}
+	for rows.Next() {
+		var dia int
+		var p entity.PeriodoJornada
+		if err := rows.Scan(&dia, &p.Inicio, &p.Fim); err != nil {
+			return fmt.Errorf("erro ao ler período da jornada: %w", err)
+		}
+		if n := len(j.Dias); n > 0 && j.Dias[n-1].Dia == dia {
+			j.Dias[n-1].Periodos = append(j.Dias[n-1].Periodos, p)
+		} else {
+			j.Dias = append(j.Dias, entity.DiaJornada{Dia: dia, Periodos: []entity.PeriodoJornada{p}})
+		}
+	}
+	return rows.Err()
+}
+
+// GetJornadaByID busca uma jornada com seus períodos
+func GetJornadaByID(id int64) (*entity.Jornada, error) {
+	var j entity.Jornada
+	err := DB.QueryRow(`SELECT jornadaID, nome, tipo, cicloDias, trabalhaFeriados FROM jornada WHERE jornadaID = ?`, id).
+		Scan(&j.ID, &j.Nome, &j.Tipo, &j.CicloDias, &j.TrabalhaFeriados)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar jornada: %w", err)
+	}
+	if err := carregarPeriodosJornada(&j); err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+// ListJornadas lista todas as jornadas com seus períodos
+func ListJornadas() ([]*entity.Jornada, error) {
+	rows, err := DB.Query(`SELECT jornadaID, nome, tipo, cicloDias, trabalhaFeriados FROM jornada ORDER BY nome`)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar jornadas: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.Jornada
+	for rows.Next() {
+		var j entity.Jornada
+		if err := rows.Scan(&j.ID, &j.Nome, &j.Tipo, &j.CicloDias, &j.TrabalhaFeriados); err != nil {
+			return nil, fmt.Errorf("erro ao ler jornada: %w", err)
+		}
+		lista = append(lista, &j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, j := range lista {
+		if err := carregarPeriodosJornada(j); err != nil {
+			return nil, err
+		}
+	}
+	return lista, nil
+}
+
+// UpdateJornada atualiza a jornada e substitui seus períodos
+func UpdateJornada(j *entity.Jornada) error {
+	query := `UPDATE jornada SET nome = ?, tipo = ?, cicloDias = ?, trabalhaFeriados = ? WHERE jornadaID = ?`
+	if _, err := DB.Exec(query, j.Nome, j.Tipo, j.CicloDias, j.TrabalhaFeriados, j.ID); err != nil {
+		return fmt.Errorf("erro ao atualizar jornada: %w", err)
+	}
+	if _, err := DB.Exec(`DELETE FROM jornada_periodo WHERE jornadaID = ?`, j.ID); err != nil {
+		return fmt.Errorf("erro ao limpar períodos da jornada: %w", err)
+	}
+	return insertPeriodosJornada(j)
+}
+
+// DeleteJornada remove uma jornada e seus períodos
+func DeleteJornada(id int64) error {
+	if _, err := DB.Exec(`DELETE FROM jornada_periodo WHERE jornadaID = ?`, id); err != nil {
+		return fmt.Errorf("erro ao remover períodos da jornada: %w", err)
+	}
+	if _, err := DB.Exec(`DELETE FROM jornada WHERE jornadaID = ?`, id); err != nil {
+		return fmt.Errorf("erro ao deletar jornada: %w", err)
+	}
+	return nil
+}
+
+// JornadaEmUso indica se a jornada está atribuída a algum funcionário
+func JornadaEmUso(id int64) (bool, error) {
+	var n int
+	if err := DB.QueryRow(`SELECT COUNT(*) FROM funcionario_jornada WHERE jornadaID = ?`, id).Scan(&n); err != nil {
+		return false, fmt.Errorf("erro ao verificar uso da jornada: %w", err)
+	}
+	return n > 0, nil
+}
+
+// CreateFuncionarioJornada atribui uma jornada a um funcionário a partir de uma data
+func CreateFuncionarioJornada(a *entity.FuncionarioJornada) error {
+	query := `INSERT INTO funcionario_jornada (funcionarioID, jornadaID, inicio) VALUES (?, ?, ?)`
+
+	result, err := DB.Exec(query, a.FuncionarioID, a.JornadaID, timeToDateString.TimeToDateString(a.Inicio))
+	if err != nil {
+		return fmt.Errorf("erro ao atribuir jornada: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID da atribuição de jornada: %w", err)
+	}
+	a.ID = id
+	return nil
+}
+
+// GetFuncionarioJornadaByID busca uma atribuição de jornada (sem a jornada carregada)
+func GetFuncionarioJornadaByID(id int64) (*entity.FuncionarioJornada, error) {
+	var a entity.FuncionarioJornada
+	var inicioStr string
+	err := DB.QueryRow(`SELECT funcionarioJornadaID, funcionarioID, jornadaID, inicio FROM funcionario_jornada WHERE funcionarioJornadaID = ?`, id).
+		Scan(&a.ID, &a.FuncionarioID, &a.JornadaID, &inicioStr)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar atribuição de jornada: %w", err)
+	}
+	if a.Inicio, err = dateStringToTime.DateStringToTime(inicioStr); err != nil {
+		return nil, fmt.Errorf("erro ao converter início da jornada: %w", err)
+	}
+	return &a, nil
+}
+
+// DeleteFuncionarioJornada remove uma atribuição de jornada
+func DeleteFuncionarioJornada(id int64) error {
+	if _, err := DB.Exec(`DELETE FROM funcionario_jornada WHERE funcionarioJornadaID = ?`, id); err != nil {
+		return fmt.Errorf("erro ao remover atribuição de jornada: %w", err)
+	}
+	return nil
+}
+
+// ListJornadasByFuncionarioID lista as atribuições de jornada do funcionário em ordem de início,
+// com a jornada de cada uma carregada
+func ListJornadasByFuncionarioID(funcionarioID int64) ([]*entity.FuncionarioJornada, error) {
+	rows, err := DB.Query(`SELECT funcionarioJornadaID, funcionarioID, jornadaID, inicio
+		FROM funcionario_jornada WHERE funcionarioID = ? ORDER BY inicio`, funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar jornadas do funcionário: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.FuncionarioJornada
+	for rows.Next() {
+		var a entity.FuncionarioJornada
+		var inicioStr string
+		if err := rows.Scan(&a.ID, &a.FuncionarioID, &a.JornadaID, &inicioStr); err != nil {
+			return nil, fmt.Errorf("erro ao ler atribuição de jornada: %w", err)
+		}
+		if a.Inicio, err = dateStringToTime.DateStringToTime(inicioStr); err != nil {
+			return nil, fmt.Errorf("erro ao converter início da jornada: %w", err)
+		}
+		lista = append(lista, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	jornadas := map[int64]*entity.Jornada{}
+	for _, a := range lista {
+		if j, ok := jornadas[a.JornadaID]; ok {
+			a.Jornada = j
+			continue
+		}
+		j, err := GetJornadaByID(a.JornadaID)
+		if err != nil {
+			return nil, err
+		}
+		jornadas[a.JornadaID] = j
+		a.Jornada = j
+	}
+	return lista, nil
+}
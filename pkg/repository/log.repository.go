@@ -2,6 +2,7 @@ package repository
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 	"AutoGRH/pkg/utils/dateStringToTime"
 	"database/sql"
 	"errors"
@@ -26,22 +27,38 @@ func ListAllLogsView(limit int) ([]*LogView, error) {
 	if limit <= 0 {
 		limit = 200
 	}
+	return listLogsView(`SELECT `+logViewColumns+` `+logViewFrom+` ORDER BY l.data DESC, l.logID DESC LIMIT ?`, limit)
+}
+
+const (
+	logViewColumns = `l.logID, l.usuarioID, l.eventoID, e.tipo, l.action, l.data`
+	logViewFrom    = `FROM log l JOIN evento e ON e.eventoID = l.eventoID`
+)
+
+// consultaLogs define filtros e ordenações da listagem de logs
+var consultaLogs = especConsulta{
+	chave:  "l.logID",
+	padrao: "-data",
+	ordens: map[string]string{"data": "l.data"},
+	filtros: map[string]filtroSQL{
+		"usuario_id": filtroID("l.usuarioID"),
+		"evento_id":  filtroID("l.eventoID"),
+		"evento":     filtroMaiusculo("e.tipo"),
+		"texto":      filtroContem("l.action"),
+		"data_de":    filtroDesde("l.data"),
+		"data_ate":   filtroAte("l.data"),
+	},
+}
+
+// BuscarLogs lista uma página dos logs, por padrão dos mais recentes
+func BuscarLogs(q consulta.Consulta) (consulta.Pagina[*LogView], error) {
+	return buscar(q, consultaLogs, logViewColumns, logViewFrom,
+		listLogsView, func(l *LogView) int64 { return l.ID })
+}
 
-	const q = `
-		SELECT
-			l.logID,
-			l.usuarioID,
-			l.eventoID,
-			e.tipo      AS evento_nome,
-			l.action    AS message,
-			l.data
-		FROM log l
-		JOIN evento e ON e.eventoID = l.eventoID
-		ORDER BY l.data DESC, l.logID DESC
-		LIMIT ?
-	`
-
-	rows, err := DB.Query(q, limit)
+// listLogsView executa uma consulta no formato de logViewColumns
+func listLogsView(query string, args ...interface{}) ([]*LogView, error) {
+	rows, err := DB.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("ListAllLogsView: %w", err)
 	}
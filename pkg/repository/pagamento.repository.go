@@ -9,8 +9,8 @@ import (
 // CreatePagamento insere um novo pagamento no banco
 func CreatePagamento(p *entity.Pagamento) error {
 	query := `INSERT INTO pagamento 
-		(funcionarioID, folhaID, salarioBase, adicional, descontoINSS, salarioFamilia, descontoVales, valorFinal, pago)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		(funcionarioID, folhaID, salarioBase, adicional, descontoINSS, salarioFamilia, descontoVales, descontoDSR, horasExtras, fgts, valorFinal, pago)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	result, err := DB.Exec(query,
 		p.FuncionarioID,
@@ -20,6 +20,9 @@ func CreatePagamento(p *entity.Pagamento) error {
 		p.DescontoINSS,
 		p.SalarioFamilia,
 		p.DescontoVales,
+		p.DescontoDSR,
+		p.HorasExtras,
+		p.FGTS,
 		p.ValorFinal,
 		p.Pago,
 	)
@@ -38,7 +41,7 @@ func CreatePagamento(p *entity.Pagamento) error {
 // UpdatePagamento atualiza os dados de um pagamento existente
 func UpdatePagamento(p *entity.Pagamento) error {
 	query := `UPDATE pagamento 
-		SET salarioBase = ?, adicional = ?, descontoINSS = ?, salarioFamilia = ?, descontoVales = ?, valorFinal = ?, pago = ?
+		SET salarioBase = ?, adicional = ?, descontoINSS = ?, salarioFamilia = ?, descontoVales = ?, descontoDSR = ?, horasExtras = ?, fgts = ?, valorFinal = ?, pago = ?
 		WHERE pagamentoID = ?`
 
 	_, err := DB.Exec(query,
@@ -47,6 +50,9 @@ func UpdatePagamento(p *entity.Pagamento) error {
 		p.DescontoINSS,
 		p.SalarioFamilia,
 		p.DescontoVales,
+		p.DescontoDSR,
+		p.HorasExtras,
+		p.FGTS,
 		p.ValorFinal,
 		p.Pago,
 		p.ID,
@@ -59,7 +65,7 @@ func UpdatePagamento(p *entity.Pagamento) error {
 
 // GetPagamentoByID retorna um pagamento pelo ID
 func GetPagamentoByID(id int64) (*entity.Pagamento, error) {
-	query := `SELECT pagamentoID, funcionarioID, folhaID, salarioBase, adicional, descontoINSS, salarioFamilia, descontoVales, valorFinal, pago
+	query := `SELECT pagamentoID, funcionarioID, folhaID, salarioBase, adicional, descontoINSS, salarioFamilia, descontoVales, descontoDSR, horasExtras, fgts, valorFinal, pago
 			  FROM pagamento WHERE pagamentoID = ?`
 
 	var p entity.Pagamento
@@ -72,6 +78,9 @@ func GetPagamentoByID(id int64) (*entity.Pagamento, error) {
 		&p.DescontoINSS,
 		&p.SalarioFamilia,
 		&p.DescontoVales,
+		&p.DescontoDSR,
+		&p.HorasExtras,
+		&p.FGTS,
 		&p.ValorFinal,
 		&p.Pago,
 	)
@@ -86,7 +95,7 @@ func GetPagamentoByID(id int64) (*entity.Pagamento, error) {
 
 // GetPagamentosByFolhaID retorna todos os pagamentos de uma folha
 func GetPagamentosByFolhaID(folhaID int64) ([]entity.Pagamento, error) {
-	query := `SELECT pagamentoID, funcionarioID, folhaID, salarioBase, adicional, descontoINSS, salarioFamilia, descontoVales, valorFinal, pago
+	query := `SELECT pagamentoID, funcionarioID, folhaID, salarioBase, adicional, descontoINSS, salarioFamilia, descontoVales, descontoDSR, horasExtras, fgts, valorFinal, pago
 			  FROM pagamento WHERE folhaID = ?`
 
 	rows, err := DB.Query(query, folhaID)
@@ -107,6 +116,9 @@ func GetPagamentosByFolhaID(folhaID int64) ([]entity.Pagamento, error) {
 			&p.DescontoINSS,
 			&p.SalarioFamilia,
 			&p.DescontoVales,
+			&p.DescontoDSR,
+			&p.HorasExtras,
+			&p.FGTS,
 			&p.ValorFinal,
 			&p.Pago,
 		); err != nil {
@@ -119,12 +131,20 @@ func GetPagamentosByFolhaID(folhaID int64) ([]entity.Pagamento, error) {
 		return nil, fmt.Errorf("erro ao iterar pagamentos: %w", err)
 	}
 
+	rateios, err := ListRateiosByFolhaID(folhaID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pagamentos {
+		pagamentos[i].Rateio = rateios[pagamentos[i].ID]
+	}
+
 	return pagamentos, nil
 }
 
 // ListPagamentosByFuncionarioID lista os pagamentos de um funcionário
 func ListPagamentosByFuncionarioID(funcionarioID int64) ([]entity.Pagamento, error) {
-	query := `SELECT pagamentoID, funcionarioID, folhaID, salarioBase, adicional, descontoINSS, salarioFamilia, descontoVales, valorFinal, pago
+	query := `SELECT pagamentoID, funcionarioID, folhaID, salarioBase, adicional, descontoINSS, salarioFamilia, descontoVales, descontoDSR, horasExtras, fgts, valorFinal, pago
 			  FROM pagamento WHERE funcionarioID = ?`
 
 	rows, err := DB.Query(query, funcionarioID)
@@ -145,6 +165,9 @@ func ListPagamentosByFuncionarioID(funcionarioID int64) ([]entity.Pagamento, err
 			&p.DescontoINSS,
 			&p.SalarioFamilia,
 			&p.DescontoVales,
+			&p.DescontoDSR,
+			&p.HorasExtras,
+			&p.FGTS,
 			&p.ValorFinal,
 			&p.Pago,
 		); err != nil {
@@ -160,6 +183,16 @@ func ListPagamentosByFuncionarioID(funcionarioID int64) ([]entity.Pagamento, err
 	return pagamentos, nil
 }
 
+// DeletePagamento remove um pagamento
+func DeletePagamento(id int64) error {
+	query := `DELETE FROM pagamento WHERE pagamentoID = ?`
+	_, err := DB.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("erro ao deletar pagamento: %w", err)
+	}
+	return nil
+}
+
 // DeletePagamentosByFolhaID remove todos os pagamentos de uma folha
 func DeletePagamentosByFolhaID(folhaID int64) error {
 	query := `DELETE FROM pagamento WHERE folhaID = ?`
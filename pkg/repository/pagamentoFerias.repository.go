@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"AutoGRH/pkg/utils/nullStringToTimePtr"
+	"AutoGRH/pkg/utils/ptrToNullTime"
+	"AutoGRH/pkg/utils/timeToDateString"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const pagamentoFeriasColumns = `pagamentoFeriasID, descansoID, funcionarioID, dias, dataPrevista, dataPagamento,
+		valorFerias, valorTerco, valorBruto, descontoINSS, descontoIRRF, valorLiquido, pago`
+
+// CreatePagamentoFerias insere o pagamento de férias de um descanso
+func CreatePagamentoFerias(p *entity.PagamentoFerias) error {
+	return insertPagamentoFerias(DB, p)
+}
+
+func insertPagamentoFerias(db executor, p *entity.PagamentoFerias) error {
+	query := `INSERT INTO pagamento_ferias (descansoID, funcionarioID, dias, dataPrevista, dataPagamento,
+		valorFerias, valorTerco, valorBruto, descontoINSS, descontoIRRF, valorLiquido, pago)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := db.Exec(query,
+		p.DescansoID, p.FuncionarioID, p.Dias,
+		timeToDateString.TimeToDateString(p.DataPrevista),
+		ptrToNullTime.PtrToNullTime(p.DataPagamento),
+		p.ValorFerias, p.ValorTerco, p.ValorBruto, p.DescontoINSS, p.DescontoIRRF, p.ValorLiquido, p.Pago,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir pagamento de férias: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID do pagamento de férias: %w", err)
+	}
+	p.ID = id
+	return nil
+}
+
+func scanPagamentoFerias(row rowScanner) (*entity.PagamentoFerias, error) {
+	var p entity.PagamentoFerias
+	var dataPrevistaStr string
+	var dataPagamento sql.NullString
+
+	if err := row.Scan(
+		&p.ID, &p.DescansoID, &p.FuncionarioID, &p.Dias, &dataPrevistaStr, &dataPagamento,
+		&p.ValorFerias, &p.ValorTerco, &p.ValorBruto, &p.DescontoINSS, &p.DescontoIRRF, &p.ValorLiquido, &p.Pago,
+	); err != nil {
+		return nil, err
+	}
+	t, err := dateStringToTime.DateStringToTime(dataPrevistaStr)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao converter data prevista: %w", err)
+	}
+	p.DataPrevista = t
+	if p.DataPagamento, err = nullStringToTimePtr.NullStringToTimePtr(dataPagamento); err != nil {
+		return nil, fmt.Errorf("erro ao converter data de pagamento: %w", err)
+	}
+	return &p, nil
+}
+
+func getPagamentoFerias(query string, args ...interface{}) (*entity.PagamentoFerias, error) {
+	p, err := scanPagamentoFerias(DB.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar pagamento de férias: %w", err)
+	}
+	return p, nil
+}
+
+func listPagamentosFerias(query string, args ...interface{}) ([]*entity.PagamentoFerias, error) {
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar pagamentos de férias: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.PagamentoFerias
+	for rows.Next() {
+		p, err := scanPagamentoFerias(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler pagamento de férias: %w", err)
+		}
+		lista = append(lista, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao iterar pagamentos de férias: %w", err)
+	}
+	return lista, nil
+}
+
+// GetPagamentoFeriasByID busca um pagamento de férias pelo ID
+func GetPagamentoFeriasByID(id int64) (*entity.PagamentoFerias, error) {
+	return getPagamentoFerias(`SELECT `+pagamentoFeriasColumns+` FROM pagamento_ferias WHERE pagamentoFeriasID = ?`, id)
+}
+
+// GetPagamentoFeriasByDescansoID busca o pagamento de férias de um descanso
+func GetPagamentoFeriasByDescansoID(descansoID int64) (*entity.PagamentoFerias, error) {
+	return getPagamentoFerias(`SELECT `+pagamentoFeriasColumns+` FROM pagamento_ferias WHERE descansoID = ?`, descansoID)
+}
+
+// UpdatePagamentoFerias atualiza valores, datas e status de um pagamento de férias
+func UpdatePagamentoFerias(p *entity.PagamentoFerias) error {
+	return updatePagamentoFerias(DB, p)
+}
+
+func updatePagamentoFerias(db executor, p *entity.PagamentoFerias) error {
+	query := `UPDATE pagamento_ferias SET dias = ?, dataPrevista = ?, dataPagamento = ?,
+		valorFerias = ?, valorTerco = ?, valorBruto = ?, descontoINSS = ?, descontoIRRF = ?, valorLiquido = ?, pago = ?
+		WHERE pagamentoFeriasID = ?`
+
+	_, err := db.Exec(query,
+		p.Dias,
+		timeToDateString.TimeToDateString(p.DataPrevista),
+		ptrToNullTime.PtrToNullTime(p.DataPagamento),
+		p.ValorFerias, p.ValorTerco, p.ValorBruto, p.DescontoINSS, p.DescontoIRRF, p.ValorLiquido, p.Pago,
+		p.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar pagamento de férias: %w", err)
+	}
+	return nil
+}
+
+// MarcarPagamentoFeriasComoPago registra a data em que o pagamento foi efetuado
+func MarcarPagamentoFeriasComoPago(id int64, data time.Time) error {
+	_, err := DB.Exec(`UPDATE pagamento_ferias SET pago = TRUE, dataPagamento = ? WHERE pagamentoFeriasID = ?`,
+		timeToDateString.TimeToDateString(data), id)
+	if err != nil {
+		return fmt.Errorf("erro ao marcar pagamento de férias como pago: %w", err)
+	}
+	return nil
+}
+
+// ListPagamentosFerias lista todos os pagamentos de férias, do mais recente para o mais antigo
+func ListPagamentosFerias() ([]*entity.PagamentoFerias, error) {
+	return listPagamentosFerias(`SELECT ` + pagamentoFeriasColumns + ` FROM pagamento_ferias ORDER BY dataPrevista DESC`)
+}
+
+// ListPagamentosFeriasByFuncionarioID lista os pagamentos de férias de um funcionário
+func ListPagamentosFeriasByFuncionarioID(funcionarioID int64) ([]*entity.PagamentoFerias, error) {
+	return listPagamentosFerias(`SELECT `+pagamentoFeriasColumns+` FROM pagamento_ferias
+		WHERE funcionarioID = ? ORDER BY dataPrevista DESC`, funcionarioID)
+}
+
+// ListPagamentosFeriasPendentesAte lista pagamentos não efetuados com data prevista até a data informada
+func ListPagamentosFeriasPendentesAte(data time.Time) ([]*entity.PagamentoFerias, error) {
+	return listPagamentosFerias(`SELECT `+pagamentoFeriasColumns+` FROM pagamento_ferias
+		WHERE pago = FALSE AND dataPrevista <= ? ORDER BY dataPrevista`, timeToDateString.TimeToDateString(data))
+}
+
+// ListDescansosAprovadosSemPagamentoFerias retorna descansos aprovados, não pagos, que ainda não têm pagamento de férias
+func ListDescansosAprovadosSemPagamentoFerias() ([]*entity.Descanso, error) {
+	query := `SELECT d.descansoID, d.feriasID, d.inicio, d.fim, d.valor, d.pago, d.aprovado
+		FROM descanso d
+		LEFT JOIN pagamento_ferias pf ON pf.descansoID = d.descansoID
+		WHERE d.aprovado = TRUE AND d.pago = FALSE AND pf.pagamentoFeriasID IS NULL`
+
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar descansos sem pagamento de férias: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.Descanso
+	for rows.Next() {
+		var d entity.Descanso
+		var inicioStr, fimStr string
+		if err := rows.Scan(&d.ID, &d.FeriasID, &inicioStr, &fimStr, &d.Valor, &d.Pago, &d.Aprovado); err != nil {
+			return nil, fmt.Errorf("erro ao ler descanso: %w", err)
+		}
+		if d.Inicio, err = dateStringToTime.DateStringToTime(inicioStr); err != nil {
+			return nil, fmt.Errorf("erro ao converter início do descanso: %w", err)
+		}
+		if d.Fim, err = dateStringToTime.DateStringToTime(fimStr); err != nil {
+			return nil, fmt.Errorf("erro ao converter fim do descanso: %w", err)
+		}
+		lista = append(lista, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao iterar descansos: %w", err)
+	}
+	return lista, nil
+}
@@ -2,16 +2,60 @@ package repository
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
+	"AutoGRH/pkg/utils/documentos"
+	"AutoGRH/pkg/utils/nullStringToTimePtr"
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
 )
 
+const pessoaColumns = `pessoaID, nome, cpf, rg, nomeSocial, sexo, identidadeGenero, estadoCivil, grauInstrucao, racaCor,
+	anonimizadoEm`
+
+func scanPessoa(row rowScanner) (*entity.Pessoa, error) {
+	var p entity.Pessoa
+	var anonimizadoEm sql.NullString
+	if err := row.Scan(&p.ID, &p.Nome, &p.CPF, &p.RG, &p.NomeSocial, &p.Sexo, &p.IdentidadeGenero,
+		&p.EstadoCivil, &p.GrauInstrucao, &p.RacaCor, &anonimizadoEm); err != nil {
+		return nil, err
+	}
+	var err error
+	if p.AnonimizadoEm, err = nullStringToTimePtr.NullStringToTimePtr(anonimizadoEm); err != nil {
+		return nil, fmt.Errorf("erro ao converter data de anonimização: %w", err)
+	}
+	if err := decifrarCampos(map[string]*string{"pessoa.cpf": &p.CPF, "pessoa.rg": &p.RG}); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// documentosPessoa devolve CPF e RG como gravados (cifrados, se configurado) e seus índices cegos
+func documentosPessoa(p *entity.Pessoa) (cpf, rg string, cpfIndice, rgIndice sql.NullString, err error) {
+	if cpf, err = cifrar("pessoa.cpf", p.CPF); err != nil {
+		return
+	}
+	if rg, err = cifrar("pessoa.rg", p.RG); err != nil {
+		return
+	}
+	return cpf, rg, indiceCego("pessoa.cpf", p.CPF), indiceCego("pessoa.rg", p.RG), nil
+}
+
 // CreatePessoa insere uma nova pessoa no banco de dados
 func CreatePessoa(p *entity.Pessoa) error {
-	query := `INSERT INTO pessoa (nome, cpf, rg, endereco, contato, contatoEmergencia)
-			  VALUES (?, ?, ?, ?, ?, ?)`
-	result, err := DB.Exec(query, p.Nome, p.CPF, p.RG, p.Endereco, p.Contato, p.ContatoEmergencia)
+	return insertPessoa(DB, p)
+}
+
+func insertPessoa(db executor, p *entity.Pessoa) error {
+	cpf, rg, cpfIndice, rgIndice, err := documentosPessoa(p)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO pessoa (nome, cpf, rg, cpfIndice, rgIndice, nomeSocial, sexo, identidadeGenero, estadoCivil, grauInstrucao, racaCor)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := db.Exec(query, p.Nome, cpf, rg, cpfIndice, rgIndice, p.NomeSocial, p.Sexo, p.IdentidadeGenero,
+		p.EstadoCivil, p.GrauInstrucao, p.RacaCor)
 	if err != nil {
 		return fmt.Errorf("erro ao inserir pessoa: %w", err)
 	}
@@ -23,43 +67,42 @@ func CreatePessoa(p *entity.Pessoa) error {
 	return nil
 }
 
-// GetPessoaByID retorna uma pessoa pelo ID
+// GetPessoaByID retorna uma pessoa pelo ID, com endereço e contatos
 func GetPessoaByID(id int64) (*entity.Pessoa, error) {
-	query := `SELECT pessoaID, nome, cpf, rg, endereco, contato, contatoEmergencia
-			  FROM pessoa WHERE pessoaID = ?`
-	row := DB.QueryRow(query, id)
-
-	var p entity.Pessoa
-	if err := row.Scan(&p.ID, &p.Nome, &p.CPF, &p.RG, &p.Endereco, &p.Contato, &p.ContatoEmergencia); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("erro ao buscar pessoa: %w", err)
-	}
-	return &p, nil
+	return getPessoa(`SELECT `+pessoaColumns+` FROM pessoa WHERE pessoaID = ?`, id)
 }
 
-// GetPessoaByCPF retorna uma pessoa pelo CPF
+// GetPessoaByCPF retorna uma pessoa pelo CPF, com endereço e contatos
 func GetPessoaByCPF(cpf string) (*entity.Pessoa, error) {
-	query := `SELECT pessoaID, nome, cpf, rg, endereco, contato, contatoEmergencia
-			  FROM pessoa WHERE cpf = ?`
-	row := DB.QueryRow(query, cpf)
+	cond, args := condicaoIgual("pessoa.cpf", "cpf", cpf)
+	return getPessoa(`SELECT `+pessoaColumns+` FROM pessoa WHERE `+cond, args...)
+}
 
-	var p entity.Pessoa
-	if err := row.Scan(&p.ID, &p.Nome, &p.CPF, &p.RG, &p.Endereco, &p.Contato, &p.ContatoEmergencia); err != nil {
+func getPessoa(query string, args ...interface{}) (*entity.Pessoa, error) {
+	p, err := scanPessoa(DB.QueryRow(query, args...))
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("erro ao buscar pessoa por CPF: %w", err)
+		return nil, fmt.Errorf("erro ao buscar pessoa: %w", err)
 	}
-	return &p, nil
+	if err := anexarDadosPessoas([]*entity.Pessoa{p}); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
 
-// UpdatePessoa atualiza os dados de uma pessoa
+// UpdatePessoa atualiza os dados de uma pessoa; endereço e contatos têm gravação própria
 func UpdatePessoa(p *entity.Pessoa) error {
-	query := `UPDATE pessoa SET nome = ?, cpf = ?, rg = ?, endereco = ?, contato = ?, contatoEmergencia = ?
+	cpf, rg, cpfIndice, rgIndice, err := documentosPessoa(p)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE pessoa SET nome = ?, cpf = ?, rg = ?, cpfIndice = ?, rgIndice = ?, nomeSocial = ?, sexo = ?,
+			  identidadeGenero = ?, estadoCivil = ?, grauInstrucao = ?, racaCor = ?
 			  WHERE pessoaID = ?`
-	_, err := DB.Exec(query, p.Nome, p.CPF, p.RG, p.Endereco, p.Contato, p.ContatoEmergencia, p.ID)
+	_, err = DB.Exec(query, p.Nome, cpf, rg, cpfIndice, rgIndice, p.NomeSocial, p.Sexo, p.IdentidadeGenero,
+		p.EstadoCivil, p.GrauInstrucao, p.RacaCor, p.ID)
 	if err != nil {
 		return fmt.Errorf("erro ao atualizar pessoa: %w", err)
 	}
@@ -78,9 +121,9 @@ func DeletePessoa(id int64) error {
 
 // ExistsPessoaByCPF verifica se já existe uma pessoa com o CPF informado
 func ExistsPessoaByCPF(cpf string) (bool, error) {
-	query := `SELECT COUNT(*) FROM pessoa WHERE cpf = ?`
+	cond, args := condicaoIgual("pessoa.cpf", "cpf", cpf)
 	var count int
-	err := DB.QueryRow(query, cpf).Scan(&count)
+	err := DB.QueryRow(`SELECT COUNT(*) FROM pessoa WHERE `+cond, args...).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("erro ao verificar existência por CPF: %w", err)
 	}
@@ -89,9 +132,9 @@ func ExistsPessoaByCPF(cpf string) (bool, error) {
 
 // ExistsPessoaByRG verifica se já existe uma pessoa com o RG informado
 func ExistsPessoaByRG(rg string) (bool, error) {
-	query := `SELECT COUNT(*) FROM pessoa WHERE rg = ?`
+	cond, args := condicaoIgual("pessoa.rg", "rg", rg)
 	var count int
-	err := DB.QueryRow(query, rg).Scan(&count)
+	err := DB.QueryRow(`SELECT COUNT(*) FROM pessoa WHERE `+cond, args...).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("erro ao verificar existência por RG: %w", err)
 	}
@@ -100,54 +143,97 @@ func ExistsPessoaByRG(rg string) (bool, error) {
 
 // SearchPessoaByNome busca pessoas com nome semelhante
 func SearchPessoaByNome(nome string) ([]*entity.Pessoa, error) {
-	query := `SELECT pessoaID, nome, cpf, rg, endereco, contato, contatoEmergencia
-			  FROM pessoa WHERE nome LIKE ?`
-
 	nomeLike := fmt.Sprintf("%%%s%%", nome)
-	rows, err := DB.Query(query, nomeLike)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao buscar pessoas por nome: %w", err)
-	}
-	defer func() {
-		if cerr := rows.Close(); cerr != nil {
-			log.Printf("erro ao fechar rows em SearchPessoaByNome: %v", cerr)
-		}
-	}()
-
-	var lista []*entity.Pessoa
-	for rows.Next() {
-		var p entity.Pessoa
-		err := rows.Scan(&p.ID, &p.Nome, &p.CPF, &p.RG, &p.Endereco, &p.Contato, &p.ContatoEmergencia)
-		if err != nil {
-			return nil, fmt.Errorf("erro ao ler pessoa: %w", err)
-		}
-		lista = append(lista, &p)
-	}
-	return lista, nil
+	return listPessoas(`SELECT `+pessoaColumns+` FROM pessoa WHERE nome LIKE ?`, nomeLike)
 }
 
 // ListPessoas retorna todas as pessoas cadastradas
 func ListPessoas() ([]*entity.Pessoa, error) {
-	query := `SELECT pessoaID, nome, cpf, rg, endereco, contato, contatoEmergencia FROM pessoa`
+	return listPessoas(`SELECT ` + pessoaColumns + ` FROM pessoa`)
+}
+
+// consultaPessoas define filtros e ordenações da listagem de pessoas
+var consultaPessoas = especConsulta{
+	chave:  "pessoaID",
+	ordens: map[string]string{"nome": "nome", "cpf": "cpf"},
+	filtros: map[string]filtroSQL{
+		"nome": filtroContem("nome"),
+		"cpf":  filtroCifrado("pessoa.cpf", "cpf", documentos.SoDigitos),
+		"rg":   filtroCifrado("pessoa.rg", "rg", nil),
+	},
+}
+
+// BuscarPessoas lista uma página de pessoas, com endereço e contatos. Com o CPF cifrado não há
+// ordenação por ele: a ordem seria a do texto cifrado.
+func BuscarPessoas(q consulta.Consulta) (consulta.Pagina[*entity.Pessoa], error) {
+	if strings.TrimPrefix(q.Ordem, "-") == "cpf" && cifrador != nil && colunasCifradas["pessoa.cpf"] {
+		return consulta.Pagina[*entity.Pessoa]{}, consulta.Invalida("ordenação por cpf indisponível com o CPF cifrado")
+	}
+	return buscar(q, consultaPessoas, pessoaColumns, `FROM pessoa`,
+		listPessoas, func(p *entity.Pessoa) int64 { return p.ID })
+}
 
-	rows, err := DB.Query(query)
+func listPessoas(query string, args ...interface{}) ([]*entity.Pessoa, error) {
+	rows, err := DB.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao listar pessoas: %w", err)
 	}
 	defer func() {
 		if cerr := rows.Close(); cerr != nil {
-			log.Printf("erro ao fechar rows em ListPessoas: %v", cerr)
+			log.Printf("erro ao fechar rows em listPessoas: %v", cerr)
 		}
 	}()
 
 	var lista []*entity.Pessoa
 	for rows.Next() {
-		var p entity.Pessoa
-		err := rows.Scan(&p.ID, &p.Nome, &p.CPF, &p.RG, &p.Endereco, &p.Contato, &p.ContatoEmergencia)
+		p, err := scanPessoa(rows)
 		if err != nil {
 			return nil, fmt.Errorf("erro ao ler pessoa: %w", err)
 		}
-		lista = append(lista, &p)
+		lista = append(lista, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := anexarDadosPessoas(lista); err != nil {
+		return nil, err
 	}
 	return lista, nil
 }
+
+// anexarDadosPessoas carrega endereço, contatos e contatos de emergência das pessoas em três consultas
+func anexarDadosPessoas(lista []*entity.Pessoa) error {
+	if len(lista) == 0 {
+		return nil
+	}
+	porID := make(map[int64]*entity.Pessoa, len(lista))
+	ids := make([]interface{}, 0, len(lista))
+	for _, p := range lista {
+		porID[p.ID] = p
+		ids = append(ids, p.ID)
+	}
+	in := "(" + strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",") + ")"
+
+	enderecos, err := listEnderecos(`WHERE pessoaID IN `+in, ids...)
+	if err != nil {
+		return err
+	}
+	for _, e := range enderecos {
+		porID[e.PessoaID].Endereco = e
+	}
+	contatos, err := listContatos(`WHERE pessoaID IN `+in, ids...)
+	if err != nil {
+		return err
+	}
+	for _, c := range contatos {
+		porID[c.PessoaID].Contatos = append(porID[c.PessoaID].Contatos, c)
+	}
+	emergencia, err := listContatosEmergencia(`WHERE pessoaID IN `+in, ids...)
+	if err != nil {
+		return err
+	}
+	for _, c := range emergencia {
+		porID[c.PessoaID].ContatosEmergencia = append(porID[c.PessoaID].ContatosEmergencia, c)
+	}
+	return nil
+}
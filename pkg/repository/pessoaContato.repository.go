@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/cadastro"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Endereço
+
+func listEnderecos(where string, args ...interface{}) ([]*entity.Endereco, error) {
+	rows, err := DB.Query(`SELECT enderecoID, pessoaID, cep, logradouro, numero, complemento, bairro, cidade, uf, codigoIBGE
+		FROM pessoa_endereco `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar endereços: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.Endereco
+	for rows.Next() {
+		var e entity.Endereco
+		if err := rows.Scan(&e.ID, &e.PessoaID, &e.CEP, &e.Logradouro, &e.Numero, &e.Complemento,
+			&e.Bairro, &e.Cidade, &e.UF, &e.CodigoIBGE); err != nil {
+			return nil, fmt.Errorf("erro ao ler endereço: %w", err)
+		}
+		if err := decifrarCampos(map[string]*string{"pessoa_endereco.cep": &e.CEP, "pessoa_endereco.logradouro": &e.Logradouro,
+			"pessoa_endereco.numero": &e.Numero, "pessoa_endereco.complemento": &e.Complemento}); err != nil {
+			return nil, err
+		}
+		lista = append(lista, &e)
+	}
+	return lista, rows.Err()
+}
+
+// GetEnderecoByPessoaID retorna o endereço da pessoa, ou nil
+func GetEnderecoByPessoaID(pessoaID int64) (*entity.Endereco, error) {
+	lista, err := listEnderecos(`WHERE pessoaID = ?`, pessoaID)
+	if err != nil || len(lista) == 0 {
+		return nil, err
+	}
+	return lista[0], nil
+}
+
+// SaveEndereco grava o endereço da pessoa, substituindo o anterior
+func SaveEndereco(e *entity.Endereco) error {
+	gravado := map[string]string{}
+	for coluna, valor := range map[string]string{"cep": e.CEP, "logradouro": e.Logradouro, "numero": e.Numero, "complemento": e.Complemento} {
+		v, err := cifrar("pessoa_endereco."+coluna, valor)
+		if err != nil {
+			return err
+		}
+		gravado[coluna] = v
+	}
+	_, err := DB.Exec(`INSERT INTO pessoa_endereco (pessoaID, cep, logradouro, numero, complemento, bairro, cidade, uf, codigoIBGE)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE cep = VALUES(cep), logradouro = VALUES(logradouro), numero = VALUES(numero),
+			complemento = VALUES(complemento), bairro = VALUES(bairro), cidade = VALUES(cidade), uf = VALUES(uf),
+			codigoIBGE = VALUES(codigoIBGE)`,
+		e.PessoaID, gravado["cep"], gravado["logradouro"], gravado["numero"], gravado["complemento"], e.Bairro, e.Cidade, e.UF, e.CodigoIBGE)
+	if err != nil {
+		return fmt.Errorf("erro ao gravar endereço: %w", err)
+	}
+	if err := DB.QueryRow(`SELECT enderecoID FROM pessoa_endereco WHERE pessoaID = ?`, e.PessoaID).Scan(&e.ID); err != nil {
+		return fmt.Errorf("erro ao obter ID do endereço: %w", err)
+	}
+	return nil
+}
+
+// DeleteEndereco remove o endereço da pessoa
+func DeleteEndereco(pessoaID int64) error {
+	if _, err := DB.Exec(`DELETE FROM pessoa_endereco WHERE pessoaID = ?`, pessoaID); err != nil {
+		return fmt.Errorf("erro ao remover endereço: %w", err)
+	}
+	return nil
+}
+
+// Contatos
+
+func listContatos(where string, args ...interface{}) ([]entity.Contato, error) {
+	rows, err := DB.Query(`SELECT contatoID, pessoaID, tipo, valor, principal FROM pessoa_contato `+where+
+		` ORDER BY pessoaID, principal DESC, contatoID`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar contatos: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []entity.Contato
+	for rows.Next() {
+		var c entity.Contato
+		if err := rows.Scan(&c.ID, &c.PessoaID, &c.Tipo, &c.Valor, &c.Principal); err != nil {
+			return nil, fmt.Errorf("erro ao ler contato: %w", err)
+		}
+		lista = append(lista, c)
+	}
+	return lista, rows.Err()
+}
+
+// ListContatosByPessoaID lista os contatos da pessoa, o principal de cada tipo primeiro
+func ListContatosByPessoaID(pessoaID int64) ([]entity.Contato, error) {
+	return listContatos(`WHERE pessoaID = ?`, pessoaID)
+}
+
+// SaveContatos substitui os contatos da pessoa
+func SaveContatos(pessoaID int64, contatos []entity.Contato) error {
+	if _, err := DB.Exec(`DELETE FROM pessoa_contato WHERE pessoaID = ?`, pessoaID); err != nil {
+		return fmt.Errorf("erro ao remover contatos: %w", err)
+	}
+	for i := range contatos {
+		c := &contatos[i]
+		c.PessoaID = pessoaID
+		result, err := DB.Exec(`INSERT INTO pessoa_contato (pessoaID, tipo, valor, principal) VALUES (?, ?, ?, ?)`,
+			pessoaID, c.Tipo, c.Valor, c.Principal)
+		if err != nil {
+			return fmt.Errorf("erro ao inserir contato: %w", err)
+		}
+		if c.ID, err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("erro ao obter ID do contato: %w", err)
+		}
+	}
+	return nil
+}
+
+// Contatos de emergência
+
+func listContatosEmergencia(where string, args ...interface{}) ([]entity.ContatoEmergencia, error) {
+	rows, err := DB.Query(`SELECT contatoEmergenciaID, pessoaID, nome, parentesco, telefone
+		FROM pessoa_contato_emergencia `+where+` ORDER BY pessoaID, contatoEmergenciaID`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar contatos de emergência: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []entity.ContatoEmergencia
+	for rows.Next() {
+		var c entity.ContatoEmergencia
+		if err := rows.Scan(&c.ID, &c.PessoaID, &c.Nome, &c.Parentesco, &c.Telefone); err != nil {
+			return nil, fmt.Errorf("erro ao ler contato de emergência: %w", err)
+		}
+		lista = append(lista, c)
+	}
+	return lista, rows.Err()
+}
+
+// ListContatosEmergenciaByPessoaID lista os contatos de emergência da pessoa na ordem de cadastro
+func ListContatosEmergenciaByPessoaID(pessoaID int64) ([]entity.ContatoEmergencia, error) {
+	return listContatosEmergencia(`WHERE pessoaID = ?`, pessoaID)
+}
+
+// SaveContatosEmergencia substitui os contatos de emergência da pessoa
+func SaveContatosEmergencia(pessoaID int64, contatos []entity.ContatoEmergencia) error {
+	if _, err := DB.Exec(`DELETE FROM pessoa_contato_emergencia WHERE pessoaID = ?`, pessoaID); err != nil {
+		return fmt.Errorf("erro ao remover contatos de emergência: %w", err)
+	}
+	for i := range contatos {
+		c := &contatos[i]
+		c.PessoaID = pessoaID
+		result, err := DB.Exec(`INSERT INTO pessoa_contato_emergencia (pessoaID, nome, parentesco, telefone) VALUES (?, ?, ?, ?)`,
+			pessoaID, c.Nome, c.Parentesco, c.Telefone)
+		if err != nil {
+			return fmt.Errorf("erro ao inserir contato de emergência: %w", err)
+		}
+		if c.ID, err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("erro ao obter ID do contato de emergência: %w", err)
+		}
+	}
+	return nil
+}
+
+// pessoaTemDadosEstruturados indica se a pessoa já tem endereço ou contatos nas tabelas novas
+func pessoaTemDadosEstruturados(pessoaID int64) (bool, error) {
+	var n int
+	err := DB.QueryRow(`SELECT
+		(SELECT COUNT(*) FROM pessoa_endereco WHERE pessoaID = ?) +
+		(SELECT COUNT(*) FROM pessoa_contato WHERE pessoaID = ?) +
+		(SELECT COUNT(*) FROM pessoa_contato_emergencia WHERE pessoaID = ?)`, pessoaID, pessoaID, pessoaID).Scan(&n)
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// migrarContatosTexto converte, com melhor esforço, o endereço e os contatos em texto livre das pessoas
+// ainda não migradas. O texto original é mantido nas colunas antigas para conferência; pessoas que já
+// têm dados estruturados só são marcadas como migradas.
+func migrarContatosTexto() {
+	rows, err := DB.Query(`SELECT pessoaID, COALESCE(endereco, ''), COALESCE(contato, ''), COALESCE(contatoEmergencia, '')
+		FROM pessoa WHERE contatosMigrados = FALSE`)
+	if err != nil {
+		log.Printf("Erro ao ler contatos para migração: %v", err)
+		return
+	}
+	type legado struct {
+		id                            int64
+		endereco, contato, emergencia string
+	}
+	var pendentes []legado
+	for rows.Next() {
+		var l legado
+		if err := rows.Scan(&l.id, &l.endereco, &l.contato, &l.emergencia); err != nil {
+			continue
+		}
+		if l.endereco, err = decifrar("pessoa.endereco", l.endereco); err != nil {
+			log.Printf("Erro ao migrar contatos da pessoa %d: %v", l.id, err)
+			continue
+		}
+		pendentes = append(pendentes, l)
+	}
+	_ = rows.Close()
+
+	for _, l := range pendentes {
+		tem, err := pessoaTemDadosEstruturados(l.id)
+		if err != nil {
+			log.Printf("Erro ao migrar contatos da pessoa %d: %v", l.id, err)
+			continue
+		}
+		if !tem {
+			if err := migrarContatosPessoa(l.id, l.endereco, l.contato, l.emergencia); err != nil {
+				log.Printf("Erro ao migrar contatos da pessoa %d: %v", l.id, err)
+				continue
+			}
+		}
+		if _, err := DB.Exec(`UPDATE pessoa SET contatosMigrados = TRUE WHERE pessoaID = ?`, l.id); err != nil {
+			log.Printf("Erro ao marcar contatos migrados da pessoa %d: %v", l.id, err)
+		}
+	}
+}
+
+func migrarContatosPessoa(pessoaID int64, endereco, contato, emergencia string) error {
+	if strings.TrimSpace(endereco) != "" {
+		e := cadastro.ParseEndereco(endereco)
+		if err := SaveEndereco(&entity.Endereco{
+			PessoaID: pessoaID, CEP: e.CEP, Logradouro: limitar(e.Logradouro, 150), Numero: limitar(e.Numero, 20),
+			Complemento: limitar(e.Complemento, 100), Bairro: limitar(e.Bairro, 100), Cidade: limitar(e.Cidade, 100), UF: e.UF,
+		}); err != nil {
+			return err
+		}
+	}
+	var contatos []entity.Contato
+	principal := map[string]bool{}
+	for _, c := range cadastro.ParseContatos(contato) {
+		contatos = append(contatos, entity.Contato{Tipo: c.Tipo, Valor: c.Valor, Principal: !principal[c.Tipo]})
+		principal[c.Tipo] = true
+	}
+	if len(contatos) > 0 {
+		if err := SaveContatos(pessoaID, contatos); err != nil {
+			return err
+		}
+	}
+	var emergencias []entity.ContatoEmergencia
+	for _, c := range cadastro.ParseEmergencia(emergencia) {
+		nome := c.Nome
+		if nome == "" {
+			nome = "Não informado"
+		}
+		emergencias = append(emergencias, entity.ContatoEmergencia{Nome: limitar(nome, 100), Parentesco: c.Parentesco, Telefone: c.Telefone})
+	}
+	if len(emergencias) > 0 {
+		return SaveContatosEmergencia(pessoaID, emergencias)
+	}
+	return nil
+}
+
+// limitar corta o texto no tamanho da coluna, sem partir caracteres
+func limitar(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
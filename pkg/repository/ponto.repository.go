@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"AutoGRH/pkg/utils/timeToDateString"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const pontoDiaColumns = `pontoDiaID, funcionarioID, data, marcacoes, minutosPrevistos, minutosTrabalhados,
+	minutosAtraso, minutosExtra, minutosExtra100, falta, inconsistente`
+
+// UpsertPontoDia grava a apuração do dia, substituindo a existente para o mesmo funcionário e data
+func UpsertPontoDia(p *entity.PontoDia) error {
+	query := `INSERT INTO ponto_dia
+		(funcionarioID, data, marcacoes, minutosPrevistos, minutosTrabalhados, minutosAtraso, minutosExtra, minutosExtra100, falta, inconsistente)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			pontoDiaID = LAST_INSERT_ID(pontoDiaID),
+			marcacoes = VALUES(marcacoes),
+			minutosPrevistos = VALUES(minutosPrevistos),
+			minutosTrabalhados = VALUES(minutosTrabalhados),
+			minutosAtraso = VALUES(minutosAtraso),
+			minutosExtra = VALUES(minutosExtra),
+			minutosExtra100 = VALUES(minutosExtra100),
+			falta = VALUES(falta),
+			inconsistente = VALUES(inconsistente)`
+
+	result, err := DB.Exec(query,
+		p.FuncionarioID,
+		timeToDateString.TimeToDateString(p.Data),
+		strings.Join(p.Marcacoes, ","),
+		p.MinutosPrevistos,
+		p.MinutosTrabalhados,
+		p.MinutosAtraso,
+		p.MinutosExtra,
+		p.MinutosExtra100,
+		p.Falta,
+		p.Inconsistente,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao gravar ponto do dia: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID do ponto do dia: %w", err)
+	}
+	p.ID = id
+	return nil
+}
+
+func scanPontoDia(row rowScanner) (*entity.PontoDia, error) {
+	var p entity.PontoDia
+	var dataStr, marcacoes string
+	if err := row.Scan(&p.ID, &p.FuncionarioID, &dataStr, &marcacoes, &p.MinutosPrevistos, &p.MinutosTrabalhados,
+		&p.MinutosAtraso, &p.MinutosExtra, &p.MinutosExtra100, &p.Falta, &p.Inconsistente); err != nil {
+		return nil, err
+	}
+	var err error
+	if p.Data, err = dateStringToTime.DateStringToTime(dataStr); err != nil {
+		return nil, fmt.Errorf("erro ao converter data do ponto: %w", err)
+	}
+	p.Marcacoes = []string{}
+	if marcacoes != "" {
+		p.Marcacoes = strings.Split(marcacoes, ",")
+	}
+	return &p, nil
+}
+
+// GetPontoDia busca a apuração de um funcionário em um dia
+func GetPontoDia(funcionarioID int64, dia time.Time) (*entity.PontoDia, error) {
+	p, err := scanPontoDia(DB.QueryRow(`SELECT `+pontoDiaColumns+` FROM ponto_dia WHERE funcionarioID = ? AND data = ?`,
+		funcionarioID, timeToDateString.TimeToDateString(dia)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar ponto do dia: %w", err)
+	}
+	return p, nil
+}
+
+// ListPontoDiasByFuncionarioPeriodo lista as apurações de um funcionário com data em [inicio, fim]
+func ListPontoDiasByFuncionarioPeriodo(funcionarioID int64, inicio, fim time.Time) ([]*entity.PontoDia, error) {
+	rows, err := DB.Query(`SELECT `+pontoDiaColumns+` FROM ponto_dia WHERE funcionarioID = ? AND data BETWEEN ? AND ? ORDER BY data`,
+		funcionarioID, timeToDateString.TimeToDateString(inicio), timeToDateString.TimeToDateString(fim))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar ponto: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.PontoDia
+	for rows.Next() {
+		p, err := scanPontoDia(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler ponto: %w", err)
+		}
+		lista = append(lista, p)
+	}
+	return lista, rows.Err()
+}
+
+// GetMinutosExtraByFuncionarioMesAno soma as horas extras apuradas no mês, separando as de 50% e as de 100%
+func GetMinutosExtraByFuncionarioMesAno(funcionarioID int64, mes int, ano int) (extra50 int, extra100 int, err error) {
+	query := `
+SELECT COALESCE(SUM(minutosExtra),0), COALESCE(SUM(minutosExtra100),0)
+FROM ponto_dia
+WHERE funcionarioID = ?
+  AND MONTH(data) = ?
+  AND YEAR(data)  = ?`
+
+	if err := DB.QueryRow(query, funcionarioID, mes, ano).Scan(&extra50, &extra100); err != nil {
+		return 0, 0, fmt.Errorf("erro ao somar horas extras do funcionário %d em %02d/%d: %w",
+			funcionarioID, mes, ano, err)
+	}
+	return extra50, extra100, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"AutoGRH/pkg/entity"
+	"database/sql"
+	"fmt"
+)
+
+// CreateRegraAusencia insere uma regra de limite de ausências
+func CreateRegraAusencia(r *entity.RegraAusencia) error {
+	query := `INSERT INTO regra_ausencia (escopo, referencia, maxAusentes, modo, ativo) VALUES (?, ?, ?, ?, ?)`
+
+	result, err := DB.Exec(query, r.Escopo, r.Referencia, r.MaxAusentes, r.Modo, r.Ativo)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir regra de ausência: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID da regra de ausência: %w", err)
+	}
+	r.ID = id
+	return nil
+}
+
+// GetRegraAusenciaByID busca uma regra pelo ID
+func GetRegraAusenciaByID(id int64) (*entity.RegraAusencia, error) {
+	query := `SELECT regraAusenciaID, escopo, referencia, maxAusentes, modo, ativo
+		FROM regra_ausencia WHERE regraAusenciaID = ?`
+
+	var r entity.RegraAusencia
+	err := DB.QueryRow(query, id).Scan(&r.ID, &r.Escopo, &r.Referencia, &r.MaxAusentes, &r.Modo, &r.Ativo)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar regra de ausência: %w", err)
+	}
+	return &r, nil
+}
+
+// UpdateRegraAusencia atualiza uma regra existente
+func UpdateRegraAusencia(r *entity.RegraAusencia) error {
+	query := `UPDATE regra_ausencia SET escopo = ?, referencia = ?, maxAusentes = ?, modo = ?, ativo = ?
+		WHERE regraAusenciaID = ?`
+
+	_, err := DB.Exec(query, r.Escopo, r.Referencia, r.MaxAusentes, r.Modo, r.Ativo, r.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar regra de ausência: %w", err)
+	}
+	return nil
+}
+
+// DeleteRegraAusencia remove uma regra
+func DeleteRegraAusencia(id int64) error {
+	_, err := DB.Exec(`DELETE FROM regra_ausencia WHERE regraAusenciaID = ?`, id)
+	if err != nil {
+		return fmt.Errorf("erro ao deletar regra de ausência: %w", err)
+	}
+	return nil
+}
+
+func listRegrasAusencia(query string, args ...interface{}) ([]*entity.RegraAusencia, error) {
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar regras de ausência: %w", err)
+	}
+	defer rows.Close()
+
+	var lista []*entity.RegraAusencia
+	for rows.Next() {
+		var r entity.RegraAusencia
+		if err := rows.Scan(&r.ID, &r.Escopo, &r.Referencia, &r.MaxAusentes, &r.Modo, &r.Ativo); err != nil {
+			return nil, fmt.Errorf("erro ao ler regra de ausência: %w", err)
+		}
+		lista = append(lista, &r)
+	}
+	return lista, rows.Err()
+}
+
+// ListRegrasAusencia lista todas as regras
+func ListRegrasAusencia() ([]*entity.RegraAusencia, error) {
+	return listRegrasAusencia(`SELECT regraAusenciaID, escopo, referencia, maxAusentes, modo, ativo
+		FROM regra_ausencia ORDER BY escopo, referencia`)
+}
+
+// ListRegrasAusenciaAtivas lista apenas as regras ativas
+func ListRegrasAusenciaAtivas() ([]*entity.RegraAusencia, error) {
+	return listRegrasAusencia(`SELECT regraAusenciaID, escopo, referencia, maxAusentes, modo, ativo
+		FROM regra_ausencia WHERE ativo = TRUE ORDER BY escopo, referencia`)
+}
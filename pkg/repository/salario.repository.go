@@ -12,9 +12,13 @@ import (
 
 // CreateSalario cria um salário para um funcionário
 func CreateSalario(s *entity.Salario) error {
+	return insertSalario(DB, s)
+}
+
+func insertSalario(db executor, s *entity.Salario) error {
 	query := `INSERT INTO salario (funcionarioID, inicio, valor) VALUES (?, ?, ?)`
 
-	result, err := DB.Exec(query, s.FuncionarioID, s.Inicio, s.Valor)
+	result, err := db.Exec(query, s.FuncionarioID, s.Inicio, s.Valor)
 	if err != nil {
 		return fmt.Errorf("erro ao inserir salário: %w", err)
 	}
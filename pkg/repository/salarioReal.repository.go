@@ -12,8 +12,12 @@ import (
 
 // CreateSalarioReal insere um novo salário real no banco de dados
 func CreateSalarioReal(s *entity.SalarioReal) error {
+	return insertSalarioReal(DB, s)
+}
+
+func insertSalarioReal(db executor, s *entity.SalarioReal) error {
 	query := `INSERT INTO salario_real (funcionarioID, inicio, fim, valor) VALUES (?, ?, ?, ?)`
-	result, err := DB.Exec(query,
+	result, err := db.Exec(query,
 		s.FuncionarioID,
 		s.Inicio,
 		ptrToNullTime.PtrToNullTime(s.Fim),
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 	"AutoGRH/pkg/utils/dateStringToTime"
 	"AutoGRH/pkg/utils/timeToDateString"
 	"database/sql"
@@ -222,6 +223,19 @@ func GetValesByFuncionarioMesAno(funcionarioID int64, mes int, ano int) ([]entit
 	return vales, nil
 }
 
+// GetValesEmAberto resume os vales ativos ainda não pagos de um funcionário
+func GetValesEmAberto(funcionarioID int64) (entity.ValesEmAberto, error) {
+	query := `SELECT COUNT(*), COALESCE(SUM(valor),0),
+	                 COALESCE(SUM(aprovado = FALSE),0), COALESCE(SUM(CASE WHEN aprovado = TRUE THEN valor ELSE 0 END),0)
+			  FROM vale
+			  WHERE funcionarioID = ? AND ativo = TRUE AND pago = FALSE`
+	var v entity.ValesEmAberto
+	if err := DB.QueryRow(query, funcionarioID).Scan(&v.Quantidade, &v.Total, &v.AguardandoAprovacao, &v.AprovadosTotal); err != nil {
+		return v, fmt.Errorf("erro ao resumir vales em aberto: %w", err)
+	}
+	return v, nil
+}
+
 func MarcarValesComoPagos(mes int, ano int) error {
 	query := `UPDATE vale 
               SET pago = TRUE 
@@ -247,12 +261,34 @@ func MarcarTodosValesComoPagos() error {
 
 // ListAllVales retorna todos os vales ATIVOS (pendentes, aprovados pagos e não pagos)
 func ListAllVales() ([]entity.Vale, error) {
-	query := `SELECT valeID, funcionarioID, valor, data, aprovado, pago, ativo
-			  FROM vale
-			  WHERE ativo = TRUE
-      ORDER BY data DESC, valeID DESC`
+	return listVales(`SELECT ` + valeColumns + ` FROM vale WHERE ativo = TRUE ORDER BY data DESC, valeID DESC`)
+}
 
-	rows, err := DB.Query(query)
+const valeColumns = `valeID, funcionarioID, valor, data, aprovado, pago, ativo`
+
+// consultaVales define filtros e ordenações da listagem de vales ativos
+var consultaVales = especConsulta{
+	chave:  "valeID",
+	padrao: "-data",
+	ordens: map[string]string{"data": "data", "valor": "valor"},
+	filtros: map[string]filtroSQL{
+		"funcionario_id": filtroID("funcionarioID"),
+		"aprovado":       filtroBool("aprovado"),
+		"pago":           filtroBool("pago"),
+		"data_de":        filtroDesde("data"),
+		"data_ate":       filtroAte("data"),
+	},
+}
+
+// BuscarVales lista uma página dos vales ativos, por padrão dos mais recentes
+func BuscarVales(q consulta.Consulta) (consulta.Pagina[entity.Vale], error) {
+	return buscar(q, consultaVales, valeColumns, `FROM vale`,
+		listVales, func(v entity.Vale) int64 { return v.ID }, "ativo = TRUE")
+}
+
+// listVales executa uma consulta no formato de valeColumns
+func listVales(query string, args ...interface{}) ([]entity.Vale, error) {
+	rows, err := DB.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao listar vales: %w", err)
 	}
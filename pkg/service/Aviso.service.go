@@ -5,15 +5,32 @@ import (
 	"AutoGRH/pkg/repository"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 )
 
+// Avisos de contrato de experiência (referência: funcionarioID)
+const (
+	AvisoExperienciaVencendo      = "EXPERIENCIA_VENCENDO"
+	AvisoExperienciaIndeterminado = "EXPERIENCIA_INDETERMINADO"
+)
+
+// AvisoContratoLimite avisa que estágio, aprendizagem ou temporário chega à duração máxima legal
+// (referência: funcionarioID)
+const AvisoContratoLimite = "CONTRATO_LIMITE"
+
+// diasAvisoContratoLimite é a antecedência do aviso de duração máxima do contrato
+const diasAvisoContratoLimite = 30
+
 type AvisoService struct {
 	auth *AuthService
+
+	// diasAvisoExperiencia é a antecedência, em dias, do aviso de término da experiência
+	diasAvisoExperiencia int
 }
 
-func NewAvisoService(auth *AuthService) *AvisoService {
-	return &AvisoService{auth: auth}
+func NewAvisoService(auth *AuthService, diasAvisoExperiencia int) *AvisoService {
+	return &AvisoService{auth: auth, diasAvisoExperiencia: diasAvisoExperiencia}
 }
 
 func (s *AvisoService) List(ctx context.Context, claims Claims) ([]entity.Aviso, error) {
@@ -30,13 +47,23 @@ func (s *AvisoService) RunDaily(ctx context.Context) error {
 	now := time.Now()
 	horizonte := now.AddDate(0, 0, 60) // 60 dias
 
+	// prestadores PJ não têm férias: nenhum aviso de férias para eles
+	semFerias := make(map[int64]bool)
+	if todos, err := repository.ListTodosFuncionarios(); err == nil {
+		for _, f := range todos {
+			if !f.TemFerias() {
+				semFerias[f.ID] = true
+			}
+		}
+	}
+
 	// ===== Férias =====
 	if ferias, err := repository.ListFerias(); err == nil {
 		for _, f := range ferias {
 			nome, _ := repository.GetFuncionarioNomeByID(f.FuncionarioID) // se der erro, cai no fallback abaixo
 
 			// Se já pagas, limpa quaisquer avisos relacionados
-			if f.Pago {
+			if f.Pago || semFerias[f.FuncionarioID] {
 				_ = repository.DeleteAvisoByTypeAndRef("FERIAS_VENCENDO", f.ID)
 				_ = repository.DeleteAvisoByTypeAndRef("FERIAS_VENCIDAS", f.ID)
 				continue
@@ -116,9 +143,105 @@ func (s *AvisoService) RunDaily(ctx context.Context) error {
 	// Descansos aprovados limpam os pendentes
 	_ = repository.DeleteAvisosByType("DESCANSO_PENDENTE")
 
+	// ===== Pagamentos de férias próximos do prazo (2 dias antes do descanso) =====
+	// limpa e recria: pagamentos já efetuados deixam de aparecer
+	_ = repository.DeleteAvisosByType("PAGAMENTO_FERIAS_PENDENTE")
+	if pend, err := repository.ListPagamentosFeriasPendentesAte(now.AddDate(0, 0, 5)); err == nil {
+		for _, p := range pend {
+			nome, _ := repository.GetFuncionarioNomeByID(p.FuncionarioID)
+			ref := p.ID
+			situacao := "vence em"
+			if p.EmAtraso(now) {
+				situacao = "venceu em"
+			}
+			msg := fmt.Sprintf("Pagamento de férias de %s (R$ %.2f) %s %s.",
+				firstOrID(nome, p.FuncionarioID), p.ValorLiquido, situacao, p.DataPrevista.Format("02/01/2006"))
+			_ = repository.CreateAviso(&entity.Aviso{
+				Tipo:         "PAGAMENTO_FERIAS_PENDENTE",
+				Mensagem:     msg,
+				ReferenciaID: &ref,
+				CriadoEm:     time.Now(),
+				Ativo:        true,
+			})
+		}
+	}
+
+	// ===== Contratos de experiência e duração máxima =====
+	if ativos, err := repository.ListFuncionariosAtivos(); err == nil {
+		hoje := truncateDate(now)
+		for _, f := range ativos {
+			s.avisarLimiteContrato(f, hoje)
+
+			if !f.EmExperiencia() {
+				_ = repository.DeleteAvisoByTypeAndRef(AvisoExperienciaVencendo, f.ID)
+				continue
+			}
+			nome, _ := repository.GetFuncionarioNomeByID(f.ID)
+			ref := f.ID
+			termino := truncateDate(*f.TerminoExperiencia())
+
+			if termino.Before(hoje) {
+				// o contrato seguiu após o término sem efetivação nem encerramento
+				_ = repository.SetPrazoContrato(f.ID, entity.ContratoPrazoIndeterminado)
+				_ = repository.DeleteAvisoByTypeAndRef(AvisoExperienciaVencendo, f.ID)
+				_ = repository.CreateAviso(&entity.Aviso{
+					Tipo: AvisoExperienciaIndeterminado,
+					Mensagem: fmt.Sprintf("A experiência de %s terminou em %s sem efetivação nem encerramento: o contrato passou a prazo indeterminado.",
+						firstOrID(nome, f.ID), termino.Format("02/01/2006")),
+					ReferenciaID: &ref,
+					CriadoEm:     time.Now(),
+					Ativo:        true,
+				})
+				continue
+			}
+			if termino.After(hoje.AddDate(0, 0, s.diasAvisoExperiencia)) {
+				_ = repository.DeleteAvisoByTypeAndRef(AvisoExperienciaVencendo, f.ID)
+				continue
+			}
+
+			msg := fmt.Sprintf("Contrato de experiência de %s termina em %s: prorrogar, efetivar ou encerrar.",
+				firstOrID(nome, f.ID), termino.Format("02/01/2006"))
+			if f.FimProrrogacao != nil {
+				msg = fmt.Sprintf("Prorrogação da experiência de %s termina em %s: efetivar ou encerrar.",
+					firstOrID(nome, f.ID), termino.Format("02/01/2006"))
+			}
+			_ = repository.CreateAviso(&entity.Aviso{
+				Tipo:         AvisoExperienciaVencendo,
+				Mensagem:     msg,
+				ReferenciaID: &ref,
+				CriadoEm:     time.Now(),
+				Ativo:        true,
+			})
+		}
+	}
+
 	return nil
 }
 
+// avisarLimiteContrato cria o aviso quando estágio, aprendizagem ou temporário está a menos de
+// diasAvisoContratoLimite do limite legal; fora da janela (ou sem limite), remove o aviso
+func (s *AvisoService) avisarLimiteContrato(f *entity.Funcionario, hoje time.Time) {
+	limite := f.LimiteContrato()
+	if limite == nil || truncateDate(*limite).After(hoje.AddDate(0, 0, diasAvisoContratoLimite)) {
+		_ = repository.DeleteAvisoByTypeAndRef(AvisoContratoLimite, f.ID)
+		return
+	}
+	nome, _ := repository.GetFuncionarioNomeByID(f.ID)
+	ref := f.ID
+	situacao := "atinge"
+	if truncateDate(*limite).Before(hoje) {
+		situacao = "atingiu"
+	}
+	_ = repository.CreateAviso(&entity.Aviso{
+		Tipo: AvisoContratoLimite,
+		Mensagem: fmt.Sprintf("Contrato %s de %s %s a duração máxima legal em %s.",
+			strings.ToLower(f.TipoContrato), firstOrID(nome, f.ID), situacao, limite.Format("02/01/2006")),
+		ReferenciaID: &ref,
+		CriadoEm:     time.Now(),
+		Ativo:        true,
+	})
+}
+
 // firstOrID: se nome estiver vazio, retorna "funcionário <ID>"
 func firstOrID(nome string, id int64) string {
 	if nome != "" {
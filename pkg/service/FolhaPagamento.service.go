@@ -57,6 +57,10 @@ func (s *FolhaPagamentoService) CriarFolhaSalario(ctx context.Context, claims Cl
 	var total float64
 
 	for _, f := range funcionarios {
+		// prestador PJ não entra na folha
+		if !f.EntraNaFolha() {
+			continue
+		}
 		salarioReal, err := repository.GetSalarioRealAtual(f.ID)
 		if err != nil {
 			return nil, fmt.Errorf("erro ao buscar salário real: %w", err)
@@ -69,6 +73,18 @@ func (s *FolhaPagamentoService) CriarFolhaSalario(ctx context.Context, claims Cl
 		if err != nil {
 			return nil, fmt.Errorf("erro ao buscar faltas: %w", err)
 		}
+		minutosAtraso, err := repository.GetMinutosAtrasoByFuncionarioMesAno(f.ID, mes, ano)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao buscar atrasos: %w", err)
+		}
+		dsr, err := descontoDSRDoMes(f.ID, salarioReal.Valor, mes, ano)
+		if err != nil {
+			return nil, err
+		}
+		extras, err := adicionalHorasExtrasDoMes(f.ID, folha.ID, salarioReal.Valor, mes, ano)
+		if err != nil {
+			return nil, err
+		}
 
 		vales, err := repository.GetValesByFuncionarioMesAno(f.ID, mes, ano)
 		if err != nil {
@@ -82,15 +98,22 @@ func (s *FolhaPagamentoService) CriarFolhaSalario(ctx context.Context, claims Cl
 		}
 
 		salarioBase := salarioReal.Valor
-		descontoFaltas := (salarioBase / 30) * float64(faltas)
-		valorFinal := salarioBase - descontoFaltas - totalVales
-
-		pag := entity.NewPagamento(f.ID, folha.ID, valorFinal)
+		descontoFaltas := descontoAusencias(salarioBase, faltas, minutosAtraso)
+
+		pag := entity.NewPagamento(f.ID, folha.ID, salarioBase)
+		pag.DescontoVales = totalVales
+		pag.DescontoDSR = dsr
+		pag.HorasExtras = extras
+		pag.RecalcularValorFinal(descontoFaltas)
+		pag.FGTS = depositoFGTS(f, pag, descontoFaltas)
 		if err := repository.CreatePagamento(pag); err != nil {
 			return nil, fmt.Errorf("erro ao criar pagamento: %w", err)
 		}
+		if err := ratearPagamento(pag, mes, ano); err != nil {
+			return nil, fmt.Errorf("erro ao ratear pagamento: %w", err)
+		}
 
-		total += valorFinal
+		total += pag.ValorFinal
 	}
 
 	folha.ValorTotal = total
@@ -192,6 +215,72 @@ func (s *FolhaPagamentoService) RecalcularFolha(ctx context.Context, claims Clai
 	return nil
 }
 
+// horasMensaisPadrao é a jornada mensal de referência (44h semanais) usada para valorar atrasos
+const horasMensaisPadrao = 220
+
+// descontoAusencias valora as faltas injustificadas (1/30 do salário por dia) e os atrasos
+// (salário-hora por minuto). Ausências justificadas não entram na contagem.
+func descontoAusencias(salarioBase float64, dias, minutosAtraso int) float64 {
+	desconto := (salarioBase / 30) * float64(dias)
+	desconto += salarioBase / horasMensaisPadrao / 60 * float64(minutosAtraso)
+	return arredondar2(desconto)
+}
+
+// descontoDSRDoMes busca as faltas do mês e valora o repouso semanal perdido
+func descontoDSRDoMes(funcionarioID int64, salarioBase float64, mes, ano int) (float64, error) {
+	inicio := time.Date(ano, time.Month(mes), 1, 0, 0, 0, 0, time.Local)
+	// recua um mês para pegar faltas de vários dias que começaram antes e avançam sobre este mês
+	faltas, err := repository.GetFaltasByFuncionarioPeriodo(funcionarioID, inicio.AddDate(0, -1, 0), inicio.AddDate(0, 1, -1))
+	if err != nil {
+		return 0, fmt.Errorf("erro ao buscar faltas do mês: %w", err)
+	}
+	// a última semana do mês pode terminar no mês seguinte
+	cal, err := carregarCalendario(inicio.AddDate(0, 0, -7), inicio.AddDate(0, 1, 7))
+	if err != nil {
+		return 0, fmt.Errorf("erro ao carregar calendário: %w", err)
+	}
+	return calcularPerdaDSR(salarioBase, faltas, mes, ano, cal.ehFeriado), nil
+}
+
+// depositoFGTS é o depósito do empregador sobre a remuneração do mês; não desconta do líquido
+func depositoFGTS(f *entity.Funcionario, p *entity.Pagamento, descontoFaltas float64) float64 {
+	remuneracao := p.SalarioBase + p.Adicional + p.HorasExtras - p.DescontoDSR - descontoFaltas
+	return arredondar2(f.AliquotaFGTS() * max(0, remuneracao))
+}
+
+// adicionalHorasExtrasDoMes valora as horas extras apuradas no ponto pelo salário-hora, com adicional
+// de 50% nos dias comuns e de 100% em domingos e feriados. Dias cujas horas extras foram ao banco de
+// horas ficam de fora; entram o saldo vencido do banco e os pagamentos do banco no mês, a 50%.
+func adicionalHorasExtrasDoMes(funcionarioID, folhaID int64, salarioBase float64, mes, ano int) (float64, error) {
+	banco, creditados, err := horasBancoNaFolha(funcionarioID, folhaID, mes, ano)
+	if err != nil {
+		return 0, err
+	}
+
+	var extra50, extra100 int
+	if len(creditados) == 0 {
+		extra50, extra100, err = repository.GetMinutosExtraByFuncionarioMesAno(funcionarioID, mes, ano)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		inicio := time.Date(ano, time.Month(mes), 1, 0, 0, 0, 0, time.Local)
+		dias, err := repository.ListPontoDiasByFuncionarioPeriodo(funcionarioID, inicio, inicio.AddDate(0, 1, -1))
+		if err != nil {
+			return 0, err
+		}
+		for _, d := range dias {
+			if !creditados[d.ID] {
+				extra50 += d.MinutosExtra
+				extra100 += d.MinutosExtra100
+			}
+		}
+	}
+
+	valorMinuto := salarioBase / horasMensaisPadrao / 60
+	return arredondar2(valorMinuto * (1.5*float64(extra50+banco) + 2*float64(extra100))), nil
+}
+
 func (s *FolhaPagamentoService) rebuildPagamentosSalario(
 	ctx context.Context,
 	claims Claims,
@@ -214,6 +303,15 @@ func (s *FolhaPagamentoService) rebuildPagamentosSalario(
 
 	var total float64
 	for _, f := range funcionarios {
+		// prestador PJ não entra na folha; remove o pagamento se o contrato mudou depois de gerada
+		if !f.EntraNaFolha() {
+			if pag, ok := mapPag[f.ID]; ok {
+				if err := repository.DeletePagamento(pag.ID); err != nil {
+					return err
+				}
+			}
+			continue
+		}
 		salarioReal, err := repository.GetSalarioRealAtual(f.ID)
 		if err != nil {
 			return fmt.Errorf("erro ao buscar salário real: %w", err)
@@ -226,6 +324,18 @@ func (s *FolhaPagamentoService) rebuildPagamentosSalario(
 		if err != nil {
 			return fmt.Errorf("erro ao buscar faltas: %w", err)
 		}
+		minutosAtraso, err := repository.GetMinutosAtrasoByFuncionarioMesAno(f.ID, folha.Mes, folha.Ano)
+		if err != nil {
+			return fmt.Errorf("erro ao buscar atrasos: %w", err)
+		}
+		dsr, err := descontoDSRDoMes(f.ID, salarioReal.Valor, folha.Mes, folha.Ano)
+		if err != nil {
+			return err
+		}
+		extras, err := adicionalHorasExtrasDoMes(f.ID, folha.ID, salarioReal.Valor, folha.Mes, folha.Ano)
+		if err != nil {
+			return err
+		}
 
 		vales, err := repository.GetValesByFuncionarioMesAno(f.ID, folha.Mes, folha.Ano)
 		if err != nil {
@@ -240,25 +350,37 @@ func (s *FolhaPagamentoService) rebuildPagamentosSalario(
 
 		// cálculo automático
 		salarioBase := salarioReal.Valor
-		descontoFaltas := (salarioBase / 30) * float64(faltas)
+		descontoFaltas := descontoAusencias(salarioBase, faltas, minutosAtraso)
 
 		if pag, ok := mapPag[f.ID]; ok {
 			pag.SalarioBase = salarioBase
 			pag.DescontoVales = totalVales
+			pag.DescontoDSR = dsr
+			pag.HorasExtras = extras
 			pag.RecalcularValorFinal(descontoFaltas)
+			pag.FGTS = depositoFGTS(f, pag, descontoFaltas)
 
 			if err := repository.UpdatePagamento(pag); err != nil {
 				return fmt.Errorf("erro ao atualizar pagamento: %w", err)
 			}
+			if err := ratearPagamento(pag, folha.Mes, folha.Ano); err != nil {
+				return fmt.Errorf("erro ao ratear pagamento: %w", err)
+			}
 			total += pag.ValorFinal
 		} else {
 			p := entity.NewPagamento(f.ID, folha.ID, salarioBase)
 			p.DescontoVales = totalVales
+			p.DescontoDSR = dsr
+			p.HorasExtras = extras
 			p.RecalcularValorFinal(descontoFaltas)
+			p.FGTS = depositoFGTS(f, p, descontoFaltas)
 
 			if err := repository.CreatePagamento(p); err != nil {
 				return fmt.Errorf("erro ao criar pagamento: %w", err)
 			}
+			if err := ratearPagamento(p, folha.Mes, folha.Ano); err != nil {
+				return fmt.Errorf("erro ao ratear pagamento: %w", err)
+			}
 			total += p.ValorFinal
 		}
 	}
@@ -2,10 +2,16 @@ package service
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/planilha"
 	"context"
+	"errors"
 	"fmt"
 )
 
+// ErrFolhaNaoEncontrada indica folha de pagamento inexistente
+var ErrFolhaNaoEncontrada = errors.New("folha de pagamento não encontrada")
+
 type PagamentoRepository interface {
 	GetPagamentoByID(id int64) (*entity.Pagamento, error)
 	Update(p *entity.Pagamento) error
@@ -133,3 +139,19 @@ func (s *PagamentoService) ListarPagamentosDaFolha(ctx context.Context, claims C
 	}
 	return rows, nil
 }
+
+// ExportarPagamentosDaFolha monta a planilha dos pagamentos da folha, com nomes e totais
+func (s *PagamentoService) ExportarPagamentosDaFolha(ctx context.Context, claims Claims, folhaID int64) (*planilha.Tabela, error) {
+	rows, err := s.ListarPagamentosDaFolha(ctx, claims, folhaID)
+	if err != nil {
+		return nil, err
+	}
+	folha, err := repository.GetFolhaPagamentoByID(folhaID)
+	if err != nil {
+		return nil, err
+	}
+	if folha == nil {
+		return nil, ErrFolhaNaoEncontrada
+	}
+	return TabelaPagamentos(folha, rows)
+}
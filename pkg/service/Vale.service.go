@@ -2,6 +2,7 @@ package service
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 	"context"
 	"fmt"
 	"time"
@@ -17,6 +18,7 @@ type ValeRepository interface {
 	ListPendentes() ([]entity.Vale, error)
 	ListAprovadosNaoPagos() ([]entity.Vale, error)
 	ListAll() ([]entity.Vale, error)
+	Buscar(q consulta.Consulta) (consulta.Pagina[entity.Vale], error)
 }
 
 type ValeService struct {
@@ -75,6 +77,13 @@ func (s *ValeService) ListarVales(ctx context.Context, claims Claims) ([]entity.
 	return s.repo.ListAll()
 }
 
+func (s *ValeService) BuscarVales(ctx context.Context, claims Claims, q consulta.Consulta) (consulta.Pagina[entity.Vale], error) {
+	if err := s.auth.Authorize(ctx, claims, ""); err != nil {
+		return consulta.Pagina[entity.Vale]{}, err
+	}
+	return s.repo.Buscar(q)
+}
+
 func (s *ValeService) ListarValesFuncionario(ctx context.Context, claims Claims, funcionarioID int64) ([]entity.Vale, error) {
 	if err := s.auth.Authorize(ctx, claims, ""); err != nil {
 		return nil, err
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Apuração diária do ponto. As marcações são pareadas em ordem (entrada, saída, entrada, saída...);
+// variações de até 10 minutos no dia em relação à jornada prevista não são computadas
+// (CLT art. 58, §1º). Trabalho em dia sem jornada prevista é todo hora extra: a 100% em
+// domingos e feriados e a 50% nos demais. Turnos que atravessam a meia-noite (ex.: 22:00 às 06:00)
+// são apurados no dia em que começam: as marcações da madrugada seguinte entram nele como "06:00+1".
+
+// toleranciaPontoMinutos é a variação diária desconsiderada na apuração
+const toleranciaPontoMinutos = 10
+
+// sufixoDiaSeguinte marca a marcação feita no dia seguinte ao da apuração, num turno noturno
+const sufixoDiaSeguinte = "+1"
+
+// janelaSaidaTurnoMinutos é até quanto depois do fim previsto uma marcação da madrugada ainda fecha o turno da véspera
+const janelaSaidaTurnoMinutos = 4 * 60
+
+// minutosDoHorario converte "hh:mm" (ou "hh:mm+1", do dia seguinte) em minutos desde a meia-noite do dia
+func minutosDoHorario(h string) (int, error) {
+	extra := 0
+	if strings.HasSuffix(h, sufixoDiaSeguinte) {
+		h, extra = strings.TrimSuffix(h, sufixoDiaSeguinte), 24*60
+	}
+	t, err := time.Parse("15:04", h)
+	if err != nil {
+		return 0, fmt.Errorf("horário inválido: %q", h)
+	}
+	return t.Hour()*60 + t.Minute() + extra, nil
+}
+
+// juntarMarcacoes une as marcações já gravadas com as novas, sem repetir horários, em ordem
+func juntarMarcacoes(atuais []string, novas []string) []string {
+	vistas := map[string]bool{}
+	var lista []string
+	for _, h := range append(append([]string{}, atuais...), novas...) {
+		if h == "" || vistas[h] {
+			continue
+		}
+		vistas[h] = true
+		lista = append(lista, h)
+	}
+	sort.SliceStable(lista, func(i, j int) bool {
+		a, errA := minutosDoHorario(lista[i])
+		b, errB := minutosDoHorario(lista[j])
+		if errA != nil || errB != nil {
+			return lista[i] < lista[j]
+		}
+		return a < b
+	})
+	return lista
+}
+
+// limiteTurnoAnterior devolve até que minuto do dia as marcações fecham o turno da véspera, ou -1 se
+// a jornada da véspera não passa da meia-noite. O limite é o fim previsto mais a janela de saída, sem
+// passar do meio do caminho até a primeira entrada prevista do próprio dia. Feriados não contam aqui:
+// quem trabalha no feriado à noite também sai na madrugada seguinte.
+func limiteTurnoAnterior(esc *escala, dia time.Time) int {
+	anteriores, _, _ := esc.periodosPrevistos(dia.AddDate(0, 0, -1), nil)
+	fimAnterior := 0
+	for _, p := range anteriores {
+		if _, fim, err := p.Intervalo(); err == nil && fim > fimAnterior {
+			fimAnterior = fim
+		}
+	}
+	if fimAnterior <= 24*60 {
+		return -1
+	}
+	fimAnterior -= 24 * 60
+
+	limite := fimAnterior + janelaSaidaTurnoMinutos
+	periodos, _, _ := esc.periodosPrevistos(dia, nil)
+	for _, p := range periodos {
+		if ini, _, err := p.Intervalo(); err == nil {
+			if meio := (fimAnterior + ini) / 2; meio < limite {
+				limite = meio
+			}
+		}
+	}
+	return limite
+}
+
+// atribuirMarcacoesAosTurnos distribui as marcações de [inicio, fim], indexadas por chaveDia, pelos dias
+// de apuração: as da madrugada que fecham o turno noturno da véspera passam para ela com o sufixo "+1"
+func atribuirMarcacoesAosTurnos(marcacoes map[string][]string, esc *escala, inicio, fim time.Time) (map[string][]string, error) {
+	res := make(map[string][]string, len(marcacoes))
+	for d := inicio; !d.After(fim); d = d.AddDate(0, 0, 1) {
+		lista := marcacoes[chaveDia(d)]
+		if len(lista) == 0 {
+			continue
+		}
+		limite := limiteTurnoAnterior(esc, d)
+		for _, h := range lista {
+			m, err := minutosDoHorario(h)
+			if err != nil {
+				return nil, err
+			}
+			if m <= limite {
+				anterior := chaveDia(d.AddDate(0, 0, -1))
+				res[anterior] = append(res[anterior], h+sufixoDiaSeguinte)
+				continue
+			}
+			res[chaveDia(d)] = append(res[chaveDia(d)], h)
+		}
+	}
+	return res, nil
+}
+
+// apurarDia calcula horas trabalhadas, atraso, falta e horas extras do dia. previsto é a jornada
+// do dia em minutos (0 em folgas, feriados, férias e ausências justificadas); descansoRemunerado
+// marca domingos e feriados.
+func apurarDia(funcionarioID int64, dia time.Time, marcacoes []string, previsto int, descansoRemunerado bool) (*entity.PontoDia, error) {
+	p := entity.NewPontoDia(funcionarioID, dia)
+	p.Marcacoes = juntarMarcacoes(nil, marcacoes)
+	p.MinutosPrevistos = previsto
+
+	for i := 0; i+1 < len(p.Marcacoes); i += 2 {
+		ent, err := minutosDoHorario(p.Marcacoes[i])
+		if err != nil {
+			return nil, err
+		}
+		sai, err := minutosDoHorario(p.Marcacoes[i+1])
+		if err != nil {
+			return nil, err
+		}
+		p.MinutosTrabalhados += sai - ent
+	}
+	p.Inconsistente = len(p.Marcacoes)%2 == 1
+
+	switch {
+	case previsto == 0:
+		if descansoRemunerado {
+			p.MinutosExtra100 = p.MinutosTrabalhados
+		} else {
+			p.MinutosExtra = p.MinutosTrabalhados
+		}
+	case len(p.Marcacoes) == 0:
+		p.Falta = true
+	case p.Inconsistente:
+		// marcação faltando: não gera atraso nem extra até a correção
+	default:
+		saldo := p.MinutosTrabalhados - previsto
+		if saldo > toleranciaPontoMinutos {
+			p.MinutosExtra = saldo
+		} else if saldo < -toleranciaPontoMinutos {
+			p.MinutosAtraso = -saldo
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,451 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BancoHorasRepository define as operações de acesso ao banco de horas
+type BancoHorasRepository interface {
+	Create(l *entity.BancoHorasLancamento) error
+	GetByID(id int64) (*entity.BancoHorasLancamento, error)
+	Delete(id int64) error
+	ListByFuncionario(funcionarioID int64) ([]*entity.BancoHorasLancamento, error)
+	GetConfig(funcionarioID int64) (*entity.BancoHorasConfig, error)
+	SaveConfig(c *entity.BancoHorasConfig) error
+}
+
+// BancoHorasService mantém o banco de horas dos funcionários: créditos de horas extras, débitos de
+// atrasos e folgas compensadas, vencimento dos créditos e pagamento do saldo vencido em folha
+type BancoHorasService struct {
+	authService *AuthService
+	logRepo     LogRepository
+	repo        BancoHorasRepository
+}
+
+func NewBancoHorasService(auth *AuthService, logRepo LogRepository, repo BancoHorasRepository) *BancoHorasService {
+	return &BancoHorasService{authService: auth, logRepo: logRepo, repo: repo}
+}
+
+// mesesValidadeBancoHorasPadrao é o prazo de compensação do banco de horas por acordo individual
+// (CLT art. 59, §5º)
+const mesesValidadeBancoHorasPadrao = 6
+
+// configBancoHoras devolve a configuração do funcionário; sem cadastro, ele não participa do banco
+func configBancoHoras(funcionarioID int64) (*entity.BancoHorasConfig, error) {
+	c, err := repository.GetBancoHorasConfig(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		c = &entity.BancoHorasConfig{FuncionarioID: funcionarioID, MesesValidade: mesesValidadeBancoHorasPadrao}
+	}
+	return c, nil
+}
+
+// Apuração do saldo. Os lançamentos são aplicados em ordem cronológica: débitos consomem os créditos
+// mais antigos ainda válidos (primeiro a vencer, primeiro a compensar) e, sem crédito, deixam o saldo
+// negativo, que é abatido pelos créditos seguintes. No dia do vencimento o que restou do crédito passa
+// a ser saldo vencido, que não compensa mais folgas e é pago como hora extra. Pagamentos consomem
+// primeiro o saldo vencido.
+
+type creditoBancoHoras struct {
+	minutos    int
+	vencimento *time.Time
+}
+
+type saldoBancoHoras struct {
+	creditos []*creditoBancoHoras
+	negativo int
+	vencidos int
+}
+
+func (b *saldoBancoHoras) vencer(dia time.Time) {
+	abertos := b.creditos[:0]
+	for _, c := range b.creditos {
+		if c.vencimento != nil && !dia.Before(truncateDate(*c.vencimento)) {
+			b.vencidos += c.minutos
+			continue
+		}
+		abertos = append(abertos, c)
+	}
+	b.creditos = abertos
+}
+
+func (b *saldoBancoHoras) consumir(minutos int) int {
+	for minutos > 0 && len(b.creditos) > 0 {
+		c := b.creditos[0]
+		usado := min(c.minutos, minutos)
+		c.minutos -= usado
+		minutos -= usado
+		if c.minutos == 0 {
+			b.creditos = b.creditos[1:]
+		}
+	}
+	return minutos
+}
+
+func (b *saldoBancoHoras) aplicar(l *entity.BancoHorasLancamento) {
+	switch l.Tipo {
+	case entity.BancoHorasCredito:
+		abate := min(b.negativo, l.Minutos)
+		b.negativo -= abate
+		if resto := l.Minutos - abate; resto > 0 {
+			b.creditos = append(b.creditos, &creditoBancoHoras{minutos: resto, vencimento: l.Vencimento})
+		}
+	case entity.BancoHorasDebito:
+		b.negativo += b.consumir(l.Minutos)
+	case entity.BancoHorasPagamento:
+		pago := min(b.vencidos, l.Minutos)
+		b.vencidos -= pago
+		b.negativo += b.consumir(l.Minutos - pago)
+	}
+}
+
+func (b *saldoBancoHoras) saldo() int {
+	total := -b.negativo
+	for _, c := range b.creditos {
+		total += c.minutos
+	}
+	return total
+}
+
+// ItemExtratoBancoHoras é um lançamento do extrato com o saldo após aplicá-lo
+type ItemExtratoBancoHoras struct {
+	*entity.BancoHorasLancamento
+	Saldo int `json:"saldo"`
+}
+
+// ExtratoBancoHorasDTO é o saldo do banco de horas de um funcionário em uma data e o seu extrato
+type ExtratoBancoHorasDTO struct {
+	FuncionarioID   int64                   `json:"funcionario_id"`
+	Ate             time.Time               `json:"ate"`
+	Ativo           bool                    `json:"ativo"`
+	MesesValidade   int                     `json:"meses_validade"`
+	SaldoMinutos    int                     `json:"saldo_minutos"`    // disponível para compensação
+	VencidosMinutos int                     `json:"vencidos_minutos"` // vencido e ainda não pago em folha
+	Lancamentos     []ItemExtratoBancoHoras `json:"lancamentos"`
+}
+
+// apurarBancoHoras aplica os lançamentos até a data (inclusive) e vence os créditos cujo vencimento
+// chegou até ela. Os lançamentos devem estar em ordem cronológica.
+func apurarBancoHoras(lancamentos []*entity.BancoHorasLancamento, ate time.Time) (saldo, vencidos int, itens []ItemExtratoBancoHoras) {
+	ate = truncateDate(ate)
+	b := &saldoBancoHoras{}
+	itens = []ItemExtratoBancoHoras{}
+	for _, l := range lancamentos {
+		dia := truncateDate(l.Data)
+		if dia.After(ate) {
+			break
+		}
+		b.vencer(dia)
+		b.aplicar(l)
+		itens = append(itens, ItemExtratoBancoHoras{BancoHorasLancamento: l, Saldo: b.saldo()})
+	}
+	b.vencer(ate)
+	return b.saldo(), b.vencidos, itens
+}
+
+// Extrato devolve o saldo do banco de horas do funcionário na data e os lançamentos até ela
+func (s *BancoHorasService) Extrato(ctx context.Context, claims Claims, funcionarioID int64, ate time.Time) (*ExtratoBancoHorasDTO, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	f, err := repository.GetFuncionarioByID(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, nil
+	}
+	if ate.IsZero() {
+		ate = s.authService.clock()
+	}
+	cfg, err := configBancoHoras(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	lancamentos, err := s.repo.ListByFuncionario(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &ExtratoBancoHorasDTO{
+		FuncionarioID: funcionarioID,
+		Ate:           truncateDate(ate),
+		Ativo:         cfg.Ativo,
+		MesesValidade: cfg.MesesValidade,
+	}
+	dto.SaldoMinutos, dto.VencidosMinutos, dto.Lancamentos = apurarBancoHoras(lancamentos, ate)
+	return dto, nil
+}
+
+// Lancar registra um lançamento manual: CREDITO (vence pelo prazo configurado), DEBITO ou
+// PAGAMENTO (pago como hora extra na folha de salário do mês do lançamento)
+func (s *BancoHorasService) Lancar(ctx context.Context, claims Claims, l *entity.BancoHorasLancamento) error {
+	if err := s.authService.Authorize(ctx, claims, "bancohoras:update"); err != nil {
+		return err
+	}
+	l.Tipo = strings.ToUpper(strings.TrimSpace(l.Tipo))
+	l.Descricao = strings.TrimSpace(l.Descricao)
+	l.Origem = entity.BancoHorasOrigemManual
+	l.Referencia = nil
+	l.Vencimento = nil
+	if l.Minutos <= 0 {
+		return fmt.Errorf("minutos devem ser maiores que zero")
+	}
+	if l.Data.IsZero() {
+		return fmt.Errorf("data do lançamento é obrigatória")
+	}
+	l.Data = truncateDate(l.Data)
+
+	f, err := repository.GetFuncionarioByID(l.FuncionarioID)
+	if err != nil {
+		return err
+	}
+	if f == nil {
+		return fmt.Errorf("funcionário não encontrado")
+	}
+	cfg, err := configBancoHoras(l.FuncionarioID)
+	if err != nil {
+		return err
+	}
+
+	switch l.Tipo {
+	case entity.BancoHorasCredito:
+		venc := l.Data.AddDate(0, cfg.MesesValidade, 0)
+		l.Vencimento = &venc
+	case entity.BancoHorasDebito:
+	case entity.BancoHorasPagamento:
+		lancamentos, err := s.repo.ListByFuncionario(l.FuncionarioID)
+		if err != nil {
+			return err
+		}
+		saldo, vencidos, _ := apurarBancoHoras(lancamentos, l.Data)
+		if l.Minutos > saldo+vencidos {
+			return fmt.Errorf("pagamento de %d minutos maior que o saldo do banco de horas (%d)", l.Minutos, max(saldo+vencidos, 0))
+		}
+	default:
+		return fmt.Errorf("tipo inválido: use CREDITO, DEBITO ou PAGAMENTO")
+	}
+
+	if err := s.repo.Create(l); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe: fmt.Sprintf("Banco de horas: %s de %d min para funcionário ID=%d em %s (lançamento ID=%d)",
+			l.Tipo, l.Minutos, l.FuncionarioID, l.Data.Format("2006-01-02"), l.ID),
+	})
+	return nil
+}
+
+// ExcluirLancamento remove um lançamento manual. Lançamentos do ponto, de folgas e de folhas são
+// desfeitos pela reimportação do ponto, pela exclusão da falta ou da folha.
+func (s *BancoHorasService) ExcluirLancamento(ctx context.Context, claims Claims, funcionarioID, id int64) error {
+	if err := s.authService.Authorize(ctx, claims, "bancohoras:update"); err != nil {
+		return err
+	}
+	l, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if l == nil || l.FuncionarioID != funcionarioID {
+		return fmt.Errorf("lançamento não encontrado")
+	}
+	if l.Origem != entity.BancoHorasOrigemManual {
+		return fmt.Errorf("apenas lançamentos manuais podem ser excluídos (origem %s)", l.Origem)
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  5,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Banco de horas: excluiu lançamento ID=%d do funcionário ID=%d", id, funcionarioID),
+	})
+	return nil
+}
+
+// ConcederFolga compensa um dia de trabalho pelo banco: lança a ausência ABONADA do dia e debita
+// do banco a jornada prevista. O saldo pode ficar negativo, a compensar com horas extras futuras.
+// Excluir a falta desfaz o débito.
+func (s *BancoHorasService) ConcederFolga(ctx context.Context, claims Claims, funcionarioID int64, dia time.Time, descricao string) (*entity.BancoHorasLancamento, error) {
+	if err := s.authService.Authorize(ctx, claims, "bancohoras:update"); err != nil {
+		return nil, err
+	}
+	if dia.IsZero() {
+		return nil, fmt.Errorf("data da folga é obrigatória")
+	}
+	dia = truncateDate(dia)
+
+	f, err := repository.GetFuncionarioByID(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, fmt.Errorf("funcionário não encontrado")
+	}
+	cfg, err := configBancoHoras(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Ativo {
+		return nil, fmt.Errorf("funcionário não participa do banco de horas")
+	}
+
+	faltas, err := repository.GetFaltasByFuncionarioPeriodo(funcionarioID, dia.AddDate(0, -1, 0), dia)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar faltas: %w", err)
+	}
+	if aus := ausenciasDoDia(faltas, dia); aus.justificada || aus.falta || aus.faltaPonto != nil {
+		return nil, fmt.Errorf("já há ausência lançada em %s", dia.Format("2006-01-02"))
+	}
+	esc, err := carregarEscala(funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar jornada: %w", err)
+	}
+	cal, err := carregarCalendario(dia, dia)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar calendário: %w", err)
+	}
+	previsto := esc.minutosPrevistos(dia, cal)
+	if previsto == 0 {
+		return nil, fmt.Errorf("não há jornada prevista em %s", dia.Format("2006-01-02"))
+	}
+
+	if descricao = strings.TrimSpace(descricao); descricao == "" {
+		descricao = "Folga compensada"
+	}
+	falta := entity.NewAusencia(entity.FaltaTipoAbonada, dia, 1, funcionarioID)
+	l := entity.NewBancoHorasLancamento(funcionarioID, dia, entity.BancoHorasDebito, previsto, descricao)
+	l.Origem = entity.BancoHorasOrigemFolga
+	// a ausência e o débito entram juntos ou nenhum dos dois
+	if err := repository.CreateFolgaCompensada(falta, l); err != nil {
+		return nil, err
+	}
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe: fmt.Sprintf("Banco de horas: folga compensada em %s para funcionário ID=%d (%d min, falta ID=%d)",
+			dia.Format("2006-01-02"), funcionarioID, previsto, falta.ID),
+	})
+	return l, nil
+}
+
+// Configurar inclui ou retira o funcionário do banco de horas e define o prazo de validade dos créditos.
+// A mudança vale para as horas apuradas a partir de então; os créditos já lançados mantêm o vencimento.
+func (s *BancoHorasService) Configurar(ctx context.Context, claims Claims, c *entity.BancoHorasConfig) error {
+	if err := s.authService.Authorize(ctx, claims, "bancohoras:update"); err != nil {
+		return err
+	}
+	if c.MesesValidade == 0 {
+		c.MesesValidade = mesesValidadeBancoHorasPadrao
+	}
+	// acordo coletivo admite até 12 meses (CLT art. 59, §2º)
+	if c.MesesValidade < 1 || c.MesesValidade > 12 {
+		return fmt.Errorf("validade deve ser de 1 a 12 meses")
+	}
+	f, err := repository.GetFuncionarioByID(c.FuncionarioID)
+	if err != nil {
+		return err
+	}
+	if f == nil {
+		return fmt.Errorf("funcionário não encontrado")
+	}
+	if err := s.repo.SaveConfig(c); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  4,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe: fmt.Sprintf("Banco de horas do funcionário ID=%d: ativo=%t, validade %d meses",
+			c.FuncionarioID, c.Ativo, c.MesesValidade),
+	})
+	return nil
+}
+
+// lancarPontoNoBanco leva ao banco as horas extras e o atraso do dia apurado no ponto. O lançamento
+// de cada dia é refeito a cada reimportação; devolve se o dia gerou crédito ou débito.
+func lancarPontoNoBanco(cfg *entity.BancoHorasConfig, dia *entity.PontoDia, atrasoLancado bool) (bool, error) {
+	ref := dia.ID
+	gravar := func(tipo string, minutos int, descricao string) error {
+		if minutos <= 0 {
+			return repository.DeleteLancamentosBancoHorasByReferencia(dia.FuncionarioID, tipo, entity.BancoHorasOrigemPonto, ref)
+		}
+		l := entity.NewBancoHorasLancamento(dia.FuncionarioID, dia.Data, tipo, minutos, descricao)
+		l.Origem = entity.BancoHorasOrigemPonto
+		l.Referencia = &ref
+		if tipo == entity.BancoHorasCredito {
+			venc := truncateDate(dia.Data).AddDate(0, cfg.MesesValidade, 0)
+			l.Vencimento = &venc
+		}
+		return repository.UpsertLancamentoBancoHoras(l)
+	}
+
+	extras := dia.MinutosExtra + dia.MinutosExtra100
+	if err := gravar(entity.BancoHorasCredito, extras, "Horas extras do ponto"); err != nil {
+		return false, err
+	}
+	atraso := 0
+	if !atrasoLancado {
+		atraso = dia.MinutosAtraso
+	}
+	if err := gravar(entity.BancoHorasDebito, atraso, "Atraso do ponto"); err != nil {
+		return false, err
+	}
+	return extras > 0 || atraso > 0, nil
+}
+
+// horasBancoNaFolha prepara o banco de horas para a folha de salário do mês: refaz o pagamento do saldo
+// vencido até o fim do mês (origem FOLHA) e devolve os minutos a pagar como hora extra a 50% — o
+// vencido mais os pagamentos manuais do mês — e os dias do ponto cujas horas extras foram ao banco.
+func horasBancoNaFolha(funcionarioID, folhaID int64, mes, ano int) (minutos int, creditados map[int64]bool, err error) {
+	if err := repository.DeleteLancamentosBancoHorasByReferencia(funcionarioID, entity.BancoHorasPagamento, entity.BancoHorasOrigemFolha, folhaID); err != nil {
+		return 0, nil, err
+	}
+	lancamentos, err := repository.ListLancamentosBancoHorasByFuncionario(funcionarioID)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(lancamentos) == 0 {
+		return 0, nil, nil
+	}
+
+	inicio := time.Date(ano, time.Month(mes), 1, 0, 0, 0, 0, time.Local)
+	fimMes := inicio.AddDate(0, 1, -1)
+	creditados = map[int64]bool{}
+	for _, l := range lancamentos {
+		if l.Tipo == entity.BancoHorasCredito && l.Origem == entity.BancoHorasOrigemPonto && l.Referencia != nil {
+			creditados[*l.Referencia] = true
+		}
+		d := truncateDate(l.Data)
+		if l.Tipo == entity.BancoHorasPagamento && l.Origem == entity.BancoHorasOrigemManual && !d.Before(inicio) && !d.After(fimMes) {
+			minutos += l.Minutos
+		}
+	}
+
+	_, vencidos, _ := apurarBancoHoras(lancamentos, fimMes)
+	if vencidos > 0 {
+		ref := folhaID
+		pag := entity.NewBancoHorasLancamento(funcionarioID, fimMes, entity.BancoHorasPagamento, vencidos,
+			fmt.Sprintf("Saldo vencido pago na folha %02d/%d", mes, ano))
+		pag.Origem = entity.BancoHorasOrigemFolha
+		pag.Referencia = &ref
+		if err := repository.CreateLancamentoBancoHoras(pag); err != nil {
+			return 0, nil, err
+		}
+		minutos += vencidos
+	}
+	return minutos, creditados, nil
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/documentos"
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Quantidade de resultados da busca global
+const (
+	LimiteBuscaPadrao = 20
+	LimiteBuscaMaximo = 50
+)
+
+var (
+	reBuscaCompetencia   = regexp.MustCompile(`^(\d{1,2})/(\d{4})$`)
+	reBuscaIdentificador = regexp.MustCompile(`^(?:(vale|folha)\s*)?#?\s*(\d+)$`)
+	reBuscaDocumento     = regexp.MustCompile(`^[\d.\-/\s]+$`)
+)
+
+// ordemTipoBusca desempata resultados de mesma relevância
+var ordemTipoBusca = map[string]int{
+	entity.BuscaFuncionario: 0,
+	entity.BuscaPessoa:      1,
+	entity.BuscaCargo:       2,
+	entity.BuscaDocumento:   3,
+	entity.BuscaVale:        4,
+	entity.BuscaFolha:       5,
+}
+
+type BuscaService struct {
+	auth *AuthService
+}
+
+func NewBuscaService(auth *AuthService) *BuscaService {
+	return &BuscaService{auth: auth}
+}
+
+// Buscar procura o termo em pessoas, funcionários, cargos, documentos, vales e folhas. Números são
+// tratados como trechos de CPF/PIS e como identificadores ("vale 12", "folha #3", "#12"); "MM/AAAA"
+// encontra as folhas da competência. Os resultados vêm do mais para o menos relevante.
+func (s *BuscaService) Buscar(ctx context.Context, claims Claims, termo string, limite int) ([]entity.ResultadoBusca, error) {
+	if err := s.auth.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	termo = strings.Join(strings.Fields(termo), " ")
+	if len([]rune(termo)) < 2 {
+		verr := &ErroValidacao{}
+		verr.Add("q", "termo de busca deve ter ao menos 2 caracteres")
+		return nil, verr
+	}
+	if limite <= 0 {
+		limite = LimiteBuscaPadrao
+	}
+	if limite > LimiteBuscaMaximo {
+		limite = LimiteBuscaMaximo
+	}
+
+	var itens []entity.ResultadoBusca
+	juntar := func(lista []entity.ResultadoBusca, err error) error {
+		itens = append(itens, lista...)
+		return err
+	}
+
+	// alvo é o texto comparado com os resultados para calcular a relevância
+	minusculo := strings.ToLower(termo)
+	alvo := minusculo
+	switch {
+	case reBuscaCompetencia.MatchString(termo):
+		m := reBuscaCompetencia.FindStringSubmatch(termo)
+		mes, _ := strconv.Atoi(m[1])
+		ano, _ := strconv.Atoi(m[2])
+		if err := juntar(repository.BuscaFolhasPorCompetencia(mes, ano)); err != nil {
+			return nil, err
+		}
+	case reBuscaIdentificador.MatchString(minusculo):
+		m := reBuscaIdentificador.FindStringSubmatch(minusculo)
+		id, err := strconv.ParseInt(m[2], 10, 64)
+		if err != nil {
+			break
+		}
+		if m[1] != "folha" {
+			if err := juntar(repository.BuscaValePorID(id)); err != nil {
+				return nil, err
+			}
+		}
+		if m[1] != "vale" {
+			if err := juntar(repository.BuscaFolhaPorID(id)); err != nil {
+				return nil, err
+			}
+		}
+		alvo = m[2]
+		if m[1] == "" && len(m[2]) >= 3 {
+			if err := juntar(repository.BuscaDigitos(m[2], limite)); err != nil {
+				return nil, err
+			}
+		}
+	case reBuscaDocumento.MatchString(termo):
+		// CPF ou PIS com máscara
+		alvo = documentos.SoDigitos(termo)
+		if len(alvo) >= 3 {
+			if err := juntar(repository.BuscaDigitos(alvo, limite)); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		if err := juntar(repository.BuscaTexto(termo, limite)); err != nil {
+			return nil, err
+		}
+	}
+
+	podeVerDocumentos := s.auth.Authorize(ctx, claims, "documento:list") == nil
+	unicos := make(map[string]int, len(itens))
+	out := make([]entity.ResultadoBusca, 0, len(itens))
+	for _, r := range itens {
+		if r.Tipo == entity.BuscaDocumento && !podeVerDocumentos {
+			continue
+		}
+		if r.Tipo == entity.BuscaDocumento {
+			r.Titulo = path.Base(strings.ReplaceAll(r.Titulo, `\`, "/"))
+		}
+		r.Relevancia = relevanciaBusca(alvo, r)
+		r.Link = linkBusca(r)
+		chave := fmt.Sprintf("%s:%d", r.Tipo, r.ID)
+		if i, ok := unicos[chave]; ok {
+			if r.Relevancia > out[i].Relevancia {
+				out[i] = r
+			}
+			continue
+		}
+		unicos[chave] = len(out)
+		out = append(out, r)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Relevancia != out[j].Relevancia {
+			return out[i].Relevancia > out[j].Relevancia
+		}
+		if out[i].Tipo != out[j].Tipo {
+			return ordemTipoBusca[out[i].Tipo] < ordemTipoBusca[out[j].Tipo]
+		}
+		return out[i].Titulo < out[j].Titulo
+	})
+	if len(out) > limite {
+		out = out[:limite]
+	}
+	return out, nil
+}
+
+// relevanciaBusca pontua o resultado pelo melhor casamento do termo no título ou, com peso menor,
+// no detalhe: texto igual, palavra inteira (no começo ou não), começo de palavra, qualquer trecho
+func relevanciaBusca(termo string, r entity.ResultadoBusca) float64 {
+	if r.Tipo == entity.BuscaVale || r.Tipo == entity.BuscaFolha {
+		return 1
+	}
+	termo = semAcento(termo)
+	p := pontuarTexto(termo, semAcento(strings.ToLower(r.Titulo)))
+	if d := 0.8 * pontuarTexto(termo, semAcento(strings.ToLower(r.Detalhe))); d > p {
+		p = d
+	}
+	return float64(int(p*100+0.5)) / 100
+}
+
+func pontuarTexto(termo, texto string) float64 {
+	switch {
+	case texto == "":
+		return 0
+	case texto == termo:
+		return 1
+	case strings.HasPrefix(texto, termo+" "):
+		return 0.9
+	case strings.Contains(" "+texto+" ", " "+termo+" "):
+		return 0.8
+	case strings.HasPrefix(texto, termo):
+		return 0.7
+	case strings.Contains(" "+texto, " "+termo):
+		return 0.6
+	case strings.Contains(texto, termo):
+		return 0.5
+	}
+	// termo com várias palavras: todas presentes, em qualquer ordem
+	palavras := strings.Fields(termo)
+	achadas := 0
+	for _, p := range palavras {
+		if strings.Contains(texto, p) {
+			achadas++
+		}
+	}
+	if len(palavras) > 1 && achadas == len(palavras) {
+		return 0.4
+	}
+	return 0.2 * float64(achadas) / float64(len(palavras))
+}
+
+var semAcentoReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "ê", "e", "è", "e", "ë", "e",
+	"í", "i", "î", "i", "ì", "i", "ï", "i",
+	"ó", "o", "ô", "o", "õ", "o", "ò", "o", "ö", "o",
+	"ú", "u", "û", "u", "ù", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// semAcento remove acentos de texto em minúsculas, como a collation do MySQL faz na consulta
+func semAcento(s string) string {
+	return semAcentoReplacer.Replace(s)
+}
+
+// linkBusca é a rota da API que devolve o recurso encontrado
+func linkBusca(r entity.ResultadoBusca) string {
+	switch r.Tipo {
+	case entity.BuscaPessoa:
+		return fmt.Sprintf("/pessoas/%d", r.ID)
+	case entity.BuscaFuncionario:
+		return fmt.Sprintf("/funcionarios/%d", r.ID)
+	case entity.BuscaCargo:
+		return fmt.Sprintf("/cargos/%d", r.ID)
+	case entity.BuscaDocumento:
+		return fmt.Sprintf("/documentos/%d/download", r.ID)
+	case entity.BuscaVale:
+		return fmt.Sprintf("/vales/%d", r.ID)
+	case entity.BuscaFolha:
+		return fmt.Sprintf("/folhas/%d", r.ID)
+	}
+	return ""
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/feriadosNacionais"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FeriadoRepository define as operações de acesso aos feriados cadastrados
+type FeriadoRepository interface {
+	Create(f *entity.Feriado) error
+	GetByID(id int64) (*entity.Feriado, error)
+	Update(f *entity.Feriado) error
+	Delete(id int64) error
+	ListEntre(inicio, fim time.Time) ([]*entity.Feriado, error)
+}
+
+// CalendarioService mantém o calendário de feriados e dias sem expediente da empresa
+// e responde quantos dias úteis há em um intervalo
+type CalendarioService struct {
+	authService *AuthService
+	logRepo     LogRepository
+	repo        FeriadoRepository
+}
+
+func NewCalendarioService(auth *AuthService, logRepo LogRepository, repo FeriadoRepository) *CalendarioService {
+	return &CalendarioService{authService: auth, logRepo: logRepo, repo: repo}
+}
+
+// calendario é o conjunto de feriados de um intervalo: nacionais calculados para cada ano e os
+// cadastrados (recorrentes projetados no ano). Dia útil é de segunda a sexta sem feriado.
+type calendario struct {
+	dias map[string][]*entity.Feriado
+}
+
+func chaveDia(t time.Time) string { return t.Format("2006-01-02") }
+
+// montarCalendario combina os feriados nacionais dos anos de [inicio, fim] com os cadastrados
+func montarCalendario(inicio, fim time.Time, cadastrados []*entity.Feriado) *calendario {
+	c := &calendario{dias: map[string][]*entity.Feriado{}}
+	add := func(f *entity.Feriado) { c.dias[chaveDia(f.Data)] = append(c.dias[chaveDia(f.Data)], f) }
+
+	for ano := inicio.Year(); ano <= fim.Year(); ano++ {
+		for _, n := range feriadosNacionais.FeriadosNacionais(ano, inicio.Location()) {
+			tipo := entity.FeriadoTipoNacional
+			if n.Facultativo {
+				tipo = entity.FeriadoTipoFacultativo
+			}
+			add(&entity.Feriado{Data: n.Data, Nome: n.Nome, Tipo: tipo, Recorrente: true})
+		}
+		for _, f := range cadastrados {
+			if !f.Recorrente {
+				continue
+			}
+			proj := *f
+			proj.Data = time.Date(ano, f.Data.Month(), f.Data.Day(), 0, 0, 0, 0, inicio.Location())
+			add(&proj)
+		}
+	}
+	for _, f := range cadastrados {
+		if !f.Recorrente {
+			add(f)
+		}
+	}
+	return c
+}
+
+// carregarCalendario monta o calendário de [inicio, fim] a partir do banco
+func carregarCalendario(inicio, fim time.Time) (*calendario, error) {
+	cadastrados, err := repository.ListFeriadosEntre(inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	return montarCalendario(inicio, fim, cadastrados), nil
+}
+
+// ehFeriado indica se o dia tem feriado ou folga sem expediente (pontos facultativos não contam)
+func (c *calendario) ehFeriado(dia time.Time) bool {
+	for _, f := range c.dias[chaveDia(dia)] {
+		if f.SemExpediente() {
+			return true
+		}
+	}
+	return false
+}
+
+// ehDiaUtil indica se o dia é de segunda a sexta e não é feriado
+func (c *calendario) ehDiaUtil(dia time.Time) bool {
+	wd := dia.Weekday()
+	return wd != time.Saturday && wd != time.Sunday && !c.ehFeriado(dia)
+}
+
+// diasUteis conta os dias úteis em [inicio, fim]
+func (c *calendario) diasUteis(inicio, fim time.Time) int {
+	total := 0
+	for d := truncateDate(inicio); !d.After(fim); d = d.AddDate(0, 0, 1) {
+		if c.ehDiaUtil(d) {
+			total++
+		}
+	}
+	return total
+}
+
+// nesimoDiaUtil devolve o n-ésimo dia útil do mês. Com sabado = true o sábado conta como útil,
+// como no prazo de pagamento de salários (CLT art. 459, §1º).
+func (c *calendario) nesimoDiaUtil(ano int, mes time.Month, n int, sabado bool, loc *time.Location) time.Time {
+	d := time.Date(ano, mes, 1, 0, 0, 0, 0, loc)
+	for cont := 0; ; d = d.AddDate(0, 0, 1) {
+		util := c.ehDiaUtil(d) || (sabado && d.Weekday() == time.Saturday && !c.ehFeriado(d))
+		if util {
+			cont++
+			if cont == n {
+				return d
+			}
+		}
+	}
+}
+
+// diaUtilAntes devolve o n-ésimo dia útil anterior a dia (o próprio dia não conta)
+func (c *calendario) diaUtilAntes(dia time.Time, n int) time.Time {
+	d := truncateDate(dia)
+	for cont := 0; cont < n; {
+		d = d.AddDate(0, 0, -1)
+		if c.ehDiaUtil(d) {
+			cont++
+		}
+	}
+	return d
+}
+
+// feriadosEntre lista os feriados de [inicio, fim] em ordem de data
+func (c *calendario) feriadosEntre(inicio, fim time.Time) []*entity.Feriado {
+	lista := []*entity.Feriado{}
+	for d := truncateDate(inicio); !d.After(fim); d = d.AddDate(0, 0, 1) {
+		lista = append(lista, c.dias[chaveDia(d)]...)
+	}
+	sort.SliceStable(lista, func(i, j int) bool { return lista[i].Data.Before(lista[j].Data) })
+	return lista
+}
+
+// DiasUteis conta os dias úteis (segunda a sexta, sem feriados e folgas da empresa) em [inicio, fim].
+// Não exige credenciais: é a API do calendário para os demais serviços.
+func (s *CalendarioService) DiasUteis(inicio, fim time.Time) (int, error) {
+	inicio, fim = truncateDate(inicio), truncateDate(fim)
+	cal, err := carregarCalendario(inicio, fim)
+	if err != nil {
+		return 0, err
+	}
+	return cal.diasUteis(inicio, fim), nil
+}
+
+// EhDiaUtil indica se o dia é útil no calendário da empresa
+func (s *CalendarioService) EhDiaUtil(dia time.Time) (bool, error) {
+	dia = truncateDate(dia)
+	cal, err := carregarCalendario(dia, dia)
+	if err != nil {
+		return false, err
+	}
+	return cal.ehDiaUtil(dia), nil
+}
+
+// DiasUteisDTO é a resposta da consulta de dias úteis de um intervalo
+type DiasUteisDTO struct {
+	Inicio    time.Time         `json:"inicio"`
+	Fim       time.Time         `json:"fim"`
+	DiasUteis int               `json:"dias_uteis"`
+	Feriados  []*entity.Feriado `json:"feriados"`
+}
+
+// ConsultarDiasUteis conta os dias úteis de [inicio, fim] e lista os feriados do intervalo
+func (s *CalendarioService) ConsultarDiasUteis(ctx context.Context, claims Claims, inicio, fim time.Time) (*DiasUteisDTO, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	inicio, fim = truncateDate(inicio), truncateDate(fim)
+	if fim.Before(inicio) {
+		return nil, fmt.Errorf("data final anterior à inicial")
+	}
+	cal, err := carregarCalendario(inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	return &DiasUteisDTO{
+		Inicio:    inicio,
+		Fim:       fim,
+		DiasUteis: cal.diasUteis(inicio, fim),
+		Feriados:  cal.feriadosEntre(inicio, fim),
+	}, nil
+}
+
+// ListarAno lista os feriados do ano: nacionais, pontos facultativos e os cadastrados
+func (s *CalendarioService) ListarAno(ctx context.Context, claims Claims, ano int) ([]*entity.Feriado, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	if ano < 1900 || ano > 2200 {
+		return nil, fmt.Errorf("ano inválido")
+	}
+	inicio := time.Date(ano, time.January, 1, 0, 0, 0, 0, time.Local)
+	fim := time.Date(ano, time.December, 31, 0, 0, 0, 0, time.Local)
+	cadastrados, err := s.repo.ListEntre(inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	return montarCalendario(inicio, fim, cadastrados).feriadosEntre(inicio, fim), nil
+}
+
+func validarFeriado(f *entity.Feriado) error {
+	f.Tipo = strings.ToUpper(strings.TrimSpace(f.Tipo))
+	f.Nome = strings.TrimSpace(f.Nome)
+	f.Localidade = strings.TrimSpace(f.Localidade)
+	if f.Tipo == "" {
+		f.Tipo = entity.FeriadoTipoEmpresa
+	}
+	if !f.Cadastrado() {
+		return fmt.Errorf("tipo inválido: use ESTADUAL, MUNICIPAL ou EMPRESA (feriados nacionais são calculados)")
+	}
+	if f.Nome == "" {
+		return fmt.Errorf("nome do feriado é obrigatório")
+	}
+	if f.Data.IsZero() {
+		return fmt.Errorf("data do feriado é obrigatória")
+	}
+	f.Data = truncateDate(f.Data)
+	return nil
+}
+
+func (s *CalendarioService) Criar(ctx context.Context, claims Claims, f *entity.Feriado) error {
+	if err := s.authService.Authorize(ctx, claims, "calendario:update"); err != nil {
+		return err
+	}
+	if err := validarFeriado(f); err != nil {
+		return err
+	}
+	if err := s.repo.Create(f); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Feriado criado ID=%d %s %s (%s)", f.ID, f.Data.Format("2006-01-02"), f.Nome, f.Tipo),
+	})
+	return nil
+}
+
+func (s *CalendarioService) Atualizar(ctx context.Context, claims Claims, f *entity.Feriado) error {
+	if err := s.authService.Authorize(ctx, claims, "calendario:update"); err != nil {
+		return err
+	}
+	atual, err := s.repo.GetByID(f.ID)
+	if err != nil {
+		return err
+	}
+	if atual == nil {
+		return fmt.Errorf("feriado não encontrado")
+	}
+	if err := validarFeriado(f); err != nil {
+		return err
+	}
+	if err := s.repo.Update(f); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  4,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Feriado atualizado ID=%d %s %s (%s)", f.ID, f.Data.Format("2006-01-02"), f.Nome, f.Tipo),
+	})
+	return nil
+}
+
+func (s *CalendarioService) Excluir(ctx context.Context, claims Claims, id int64) error {
+	if err := s.authService.Authorize(ctx, claims, "calendario:update"); err != nil {
+		return err
+	}
+	atual, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if atual == nil {
+		return fmt.Errorf("feriado não encontrado")
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  5,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Feriado excluído ID=%d", id),
+	})
+	return nil
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/iCalendar"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Feeds de calendário disponíveis
+const (
+	FeedCalendarioTodos       = "todos"
+	FeedCalendarioDescansos   = "descansos"
+	FeedCalendarioVencimentos = "vencimentos"
+	FeedCalendarioExperiencia = "experiencia"
+	FeedCalendarioFolhas      = "folhas"
+)
+
+// FeedsCalendario lista os feeds na ordem em que são apresentados ao usuário
+var FeedsCalendario = []string{
+	FeedCalendarioTodos,
+	FeedCalendarioDescansos,
+	FeedCalendarioVencimentos,
+	FeedCalendarioExperiencia,
+	FeedCalendarioFolhas,
+}
+
+// ErrTokenCalendarioInvalido indica token de feed inexistente, revogado ou de usuário inativo
+var ErrTokenCalendarioInvalido = errors.New("token de calendário inválido")
+
+// ErrFeedCalendarioInvalido indica um nome de feed desconhecido
+var ErrFeedCalendarioInvalido = errors.New("feed de calendário inválido")
+
+// CalendarioTokenRepository define as operações de acesso aos tokens de feed .ics
+type CalendarioTokenRepository interface {
+	Save(t *entity.CalendarioToken) error
+	GetByHash(hash string) (*entity.CalendarioToken, error)
+	DeleteByUsuarioID(usuarioID int64) error
+	Touch(id int64, quando time.Time) error
+}
+
+// CalendarioICSService publica as datas de RH como feeds iCalendar assináveis
+type CalendarioICSService struct {
+	authService *AuthService
+	logRepo     LogRepository
+	repo        CalendarioTokenRepository
+}
+
+func NewCalendarioICSService(auth *AuthService, logRepo LogRepository, repo CalendarioTokenRepository) *CalendarioICSService {
+	return &CalendarioICSService{authService: auth, logRepo: logRepo, repo: repo}
+}
+
+func hashTokenCalendario(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GerarToken cria (ou substitui) o token de feed do usuário. O valor só é devolvido nesta chamada.
+func (s *CalendarioICSService) GerarToken(ctx context.Context, claims Claims) (string, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("erro ao gerar token de calendário: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	t := &entity.CalendarioToken{
+		UsuarioID: claims.UserID,
+		TokenHash: hashTokenCalendario(token),
+		CriadoEm:  s.authService.clock(),
+	}
+	if err := s.repo.Save(t); err != nil {
+		return "", err
+	}
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Token de calendário gerado para usuário ID=%d", claims.UserID),
+	})
+	return token, nil
+}
+
+// RevogarToken remove o token de feed do usuário; assinaturas existentes deixam de funcionar
+func (s *CalendarioICSService) RevogarToken(ctx context.Context, claims Claims) error {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteByUsuarioID(claims.UserID); err != nil {
+		return err
+	}
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  5,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Token de calendário revogado para usuário ID=%d", claims.UserID),
+	})
+	return nil
+}
+
+// ClaimsPorToken resolve o token do feed para as credenciais do usuário dono dele
+func (s *CalendarioICSService) ClaimsPorToken(ctx context.Context, token string) (Claims, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return Claims{}, ErrTokenCalendarioInvalido
+	}
+	t, err := s.repo.GetByHash(hashTokenCalendario(token))
+	if err != nil {
+		return Claims{}, err
+	}
+	if t == nil {
+		return Claims{}, ErrTokenCalendarioInvalido
+	}
+
+	u, err := repository.GetUsuarioByID(t.UsuarioID)
+	if err != nil {
+		return Claims{}, err
+	}
+	if u == nil || !u.Ativo {
+		return Claims{}, ErrTokenCalendarioInvalido
+	}
+
+	perfil := "usuario"
+	if u.IsAdmin {
+		perfil = "admin"
+	}
+	_ = s.repo.Touch(t.ID, s.authService.clock())
+
+	return Claims{UserID: u.ID, Nome: u.Username, Perfil: perfil}, nil
+}
+
+// Feed monta o calendário .ics solicitado. Os UIDs são derivados dos IDs dos registros,
+// de modo que alterações substituem o evento anterior no cliente.
+func (s *CalendarioICSService) Feed(ctx context.Context, claims Claims, feed string) ([]byte, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+
+	feed = strings.ToLower(strings.TrimSpace(feed))
+	hoje := truncateDate(s.authService.clock())
+	inicio := hoje.AddDate(-1, 0, 0)
+	fim := hoje.AddDate(2, 0, 0)
+
+	nomes := map[int64]string{}
+	nome := func(funcionarioID int64) string {
+		if n, ok := nomes[funcionarioID]; ok {
+			return n
+		}
+		n, err := repository.GetFuncionarioNomeByID(funcionarioID)
+		if err != nil || n == "" {
+			n = fmt.Sprintf("Funcionário %d", funcionarioID)
+		}
+		nomes[funcionarioID] = n
+		return n
+	}
+
+	var eventos []iCalendar.Event
+	var titulo string
+
+	incluir := func(f string) bool { return feed == FeedCalendarioTodos || feed == f }
+
+	switch feed {
+	case FeedCalendarioTodos:
+		titulo = "AutoGRH - RH"
+	case FeedCalendarioDescansos:
+		titulo = "AutoGRH - Férias"
+	case FeedCalendarioVencimentos:
+		titulo = "AutoGRH - Vencimentos de férias"
+	case FeedCalendarioExperiencia:
+		titulo = "AutoGRH - Fim de experiência"
+	case FeedCalendarioFolhas:
+		titulo = "AutoGRH - Fechamento de folha"
+	default:
+		return nil, fmt.Errorf("%w: use %s", ErrFeedCalendarioInvalido, strings.Join(FeedsCalendario, ", "))
+	}
+
+	if incluir(FeedCalendarioDescansos) {
+		evs, err := eventosDescansos(inicio, fim)
+		if err != nil {
+			return nil, err
+		}
+		eventos = append(eventos, evs...)
+	}
+	if incluir(FeedCalendarioVencimentos) {
+		evs, err := eventosVencimentos(inicio, fim, nome)
+		if err != nil {
+			return nil, err
+		}
+		eventos = append(eventos, evs...)
+	}
+	if incluir(FeedCalendarioExperiencia) {
+		evs, err := eventosExperiencia(inicio, fim, nome)
+		if err != nil {
+			return nil, err
+		}
+		eventos = append(eventos, evs...)
+	}
+	if incluir(FeedCalendarioFolhas) {
+		evs, err := eventosFolhas(hoje)
+		if err != nil {
+			return nil, err
+		}
+		eventos = append(eventos, evs...)
+	}
+
+	sort.SliceStable(eventos, func(i, j int) bool { return eventos[i].Inicio.Before(eventos[j].Inicio) })
+
+	cal := iCalendar.Calendar{Nome: titulo, Eventos: eventos, Gerado: s.authService.clock()}
+	return cal.Bytes(), nil
+}
+
+func eventosDescansos(inicio, fim time.Time) ([]iCalendar.Event, error) {
+	lista, err := repository.ListDescansosCalendario(inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	eventos := make([]iCalendar.Event, 0, len(lista))
+	for _, d := range lista {
+		status := "pendente"
+		if d.Pago {
+			status = "pago"
+		} else if d.Aprovado {
+			status = "aprovado"
+		}
+		summary := "Férias: " + d.Nome
+		if !d.Aprovado {
+			summary += " (pendente)"
+		}
+		eventos = append(eventos, iCalendar.Event{
+			UID:         fmt.Sprintf("descanso-%d@autogrh", d.DescansoID),
+			Summary:     summary,
+			Description: fmt.Sprintf("Cargo: %s\nStatus: %s", d.Cargo, status),
+			Inicio:      d.Inicio,
+			Fim:         d.Fim,
+			Categoria:   "Férias",
+		})
+	}
+	return eventos, nil
+}
+
+func eventosVencimentos(inicio, fim time.Time, nome func(int64) string) ([]iCalendar.Event, error) {
+	lista, err := repository.ListFerias()
+	if err != nil {
+		return nil, err
+	}
+	var eventos []iCalendar.Event
+	for _, f := range lista {
+		if f.Pago || f.Vencimento.Before(inicio) || f.Vencimento.After(fim) {
+			continue
+		}
+		eventos = append(eventos, iCalendar.Event{
+			UID:     fmt.Sprintf("ferias-vencimento-%d@autogrh", f.ID),
+			Summary: "Vencimento de férias: " + nome(f.FuncionarioID),
+			Description: fmt.Sprintf("Período aquisitivo iniciado em %s, %d dias de direito",
+				f.Inicio.Format("02/01/2006"), f.Dias),
+			Inicio:    f.Vencimento,
+			Fim:       f.Vencimento,
+			Categoria: "Vencimento de férias",
+		})
+	}
+	return eventos, nil
+}
+
+func eventosExperiencia(inicio, fim time.Time, nome func(int64) string) ([]iCalendar.Event, error) {
+	lista, err := repository.ListFuncionariosAtivos()
+	if err != nil {
+		return nil, err
+	}
+	var eventos []iCalendar.Event
+	for _, f := range lista {
+		if !f.EmExperiencia() {
+			continue
+		}
+		termino := truncateDate(*f.TerminoExperiencia())
+		if termino.Before(inicio) || termino.After(fim) {
+			continue
+		}
+		descricao := fmt.Sprintf("Admissão em %s, experiência até %s", f.Admissao.Format("02/01/2006"), termino.Format("02/01/2006"))
+		if f.FimProrrogacao != nil {
+			descricao += " (prorrogada)"
+		}
+		eventos = append(eventos, iCalendar.Event{
+			UID:         fmt.Sprintf("experiencia-%d@autogrh", f.ID),
+			Summary:     "Fim da experiência: " + nome(f.ID),
+			Description: descricao,
+			Inicio:      termino,
+			Fim:         termino,
+			Categoria:   "Experiência",
+		})
+	}
+	return eventos, nil
+}
+
+// eventosFolhas publica o prazo de fechamento da folha de salário (5º dia útil do mês seguinte)
+// dos últimos 12 meses e dos próximos 2
+func eventosFolhas(hoje time.Time) ([]iCalendar.Event, error) {
+	folhas, err := repository.ListFolhasPagamentos()
+	if err != nil {
+		return nil, err
+	}
+	pagas := map[string]bool{}
+	for _, f := range folhas {
+		if f.Tipo == "SALARIO" {
+			pagas[fmt.Sprintf("%d-%02d", f.Ano, f.Mes)] = f.Pago
+		}
+	}
+
+	base := time.Date(hoje.Year(), hoje.Month(), 1, 0, 0, 0, 0, hoje.Location())
+	cal, err := carregarCalendario(base.AddDate(0, -11, 0), base.AddDate(0, 4, 0))
+	if err != nil {
+		return nil, err
+	}
+	var eventos []iCalendar.Event
+	for i := -12; i <= 2; i++ {
+		comp := base.AddDate(0, i, 0)
+		ref := comp.AddDate(0, 1, 0)
+		prazo := cal.nesimoDiaUtil(ref.Year(), ref.Month(), 5, true, ref.Location())
+
+		status := "não gerada"
+		if pago, ok := pagas[fmt.Sprintf("%d-%02d", comp.Year(), int(comp.Month()))]; ok {
+			status = "aberta"
+			if pago {
+				status = "fechada"
+			}
+		}
+		eventos = append(eventos, iCalendar.Event{
+			UID:         fmt.Sprintf("folha-%d-%02d-SALARIO@autogrh", comp.Year(), int(comp.Month())),
+			Summary:     fmt.Sprintf("Fechamento da folha %02d/%d", int(comp.Month()), comp.Year()),
+			Description: "Prazo de pagamento dos salários. Situação: " + status,
+			Inicio:      prazo,
+			Fim:         prazo,
+			Categoria:   "Folha de pagamento",
+		})
+	}
+	return eventos, nil
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// CargoRepository define as operações de acesso ao catálogo de cargos e ao histórico dos funcionários
+type CargoRepository interface {
+	Create(c *entity.Cargo) error
+	GetByID(id int64) (*entity.Cargo, error)
+	GetByTitulo(titulo string) (*entity.Cargo, error)
+	Update(c *entity.Cargo) error
+	Delete(id int64) error
+	List() ([]*entity.Cargo, error)
+	EmUso(id int64) (bool, error)
+
+	Registrar(fc *entity.FuncionarioCargo) error
+	ListByFuncionario(funcionarioID int64) ([]*entity.FuncionarioCargo, error)
+}
+
+// CargoService mantém o catálogo de cargos e registra as promoções dos funcionários
+type CargoService struct {
+	authService *AuthService
+	logRepo     LogRepository
+	repo        CargoRepository
+}
+
+func NewCargoService(auth *AuthService, logRepo LogRepository, repo CargoRepository) *CargoService {
+	return &CargoService{authService: auth, logRepo: logRepo, repo: repo}
+}
+
+func validarCargo(c *entity.Cargo) error {
+	c.Titulo = strings.TrimSpace(c.Titulo)
+	c.Departamento = strings.TrimSpace(c.Departamento)
+	c.CBO = strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, c.CBO)
+
+	if c.Titulo == "" {
+		return fmt.Errorf("título do cargo é obrigatório")
+	}
+	if len([]rune(c.Titulo)) > 50 {
+		return fmt.Errorf("título do cargo deve ter até 50 caracteres")
+	}
+	if c.CBO != "" && len(c.CBO) != 6 {
+		return fmt.Errorf("código CBO deve ter 6 dígitos (ex.: 2124-05)")
+	}
+	if c.SalarioMin < 0 || c.SalarioMax < 0 {
+		return fmt.Errorf("faixa salarial não pode ser negativa")
+	}
+	if c.SalarioMax > 0 && c.SalarioMax < c.SalarioMin {
+		return fmt.Errorf("salário máximo menor que o mínimo")
+	}
+	return nil
+}
+
+// tituloDisponivel garante que nenhum outro cargo use o título
+func (s *CargoService) tituloDisponivel(c *entity.Cargo) error {
+	existente, err := s.repo.GetByTitulo(c.Titulo)
+	if err != nil {
+		return err
+	}
+	if existente != nil && existente.ID != c.ID {
+		return fmt.Errorf("já existe o cargo %q", existente.Titulo)
+	}
+	return nil
+}
+
+func (s *CargoService) CriarCargo(ctx context.Context, claims Claims, c *entity.Cargo) error {
+	if err := s.authService.Authorize(ctx, claims, "cargo:update"); err != nil {
+		return err
+	}
+	if err := validarCargo(c); err != nil {
+		return err
+	}
+	if err := s.tituloDisponivel(c); err != nil {
+		return err
+	}
+	c.Ativo = true
+	if err := s.repo.Create(c); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Cargo criado ID=%d %s", c.ID, c.Titulo),
+	})
+	return nil
+}
+
+// AtualizarCargo altera o cargo; um novo título passa a valer para quem o ocupa
+func (s *CargoService) AtualizarCargo(ctx context.Context, claims Claims, c *entity.Cargo) error {
+	if err := s.authService.Authorize(ctx, claims, "cargo:update"); err != nil {
+		return err
+	}
+	atual, err := s.repo.GetByID(c.ID)
+	if err != nil {
+		return err
+	}
+	if atual == nil {
+		return fmt.Errorf("cargo não encontrado")
+	}
+	if err := validarCargo(c); err != nil {
+		return err
+	}
+	if err := s.tituloDisponivel(c); err != nil {
+		return err
+	}
+	if err := s.repo.Update(c); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  4,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Cargo atualizado ID=%d %s", c.ID, c.Titulo),
+	})
+	return nil
+}
+
+// ExcluirCargo remove um cargo que nunca foi ocupado; os demais podem ser desativados
+func (s *CargoService) ExcluirCargo(ctx context.Context, claims Claims, id int64) error {
+	if err := s.authService.Authorize(ctx, claims, "cargo:update"); err != nil {
+		return err
+	}
+	emUso, err := s.repo.EmUso(id)
+	if err != nil {
+		return err
+	}
+	if emUso {
+		return fmt.Errorf("cargo presente no histórico de funcionários não pode ser excluído; desative-o")
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  5,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Cargo excluído ID=%d", id),
+	})
+	return nil
+}
+
+func (s *CargoService) BuscarCargo(ctx context.Context, claims Claims, id int64) (*entity.Cargo, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *CargoService) ListarCargos(ctx context.Context, claims Claims) ([]*entity.Cargo, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	return s.repo.List()
+}
+
+// ListarHistorico lista os cargos ocupados pelo funcionário, do mais antigo ao atual
+func (s *CargoService) ListarHistorico(ctx context.Context, claims Claims, funcionarioID int64) ([]*entity.FuncionarioCargo, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	lista, err := s.repo.ListByFuncionario(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	if lista == nil {
+		lista = []*entity.FuncionarioCargo{}
+	}
+	return lista, nil
+}
+
+// avisoFaixa descreve o valor fora da faixa salarial do cargo ("" quando está dentro)
+func avisoFaixa(c *entity.Cargo, rotulo string, valor float64) string {
+	if !c.ForaDaFaixa(valor) {
+		return ""
+	}
+	faixa := fmt.Sprintf("R$ %.2f a R$ %.2f", c.SalarioMin, c.SalarioMax)
+	if c.SalarioMax == 0 {
+		faixa = fmt.Sprintf("a partir de R$ %.2f", c.SalarioMin)
+	}
+	return fmt.Sprintf("%s de R$ %.2f fora da faixa do cargo %s (%s)", rotulo, valor, c.Titulo, faixa)
+}
+
+// avisoFaixaSalarial confere um novo salário contra a faixa do cargo do funcionário. É só um aviso:
+// funcionário sem cargo do catálogo ou falha na consulta não geram mensagem.
+func avisoFaixaSalarial(funcionarioID int64, rotulo string, valor float64) string {
+	f, err := repository.GetFuncionarioByID(funcionarioID)
+	if err != nil || f == nil || f.CargoID == nil {
+		return ""
+	}
+	c, err := repository.GetCargoByID(*f.CargoID)
+	if err != nil || c == nil {
+		return ""
+	}
+	return avisoFaixa(c, rotulo, valor)
+}
+
+// PromocaoInput descreve uma mudança de cargo; os salários são opcionais
+type PromocaoInput struct {
+	CargoID     int64
+	Data        time.Time // zero: hoje
+	Observacao  string
+	Salario     *float64 // novo salário registrado em carteira
+	SalarioReal *float64 // novo salário real (usado na folha)
+}
+
+// PromocaoDTO é o resultado da mudança de cargo, com os avisos de faixa salarial
+type PromocaoDTO struct {
+	Historico   *entity.FuncionarioCargo `json:"historico"`
+	Salario     *entity.Salario          `json:"salario,omitempty"`
+	SalarioReal *entity.SalarioReal      `json:"salario_real,omitempty"`
+	Avisos      []string                 `json:"avisos"`
+}
+
+// PromoverFuncionario muda o cargo do funcionário a partir de uma data (até hoje), registrando o
+// histórico e, se informados, os novos salários registrado e real com início na mesma data
+func (s *CargoService) PromoverFuncionario(ctx context.Context, claims Claims, funcionarioID int64, in PromocaoInput) (*PromocaoDTO, error) {
+	if err := s.authService.Authorize(ctx, claims, "funcionario:update"); err != nil {
+		return nil, err
+	}
+	hoje := truncateDate(s.authService.clock())
+	data := truncateDate(in.Data)
+	if in.Data.IsZero() {
+		data = hoje
+	}
+	if data.After(hoje) {
+		return nil, fmt.Errorf("a mudança de cargo não pode ter data futura")
+	}
+	if (in.Salario != nil && *in.Salario <= 0) || (in.SalarioReal != nil && *in.SalarioReal <= 0) {
+		return nil, fmt.Errorf("salário deve ser maior que zero")
+	}
+
+	f, err := repository.GetFuncionarioByID(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil || !f.Ativo {
+		return nil, fmt.Errorf("funcionário não encontrado ou inativo")
+	}
+	if data.Before(truncateDate(f.Admissao)) {
+		return nil, fmt.Errorf("a mudança de cargo não pode ser anterior à admissão")
+	}
+	c, err := s.repo.GetByID(in.CargoID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil || !c.Ativo {
+		return nil, fmt.Errorf("cargo não encontrado ou inativo")
+	}
+
+	historico, err := s.repo.ListByFuncionario(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(historico); n > 0 {
+		ultimo := historico[n-1]
+		if !truncateDate(ultimo.Inicio).Before(data) {
+			return nil, fmt.Errorf("já existe mudança de cargo em %s; a nova deve ser posterior", ultimo.Inicio.Format("02/01/2006"))
+		}
+		if ultimo.CargoID == c.ID {
+			return nil, fmt.Errorf("funcionário já ocupa o cargo %s", c.Titulo)
+		}
+	}
+
+	dto := &PromocaoDTO{Avisos: []string{}}
+	dto.Historico = entity.NewFuncionarioCargo(funcionarioID, c.ID, data, strings.TrimSpace(in.Observacao))
+	if err := s.repo.Registrar(dto.Historico); err != nil {
+		return nil, err
+	}
+	dto.Historico.Cargo = c
+	if err := repository.SetCargoFuncionario(funcionarioID, c.ID, c.Titulo); err != nil {
+		return nil, err
+	}
+
+	// salário registrado: o informado ou o vigente, conferido com a faixa do novo cargo
+	if in.Salario != nil {
+		if atual, err := repository.GetSalarioAtual(funcionarioID); err != nil {
+			return nil, err
+		} else if atual != nil {
+			if err := repository.EncerrarSalario(atual.ID, data); err != nil {
+				return nil, fmt.Errorf("erro ao encerrar salário atual: %w", err)
+			}
+		}
+		dto.Salario = entity.NewSalario(funcionarioID, data, *in.Salario)
+		if err := repository.CreateSalario(dto.Salario); err != nil {
+			return nil, err
+		}
+		if aviso := avisoFaixa(c, "Salário registrado", *in.Salario); aviso != "" {
+			dto.Avisos = append(dto.Avisos, aviso)
+		}
+	} else if atual, err := repository.GetSalarioAtual(funcionarioID); err == nil && atual != nil {
+		if aviso := avisoFaixa(c, "Salário registrado atual", atual.Valor); aviso != "" {
+			dto.Avisos = append(dto.Avisos, aviso)
+		}
+	}
+
+	if in.SalarioReal != nil {
+		if atual, err := repository.GetSalarioRealAtual(funcionarioID); err != nil {
+			return nil, err
+		} else if atual != nil {
+			if err := repository.EncerrarSalarioReal(atual.ID, data); err != nil {
+				return nil, fmt.Errorf("erro ao encerrar salário real atual: %w", err)
+			}
+		}
+		dto.SalarioReal = entity.NewSalarioReal(funcionarioID, data, *in.SalarioReal)
+		if err := repository.CreateSalarioReal(dto.SalarioReal); err != nil {
+			return nil, err
+		}
+		if aviso := avisoFaixa(c, "Salário real", *in.SalarioReal); aviso != "" {
+			dto.Avisos = append(dto.Avisos, aviso)
+		}
+	}
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  4,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Funcionário ID=%d passou ao cargo %s (ID=%d) em %s", funcionarioID, c.Titulo, c.ID, data.Format("2006-01-02")),
+	})
+	return dto, nil
+}
@@ -0,0 +1,486 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CentroCustoRepository define as operações de acesso a centros de custo, departamentos e alocações
+type CentroCustoRepository interface {
+	CreateCentro(c *entity.CentroCusto) error
+	GetCentroByID(id int64) (*entity.CentroCusto, error)
+	ListCentros() ([]*entity.CentroCusto, error)
+	UpdateCentro(c *entity.CentroCusto) error
+	DeleteCentro(id int64) error
+	CentroEmUso(id int64) (bool, error)
+
+	CreateDepartamento(d *entity.Departamento) error
+	GetDepartamentoByID(id int64) (*entity.Departamento, error)
+	ListDepartamentos() ([]*entity.Departamento, error)
+	UpdateDepartamento(d *entity.Departamento) error
+	DeleteDepartamento(id int64) error
+	DepartamentoEmUso(id int64) (bool, error)
+
+	SaveAlocacoes(funcionarioID int64, inicio time.Time, alocacoes []*entity.AlocacaoFuncionario) error
+	DeleteAlocacoes(funcionarioID int64, inicio time.Time) error
+	ListAlocacoes(funcionarioID int64) ([]*entity.AlocacaoFuncionario, error)
+}
+
+// CentroCustoService mantém centros de custo e departamentos, aloca os funcionários e resume
+// o custo da folha por centro de custo
+type CentroCustoService struct {
+	authService *AuthService
+	logRepo     LogRepository
+	repo        CentroCustoRepository
+}
+
+func NewCentroCustoService(auth *AuthService, logRepo LogRepository, repo CentroCustoRepository) *CentroCustoService {
+	return &CentroCustoService{authService: auth, logRepo: logRepo, repo: repo}
+}
+
+func (s *CentroCustoService) log(ctx context.Context, claims Claims, evento int64, detalhe string) {
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  evento,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   detalhe,
+	})
+}
+
+func validarCentroCusto(c *entity.CentroCusto) error {
+	c.Codigo = strings.ToUpper(strings.TrimSpace(c.Codigo))
+	c.Nome = strings.TrimSpace(c.Nome)
+	if c.Codigo == "" || len(c.Codigo) > 20 {
+		return fmt.Errorf("código do centro de custo é obrigatório (até 20 caracteres)")
+	}
+	if c.Nome == "" {
+		return fmt.Errorf("nome do centro de custo é obrigatório")
+	}
+	return nil
+}
+
+// codigoDisponivel garante que nenhum outro centro de custo use o código
+func (s *CentroCustoService) codigoDisponivel(c *entity.CentroCusto) error {
+	lista, err := s.repo.ListCentros()
+	if err != nil {
+		return err
+	}
+	for _, o := range lista {
+		if o.ID != c.ID && o.Codigo == c.Codigo {
+			return fmt.Errorf("já existe o centro de custo %s", c.Codigo)
+		}
+	}
+	return nil
+}
+
+func (s *CentroCustoService) CriarCentroCusto(ctx context.Context, claims Claims, c *entity.CentroCusto) error {
+	if err := s.authService.Authorize(ctx, claims, "centrocusto:update"); err != nil {
+		return err
+	}
+	if err := validarCentroCusto(c); err != nil {
+		return err
+	}
+	if err := s.codigoDisponivel(c); err != nil {
+		return err
+	}
+	c.Ativo = true
+	if err := s.repo.CreateCentro(c); err != nil {
+		return err
+	}
+	s.log(ctx, claims, 3, fmt.Sprintf("Centro de custo criado ID=%d %s", c.ID, c.Codigo))
+	return nil
+}
+
+func (s *CentroCustoService) AtualizarCentroCusto(ctx context.Context, claims Claims, c *entity.CentroCusto) error {
+	if err := s.authService.Authorize(ctx, claims, "centrocusto:update"); err != nil {
+		return err
+	}
+	atual, err := s.repo.GetCentroByID(c.ID)
+	if err != nil {
+		return err
+	}
+	if atual == nil {
+		return fmt.Errorf("centro de custo não encontrado")
+	}
+	if err := validarCentroCusto(c); err != nil {
+		return err
+	}
+	if err := s.codigoDisponivel(c); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateCentro(c); err != nil {
+		return err
+	}
+	s.log(ctx, claims, 4, fmt.Sprintf("Centro de custo atualizado ID=%d %s", c.ID, c.Codigo))
+	return nil
+}
+
+// ExcluirCentroCusto remove um centro de custo sem uso; os demais podem ser desativados
+func (s *CentroCustoService) ExcluirCentroCusto(ctx context.Context, claims Claims, id int64) error {
+	if err := s.authService.Authorize(ctx, claims, "centrocusto:update"); err != nil {
+		return err
+	}
+	emUso, err := s.repo.CentroEmUso(id)
+	if err != nil {
+		return err
+	}
+	if emUso {
+		return fmt.Errorf("centro de custo em uso não pode ser excluído; desative-o")
+	}
+	if err := s.repo.DeleteCentro(id); err != nil {
+		return err
+	}
+	s.log(ctx, claims, 5, fmt.Sprintf("Centro de custo excluído ID=%d", id))
+	return nil
+}
+
+func (s *CentroCustoService) ListarCentrosCusto(ctx context.Context, claims Claims) ([]*entity.CentroCusto, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	return s.repo.ListCentros()
+}
+
+// centroAtivo exige um centro de custo cadastrado e ativo
+func (s *CentroCustoService) centroAtivo(id int64) (*entity.CentroCusto, error) {
+	c, err := s.repo.GetCentroByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil || !c.Ativo {
+		return nil, fmt.Errorf("centro de custo %d não encontrado ou inativo", id)
+	}
+	return c, nil
+}
+
+func (s *CentroCustoService) validarDepartamento(d *entity.Departamento) error {
+	d.Nome = strings.TrimSpace(d.Nome)
+	if d.Nome == "" {
+		return fmt.Errorf("nome do departamento é obrigatório")
+	}
+	lista, err := s.repo.ListDepartamentos()
+	if err != nil {
+		return err
+	}
+	for _, o := range lista {
+		if o.ID != d.ID && strings.EqualFold(o.Nome, d.Nome) {
+			return fmt.Errorf("já existe o departamento %q", o.Nome)
+		}
+	}
+	if d.CentroCustoID != nil {
+		if _, err := s.centroAtivo(*d.CentroCustoID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CentroCustoService) CriarDepartamento(ctx context.Context, claims Claims, d *entity.Departamento) error {
+	if err := s.authService.Authorize(ctx, claims, "centrocusto:update"); err != nil {
+		return err
+	}
+	if err := s.validarDepartamento(d); err != nil {
+		return err
+	}
+	d.Ativo = true
+	if err := s.repo.CreateDepartamento(d); err != nil {
+		return err
+	}
+	s.log(ctx, claims, 3, fmt.Sprintf("Departamento criado ID=%d %s", d.ID, d.Nome))
+	return nil
+}
+
+func (s *CentroCustoService) AtualizarDepartamento(ctx context.Context, claims Claims, d *entity.Departamento) error {
+	if err := s.authService.Authorize(ctx, claims, "centrocusto:update"); err != nil {
+		return err
+	}
+	atual, err := s.repo.GetDepartamentoByID(d.ID)
+	if err != nil {
+		return err
+	}
+	if atual == nil {
+		return fmt.Errorf("departamento não encontrado")
+	}
+	if err := s.validarDepartamento(d); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateDepartamento(d); err != nil {
+		return err
+	}
+	s.log(ctx, claims, 4, fmt.Sprintf("Departamento atualizado ID=%d %s", d.ID, d.Nome))
+	return nil
+}
+
+// ExcluirDepartamento remove um departamento sem alocações; os demais podem ser desativados
+func (s *CentroCustoService) ExcluirDepartamento(ctx context.Context, claims Claims, id int64) error {
+	if err := s.authService.Authorize(ctx, claims, "centrocusto:update"); err != nil {
+		return err
+	}
+	emUso, err := s.repo.DepartamentoEmUso(id)
+	if err != nil {
+		return err
+	}
+	if emUso {
+		return fmt.Errorf("departamento com funcionários alocados não pode ser excluído; desative-o")
+	}
+	if err := s.repo.DeleteDepartamento(id); err != nil {
+		return err
+	}
+	s.log(ctx, claims, 5, fmt.Sprintf("Departamento excluído ID=%d", id))
+	return nil
+}
+
+func (s *CentroCustoService) ListarDepartamentos(ctx context.Context, claims Claims) ([]*entity.Departamento, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDepartamentos()
+}
+
+// AlocacaoItem é uma parte da distribuição do funcionário; sem centro de custo vale o do departamento
+type AlocacaoItem struct {
+	DepartamentoID int64
+	CentroCustoID  *int64
+	Percentual     float64 // 0 com um único item: 100%
+}
+
+// DefinirAlocacao grava a distribuição do funcionário entre departamentos e centros de custo a partir
+// de inicio; substitui a distribuição que já comece na mesma data. Os percentuais somam 100.
+func (s *CentroCustoService) DefinirAlocacao(ctx context.Context, claims Claims, funcionarioID int64, inicio time.Time, itens []AlocacaoItem) ([]*entity.AlocacaoFuncionario, error) {
+	if err := s.authService.Authorize(ctx, claims, "centrocusto:update"); err != nil {
+		return nil, err
+	}
+	if inicio.IsZero() {
+		return nil, fmt.Errorf("data de início é obrigatória")
+	}
+	inicio = truncateDate(inicio)
+	if len(itens) == 0 {
+		return nil, fmt.Errorf("informe ao menos uma alocação")
+	}
+	if len(itens) == 1 && itens[0].Percentual == 0 {
+		itens[0].Percentual = 100
+	}
+
+	f, err := repository.GetFuncionarioByID(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, fmt.Errorf("funcionário não encontrado")
+	}
+
+	var total float64
+	vistos := map[[2]int64]bool{}
+	alocacoes := make([]*entity.AlocacaoFuncionario, 0, len(itens))
+	for _, it := range itens {
+		if it.Percentual <= 0 || it.Percentual > 100 {
+			return nil, fmt.Errorf("percentual deve ser maior que 0 e até 100")
+		}
+		d, err := s.repo.GetDepartamentoByID(it.DepartamentoID)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil || !d.Ativo {
+			return nil, fmt.Errorf("departamento %d não encontrado ou inativo", it.DepartamentoID)
+		}
+		centroID := d.CentroCustoID
+		if it.CentroCustoID != nil {
+			centroID = it.CentroCustoID
+		}
+		if centroID == nil {
+			return nil, fmt.Errorf("departamento %s sem centro de custo: informe o centro de custo", d.Nome)
+		}
+		if _, err := s.centroAtivo(*centroID); err != nil {
+			return nil, err
+		}
+		chave := [2]int64{d.ID, *centroID}
+		if vistos[chave] {
+			return nil, fmt.Errorf("departamento e centro de custo repetidos na alocação")
+		}
+		vistos[chave] = true
+		total += it.Percentual
+		alocacoes = append(alocacoes, &entity.AlocacaoFuncionario{
+			FuncionarioID:  funcionarioID,
+			DepartamentoID: d.ID,
+			CentroCustoID:  *centroID,
+			Inicio:         inicio,
+			Percentual:     arredondar2(it.Percentual),
+		})
+	}
+	if math.Abs(total-100) > 0.001 {
+		return nil, fmt.Errorf("os percentuais da alocação somam %.2f%%; devem somar 100%%", total)
+	}
+
+	if err := s.repo.SaveAlocacoes(funcionarioID, inicio, alocacoes); err != nil {
+		return nil, err
+	}
+	s.log(ctx, claims, 4, fmt.Sprintf("Alocação do funcionário %d a partir de %s (%d centros de custo)",
+		funcionarioID, inicio.Format("2006-01-02"), len(alocacoes)))
+	return alocacoes, nil
+}
+
+// RemoverAlocacao desfaz a distribuição do funcionário que começa em inicio
+func (s *CentroCustoService) RemoverAlocacao(ctx context.Context, claims Claims, funcionarioID int64, inicio time.Time) error {
+	if err := s.authService.Authorize(ctx, claims, "centrocusto:update"); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteAlocacoes(funcionarioID, truncateDate(inicio)); err != nil {
+		return err
+	}
+	s.log(ctx, claims, 5, fmt.Sprintf("Alocação do funcionário %d a partir de %s removida", funcionarioID, inicio.Format("2006-01-02")))
+	return nil
+}
+
+// ListarAlocacoes lista o histórico de alocações do funcionário
+func (s *CentroCustoService) ListarAlocacoes(ctx context.Context, claims Claims, funcionarioID int64) ([]*entity.AlocacaoFuncionario, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	lista, err := s.repo.ListAlocacoes(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	if lista == nil {
+		lista = []*entity.AlocacaoFuncionario{}
+	}
+	return lista, nil
+}
+
+// alocacoesVigentes devolve a distribuição em vigor no dia: as alocações do início mais recente até ele
+func alocacoesVigentes(lista []*entity.AlocacaoFuncionario, dia time.Time) []*entity.AlocacaoFuncionario {
+	ref, inicio := chaveDia(dia), ""
+	for _, a := range lista {
+		if k := chaveDia(a.Inicio); k <= ref && k > inicio {
+			inicio = k
+		}
+	}
+	var vigentes []*entity.AlocacaoFuncionario
+	for _, a := range lista {
+		if inicio != "" && chaveDia(a.Inicio) == inicio {
+			vigentes = append(vigentes, a)
+		}
+	}
+	return vigentes
+}
+
+// ratearPagamento grava o rateio do pagamento pelas alocações vigentes no último dia do mês da folha.
+// A última parcela fica com a sobra do arredondamento; sem alocação, o pagamento fica sem rateio.
+func ratearPagamento(p *entity.Pagamento, mes, ano int) error {
+	lista, err := repository.ListAlocacoesByFuncionarioID(p.FuncionarioID)
+	if err != nil {
+		return err
+	}
+	vigentes := alocacoesVigentes(lista, time.Date(ano, time.Month(mes)+1, 0, 0, 0, 0, 0, time.Local))
+
+	rateio := make([]entity.RateioPagamento, 0, len(vigentes))
+	restoValor, restoFGTS := p.ValorFinal, p.FGTS
+	for i, a := range vigentes {
+		r := entity.RateioPagamento{
+			CentroCustoID:  a.CentroCustoID,
+			DepartamentoID: a.DepartamentoID,
+			Percentual:     a.Percentual,
+			Valor:          arredondar2(p.ValorFinal * a.Percentual / 100),
+			FGTS:           arredondar2(p.FGTS * a.Percentual / 100),
+		}
+		if i == len(vigentes)-1 {
+			r.Valor, r.FGTS = arredondar2(restoValor), arredondar2(restoFGTS)
+		}
+		restoValor -= r.Valor
+		restoFGTS -= r.FGTS
+		rateio = append(rateio, r)
+	}
+	if err := repository.SaveRateioPagamento(p.ID, rateio); err != nil {
+		return err
+	}
+	p.Rateio = rateio
+	return nil
+}
+
+// ResumoCentroCustoDTO é o custo da folha em um centro de custo; CentroCustoID nulo reúne os
+// pagamentos sem alocação
+type ResumoCentroCustoDTO struct {
+	CentroCustoID *int64  `json:"centro_custo_id"`
+	Codigo        string  `json:"codigo"`
+	Nome          string  `json:"nome"`
+	Funcionarios  int     `json:"funcionarios"`
+	Valor         float64 `json:"valor"` // soma das parcelas do valor final
+	FGTS          float64 `json:"fgts"`
+	Custo         float64 `json:"custo"` // valor + FGTS
+}
+
+// ResumoFolha soma os pagamentos de uma folha de salário por centro de custo
+func (s *CentroCustoService) ResumoFolha(ctx context.Context, claims Claims, folhaID int64) ([]*ResumoCentroCustoDTO, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	folha, err := repository.GetFolhaPagamentoByID(folhaID)
+	if err != nil {
+		return nil, err
+	}
+	if folha == nil {
+		return nil, nil
+	}
+	if folha.Tipo != "SALARIO" {
+		return nil, fmt.Errorf("o resumo por centro de custo vale só para a folha de salário")
+	}
+	pagamentos, err := repository.GetPagamentosByFolhaID(folhaID)
+	if err != nil {
+		return nil, err
+	}
+	centros, err := s.repo.ListCentros()
+	if err != nil {
+		return nil, err
+	}
+	porID := make(map[int64]*entity.CentroCusto, len(centros))
+	for _, c := range centros {
+		porID[c.ID] = c
+	}
+
+	resumo := map[int64]*ResumoCentroCustoDTO{}
+	funcionarios := map[int64]map[int64]bool{}
+	somar := func(centroID int64, funcionarioID int64, valor, fgts float64) {
+		r, ok := resumo[centroID]
+		if !ok {
+			r = &ResumoCentroCustoDTO{Nome: "Sem centro de custo"}
+			if c, ok := porID[centroID]; ok {
+				id := c.ID
+				r.CentroCustoID, r.Codigo, r.Nome = &id, c.Codigo, c.Nome
+			}
+			resumo[centroID] = r
+			funcionarios[centroID] = map[int64]bool{}
+		}
+		r.Valor = arredondar2(r.Valor + valor)
+		r.FGTS = arredondar2(r.FGTS + fgts)
+		r.Custo = arredondar2(r.Valor + r.FGTS)
+		funcionarios[centroID][funcionarioID] = true
+		r.Funcionarios = len(funcionarios[centroID])
+	}
+	for _, p := range pagamentos {
+		if len(p.Rateio) == 0 {
+			somar(0, p.FuncionarioID, p.ValorFinal, p.FGTS)
+			continue
+		}
+		for _, r := range p.Rateio {
+			somar(r.CentroCustoID, p.FuncionarioID, r.Valor, r.FGTS)
+		}
+	}
+
+	lista := make([]*ResumoCentroCustoDTO, 0, len(resumo))
+	for _, r := range resumo {
+		lista = append(lista, r)
+	}
+	// sem centro de custo por último
+	sort.Slice(lista, func(i, j int) bool {
+		if (lista[i].CentroCustoID == nil) != (lista[j].CentroCustoID == nil) {
+			return lista[j].CentroCustoID == nil
+		}
+		return lista[i].Codigo < lista[j].Codigo
+	})
+	return lista, nil
+}
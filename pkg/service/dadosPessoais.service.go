@@ -0,0 +1,331 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// PrazoLegalRetencaoAnos é o mínimo, contado do último desligamento, que os dados pessoais do
+// ex-funcionário ficam guardados: cobre a prescrição dos créditos trabalhistas e do FGTS (CF art. 7º, XXIX)
+const PrazoLegalRetencaoAnos = 5
+
+var (
+	ErrPessoaAnonimizada     = errors.New("os dados pessoais desta pessoa já foram anonimizados")
+	ErrPessoaComVinculoAtivo = errors.New("a pessoa tem vínculo ativo; desligue o funcionário antes de anonimizar")
+)
+
+// RetencaoConfig define a política de guarda dos dados de ex-funcionários
+type RetencaoConfig struct {
+	AnosAdicionais int  // anos de guarda além do prazo legal
+	Automatica     bool // o worker anonimiza sozinho os titulares com prazo vencido
+}
+
+// Anos devolve o prazo total de guarda, nunca menor que o legal
+func (c RetencaoConfig) Anos() int {
+	if c.AnosAdicionais < 0 {
+		return PrazoLegalRetencaoAnos
+	}
+	return PrazoLegalRetencaoAnos + c.AnosAdicionais
+}
+
+type DadosPessoaisService struct {
+	authService *AuthService
+	logRepo     LogRepository
+	retencao    RetencaoConfig
+}
+
+func NewDadosPessoaisService(auth *AuthService, logRepo LogRepository, retencao RetencaoConfig) *DadosPessoaisService {
+	return &DadosPessoaisService{
+		authService: auth,
+		logRepo:     logRepo,
+		retencao:    retencao,
+	}
+}
+
+// Retencao devolve a política de guarda configurada
+func (s *DadosPessoaisService) Retencao() RetencaoConfig {
+	return s.retencao
+}
+
+// DadosPessoaisExportados é o conteúdo do dados-pessoais.json entregue ao titular (LGPD art. 18, II)
+type DadosPessoaisExportados struct {
+	GeradoEm time.Time          `json:"gerado_em"`
+	Pessoa   *entity.Pessoa     `json:"pessoa"`
+	Vinculos []VinculoExportado `json:"vinculos"`
+}
+
+// VinculoExportado reúne tudo o que foi registrado num contrato de trabalho do titular
+type VinculoExportado struct {
+	Funcionario      *entity.Funcionario            `json:"funcionario"`
+	Cargos           []*entity.FuncionarioCargo     `json:"cargos"`
+	Salarios         []*entity.Salario              `json:"salarios"`
+	SalariosReais    []*entity.SalarioReal          `json:"salarios_reais"`
+	Faltas           []*entity.Falta                `json:"faltas"`
+	Ferias           []*entity.Ferias               `json:"ferias"`
+	Descansos        []*entity.Descanso             `json:"descansos"`
+	Vales            []entity.Vale                  `json:"vales"`
+	Pagamentos       []entity.Pagamento             `json:"pagamentos"`
+	PagamentosFerias []*entity.PagamentoFerias      `json:"pagamentos_ferias"`
+	BancoHoras       []*entity.BancoHorasLancamento `json:"banco_horas"`
+	Ponto            []*entity.PontoDia             `json:"ponto"`
+	Documentos       []DocumentoExportado           `json:"documentos"`
+}
+
+// DocumentoExportado aponta o arquivo do documento dentro do ZIP; Arquivo vazio quando o arquivo
+// não existe mais no disco
+type DocumentoExportado struct {
+	ID      int64  `json:"id"`
+	Nome    string `json:"nome"`
+	Arquivo string `json:"arquivo,omitempty"`
+}
+
+// ExportarDadosPessoais monta o ZIP com todos os dados da pessoa: dados-pessoais.json e os arquivos
+// dos documentos em documentos/<funcionarioID>/
+func (s *DadosPessoaisService) ExportarDadosPessoais(ctx context.Context, claims Claims, pessoaID int64) ([]byte, error) {
+	if err := s.authService.Authorize(ctx, claims, "lgpd:export"); err != nil {
+		return nil, err
+	}
+	if pessoaID <= 0 {
+		return nil, fmt.Errorf("ID inválido")
+	}
+	p, err := repository.GetPessoaByID(pessoaID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, ErrPessoaNaoEncontrada
+	}
+	funcionarios, err := repository.ListFuncionariosByPessoaID(pessoaID)
+	if err != nil {
+		return nil, err
+	}
+
+	agora := s.authService.clock()
+	dados := DadosPessoaisExportados{GeradoEm: agora, Pessoa: p, Vinculos: make([]VinculoExportado, 0, len(funcionarios))}
+	arquivos := map[string]string{} // caminho no ZIP -> caminho no disco
+	for _, f := range funcionarios {
+		v, err := vinculoExportado(ctx, f, agora, arquivos)
+		if err != nil {
+			return nil, err
+		}
+		dados.Vinculos = append(dados.Vinculos, v)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("dados-pessoais.json")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao montar ZIP: %w", err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(dados); err != nil {
+		return nil, fmt.Errorf("erro ao serializar dados pessoais: %w", err)
+	}
+	for _, v := range dados.Vinculos {
+		for _, d := range v.Documentos {
+			if d.Arquivo == "" {
+				continue
+			}
+			conteudo, err := os.ReadFile(arquivos[d.Arquivo])
+			if err != nil {
+				return nil, fmt.Errorf("erro ao ler documento %d: %w", d.ID, err)
+			}
+			w, err := zw.Create(d.Arquivo)
+			if err != nil {
+				return nil, fmt.Errorf("erro ao montar ZIP: %w", err)
+			}
+			if _, err := w.Write(conteudo); err != nil {
+				return nil, fmt.Errorf("erro ao montar ZIP: %w", err)
+			}
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("erro ao montar ZIP: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// vinculoExportado carrega os registros de um vínculo; os documentos encontrados no disco entram em arquivos
+func vinculoExportado(ctx context.Context, f *entity.Funcionario, agora time.Time, arquivos map[string]string) (VinculoExportado, error) {
+	v := VinculoExportado{Funcionario: f}
+	var err error
+	if v.Cargos, err = repository.ListCargosByFuncionarioID(f.ID); err != nil {
+		return v, err
+	}
+	if v.Salarios, err = repository.GetSalariosByFuncionarioID(f.ID); err != nil {
+		return v, err
+	}
+	if v.SalariosReais, err = repository.GetSalariosReaisByFuncionarioID(f.ID); err != nil {
+		return v, err
+	}
+	if v.Faltas, err = repository.GetFaltasByFuncionarioID(f.ID); err != nil {
+		return v, err
+	}
+	if v.Ferias, err = repository.GetFeriasByFuncionarioID(f.ID); err != nil {
+		return v, err
+	}
+	if v.Descansos, err = repository.GetDescansosByFuncionarioID(f.ID); err != nil {
+		return v, err
+	}
+	if v.Vales, err = repository.GetValesByFuncionarioID(f.ID); err != nil {
+		return v, err
+	}
+	if v.Pagamentos, err = repository.ListPagamentosByFuncionarioID(f.ID); err != nil {
+		return v, err
+	}
+	if v.PagamentosFerias, err = repository.ListPagamentosFeriasByFuncionarioID(f.ID); err != nil {
+		return v, err
+	}
+	if v.BancoHoras, err = repository.ListLancamentosBancoHorasByFuncionario(f.ID); err != nil {
+		return v, err
+	}
+	fim := agora
+	if f.Demissao != nil {
+		fim = *f.Demissao
+	}
+	if v.Ponto, err = repository.ListPontoDiasByFuncionarioPeriodo(f.ID, f.Admissao, fim); err != nil {
+		return v, err
+	}
+
+	docs, err := repository.GetDocumentosByFuncionarioID(ctx, f.ID)
+	if err != nil {
+		return v, err
+	}
+	v.Documentos = make([]DocumentoExportado, 0, len(docs))
+	for _, d := range docs {
+		de := DocumentoExportado{ID: d.ID, Nome: filepath.Base(d.Caminho)}
+		disco := filepath.Join(getBaseDir(), d.Caminho)
+		if _, err := os.Stat(disco); err == nil {
+			de.Arquivo = path.Join("documentos", fmt.Sprintf("%d", f.ID), fmt.Sprintf("%d_%s", d.ID, de.Nome))
+			arquivos[de.Arquivo] = disco
+		}
+		v.Documentos = append(v.Documentos, de)
+	}
+	return v, nil
+}
+
+// AnonimizarPessoa apaga os dados pessoais de um ex-funcionário (ou de pessoa sem vínculo), mantendo
+// salários, férias, vales e pagamentos para os totais das folhas. Os arquivos dos documentos são
+// removidos do disco depois que o banco é gravado.
+func (s *DadosPessoaisService) AnonimizarPessoa(ctx context.Context, claims Claims, pessoaID int64) (*entity.Anonimizacao, error) {
+	if err := s.authService.Authorize(ctx, claims, "lgpd:anonimizar"); err != nil {
+		return nil, err
+	}
+	a, err := s.anonimizar(pessoaID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  5, // DELETAR
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Anonimizou dados pessoais da pessoa ID=%d", pessoaID),
+	})
+	return a, nil
+}
+
+func (s *DadosPessoaisService) anonimizar(pessoaID int64) (*entity.Anonimizacao, error) {
+	if pessoaID <= 0 {
+		return nil, fmt.Errorf("ID inválido")
+	}
+	p, err := repository.GetPessoaByID(pessoaID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, ErrPessoaNaoEncontrada
+	}
+	if p.AnonimizadoEm != nil {
+		return nil, ErrPessoaAnonimizada
+	}
+	funcionarios, err := repository.ListFuncionariosByPessoaID(pessoaID)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range funcionarios {
+		if f.Ativo {
+			return nil, ErrPessoaComVinculoAtivo
+		}
+	}
+
+	quando := s.authService.clock()
+	caminhos, err := repository.AnonimizarPessoa(pessoaID, quando)
+	if errors.Is(err, repository.ErrPessoaJaAnonimizada) {
+		return nil, ErrPessoaAnonimizada
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range caminhos {
+		if err := os.Remove(filepath.Join(getBaseDir(), c)); err != nil && !os.IsNotExist(err) {
+			log.Printf("erro ao remover arquivo de documento anonimizado %s: %v", c, err)
+		}
+	}
+	return &entity.Anonimizacao{
+		PessoaID:            pessoaID,
+		Vinculos:            len(funcionarios),
+		DocumentosRemovidos: len(caminhos),
+		AnonimizadoEm:       quando,
+	}, nil
+}
+
+// corteRetencao é a data até a qual o último desligamento deve ter ocorrido para o prazo estar vencido
+func (s *DadosPessoaisService) corteRetencao(hoje time.Time) time.Time {
+	return hoje.AddDate(-s.retencao.Anos(), 0, 0)
+}
+
+// RetencoesVencidas lista os ex-funcionários cujo prazo de guarda já passou em hoje
+func (s *DadosPessoaisService) RetencoesVencidas(ctx context.Context, claims Claims, hoje time.Time) ([]*entity.RetencaoVencida, error) {
+	if err := s.authService.Authorize(ctx, claims, "lgpd:retencao"); err != nil {
+		return nil, err
+	}
+	return repository.ListRetencoesVencidas(s.corteRetencao(hoje))
+}
+
+// AplicarRetencao anonimiza todos os ex-funcionários com prazo de guarda vencido. Uma falha não
+// interrompe os demais; o primeiro erro é devolvido junto com as anonimizações feitas.
+func (s *DadosPessoaisService) AplicarRetencao(ctx context.Context, claims Claims, hoje time.Time) ([]*entity.Anonimizacao, error) {
+	if err := s.authService.Authorize(ctx, claims, "lgpd:retencao"); err != nil {
+		return nil, err
+	}
+	vencidas, err := repository.ListRetencoesVencidas(s.corteRetencao(hoje))
+	if err != nil {
+		return nil, err
+	}
+
+	feitas := make([]*entity.Anonimizacao, 0, len(vencidas))
+	var primeiroErro error
+	for _, r := range vencidas {
+		a, err := s.anonimizar(r.PessoaID)
+		if err != nil {
+			if primeiroErro == nil {
+				primeiroErro = fmt.Errorf("erro ao anonimizar pessoa ID=%d: %w", r.PessoaID, err)
+			}
+			continue
+		}
+		feitas = append(feitas, a)
+	}
+
+	if len(feitas) > 0 {
+		_, _ = s.logRepo.Create(ctx, LogEntry{
+			EventoID:  5, // DELETAR
+			UsuarioID: &claims.UserID,
+			Quando:    s.authService.clock(),
+			Detalhe:   fmt.Sprintf("Retenção LGPD: anonimizou %d ex-funcionário(s) desligado(s) há mais de %d anos", len(feitas), s.retencao.Anos()),
+		})
+	}
+	return feitas, primeiroErro
+}
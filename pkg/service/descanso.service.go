@@ -66,15 +66,23 @@ func (s *DescansoService) CreateDescanso(ctx context.Context, claims Claims, d *
 	d.Aprovado = false
 	d.Pago = false
 
+	conflitos, err := aplicarLimitesAusencia(ferias.FuncionarioID, d.Inicio, d.Fim)
+	if err != nil {
+		return err
+	}
+	d.Conflitos = conflitos
+
 	if err := s.repo.Create(d); err != nil {
 		return fmt.Errorf("erro ao criar descanso: %w", err)
 	}
+	avisoErr := registrarAvisoConflito(d.ID, ferias.FuncionarioID, conflitos)
 
 	_, _ = s.logRepo.Create(ctx, LogEntry{
 		EventoID:  3,
 		UsuarioID: &claims.UserID,
 		Quando:    time.Now(),
-		Detalhe:   fmt.Sprintf("Descanso criado ID=%d FeriasID=%d Dias=%d Valor=%.2f", d.ID, d.FeriasID, diasDescanso, d.Valor),
+		Detalhe: fmt.Sprintf("Descanso criado ID=%d FeriasID=%d Dias=%d Valor=%.2f", d.ID, d.FeriasID, diasDescanso, d.Valor) +
+			detalheConflitos(conflitos, avisoErr),
 	})
 	return nil
 }
@@ -93,15 +101,14 @@ func (s *DescansoService) AprovarDescanso(ctx context.Context, claims Claims, id
 	if descanso.Aprovado {
 		return fmt.Errorf("descanso já está aprovado")
 	}
-	// aprova
-	descanso.Aprovado = true
-	if err := s.repo.Update(descanso); err != nil {
-		return fmt.Errorf("erro ao aprovar descanso: %w", err)
+	// pagamento de férias, devido até 2 dias úteis antes do início
+	pagamento, err := calcularPagamentoFerias(descanso)
+	if err != nil {
+		return fmt.Errorf("erro ao gerar pagamento de férias: %w", err)
 	}
-
-	// **consome** os dias do período associado
-	if err := repository.ConsumirDiasFerias(descanso.FeriasID, descanso.DuracaoEmDias()); err != nil {
-		return fmt.Errorf("erro ao consumir dias de férias: %w", err)
+	// aprova, **consome** os dias do período associado e grava o pagamento juntos: se um falha, nada muda
+	if err := repository.AprovarDescanso(descanso, pagamento); err != nil {
+		return err
 	}
 
 	_, _ = s.logRepo.Create(ctx, LogEntry{
@@ -139,6 +146,12 @@ func (s *DescansoService) MarcarComoPago(ctx context.Context, claims Claims, id
 		}
 	}
 
+	// Mantém o registro de pagamento de férias em sincronia
+	if p, perr := repository.GetPagamentoFeriasByDescansoID(id); perr == nil && p != nil && !p.Pago {
+		_ = repository.MarcarPagamentoFeriasComoPago(p.ID, time.Now())
+		_ = repository.DeleteAvisoByTypeAndRef("PAGAMENTO_FERIAS_PENDENTE", p.ID)
+	}
+
 	_, _ = s.logRepo.Create(ctx, LogEntry{
 		EventoID:  4,
 		UsuarioID: &claims.UserID,
@@ -237,8 +250,44 @@ func (s *DescansoService) CreateDescansoAuto(ctx context.Context, claims Claims,
 		return fmt.Errorf("não há períodos disponíveis para consumo")
 	}
 
+	conflitos, err := aplicarLimitesAusencia(funcionarioID, inicio, fim)
+	if err != nil {
+		return err
+	}
+
+	criados, restantes, err := alocarDescansosFIFO(s.repo.Create, periodos, inicio, totalDias, false)
+	var avisoErr error
+	if len(criados) > 0 {
+		avisoErr = registrarAvisoConflito(criados[0].ID, funcionarioID, conflitos)
+	}
+	for i, d := range criados {
+		detalhe := fmt.Sprintf("Descanso(part) criado ID=%d FeriasID=%d Dias=%d", d.ID, d.FeriasID, d.DuracaoEmDias())
+		if i == 0 {
+			detalhe += detalheConflitos(conflitos, avisoErr)
+		}
+		_, _ = s.logRepo.Create(ctx, LogEntry{
+			EventoID:  3,
+			UsuarioID: &claims.UserID,
+			Quando:    time.Now(),
+			Detalhe:   detalhe,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if restantes > 0 {
+		return fmt.Errorf("saldo insuficiente durante a alocação (faltaram %d dias)", restantes)
+	}
+	return nil
+}
+
+// alocarDescansosFIFO distribui totalDias a partir de inicio pelos períodos informados (do mais antigo
+// para o mais novo), criando um descanso por período e consumindo os dias de cada um.
+// Retorna os descansos criados e quantos dias ficaram sem saldo.
+func alocarDescansosFIFO(create func(d *entity.Descanso) error, periodos []*entity.Ferias, inicio time.Time, totalDias int, aprovado bool) ([]*entity.Descanso, int, error) {
 	restantes := totalDias
 	cursorData := inicio
+	criados := make([]*entity.Descanso, 0, len(periodos))
 
 	for _, f := range periodos {
 		if restantes <= 0 {
@@ -252,9 +301,6 @@ func (s *DescansoService) CreateDescansoAuto(ctx context.Context, claims Claims,
 			consome = restantes
 		}
 
-		if f.Dias <= 0 {
-			return fmt.Errorf("período de férias com dias inválidos (id=%d)", f.ID)
-		}
 		valorBaseDia := f.Valor / float64(f.Dias)
 		tercoDia := f.Terco / float64(f.Dias)
 
@@ -266,27 +312,18 @@ func (s *DescansoService) CreateDescansoAuto(ctx context.Context, claims Claims,
 			Inicio:   parcInicio,
 			Fim:      parcFim,
 			Valor:    (valorBaseDia + tercoDia) * float64(consome),
-			Aprovado: false,
+			Aprovado: aprovado,
 			Pago:     false,
 		}
-		if err := s.repo.Create(d); err != nil {
-			return fmt.Errorf("erro ao criar descanso (parte): %w", err)
+		if err := create(d); err != nil {
+			return criados, restantes, fmt.Errorf("erro ao criar descanso (parte): %w", err)
 		}
 		if err := repository.ConsumirDiasFerias(f.ID, consome); err != nil {
-			return fmt.Errorf("erro ao consumir dias do período de férias: %w", err)
+			return criados, restantes, fmt.Errorf("erro ao consumir dias do período de férias: %w", err)
 		}
+		criados = append(criados, d)
 		restantes -= consome
 		cursorData = parcFim.Add(24 * time.Hour)
-
-		_, _ = s.logRepo.Create(ctx, LogEntry{
-			EventoID:  3,
-			UsuarioID: &claims.UserID,
-			Quando:    time.Now(),
-			Detalhe:   fmt.Sprintf("Descanso(part) criado ID=%d FeriasID=%d Dias=%d", d.ID, f.ID, consome),
-		})
 	}
-	if restantes > 0 {
-		return fmt.Errorf("saldo insuficiente durante a alocação (faltaram %d dias)", restantes)
-	}
-	return nil
+	return criados, restantes, nil
 }
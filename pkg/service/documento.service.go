@@ -2,6 +2,7 @@ package service
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/utils/consulta"
 	"context"
 	"fmt"
 	"io"
@@ -18,6 +19,7 @@ type DocumentoRepository interface {
 	GetByID(ctx context.Context, id int64) (*entity.Documento, error) // novo
 	List(ctx context.Context) ([]*entity.Documento, error)
 	Delete(ctx context.Context, id int64) error
+	Buscar(ctx context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Documento], error)
 }
 
 type DocumentoService struct {
@@ -120,6 +122,14 @@ func (s *DocumentoService) ListDocumentos(ctx context.Context, claims Claims) ([
 	return s.docRepo.List(ctx)
 }
 
+// BuscarDocumentos retorna uma página dos documentos, com filtros e ordenação
+func (s *DocumentoService) BuscarDocumentos(ctx context.Context, claims Claims, q consulta.Consulta) (consulta.Pagina[*entity.Documento], error) {
+	if err := s.authService.Authorize(ctx, claims, "documento:list"); err != nil {
+		return consulta.Pagina[*entity.Documento]{}, err
+	}
+	return s.docRepo.Buscar(ctx, q)
+}
+
 // GetDocumentoPath retorna o caminho absoluto de um documento para download
 func (s *DocumentoService) GetDocumentoPath(ctx context.Context, claims Claims, id int64) (string, error) {
 	if err := s.authService.Authorize(ctx, claims, "documento:list"); err != nil {
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrSecaoDossieInvalida indica um valor desconhecido em ?incluir=
+var ErrSecaoDossieInvalida = errors.New("seção do dossiê inválida")
+
+// SecoesDossie interpreta a lista "ferias,vales" de ?incluir=. "todos" traz todas as seções e a
+// lista vazia, nenhuma (só o vínculo e os totais). A ordem devolvida é a de entity.SecoesDossie.
+func SecoesDossie(incluir string) ([]string, error) {
+	pedidas := map[string]bool{}
+	for _, s := range strings.Split(incluir, ",") {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s != "" {
+			pedidas[s] = true
+		}
+	}
+	if pedidas["todos"] {
+		return append([]string(nil), entity.SecoesDossie...), nil
+	}
+
+	secoes := []string{}
+	for _, s := range entity.SecoesDossie {
+		if pedidas[s] {
+			secoes = append(secoes, s)
+			delete(pedidas, s)
+		}
+	}
+	if len(pedidas) > 0 {
+		invalidas := make([]string, 0, len(pedidas))
+		for s := range pedidas {
+			invalidas = append(invalidas, s)
+		}
+		sort.Strings(invalidas)
+		return nil, fmt.Errorf("%w: %s (use %s ou todos)", ErrSecaoDossieInvalida,
+			strings.Join(invalidas, ", "), strings.Join(entity.SecoesDossie, ", "))
+	}
+	return secoes, nil
+}
+
+// DossieFuncionario monta numa chamada o perfil do funcionário: o vínculo, as seções pedidas (uma
+// consulta por seção) e os totais calculados na data de referência. Devolve nil se o funcionário não existe.
+func (s *FuncionarioService) DossieFuncionario(ctx context.Context, claims Claims, id int64, secoes []string, hoje time.Time) (*entity.DossieFuncionario, error) {
+	if err := s.authService.Authorize(ctx, claims, "funcionario:read"); err != nil {
+		return nil, err
+	}
+	if id <= 0 {
+		return nil, fmt.Errorf("ID inválido")
+	}
+	f, err := repository.GetFuncionarioSemRelacionamentos(id)
+	if err != nil || f == nil {
+		return nil, err
+	}
+
+	d := &entity.DossieFuncionario{Funcionario: f, Incluido: secoes}
+	for _, secao := range secoes {
+		if err := carregarSecaoDossie(ctx, d, secao); err != nil {
+			return nil, fmt.Errorf("erro ao carregar %s do dossiê: %w", secao, err)
+		}
+	}
+
+	ate := hoje
+	if f.Demissao != nil && f.Demissao.Before(hoje) {
+		ate = *f.Demissao
+	}
+	d.TempoDeCasa = calcularTempoDeCasa(f.Admissao, ate)
+	if d.SaldoFerias, err = repository.GetSaldoFerias(f.ID); err != nil {
+		return nil, err
+	}
+	if d.ValesEmAberto, err = repository.GetValesEmAberto(f.ID); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// carregarSecaoDossie preenche uma seção do dossiê
+func carregarSecaoDossie(ctx context.Context, d *entity.DossieFuncionario, secao string) error {
+	f := d.Funcionario
+	var err error
+	switch secao {
+	case entity.DossiePessoa:
+		d.Pessoa, err = repository.GetPessoaByID(f.PessoaID)
+	case entity.DossieCargos:
+		d.Cargos, err = repository.ListCargosByFuncionarioID(f.ID)
+	case entity.DossieSalarios:
+		if f.SalariosRegistrados, err = repository.GetSalariosByFuncionarioID(f.ID); err == nil {
+			f.SalarioRegistradoAtual, err = repository.GetSalarioAtual(f.ID)
+		}
+	case entity.DossieSalariosReais:
+		if f.SalariosReais, err = repository.GetSalariosReaisByFuncionarioID(f.ID); err == nil {
+			f.SalarioRealAtual, err = repository.GetSalarioRealAtual(f.ID)
+		}
+	case entity.DossieDocumentos:
+		var docs []*entity.Documento
+		docs, err = repository.GetDocumentosByFuncionarioID(ctx, f.ID)
+		for _, doc := range docs {
+			f.Documentos = append(f.Documentos, *doc)
+		}
+	case entity.DossieFerias:
+		// os descansos vêm numa consulta só para todos os períodos
+		var ferias []*entity.Ferias
+		ferias, err = repository.GetFeriasByFuncionarioID(f.ID)
+		for _, fr := range ferias {
+			f.Ferias = append(f.Ferias, *fr)
+		}
+	case entity.DossieFaltas:
+		f.Faltas, err = repository.GetFaltasByFuncionarioID(f.ID)
+	case entity.DossiePagamentos:
+		f.Pagamentos, err = repository.ListPagamentosByFuncionarioID(f.ID)
+	case entity.DossieVales:
+		f.Vales, err = repository.GetValesByFuncionarioID(f.ID)
+	default:
+		err = fmt.Errorf("%w: %q", ErrSecaoDossieInvalida, secao)
+	}
+	return err
+}
+
+// calcularTempoDeCasa conta anos, meses e dias completos de admissao até ate (datas, sem horário)
+func calcularTempoDeCasa(admissao, ate time.Time) entity.TempoDeCasa {
+	inicio := time.Date(admissao.Year(), admissao.Month(), admissao.Day(), 0, 0, 0, 0, time.UTC)
+	fim := time.Date(ate.Year(), ate.Month(), ate.Day(), 0, 0, 0, 0, time.UTC)
+	t := entity.TempoDeCasa{Ate: fim}
+	if fim.Before(inicio) {
+		return t
+	}
+
+	meses := (fim.Year()-inicio.Year())*12 + int(fim.Month()) - int(inicio.Month())
+	if somarMeses(inicio, meses).After(fim) {
+		meses--
+	}
+	t.Anos, t.Meses = meses/12, meses%12
+	t.Dias = int(fim.Sub(somarMeses(inicio, meses)).Hours() / 24)
+	t.TotalDias = int(fim.Sub(inicio).Hours() / 24)
+	return t
+}
+
+// somarMeses soma meses à data limitando o dia ao fim do mês (31/01 + 1 mês = 28/02)
+func somarMeses(t time.Time, meses int) time.Time {
+	primeiro := time.Date(t.Year(), t.Month()+time.Month(meses), 1, 0, 0, 0, 0, t.Location())
+	ultimoDia := primeiro.AddDate(0, 1, -1).Day()
+	dia := t.Day()
+	if dia > ultimoDia {
+		dia = ultimoDia
+	}
+	return time.Date(primeiro.Year(), primeiro.Month(), dia, 0, 0, 0, 0, t.Location())
+}
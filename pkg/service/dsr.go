@@ -0,0 +1,57 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"time"
+)
+
+// Descanso semanal remunerado (Lei 605/1949, art. 6º): quem falta sem justificativa durante a
+// semana perde a remuneração do domingo e dos feriados daquela semana. As semanas vão de
+// segunda a domingo. Lançamentos MENSAL não têm o dia exato e por isso não geram perda de DSR.
+
+// inicioSemana devolve a segunda-feira da semana do dia
+func inicioSemana(dia time.Time) time.Time {
+	dia = truncateDate(dia)
+	desloc := (int(dia.Weekday()) + 6) % 7 // segunda = 0 ... domingo = 6
+	return dia.AddDate(0, 0, -desloc)
+}
+
+// diasRepousoPerdidos conta, nas semanas com falta injustificada no mês/ano, o domingo e os
+// feriados de segunda a sábado. Devolve também quantas semanas foram afetadas.
+func diasRepousoPerdidos(faltas []*entity.Falta, mes, ano int, feriado func(time.Time) bool) (semanas, dias int) {
+	afetadas := map[string]time.Time{}
+	for _, f := range faltas {
+		if f.Tipo != entity.FaltaTipoInjustificada {
+			continue
+		}
+		qtd := f.Quantidade
+		if qtd < 1 {
+			qtd = 1
+		}
+		for i := 0; i < qtd; i++ {
+			dia := truncateDate(f.Mes).AddDate(0, 0, i)
+			if int(dia.Month()) != mes || dia.Year() != ano {
+				continue
+			}
+			seg := inicioSemana(dia)
+			afetadas[seg.Format("2006-01-02")] = seg
+		}
+	}
+
+	for _, seg := range afetadas {
+		semanas++
+		dias++ // domingo
+		for i := 0; i < 6; i++ {
+			if feriado != nil && feriado(seg.AddDate(0, 0, i)) {
+				dias++
+			}
+		}
+	}
+	return semanas, dias
+}
+
+// calcularPerdaDSR valora os dias de repouso perdidos a 1/30 do salário
+func calcularPerdaDSR(salarioBase float64, faltas []*entity.Falta, mes, ano int, feriado func(time.Time) bool) float64 {
+	_, dias := diasRepousoPerdidos(faltas, mes, ano, feriado)
+	return arredondar2(salarioBase / 30 * float64(dias))
+}
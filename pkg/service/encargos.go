@@ -0,0 +1,77 @@
+package service
+
+import "math"
+
+// Tabelas de encargos vigentes em 2025 (INSS: Portaria Interministerial MPS/MF nº 6/2025;
+// IRRF: Lei nº 15.191/2025). Atualize aqui quando houver reajuste.
+
+type faixaINSS struct {
+	teto     float64
+	aliquota float64
+}
+
+var tabelaINSS = []faixaINSS{
+	{teto: 1518.00, aliquota: 0.075},
+	{teto: 2793.88, aliquota: 0.09},
+	{teto: 4190.83, aliquota: 0.12},
+	{teto: 8157.41, aliquota: 0.14},
+}
+
+type faixaIRRF struct {
+	teto     float64 // 0 = sem limite
+	aliquota float64
+	deducao  float64
+}
+
+var tabelaIRRF = []faixaIRRF{
+	{teto: 2428.80, aliquota: 0, deducao: 0},
+	{teto: 2826.65, aliquota: 0.075, deducao: 182.16},
+	{teto: 3751.05, aliquota: 0.15, deducao: 394.16},
+	{teto: 4664.68, aliquota: 0.225, deducao: 675.49},
+	{teto: 0, aliquota: 0.275, deducao: 908.73},
+}
+
+// descontoSimplificadoIRRF substitui as deduções legais quando for mais vantajoso
+const descontoSimplificadoIRRF = 607.20
+
+// calcularINSS aplica a tabela progressiva do INSS sobre a base (limitada ao teto)
+func calcularINSS(base float64) float64 {
+	if base <= 0 {
+		return 0
+	}
+	total := 0.0
+	anterior := 0.0
+	for _, f := range tabelaINSS {
+		if base <= anterior {
+			break
+		}
+		faixa := math.Min(base, f.teto) - anterior
+		total += faixa * f.aliquota
+		anterior = f.teto
+	}
+	return arredondar2(total)
+}
+
+// calcularIRRF aplica a tabela mensal do IRRF após deduzir o INSS
+// (ou o desconto simplificado, se maior). Dependentes não são considerados.
+func calcularIRRF(base, inss float64) float64 {
+	deducao := math.Max(inss, descontoSimplificadoIRRF)
+	tributavel := base - deducao
+	if tributavel <= 0 {
+		return 0
+	}
+	for _, f := range tabelaIRRF {
+		if f.teto == 0 || tributavel <= f.teto {
+			imposto := tributavel*f.aliquota - f.deducao
+			if imposto < 0 {
+				return 0
+			}
+			return arredondar2(imposto)
+		}
+	}
+	return 0
+}
+
+func arredondar2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/documentos"
+	"AutoGRH/pkg/utils/planilha"
+	"fmt"
+	"path/filepath"
+	"strconv"
+)
+
+// Tabelas das exportações em planilha (?formato=csv|xlsx nas listagens). Os cabeçalhos são os que
+// aparecem para o usuário; funcionários saem pelo nome e CPF da pessoa, não pelo ID.
+
+var (
+	colunaFuncionario = planilha.Coluna{Titulo: "Funcionário"}
+	colunaCPF         = planilha.Coluna{Titulo: "CPF"}
+)
+
+// identificar busca nome e CPF de todos os funcionários da lista numa consulta
+func identificar[T any](lista []T, funcionarioID func(T) int64) (map[int64]repository.IdentificacaoFuncionario, error) {
+	ids := make([]int64, 0, len(lista))
+	for _, item := range lista {
+		ids = append(ids, funcionarioID(item))
+	}
+	nomes, err := repository.IdentificarFuncionarios(ids)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao montar exportação: %w", err)
+	}
+	return nomes, nil
+}
+
+// nomeECPF devolve as duas primeiras células das linhas que citam um funcionário
+func nomeECPF(nomes map[int64]repository.IdentificacaoFuncionario, funcionarioID int64) (interface{}, interface{}) {
+	id, ok := nomes[funcionarioID]
+	if !ok {
+		return "#" + strconv.FormatInt(funcionarioID, 10), nil
+	}
+	return id.Nome, documentos.FormatarCPF(id.CPF)
+}
+
+// TabelaFuncionarios monta a exportação da listagem de funcionários
+func TabelaFuncionarios(lista []*entity.Funcionario) (*planilha.Tabela, error) {
+	nomes, err := identificar(lista, func(f *entity.Funcionario) int64 { return f.ID })
+	if err != nil {
+		return nil, err
+	}
+	t := planilha.NovaTabela("Funcionários",
+		planilha.Coluna{Titulo: "Nome"},
+		colunaCPF,
+		planilha.Coluna{Titulo: "PIS"},
+		planilha.Coluna{Titulo: "Cargo"},
+		planilha.Coluna{Titulo: "Tipo de contrato"},
+		planilha.Coluna{Titulo: "Admissão", Tipo: planilha.Data},
+		planilha.Coluna{Titulo: "Demissão", Tipo: planilha.Data},
+		planilha.Coluna{Titulo: "Salário inicial", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "Ativo", Tipo: planilha.SimNao},
+	)
+	for _, f := range lista {
+		nome, cpf := nomeECPF(nomes, f.ID)
+		t.Adicionar(nome, cpf, documentos.FormatarPIS(f.PIS), f.Cargo, f.TipoContrato,
+			f.Admissao, f.Demissao, f.SalarioInicial, f.Ativo)
+	}
+	return t, nil
+}
+
+// TabelaPessoas monta a exportação da listagem de pessoas
+func TabelaPessoas(lista []*entity.Pessoa) (*planilha.Tabela, error) {
+	t := planilha.NovaTabela("Pessoas",
+		planilha.Coluna{Titulo: "Nome"},
+		planilha.Coluna{Titulo: "Nome social"},
+		colunaCPF,
+		planilha.Coluna{Titulo: "RG"},
+		planilha.Coluna{Titulo: "Cidade"},
+		planilha.Coluna{Titulo: "UF"},
+		planilha.Coluna{Titulo: "Contato principal"},
+	)
+	for _, p := range lista {
+		var cidade, uf, contato string
+		if p.Endereco != nil {
+			cidade, uf = p.Endereco.Cidade, p.Endereco.UF
+		}
+		for i, c := range p.Contatos {
+			if i == 0 || c.Principal {
+				contato = c.Valor
+			}
+			if c.Principal {
+				break
+			}
+		}
+		t.Adicionar(p.Nome, p.NomeSocial, documentos.FormatarCPF(p.CPF), p.RG, cidade, uf, contato)
+	}
+	return t, nil
+}
+
+// TabelaFaltas monta a exportação da listagem de faltas
+func TabelaFaltas(lista []*entity.Falta) (*planilha.Tabela, error) {
+	nomes, err := identificar(lista, func(f *entity.Falta) int64 { return f.FuncionarioID })
+	if err != nil {
+		return nil, err
+	}
+	t := planilha.NovaTabela("Faltas",
+		colunaFuncionario,
+		colunaCPF,
+		planilha.Coluna{Titulo: "Tipo"},
+		planilha.Coluna{Titulo: "Data / mês de referência", Tipo: planilha.Data},
+		planilha.Coluna{Titulo: "Dias", Tipo: planilha.Inteiro},
+		planilha.Coluna{Titulo: "Minutos de atraso", Tipo: planilha.Inteiro},
+	)
+	for _, f := range lista {
+		nome, cpf := nomeECPF(nomes, f.FuncionarioID)
+		t.Adicionar(nome, cpf, f.Tipo, f.Mes, f.Quantidade, f.Minutos)
+	}
+	return t, nil
+}
+
+// TabelaVales monta a exportação da listagem de vales
+func TabelaVales(lista []entity.Vale) (*planilha.Tabela, error) {
+	nomes, err := identificar(lista, func(v entity.Vale) int64 { return v.FuncionarioID })
+	if err != nil {
+		return nil, err
+	}
+	t := planilha.NovaTabela("Vales",
+		colunaFuncionario,
+		colunaCPF,
+		planilha.Coluna{Titulo: "Data", Tipo: planilha.Data},
+		planilha.Coluna{Titulo: "Valor", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "Aprovado", Tipo: planilha.SimNao},
+		planilha.Coluna{Titulo: "Pago", Tipo: planilha.SimNao},
+		planilha.Coluna{Titulo: "Ativo", Tipo: planilha.SimNao},
+	)
+	for _, v := range lista {
+		nome, cpf := nomeECPF(nomes, v.FuncionarioID)
+		t.Adicionar(nome, cpf, v.Data, v.Valor, v.Aprovado, v.Pago, v.Ativo)
+	}
+	return t, nil
+}
+
+// TabelaFerias monta a exportação da listagem de férias
+func TabelaFerias(lista []*entity.Ferias) (*planilha.Tabela, error) {
+	nomes, err := identificar(lista, func(f *entity.Ferias) int64 { return f.FuncionarioID })
+	if err != nil {
+		return nil, err
+	}
+	t := planilha.NovaTabela("Férias",
+		colunaFuncionario,
+		colunaCPF,
+		planilha.Coluna{Titulo: "Início do período", Tipo: planilha.Data},
+		planilha.Coluna{Titulo: "Vencimento", Tipo: planilha.Data},
+		planilha.Coluna{Titulo: "Dias", Tipo: planilha.Inteiro},
+		planilha.Coluna{Titulo: "Valor", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "Terço constitucional", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "Pago", Tipo: planilha.SimNao},
+		planilha.Coluna{Titulo: "Terço pago", Tipo: planilha.SimNao},
+		planilha.Coluna{Titulo: "Vencido", Tipo: planilha.SimNao},
+	)
+	for _, f := range lista {
+		nome, cpf := nomeECPF(nomes, f.FuncionarioID)
+		t.Adicionar(nome, cpf, f.Inicio, f.Vencimento, f.Dias, f.Valor, f.Terco, f.Pago, f.TercoPago, f.Vencido)
+	}
+	return t, nil
+}
+
+// TabelaDocumentos monta a exportação da listagem de documentos
+func TabelaDocumentos(lista []*entity.Documento) (*planilha.Tabela, error) {
+	nomes, err := identificar(lista, func(d *entity.Documento) int64 { return d.FuncionarioID })
+	if err != nil {
+		return nil, err
+	}
+	t := planilha.NovaTabela("Documentos",
+		colunaFuncionario,
+		colunaCPF,
+		planilha.Coluna{Titulo: "Arquivo"},
+	)
+	for _, d := range lista {
+		nome, cpf := nomeECPF(nomes, d.FuncionarioID)
+		t.Adicionar(nome, cpf, filepath.Base(d.Caminho))
+	}
+	return t, nil
+}
+
+// TabelaLogs monta a exportação dos logs com o nome de usuário no lugar do ID
+func TabelaLogs(lista []*repository.LogView) (*planilha.Tabela, error) {
+	usuarios, err := repository.GetAllUsuarios()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao montar exportação: %w", err)
+	}
+	nomes := make(map[int64]string, len(usuarios))
+	for _, u := range usuarios {
+		nomes[u.ID] = u.Username
+	}
+	t := planilha.NovaTabela("Logs",
+		planilha.Coluna{Titulo: "Data", Tipo: planilha.DataHora},
+		planilha.Coluna{Titulo: "Usuário"},
+		planilha.Coluna{Titulo: "Evento"},
+		planilha.Coluna{Titulo: "Descrição"},
+	)
+	for _, l := range lista {
+		usuario, ok := nomes[l.UsuarioID]
+		if !ok {
+			usuario = "#" + strconv.FormatInt(l.UsuarioID, 10)
+		}
+		t.Adicionar(l.Data, usuario, l.Evento, l.Message)
+	}
+	return t, nil
+}
+
+// TabelaPagamentos monta a exportação dos pagamentos de uma folha, com uma linha final de totais
+func TabelaPagamentos(folha *entity.FolhaPagamentos, lista []entity.Pagamento) (*planilha.Tabela, error) {
+	nomes, err := identificar(lista, func(p entity.Pagamento) int64 { return p.FuncionarioID })
+	if err != nil {
+		return nil, err
+	}
+	t := planilha.NovaTabela(fmt.Sprintf("Folha %02d-%d %s", folha.Mes, folha.Ano, folha.Tipo),
+		colunaFuncionario,
+		colunaCPF,
+		planilha.Coluna{Titulo: "Salário base", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "Adicional", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "Horas extras", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "Salário-família", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "Desconto INSS", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "Desconto de vales", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "Desconto de DSR", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "Valor líquido", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "FGTS", Tipo: planilha.Moeda},
+		planilha.Coluna{Titulo: "Pago", Tipo: planilha.SimNao},
+	)
+	var total entity.Pagamento
+	for _, p := range lista {
+		nome, cpf := nomeECPF(nomes, p.FuncionarioID)
+		t.Adicionar(nome, cpf, p.SalarioBase, p.Adicional, p.HorasExtras, p.SalarioFamilia, p.DescontoINSS,
+			p.DescontoVales, p.DescontoDSR, p.ValorFinal, p.FGTS, p.Pago)
+
+		total.SalarioBase += p.SalarioBase
+		total.Adicional += p.Adicional
+		total.HorasExtras += p.HorasExtras
+		total.SalarioFamilia += p.SalarioFamilia
+		total.DescontoINSS += p.DescontoINSS
+		total.DescontoVales += p.DescontoVales
+		total.DescontoDSR += p.DescontoDSR
+		total.ValorFinal += p.ValorFinal
+		total.FGTS += p.FGTS
+	}
+	t.Adicionar("Total", nil, total.SalarioBase, total.Adicional, total.HorasExtras, total.SalarioFamilia,
+		total.DescontoINSS, total.DescontoVales, total.DescontoDSR, total.ValorFinal, total.FGTS, nil)
+	return t, nil
+}
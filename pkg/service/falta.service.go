@@ -3,8 +3,10 @@ package service
 import (
 	"AutoGRH/pkg/entity"
 	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/consulta"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -16,6 +18,7 @@ type FaltaRepository interface {
 	Delete(id int64) error
 	GetFaltasByFuncionarioID(funcionarioID int64) ([]*entity.Falta, error)
 	ListAll() ([]*entity.Falta, error)
+	Buscar(q consulta.Consulta) (consulta.Pagina[*entity.Falta], error)
 }
 
 type FaltaService struct {
@@ -33,15 +36,63 @@ func NewFaltaService(auth *AuthService, logRepo LogRepository, repo FaltaReposit
 	}
 }
 
+// validarFalta normaliza o tipo e confere os campos exigidos por ele. Sem tipo, a falta é tratada
+// como o total consolidado do mês (MENSAL), como nos lançamentos antigos.
+func validarFalta(ctx context.Context, f *entity.Falta) error {
+	f.Tipo = strings.ToUpper(strings.TrimSpace(f.Tipo))
+	if f.Tipo == "" {
+		f.Tipo = entity.FaltaTipoMensal
+	}
+	if !entity.TipoFaltaValido(f.Tipo) {
+		return fmt.Errorf("tipo de falta inválido: use MENSAL, INJUSTIFICADA, ATESTADO, LICENCA, ABONADA ou ATRASO")
+	}
+	if f.Mes.IsZero() {
+		return fmt.Errorf("data da falta é obrigatória")
+	}
+
+	switch f.Tipo {
+	case entity.FaltaTipoAtraso:
+		if f.Minutos <= 0 {
+			return fmt.Errorf("minutos de atraso devem ser maiores que zero")
+		}
+		f.Quantidade = 0
+	case entity.FaltaTipoMensal:
+		if f.Quantidade <= 0 {
+			return fmt.Errorf("quantidade de faltas deve ser maior que zero")
+		}
+		f.Minutos = 0
+	default:
+		if f.Quantidade < 0 {
+			return fmt.Errorf("quantidade de faltas deve ser maior que zero")
+		}
+		if f.Quantidade == 0 {
+			f.Quantidade = 1
+		}
+		f.Minutos = 0
+	}
+
+	if f.DocumentoID != nil {
+		doc, err := repository.GetByID(ctx, *f.DocumentoID)
+		if err != nil {
+			return fmt.Errorf("erro ao buscar documento: %w", err)
+		}
+		if doc == nil || doc.FuncionarioID != f.FuncionarioID {
+			return fmt.Errorf("documento %d não encontrado para o funcionário", *f.DocumentoID)
+		}
+	}
+	return nil
+}
+
 // Criar nova falta
 func (s *FaltaService) CreateFalta(ctx context.Context, claims Claims, f *entity.Falta) error {
 	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
 		return err
 	}
 
-	if f.Quantidade <= 0 {
-		return fmt.Errorf("quantidade de faltas deve ser maior que zero")
+	if err := validarFalta(ctx, f); err != nil {
+		return err
 	}
+	f.Origem = entity.FaltaOrigemManual
 
 	if err := s.repo.Create(f); err != nil {
 		return fmt.Errorf("erro ao registrar falta: %w", err)
@@ -52,7 +103,7 @@ func (s *FaltaService) CreateFalta(ctx context.Context, claims Claims, f *entity
 		EventoID:  3, // CRIAR
 		UsuarioID: &claims.UserID,
 		Quando:    time.Now(),
-		Detalhe:   fmt.Sprintf("Falta criada ID=%d FuncionarioID=%d Qtd=%d", f.ID, f.FuncionarioID, f.Quantidade),
+		Detalhe:   fmt.Sprintf("Falta criada ID=%d FuncionarioID=%d Tipo=%s Qtd=%d", f.ID, f.FuncionarioID, f.Tipo, f.Quantidade),
 	})
 
 	return nil
@@ -64,9 +115,22 @@ func (s *FaltaService) UpdateFalta(ctx context.Context, claims Claims, f *entity
 		return err
 	}
 
-	if f.Quantidade <= 0 {
-		return fmt.Errorf("quantidade de faltas deve ser maior que zero")
+	atual, err := s.repo.GetFaltaByID(f.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar falta: %w", err)
+	}
+	if atual == nil {
+		return fmt.Errorf("falta não encontrada")
 	}
+	f.FuncionarioID = atual.FuncionarioID
+	if f.Tipo == "" {
+		f.Tipo = atual.Tipo
+	}
+	if err := validarFalta(ctx, f); err != nil {
+		return err
+	}
+	// editada à mão, a falta deixa de ser refeita pela importação do ponto
+	f.Origem = entity.FaltaOrigemManual
 
 	if err := s.repo.Update(f); err != nil {
 		return fmt.Errorf("erro ao atualizar falta: %w", err)
@@ -77,7 +141,7 @@ func (s *FaltaService) UpdateFalta(ctx context.Context, claims Claims, f *entity
 		EventoID:  4, // ATUALIZAR
 		UsuarioID: &claims.UserID,
 		Quando:    time.Now(),
-		Detalhe:   fmt.Sprintf("Falta atualizada ID=%d FuncionarioID=%d Qtd=%d", f.ID, f.FuncionarioID, f.Quantidade),
+		Detalhe:   fmt.Sprintf("Falta atualizada ID=%d FuncionarioID=%d Tipo=%s Qtd=%d", f.ID, f.FuncionarioID, f.Tipo, f.Quantidade),
 	})
 
 	return nil
@@ -136,6 +200,14 @@ func (s *FaltaService) ListAllFaltas(ctx context.Context, claims Claims) ([]*ent
 	return s.repo.ListAll()
 }
 
+// Listar uma página das faltas, com filtros e ordenação
+func (s *FaltaService) BuscarFaltas(ctx context.Context, claims Claims, q consulta.Consulta) (consulta.Pagina[*entity.Falta], error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return consulta.Pagina[*entity.Falta]{}, err
+	}
+	return s.repo.Buscar(q)
+}
+
 func (s *FaltaService) UpsertMensal(
 	ctx context.Context,
 	claims Claims,
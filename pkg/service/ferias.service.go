@@ -3,8 +3,10 @@ package service
 import (
 	"AutoGRH/pkg/entity"
 	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/consulta"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -16,6 +18,7 @@ type FeriasRepository interface {
 	Update(ctx context.Context, f *entity.Ferias) error
 	Delete(ctx context.Context, id int64) error
 	List(ctx context.Context) ([]*entity.Ferias, error)
+	Buscar(ctx context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Ferias], error)
 }
 
 type SaldoFeriasDTO struct {
@@ -87,6 +90,13 @@ func (s *FeriasService) ListFerias(ctx context.Context, claims Claims) ([]*entit
 	return s.repo.List(ctx)
 }
 
+func (s *FeriasService) BuscarFerias(ctx context.Context, claims Claims, q consulta.Consulta) (consulta.Pagina[*entity.Ferias], error) {
+	if err := s.authService.Authorize(ctx, claims, "ferias:list"); err != nil {
+		return consulta.Pagina[*entity.Ferias]{}, err
+	}
+	return s.repo.Buscar(ctx, q)
+}
+
 func (s *FeriasService) AtualizarFerias(ctx context.Context, claims Claims, f *entity.Ferias) error {
 	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
 		return err
@@ -214,6 +224,54 @@ func truncateDate(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 }
 
+// diasDireitoPorFaltas aplica a tabela do art. 130 da CLT ao total de faltas do período aquisitivo
+func diasDireitoPorFaltas(totalFaltas int) int {
+	switch {
+	case totalFaltas >= 33:
+		return 0
+	case totalFaltas >= 24:
+		return 12
+	case totalFaltas >= 15:
+		return 18
+	case totalFaltas >= 6:
+		return 24
+	default:
+		return 30
+	}
+}
+
+// somarFaltas soma as faltas que reduzem férias (injustificadas) registradas em [inicio, fim)
+func somarFaltas(faltas []*entity.Falta, inicio, fim time.Time) int {
+	total := 0
+	for _, fal := range faltas {
+		if fal.ReduzFerias() && !fal.Mes.Before(inicio) && fal.Mes.Before(fim) {
+			total += fal.Quantidade
+		}
+	}
+	return total
+}
+
+// avosAquisitivos conta os doze avos adquiridos entre inicio e ate: cada mês completo
+// vale um avo e a fração final vale outro se tiver 15 dias ou mais (CLT art. 146)
+func avosAquisitivos(inicio, ate time.Time) int {
+	avos := 0
+	cursor := truncateDate(inicio)
+	ate = truncateDate(ate)
+	for avos < 12 {
+		prox := cursor.AddDate(0, 1, 0)
+		if !prox.After(ate) {
+			avos++
+			cursor = prox
+			continue
+		}
+		if int(ate.Sub(cursor).Hours()/24) >= 15 {
+			avos++
+		}
+		break
+	}
+	return avos
+}
+
 // Corrigida: cria períodos anuais com Início = início da CONCESSÃO (A+12m) e Vencimento = Início+12m
 func (s *FeriasService) GarantirFeriasAteHoje(ctx context.Context, claims Claims, funcionarioID int64) ([]*entity.Ferias, error) {
 	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
@@ -227,7 +285,14 @@ func (s *FeriasService) GarantirFeriasAteHoje(ctx context.Context, claims Claims
 	if funcionario == nil {
 		return nil, fmt.Errorf("funcionário não encontrado")
 	}
+	// prestadores de serviço não têm férias
+	if !funcionario.TemFerias() {
+		return []*entity.Ferias{}, nil
+	}
 	admissao := truncateDate(funcionario.Admissao)
+	if funcionario.InicioAquisitivo != nil {
+		admissao = truncateDate(*funcionario.InicioAquisitivo)
+	}
 
 	// Carrega férias existentes e indexa por data de CONCESSÃO (yyyy-mm-dd)
 	existentes, err := s.repo.GetFeriasByFuncionarioID(ctx, funcionarioID)
@@ -267,28 +332,12 @@ func (s *FeriasService) GarantirFeriasAteHoje(ctx context.Context, claims Claims
 		// Contabiliza faltas durante a AQUISIÇÃO [A, A+12m)
 		totalFaltas := 0
 		faltas, ferr := repository.GetFaltasByFuncionarioID(funcionarioID)
-		if ferr == nil && len(faltas) > 0 {
-			for _, fal := range faltas {
-				if !fal.Mes.Before(aquisicaoIni) && fal.Mes.Before(aquisicaoFim) {
-					totalFaltas += fal.Quantidade
-				}
-			}
+		if ferr == nil {
+			totalFaltas = somarFaltas(faltas, aquisicaoIni, aquisicaoFim)
 		}
 
 		// Tabela CLT
-		dias := 30
-		switch {
-		case totalFaltas >= 33:
-			dias = 0
-		case totalFaltas >= 24:
-			dias = 12
-		case totalFaltas >= 15:
-			dias = 18
-		case totalFaltas >= 6:
-			dias = 24
-		default:
-			dias = 30
-		}
+		dias := diasDireitoPorFaltas(totalFaltas)
 
 		// CONCESSÃO = fim da aquisição; VENCIMENTO = concessão + 12m
 		concessaoIni := aquisicaoFim
@@ -419,3 +468,496 @@ func (s *FeriasService) DesmarcarPago(ctx context.Context, claims Claims, id int
 	})
 	return nil
 }
+
+// Férias coletivas
+
+// Escopos aceitos por CriarFeriasColetivas
+const (
+	ColetivasEscopoTodos        = "TODOS"
+	ColetivasEscopoCargo        = "CARGO"
+	ColetivasEscopoDepartamento = "DEPARTAMENTO"
+	ColetivasEscopoFuncionarios = "FUNCIONARIOS"
+)
+
+// Situação de cada funcionário após o lançamento das férias coletivas
+const (
+	ColetivasStatusConcedido    = "CONCEDIDO"    // saldo cobriu todo o período
+	ColetivasStatusParcial      = "PARCIAL"      // parte do período virou licença remunerada
+	ColetivasStatusProporcional = "PROPORCIONAL" // menos de 12 meses: férias proporcionais e novo período aquisitivo
+	ColetivasStatusIgnorado     = "IGNORADO"
+	ColetivasStatusErro         = "ERRO"
+)
+
+// FeriasColetivasInput descreve o período e o grupo de funcionários atingido
+type FeriasColetivasInput struct {
+	Inicio         time.Time
+	Fim            time.Time
+	Escopo         string
+	Cargo          string
+	DepartamentoID int64
+	FuncionarioIDs []int64
+}
+
+// FeriasColetivasResultado resume o que foi lançado para cada funcionário
+type FeriasColetivasResultado struct {
+	FuncionarioID  int64              `json:"funcionario_id"`
+	Nome           string             `json:"nome"`
+	Status         string             `json:"status"`
+	DiasConcedidos int                `json:"dias_concedidos"`
+	DiasSemSaldo   int                `json:"dias_sem_saldo"` // tratados como licença remunerada
+	Descansos      []*entity.Descanso `json:"descansos,omitempty"`
+	Mensagem       string             `json:"mensagem,omitempty"`
+}
+
+// CriarFeriasColetivas lança o mesmo período de descanso para um grupo de funcionários ativos.
+// Os descansos já nascem aprovados e consomem os períodos mais antigos primeiro; quem tem menos
+// de 12 meses recebe férias proporcionais e inicia novo período aquisitivo na data das coletivas.
+func (s *FeriasService) CriarFeriasColetivas(ctx context.Context, claims Claims, in FeriasColetivasInput) ([]FeriasColetivasResultado, error) {
+	if err := s.authService.Authorize(ctx, claims, "ferias:create"); err != nil {
+		return nil, err
+	}
+	inicio := truncateDate(in.Inicio)
+	fim := truncateDate(in.Fim)
+	if fim.Before(inicio) {
+		return nil, fmt.Errorf("data final não pode ser antes da inicial")
+	}
+	totalDias := int(fim.Sub(inicio).Hours()/24) + 1
+
+	funcionarios, naoEncontrados, err := selecionarFuncionariosColetivas(in)
+	if err != nil {
+		return nil, err
+	}
+	if len(funcionarios) == 0 && len(naoEncontrados) == 0 {
+		return nil, fmt.Errorf("nenhum funcionário ativo encontrado para o escopo informado")
+	}
+
+	resultados := make([]FeriasColetivasResultado, 0, len(funcionarios)+len(naoEncontrados))
+	for _, f := range funcionarios {
+		res := s.aplicarFeriasColetivas(ctx, claims, f, inicio, totalDias)
+		if nome, err := repository.GetFuncionarioNomeByID(f.ID); err == nil {
+			res.Nome = nome
+		}
+		resultados = append(resultados, res)
+	}
+	for _, id := range naoEncontrados {
+		resultados = append(resultados, FeriasColetivasResultado{
+			FuncionarioID: id,
+			Status:        ColetivasStatusErro,
+			Mensagem:      "funcionário não encontrado ou inativo",
+		})
+	}
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe: fmt.Sprintf("Férias coletivas %s a %s escopo=%s funcionarios=%d",
+			inicio.Format("2006-01-02"), fim.Format("2006-01-02"), in.Escopo, len(funcionarios)),
+	})
+	return resultados, nil
+}
+
+// selecionarFuncionariosColetivas resolve o escopo em funcionários ativos.
+// Para o escopo FUNCIONARIOS também retorna os IDs que não correspondem a um funcionário ativo.
+func selecionarFuncionariosColetivas(in FeriasColetivasInput) ([]*entity.Funcionario, []int64, error) {
+	switch strings.ToUpper(strings.TrimSpace(in.Escopo)) {
+	case ColetivasEscopoTodos:
+		lista, err := repository.ListFuncionariosAtivos()
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao listar funcionários ativos: %w", err)
+		}
+		return lista, nil, nil
+	case ColetivasEscopoCargo:
+		if strings.TrimSpace(in.Cargo) == "" {
+			return nil, nil, fmt.Errorf("cargo é obrigatório para o escopo CARGO")
+		}
+		lista, err := repository.ListFuncionariosAtivosByCargo(in.Cargo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao listar funcionários do cargo: %w", err)
+		}
+		return lista, nil, nil
+	case ColetivasEscopoFuncionarios:
+		if len(in.FuncionarioIDs) == 0 {
+			return nil, nil, fmt.Errorf("informe ao menos um funcionário para o escopo FUNCIONARIOS")
+		}
+		ativos, err := repository.ListFuncionariosAtivos()
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao listar funcionários ativos: %w", err)
+		}
+		byID := make(map[int64]*entity.Funcionario, len(ativos))
+		for _, f := range ativos {
+			byID[f.ID] = f
+		}
+		lista := make([]*entity.Funcionario, 0, len(in.FuncionarioIDs))
+		var naoEncontrados []int64
+		vistos := map[int64]bool{}
+		for _, id := range in.FuncionarioIDs {
+			if vistos[id] {
+				continue
+			}
+			vistos[id] = true
+			if f, ok := byID[id]; ok {
+				lista = append(lista, f)
+			} else {
+				naoEncontrados = append(naoEncontrados, id)
+			}
+		}
+		return lista, naoEncontrados, nil
+	case ColetivasEscopoDepartamento:
+		if in.DepartamentoID == 0 {
+			return nil, nil, fmt.Errorf("departamento é obrigatório para o escopo DEPARTAMENTO")
+		}
+		ativos, err := repository.ListFuncionariosAtivos()
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao listar funcionários ativos: %w", err)
+		}
+		// vale a alocação vigente no início das coletivas
+		var lista []*entity.Funcionario
+		for _, f := range ativos {
+			alocacoes, err := repository.ListAlocacoesByFuncionarioID(f.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, a := range alocacoesVigentes(alocacoes, in.Inicio) {
+				if a.DepartamentoID == in.DepartamentoID {
+					lista = append(lista, f)
+					break
+				}
+			}
+		}
+		return lista, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("escopo inválido: use TODOS, CARGO, DEPARTAMENTO ou FUNCIONARIOS")
+	}
+}
+
+// aplicarFeriasColetivas lança as coletivas para um funcionário; falhas ficam no resultado
+// para não interromper o restante do grupo
+func (s *FeriasService) aplicarFeriasColetivas(ctx context.Context, claims Claims, f *entity.Funcionario, inicio time.Time, totalDias int) FeriasColetivasResultado {
+	res := FeriasColetivasResultado{FuncionarioID: f.ID}
+
+	if !f.TemFerias() {
+		res.Status = ColetivasStatusIgnorado
+		res.Mensagem = "contrato sem férias (PJ)"
+		return res
+	}
+	if truncateDate(f.Admissao).After(inicio) {
+		res.Status = ColetivasStatusIgnorado
+		res.Mensagem = "admitido após o início das férias coletivas"
+		return res
+	}
+
+	// relançar o mesmo período (ex.: após erro parcial) não pode duplicar descansos nem pagamentos
+	fim := inicio.AddDate(0, 0, totalDias-1)
+	existente, err := repository.GetDescansoSobreposto(f.ID, inicio, fim)
+	if err != nil {
+		res.Status = ColetivasStatusErro
+		res.Mensagem = err.Error()
+		return res
+	}
+	if existente != nil {
+		res.Status = ColetivasStatusIgnorado
+		res.Mensagem = fmt.Sprintf("já possui descanso de %s a %s no período",
+			existente.Inicio.Format("02/01/2006"), existente.Fim.Format("02/01/2006"))
+		return res
+	}
+
+	base := truncateDate(f.Admissao)
+	if f.InicioAquisitivo != nil {
+		base = truncateDate(*f.InicioAquisitivo)
+	}
+
+	var periodos []*entity.Ferias
+	proporcional := base.AddDate(1, 0, 0).After(inicio)
+	if proporcional {
+		fer, err := s.criarFeriasProporcionais(ctx, f.ID, base, inicio)
+		if err != nil {
+			res.Status = ColetivasStatusErro
+			res.Mensagem = err.Error()
+			return res
+		}
+		if fer != nil {
+			periodos = append(periodos, fer)
+		}
+	} else {
+		if _, err := s.GarantirFeriasAteHoje(ctx, claims, f.ID); err != nil {
+			res.Status = ColetivasStatusErro
+			res.Mensagem = err.Error()
+			return res
+		}
+		lista, err := repository.GetFeriasNaoPagasComSaldo(f.ID)
+		if err != nil {
+			res.Status = ColetivasStatusErro
+			res.Mensagem = fmt.Sprintf("erro ao listar períodos de férias: %v", err)
+			return res
+		}
+		periodos = lista
+	}
+
+	criados, semSaldo, err := alocarDescansosFIFO(repository.CreateDescanso, periodos, inicio, totalDias, true)
+	res.Descansos = criados
+	res.DiasConcedidos = totalDias - semSaldo
+	res.DiasSemSaldo = semSaldo
+	for _, d := range criados {
+		if _, perr := gerarPagamentoFerias(d); perr != nil && err == nil {
+			err = fmt.Errorf("erro ao gerar pagamento de férias do descanso %d: %w", d.ID, perr)
+		}
+		_, _ = s.logRepo.Create(ctx, LogEntry{
+			EventoID:  3,
+			UsuarioID: &claims.UserID,
+			Quando:    s.authService.clock(),
+			Detalhe:   fmt.Sprintf("Descanso(coletivas) criado ID=%d FeriasID=%d Dias=%d", d.ID, d.FeriasID, d.DuracaoEmDias()),
+		})
+	}
+	// Novo período aquisitivo começa no primeiro dia das coletivas (CLT art. 140); só depois dos
+	// descansos, para que uma falha na alocação não mova o período
+	if proporcional && (err == nil || len(criados) > 0) {
+		if aerr := repository.SetInicioAquisitivo(f.ID, inicio); aerr != nil && err == nil {
+			err = fmt.Errorf("erro ao reiniciar período aquisitivo: %w", aerr)
+		}
+	}
+	if err != nil {
+		res.Status = ColetivasStatusErro
+		res.Mensagem = err.Error()
+		return res
+	}
+
+	switch {
+	case proporcional:
+		res.Status = ColetivasStatusProporcional
+	case semSaldo > 0:
+		res.Status = ColetivasStatusParcial
+	default:
+		res.Status = ColetivasStatusConcedido
+	}
+	if semSaldo > 0 {
+		res.Mensagem = fmt.Sprintf("%d dia(s) sem saldo tratados como licença remunerada", semSaldo)
+	}
+	return res
+}
+
+// criarFeriasProporcionais gera o período de férias proporcionais de quem ainda não completou
+// 12 meses: 2,5 dias por avo sobre a tabela de faltas, valorado pelo salário real atual
+func (s *FeriasService) criarFeriasProporcionais(ctx context.Context, funcionarioID int64, base, inicio time.Time) (*entity.Ferias, error) {
+	// uma tentativa anterior que falhou na alocação já pode ter criado o período
+	existentes, err := repository.GetFeriasByFuncionarioID(funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar férias do funcionário: %w", err)
+	}
+	for _, fr := range existentes {
+		if fr.Inicio.Format("2006-01-02") == inicio.Format("2006-01-02") && !fr.Pago {
+			return fr, nil
+		}
+	}
+
+	avos := avosAquisitivos(base, inicio)
+
+	totalFaltas := 0
+	if faltas, err := repository.GetFaltasByFuncionarioID(funcionarioID); err == nil {
+		totalFaltas = somarFaltas(faltas, base, inicio)
+	}
+	dias := diasDireitoPorFaltas(totalFaltas) * avos / 12
+	if dias <= 0 {
+		return nil, nil
+	}
+
+	salarioReal, err := repository.GetSalarioRealAtual(funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar salário real atual: %w", err)
+	}
+	if salarioReal == nil {
+		return nil, fmt.Errorf("nenhum salário real encontrado para funcionarioID=%d", funcionarioID)
+	}
+
+	fer := entity.NewFerias(funcionarioID, inicio, dias)
+	fer.Valor = (salarioReal.Valor / 30.0) * float64(dias)
+	fer.Terco = fer.Valor / 3.0
+	if err := s.repo.Create(ctx, fer); err != nil {
+		return nil, fmt.Errorf("erro ao criar férias proporcionais: %w", err)
+	}
+	return fer, nil
+}
+
+// Calendário de férias
+
+// CalendarioFeriasDTO reúne os descansos de um intervalo e os estouros das regras de ausência
+type CalendarioFeriasDTO struct {
+	Inicio    time.Time                    `json:"inicio"`
+	Fim       time.Time                    `json:"fim"`
+	Descansos []*entity.DescansoCalendario `json:"descansos"`
+	Conflitos []entity.ConflitoAusencia    `json:"conflitos"`
+}
+
+// CalendarioFerias lista os descansos aprovados e pendentes que tocam [inicio, fim], com funcionário e cargo,
+// e aponta os intervalos em que alguma regra de ausência é excedida
+func (s *FeriasService) CalendarioFerias(ctx context.Context, claims Claims, inicio, fim time.Time) (*CalendarioFeriasDTO, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	inicio, fim = truncateDate(inicio), truncateDate(fim)
+	if fim.Before(inicio) {
+		return nil, fmt.Errorf("data final não pode ser antes da inicial")
+	}
+
+	descansos, err := repository.ListDescansosCalendario(inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	regras, err := repository.ListRegrasAusenciaAtivas()
+	if err != nil {
+		return nil, err
+	}
+	if descansos == nil {
+		descansos = []*entity.DescansoCalendario{}
+	}
+	conflitos, err := conflitosDosDescansos(regras, descansos, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	if conflitos == nil {
+		conflitos = []entity.ConflitoAusencia{}
+	}
+
+	return &CalendarioFeriasDTO{
+		Inicio:    inicio,
+		Fim:       fim,
+		Descansos: descansos,
+		Conflitos: conflitos,
+	}, nil
+}
+
+// Projeção de férias
+
+// PeriodoAquisitivoProjecaoDTO descreve um período aquisitivo, completo ou em curso, na data da projeção
+type PeriodoAquisitivoProjecaoDTO struct {
+	AquisicaoInicio time.Time `json:"aquisicao_inicio"`
+	AquisicaoFim    time.Time `json:"aquisicao_fim"`
+	Completo        bool      `json:"completo"`
+	Avos            int       `json:"avos"`
+	Faltas          int       `json:"faltas"`
+	DiasDireito     int       `json:"dias_direito"` // tabela do art. 130 proporcional aos avos
+	DiasGozados     int       `json:"dias_gozados"`
+	DiasSaldo       int       `json:"dias_saldo"`
+	Valor           float64   `json:"valor"`
+	Terco           float64   `json:"terco"`
+	Total           float64   `json:"total"`
+	FeriasID        *int64    `json:"ferias_id,omitempty"`
+	Pago            bool      `json:"pago"`
+}
+
+// ProjecaoFeriasDTO reúne os períodos aquisitivos de um funcionário e o saldo projetado em uma data
+type ProjecaoFeriasDTO struct {
+	FuncionarioID int64                          `json:"funcionario_id"`
+	Data          time.Time                      `json:"data"`
+	SalarioBase   float64                        `json:"salario_base"`
+	Periodos      []PeriodoAquisitivoProjecaoDTO `json:"periodos"`
+	DiasSaldo     int                            `json:"dias_saldo"`
+	Total         float64                        `json:"total"`
+}
+
+// ProjecaoFerias calcula, sem gravar nada, os períodos aquisitivos até a data informada: os completos
+// (com o registro de férias correspondente, se já gerado) e o em curso, com os avos adquiridos
+// (fração de 15 dias ou mais conta como mês), o direito ajustado pelas faltas e o valor projetado.
+// Retorna nil se o funcionário não existir.
+func (s *FeriasService) ProjecaoFerias(ctx context.Context, claims Claims, funcionarioID int64, data time.Time) (*ProjecaoFeriasDTO, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+
+	funcionario, err := repository.GetFuncionarioByID(funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar funcionário: %w", err)
+	}
+	if funcionario == nil {
+		return nil, nil
+	}
+	base := truncateDate(funcionario.Admissao)
+	if funcionario.InicioAquisitivo != nil {
+		base = truncateDate(*funcionario.InicioAquisitivo)
+	}
+	data = truncateDate(data)
+	if funcionario.Demissao != nil && funcionario.Demissao.Before(data) {
+		data = truncateDate(*funcionario.Demissao)
+	}
+
+	salarioReal, err := repository.GetSalarioRealAtual(funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar salário real atual: %w", err)
+	}
+	if salarioReal == nil {
+		return nil, fmt.Errorf("nenhum salário real encontrado para funcionarioID=%d", funcionarioID)
+	}
+
+	existentes, err := s.repo.GetFeriasByFuncionarioID(ctx, funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar férias do funcionário: %w", err)
+	}
+	byConcessao := map[string]*entity.Ferias{}
+	for _, f := range existentes {
+		byConcessao[f.Inicio.Format("2006-01-02")] = f
+	}
+
+	faltas, err := repository.GetFaltasByFuncionarioID(funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar faltas: %w", err)
+	}
+
+	dto := &ProjecaoFeriasDTO{
+		FuncionarioID: funcionarioID,
+		Data:          data,
+		SalarioBase:   salarioReal.Valor,
+		Periodos:      []PeriodoAquisitivoProjecaoDTO{},
+	}
+
+	for cursor := base; cursor.Before(data); cursor = truncateDate(cursor.AddDate(1, 0, 0)) {
+		p := PeriodoAquisitivoProjecaoDTO{
+			AquisicaoInicio: cursor,
+			AquisicaoFim:    truncateDate(cursor.AddDate(1, 0, 0)),
+		}
+		p.Completo = !p.AquisicaoFim.After(data)
+
+		if p.Completo {
+			p.Avos = 12
+			p.Faltas = somarFaltas(faltas, p.AquisicaoInicio, p.AquisicaoFim)
+			p.DiasDireito = diasDireitoPorFaltas(p.Faltas)
+		} else {
+			p.Avos = avosAquisitivos(cursor, data)
+			p.Faltas = somarFaltas(faltas, p.AquisicaoInicio, data.AddDate(0, 0, 1))
+			p.DiasDireito = diasDireitoPorFaltas(p.Faltas) * p.Avos / 12
+		}
+
+		p.DiasSaldo = p.DiasDireito
+		if f, ok := byConcessao[p.AquisicaoFim.Format("2006-01-02")]; ok {
+			id := f.ID
+			p.FeriasID = &id
+			p.Pago = f.Pago
+			// a aprovação já descontou os dias do descanso em f.Dias; os pendentes ainda não
+			aprovados, pendentes := 0, 0
+			for _, d := range f.Descansos {
+				if d.Aprovado {
+					aprovados += d.DuracaoEmDias()
+				} else {
+					pendentes += d.DuracaoEmDias()
+				}
+			}
+			p.DiasDireito = f.Dias + aprovados
+			p.DiasGozados = aprovados + pendentes
+			p.DiasSaldo = f.Dias - pendentes
+			if p.DiasSaldo < 0 {
+				p.DiasSaldo = 0
+			}
+		}
+
+		if !p.Pago {
+			p.Valor = arredondar2(salarioReal.Valor / 30.0 * float64(p.DiasSaldo))
+			p.Terco = arredondar2(p.Valor / 3.0)
+			p.Total = arredondar2(p.Valor + p.Terco)
+			dto.DiasSaldo += p.DiasSaldo
+			dto.Total += p.Total
+		}
+		dto.Periodos = append(dto.Periodos, p)
+	}
+	dto.Total = arredondar2(dto.Total)
+
+	return dto, nil
+}
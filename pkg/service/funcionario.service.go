@@ -2,9 +2,13 @@ package service
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/consulta"
+	"AutoGRH/pkg/utils/documentos"
 	"context"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // FuncionarioRepository interface usada pelo service
@@ -16,6 +20,7 @@ type FuncionarioRepository interface {
 	ListAtivos(ctx context.Context) ([]*entity.Funcionario, error)
 	ListInativos(ctx context.Context) ([]*entity.Funcionario, error)
 	ListTodos(ctx context.Context) ([]*entity.Funcionario, error)
+	Buscar(ctx context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Funcionario], error)
 }
 
 type FuncionarioService struct {
@@ -34,6 +39,19 @@ func NewFuncionarioService(auth *AuthService, logRepo LogRepository, repo Funcio
 
 // CreateFuncionario cria um novo funcionário
 func (s *FuncionarioService) CreateFuncionario(ctx context.Context, claims Claims, f *entity.Funcionario) error {
+	// cargo do catálogo: o título vem do cadastro de cargos
+	var cargo *entity.Cargo
+	if f.CargoID != nil {
+		c, err := repository.GetCargoByID(*f.CargoID)
+		if err != nil {
+			return err
+		}
+		if c == nil || !c.Ativo {
+			return fmt.Errorf("cargo não encontrado ou inativo")
+		}
+		cargo = c
+		f.Cargo = c.Titulo
+	}
 	f.Cargo = strings.TrimSpace(f.Cargo)
 
 	if f.PessoaID <= 0 {
@@ -54,10 +72,28 @@ func (s *FuncionarioService) CreateFuncionario(ctx context.Context, claims Claim
 	if f.Nascimento.IsZero() {
 		return fmt.Errorf("data de nascimento inválida")
 	}
+	if err := validarDocumentosFuncionario(f); err != nil {
+		return err
+	}
+	if err := validarPrazoContrato(f); err != nil {
+		return err
+	}
+	if err := validarTipoContrato(f); err != nil {
+		return err
+	}
+	if err := validarReadmissao(f); err != nil {
+		return err
+	}
 
 	if err := s.repo.Create(ctx, f); err != nil {
 		return err
 	}
+	if cargo != nil {
+		fc := entity.NewFuncionarioCargo(f.ID, cargo.ID, truncateDate(f.Admissao), "admissão")
+		if err := repository.CreateFuncionarioCargo(fc); err != nil {
+			return err
+		}
+	}
 
 	_, _ = s.logRepo.Create(ctx, LogEntry{
 		EventoID:  3, // CRIAR
@@ -69,6 +105,79 @@ func (s *FuncionarioService) CreateFuncionario(ctx context.Context, claims Claim
 	return nil
 }
 
+// validarDocumentosFuncionario confere PIS e CTPS, quando informados, e os grava só com dígitos
+func validarDocumentosFuncionario(f *entity.Funcionario) error {
+	verr := &ErroValidacao{}
+	if f.PIS = strings.TrimSpace(f.PIS); f.PIS != "" {
+		if pis, err := documentos.NormalizarPIS(f.PIS); err != nil {
+			verr.Add("pis", err.Error())
+		} else {
+			f.PIS = pis
+		}
+	}
+	if f.CTPF = strings.TrimSpace(f.CTPF); f.CTPF != "" {
+		if ctps, err := documentos.NormalizarCTPS(f.CTPF); err != nil {
+			verr.Add("ctpf", err.Error())
+		} else {
+			f.CTPF = ctps
+		}
+	}
+	return verr.Err()
+}
+
+// validarReadmissao permite um novo vínculo para a mesma pessoa só depois do fim dos anteriores: nenhum
+// pode estar ativo e a nova admissão precisa ser posterior à última demissão. Cada vínculo mantém seu
+// próprio histórico de salários, férias e folha. Na atualização (f.ID preenchido) o próprio vínculo é
+// ignorado e o seu período, da admissão à demissão, não pode se sobrepor ao de nenhum outro.
+func validarReadmissao(f *entity.Funcionario) error {
+	vinculos, err := repository.ListFuncionariosByPessoaID(f.PessoaID)
+	if err != nil {
+		return err
+	}
+	admissao := truncateDate(f.Admissao)
+	for _, v := range vinculos {
+		if f.ID > 0 {
+			if v.ID == f.ID {
+				continue
+			}
+			if err := validarSobreposicaoVinculo(f, v); err != nil {
+				return err
+			}
+			continue
+		}
+		if v.Ativo {
+			return fmt.Errorf("a pessoa já tem vínculo ativo (funcionário ID=%d); desligue-o antes da readmissão", v.ID)
+		}
+		fim := truncateDate(v.Admissao)
+		if v.Demissao != nil {
+			fim = truncateDate(*v.Demissao)
+		}
+		if !admissao.After(fim) {
+			return fmt.Errorf("a admissão deve ser posterior ao fim do vínculo anterior (funcionário ID=%d, %s)",
+				v.ID, fim.Format("02/01/2006"))
+		}
+	}
+	return nil
+}
+
+// validarSobreposicaoVinculo recusa períodos de vínculo que se cruzam; sem demissão o vínculo segue em aberto
+func validarSobreposicaoVinculo(f, v *entity.Funcionario) error {
+	fAberto := f.Demissao == nil
+	vAberto := v.Ativo || v.Demissao == nil
+	if fAberto && vAberto {
+		return fmt.Errorf("a pessoa já tem vínculo ativo (funcionário ID=%d)", v.ID)
+	}
+	fIni, vIni := truncateDate(f.Admissao), truncateDate(v.Admissao)
+	if !fAberto && vIni.After(truncateDate(*f.Demissao)) {
+		return nil
+	}
+	if !vAberto && fIni.After(truncateDate(*v.Demissao)) {
+		return nil
+	}
+	return fmt.Errorf("o período do vínculo se sobrepõe ao do funcionário ID=%d (admissão em %s)",
+		v.ID, vIni.Format("02/01/2006"))
+}
+
 // GetFuncionarioByID busca um funcionário pelo ID
 func (s *FuncionarioService) GetFuncionarioByID(ctx context.Context, claims Claims, id int64) (*entity.Funcionario, error) {
 	if id <= 0 {
@@ -102,6 +211,40 @@ func (s *FuncionarioService) UpdateFuncionario(ctx context.Context, claims Claim
 	if f.Nascimento.IsZero() {
 		return fmt.Errorf("data de nascimento inválido")
 	}
+	if err := validarDocumentosFuncionario(f); err != nil {
+		return err
+	}
+	atual, err := s.repo.GetByID(ctx, f.ID)
+	if err != nil {
+		return err
+	}
+	if atual == nil {
+		return fmt.Errorf("funcionário não encontrado")
+	}
+	// a pessoa do vínculo não muda na atualização
+	vinculo := *f
+	vinculo.PessoaID = atual.PessoaID
+	if err := validarReadmissao(&vinculo); err != nil {
+		return err
+	}
+	// sem prazo ou tipo informado, mantém o contrato atual (alterado pelas ações de experiência)
+	if f.PrazoContrato == "" {
+		f.PrazoContrato, f.FimExperiencia, f.FimProrrogacao = atual.PrazoContrato, atual.FimExperiencia, atual.FimProrrogacao
+	}
+	if strings.TrimSpace(f.TipoContrato) == "" {
+		f.TipoContrato = atual.TipoContrato
+	}
+	// cargo do catálogo só muda pelo histórico de cargos (promoção)
+	f.CargoID = atual.CargoID
+	if atual.CargoID != nil {
+		f.Cargo = atual.Cargo
+	}
+	if err := validarPrazoContrato(f); err != nil {
+		return err
+	}
+	if err := validarTipoContrato(f); err != nil {
+		return err
+	}
 
 	if err := s.repo.Update(ctx, f); err != nil {
 		return err
@@ -151,3 +294,185 @@ func (s *FuncionarioService) ListFuncionariosInativos(ctx context.Context, claim
 func (s *FuncionarioService) ListTodosFuncionarios(ctx context.Context, claims Claims) ([]*entity.Funcionario, error) {
 	return s.repo.ListTodos(ctx)
 }
+
+// BuscarFuncionarios lista uma página dos funcionários conforme filtros e ordenação
+func (s *FuncionarioService) BuscarFuncionarios(ctx context.Context, claims Claims, q consulta.Consulta) (consulta.Pagina[*entity.Funcionario], error) {
+	return s.repo.Buscar(ctx, q)
+}
+
+// Contrato de experiência (CLT arts. 445 e 451): até 90 dias, com uma única prorrogação. O padrão
+// é 45 dias prorrogáveis por mais 45.
+const (
+	diasExperienciaPadrao  = 45
+	diasMaximosExperiencia = 90
+)
+
+// limiteExperiencia é o último dia possível da experiência
+func limiteExperiencia(f *entity.Funcionario) time.Time {
+	return truncateDate(f.Admissao).AddDate(0, 0, diasMaximosExperiencia-1)
+}
+
+// validarPrazoContrato normaliza o prazo do contrato; experiência sem término usa o período padrão
+func validarPrazoContrato(f *entity.Funcionario) error {
+	f.PrazoContrato = strings.ToUpper(strings.TrimSpace(f.PrazoContrato))
+	if f.PrazoContrato == "" {
+		f.PrazoContrato = entity.ContratoPrazoIndeterminado
+	}
+	switch f.PrazoContrato {
+	case entity.ContratoPrazoIndeterminado:
+		return nil
+	case entity.ContratoExperiencia:
+	default:
+		return fmt.Errorf("prazo do contrato inválido: use INDETERMINADO ou EXPERIENCIA")
+	}
+
+	admissao := truncateDate(f.Admissao)
+	if f.FimExperiencia == nil {
+		fim := admissao.AddDate(0, 0, diasExperienciaPadrao-1)
+		f.FimExperiencia = &fim
+	}
+	fim := truncateDate(*f.FimExperiencia)
+	if fim.Before(admissao) {
+		return fmt.Errorf("fim da experiência anterior à admissão")
+	}
+	if fim.After(limiteExperiencia(f)) {
+		return fmt.Errorf("contrato de experiência não pode passar de %d dias", diasMaximosExperiencia)
+	}
+	f.FimExperiencia = &fim
+	if f.FimProrrogacao != nil {
+		prorrogacao := truncateDate(*f.FimProrrogacao)
+		if !prorrogacao.After(fim) {
+			return fmt.Errorf("fim da prorrogação deve ser posterior ao fim da experiência")
+		}
+		if prorrogacao.After(limiteExperiencia(f)) {
+			return fmt.Errorf("contrato de experiência não pode passar de %d dias", diasMaximosExperiencia)
+		}
+		f.FimProrrogacao = &prorrogacao
+	}
+	return nil
+}
+
+// validarTipoContrato normaliza o tipo de contrato (padrão CLT); experiência só existe no contrato CLT
+func validarTipoContrato(f *entity.Funcionario) error {
+	f.TipoContrato = strings.ToUpper(strings.TrimSpace(f.TipoContrato))
+	if f.TipoContrato == "" {
+		f.TipoContrato = entity.TipoContratoCLT
+	}
+	switch f.TipoContrato {
+	case entity.TipoContratoCLT, entity.TipoContratoEstagio, entity.TipoContratoAprendiz,
+		entity.TipoContratoTemporario, entity.TipoContratoPJ:
+	default:
+		return fmt.Errorf("tipo de contrato inválido: use CLT, ESTAGIO, APRENDIZ, TEMPORARIO ou PJ")
+	}
+	if f.PrazoContrato == entity.ContratoExperiencia && f.TipoContrato != entity.TipoContratoCLT {
+		return fmt.Errorf("contrato de experiência só se aplica ao tipo CLT")
+	}
+	return nil
+}
+
+// funcionarioEmExperiencia busca o funcionário e exige contrato de experiência em curso
+func (s *FuncionarioService) funcionarioEmExperiencia(ctx context.Context, id int64) (*entity.Funcionario, error) {
+	f, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, fmt.Errorf("funcionário não encontrado")
+	}
+	if !f.Ativo || !f.EmExperiencia() {
+		return nil, fmt.Errorf("funcionário não está em contrato de experiência")
+	}
+	if truncateDate(*f.TerminoExperiencia()).Before(truncateDate(s.authService.clock())) {
+		return nil, fmt.Errorf("a experiência terminou em %s; o contrato passou a prazo indeterminado",
+			f.TerminoExperiencia().Format("02/01/2006"))
+	}
+	return f, nil
+}
+
+// ProrrogarExperiencia prorroga a experiência até fim (zero: até completar 90 dias). Só cabe uma
+// prorrogação, antes do término do primeiro período.
+func (s *FuncionarioService) ProrrogarExperiencia(ctx context.Context, claims Claims, id int64, fim time.Time) (*entity.Funcionario, error) {
+	if err := s.authService.Authorize(ctx, claims, "funcionario:update"); err != nil {
+		return nil, err
+	}
+	f, err := s.funcionarioEmExperiencia(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if f.FimProrrogacao != nil {
+		return nil, fmt.Errorf("o contrato de experiência já foi prorrogado")
+	}
+	if fim.IsZero() {
+		fim = limiteExperiencia(f)
+	}
+	f.FimProrrogacao = &fim
+	if err := validarPrazoContrato(f); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, f); err != nil {
+		return nil, err
+	}
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  4,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Prorrogou experiência do funcionário ID=%d até %s", f.ID, f.FimProrrogacao.Format("2006-01-02")),
+	})
+	_ = repository.DeleteAvisoByTypeAndRef(AvisoExperienciaVencendo, f.ID)
+	return f, nil
+}
+
+// EfetivarContrato converte o contrato de experiência em contrato por prazo indeterminado
+func (s *FuncionarioService) EfetivarContrato(ctx context.Context, claims Claims, id int64) (*entity.Funcionario, error) {
+	if err := s.authService.Authorize(ctx, claims, "funcionario:update"); err != nil {
+		return nil, err
+	}
+	f, err := s.funcionarioEmExperiencia(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	f.PrazoContrato = entity.ContratoPrazoIndeterminado
+	if err := s.repo.Update(ctx, f); err != nil {
+		return nil, err
+	}
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  4,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Efetivou funcionário ID=%d (contrato por prazo indeterminado)", f.ID),
+	})
+	_ = repository.DeleteAvisoByTypeAndRef(AvisoExperienciaVencendo, f.ID)
+	return f, nil
+}
+
+// EncerrarExperiencia encerra o contrato no término da experiência: a demissão é registrada na data
+// do término vigente e o funcionário é desligado
+func (s *FuncionarioService) EncerrarExperiencia(ctx context.Context, claims Claims, id int64) (*entity.Funcionario, error) {
+	if err := s.authService.Authorize(ctx, claims, "funcionario:update"); err != nil {
+		return nil, err
+	}
+	f, err := s.funcionarioEmExperiencia(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	termino := truncateDate(*f.TerminoExperiencia())
+	f.Demissao = &termino
+	if err := s.repo.Update(ctx, f); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Delete(ctx, f.ID); err != nil {
+		return nil, err
+	}
+	f.Ativo = false
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  4,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Encerrou contrato de experiência do funcionário ID=%d em %s", f.ID, termino.Format("2006-01-02")),
+	})
+	_ = repository.DeleteAvisoByTypeAndRef(AvisoExperienciaVencendo, f.ID)
+	return f, nil
+}
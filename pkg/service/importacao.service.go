@@ -0,0 +1,568 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/planilha"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LimiteLinhasImportacao é o máximo de linhas de dados aceitas numa planilha
+const LimiteLinhasImportacao = 2000
+
+var (
+	ErrImportacaoNaoEncontrada = errors.New("importação não encontrada")
+	ErrImportacaoConcluida     = errors.New("importação já foi concluída")
+	ErrImportacaoComErros      = errors.New("a planilha tem linhas com erro; corrija e envie de novo")
+)
+
+// campoImportacao é uma coluna reconhecida na planilha de funcionários; o cabeçalho é comparado sem
+// acentos, caixa, "_" ou "-", pelo nome do campo ou por um dos apelidos
+type campoImportacao struct {
+	nome        string
+	obrigatorio bool
+	apelidos    []string
+}
+
+var camposImportacaoFuncionario = []campoImportacao{
+	{"nome", true, []string{"nome completo"}},
+	{"cpf", true, nil},
+	{"rg", true, []string{"identidade"}},
+	{"nome_social", false, nil},
+	{"sexo", false, nil},
+	{"nascimento", true, []string{"data de nascimento", "data nascimento"}},
+	{"admissao", true, []string{"data de admissao", "data admissao"}},
+	{"cargo", false, []string{"funcao"}},
+	{"cargo_id", false, nil},
+	{"pis", false, []string{"pis pasep", "nis"}},
+	{"ctps", false, []string{"ctpf", "carteira de trabalho"}},
+	{"tipo_contrato", false, []string{"tipo de contrato"}},
+	{"prazo_contrato", false, []string{"prazo"}},
+	{"salario", true, []string{"salario registrado", "salario inicial"}},
+	{"salario_real", false, nil},
+}
+
+type ImportacaoService struct {
+	authService *AuthService
+	logRepo     LogRepository
+}
+
+func NewImportacaoService(auth *AuthService, logRepo LogRepository) *ImportacaoService {
+	return &ImportacaoService{
+		authService: auth,
+		logRepo:     logRepo,
+	}
+}
+
+// planoImportacao é o resultado da leitura da planilha: cada linha de dados com seus erros e, nas
+// válidas, os registros a criar
+type planoImportacao struct {
+	mapeamento map[string]string
+	linhas     []entity.LinhaImportacao
+	itens      []*repository.FuncionarioImportado
+	comErro    int
+}
+
+// ValidarFuncionarios lê a planilha, valida todas as linhas sem gravar funcionários e registra a
+// importação, que fica VALIDADA (pronta para confirmar) ou COM_ERROS. O mapeamento opcional indica,
+// por campo, o cabeçalho da coluna; sem ele as colunas são reconhecidas pelo nome.
+func (s *ImportacaoService) ValidarFuncionarios(ctx context.Context, claims Claims, arquivo string, conteudo []byte, mapeamento map[string]string) (*entity.Importacao, error) {
+	if err := s.authService.Authorize(ctx, claims, "funcionario:create"); err != nil {
+		return nil, err
+	}
+	if len(conteudo) == 0 {
+		return nil, &ErroValidacao{Campos: []CampoInvalido{{Campo: "file", Mensagem: "arquivo vazio"}}}
+	}
+	formato, err := planilha.Formato(arquivo, conteudo)
+	if err != nil {
+		return nil, &ErroValidacao{Campos: []CampoInvalido{{Campo: "file", Mensagem: err.Error()}}}
+	}
+	plano, err := planejarImportacao(arquivo, conteudo, mapeamento)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &entity.Importacao{
+		Tipo:          entity.ImportacaoFuncionarios,
+		Arquivo:       arquivo,
+		Formato:       formato,
+		Status:        entity.ImportacaoValidada,
+		Mapeamento:    plano.mapeamento,
+		TotalLinhas:   len(plano.linhas),
+		LinhasComErro: plano.comErro,
+		Linhas:        plano.linhas,
+		UsuarioID:     claims.UserID,
+		CriadoEm:      s.authService.clock(),
+		Conteudo:      conteudo,
+	}
+	if plano.comErro > 0 {
+		imp.Status = entity.ImportacaoComErros
+	}
+	if err := repository.CreateImportacao(imp); err != nil {
+		return nil, err
+	}
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3, // CRIAR
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe: fmt.Sprintf("Validou importação de funcionários ID=%d (%d linhas, %d com erro)",
+			imp.ID, imp.TotalLinhas, imp.LinhasComErro),
+	})
+
+	return imp, nil
+}
+
+// ConfirmarImportacao relê o arquivo guardado, valida de novo (o cadastro pode ter mudado desde o
+// envio) e cria pessoas, funcionários, salários e cargos numa única transação. Se alguma linha
+// passou a ter erro, nada é gravado: a importação fica COM_ERROS e volta com ErrImportacaoComErros.
+func (s *ImportacaoService) ConfirmarImportacao(ctx context.Context, claims Claims, id int64) (*entity.Importacao, error) {
+	if err := s.authService.Authorize(ctx, claims, "funcionario:create"); err != nil {
+		return nil, err
+	}
+	imp, err := repository.GetImportacaoByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if imp == nil {
+		return nil, ErrImportacaoNaoEncontrada
+	}
+	if imp.Status == entity.ImportacaoConcluida {
+		return imp, ErrImportacaoConcluida
+	}
+
+	plano, err := planejarImportacao(imp.Arquivo, imp.Conteudo, imp.Mapeamento)
+	if err != nil {
+		return nil, err
+	}
+	imp.TotalLinhas = len(plano.linhas)
+	imp.LinhasComErro = plano.comErro
+	imp.Linhas = plano.linhas
+	if plano.comErro > 0 {
+		imp.Status = entity.ImportacaoComErros
+		if err := repository.UpdateImportacao(imp); err != nil {
+			return nil, err
+		}
+		return imp, ErrImportacaoComErros
+	}
+
+	if err := repository.CreateFuncionariosImportados(plano.itens); err != nil {
+		return nil, err
+	}
+	for i, it := range plano.itens {
+		imp.Linhas[i].PessoaID = it.Pessoa.ID
+		imp.Linhas[i].FuncionarioID = it.Funcionario.ID
+	}
+	agora := s.authService.clock()
+	imp.Status = entity.ImportacaoConcluida
+	imp.ConcluidoEm = &agora
+	if err := repository.UpdateImportacao(imp); err != nil {
+		return nil, err
+	}
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3, // CRIAR
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Concluiu importação ID=%d: %d funcionários criados", imp.ID, len(plano.itens)),
+	})
+
+	return imp, nil
+}
+
+// ListImportacoes lista as importações, sem o resultado por linha
+func (s *ImportacaoService) ListImportacoes(ctx context.Context, claims Claims) ([]*entity.Importacao, error) {
+	if err := s.authService.Authorize(ctx, claims, "funcionario:create"); err != nil {
+		return nil, err
+	}
+	return repository.ListImportacoes()
+}
+
+// GetImportacao devolve a importação com o resultado de cada linha e o arquivo original
+func (s *ImportacaoService) GetImportacao(ctx context.Context, claims Claims, id int64) (*entity.Importacao, error) {
+	if err := s.authService.Authorize(ctx, claims, "funcionario:create"); err != nil {
+		return nil, err
+	}
+	imp, err := repository.GetImportacaoByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if imp == nil {
+		return nil, ErrImportacaoNaoEncontrada
+	}
+	return imp, nil
+}
+
+// RelatorioImportacao gera o CSV (separado por ";", como o Excel em português abre) com a situação
+// de cada linha da importação
+func (s *ImportacaoService) RelatorioImportacao(ctx context.Context, claims Claims, id int64) ([]byte, error) {
+	imp, err := s.GetImportacao(ctx, claims, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\xef\xbb\xbf") // BOM: o Excel reconhece o UTF-8
+	w := csv.NewWriter(&buf)
+	w.Comma = ';'
+	_ = w.Write([]string{"linha", "nome", "cpf", "situacao", "pessoa_id", "funcionario_id", "erros"})
+	for _, l := range imp.Linhas {
+		situacao := "VALIDA"
+		switch {
+		case len(l.Erros) > 0:
+			situacao = "ERRO"
+		case l.FuncionarioID > 0:
+			situacao = "CRIADO"
+		}
+		erros := make([]string, 0, len(l.Erros))
+		for _, e := range l.Erros {
+			erros = append(erros, e.Campo+": "+e.Mensagem)
+		}
+		_ = w.Write([]string{
+			strconv.Itoa(l.Linha), l.Nome, l.CPF, situacao,
+			idRelatorio(l.PessoaID), idRelatorio(l.FuncionarioID), strings.Join(erros, " | "),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("erro ao gerar relatório da importação: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func idRelatorio(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
+
+// planejarImportacao lê a planilha, associa as colunas aos campos e valida cada linha de dados.
+// Problemas do arquivo como um todo (formato, colunas) voltam como *ErroValidacao.
+func planejarImportacao(arquivo string, conteudo []byte, mapeamento map[string]string) (*planoImportacao, error) {
+	linhas, err := planilha.Ler(arquivo, conteudo)
+	if err != nil {
+		return nil, &ErroValidacao{Campos: []CampoInvalido{{Campo: "file", Mensagem: err.Error()}}}
+	}
+	if len(linhas) == 0 {
+		return nil, &ErroValidacao{Campos: []CampoInvalido{{Campo: "file", Mensagem: "planilha vazia"}}}
+	}
+	colunas, efetivo, verr := mapearColunas(linhas[0], mapeamento)
+	if err := verr.Err(); err != nil {
+		return nil, err
+	}
+
+	plano := &planoImportacao{mapeamento: efetivo}
+	cpfs := map[string]int{}
+	rgs := map[string]int{}
+	for i, valores := range linhas[1:] {
+		if linhaVazia(valores) {
+			continue
+		}
+		if len(plano.linhas) == LimiteLinhasImportacao {
+			return nil, &ErroValidacao{Campos: []CampoInvalido{{Campo: "file",
+				Mensagem: fmt.Sprintf("planilha passa de %d linhas; divida o arquivo", LimiteLinhasImportacao)}}}
+		}
+		campo := func(nome string) string {
+			c, ok := colunas[nome]
+			if !ok || c >= len(valores) {
+				return ""
+			}
+			return valores[c]
+		}
+		linha := entity.LinhaImportacao{Linha: i + 2, Nome: campo("nome"), CPF: campo("cpf")}
+		item, erros, err := validarLinhaFuncionario(campo, linha.Linha, cpfs, rgs)
+		if err != nil {
+			return nil, err
+		}
+		linha.Erros = erros
+		if item != nil {
+			linha.CPF = item.Pessoa.CPF
+			if item.Pessoa.ID > 0 {
+				linha.PessoaExistente = true
+				linha.PessoaID = item.Pessoa.ID
+			}
+		}
+		if len(erros) > 0 {
+			plano.comErro++
+		}
+		plano.linhas = append(plano.linhas, linha)
+		plano.itens = append(plano.itens, item)
+	}
+	if len(plano.linhas) == 0 {
+		return nil, &ErroValidacao{Campos: []CampoInvalido{{Campo: "file", Mensagem: "planilha sem linhas de dados"}}}
+	}
+	return plano, nil
+}
+
+func linhaVazia(valores []string) bool {
+	for _, v := range valores {
+		if v != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizarCabecalho compara cabeçalhos sem acento, caixa e separadores
+func normalizarCabecalho(s string) string {
+	s = semAcento(strings.ToLower(s))
+	s = strings.NewReplacer("_", " ", "-", " ", "/", " ", ".", " ").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// mapearColunas devolve o índice da coluna de cada campo e o mapeamento efetivo (campo -> cabeçalho),
+// que é guardado para a confirmação ler o arquivo do mesmo jeito
+func mapearColunas(cabecalho []string, mapeamento map[string]string) (map[string]int, map[string]string, *ErroValidacao) {
+	verr := &ErroValidacao{}
+	indice := make(map[string]int, len(cabecalho))
+	for i, h := range cabecalho {
+		if n := normalizarCabecalho(h); n != "" {
+			if _, repetido := indice[n]; !repetido {
+				indice[n] = i
+			}
+		}
+	}
+
+	conhecidos := make(map[string]bool, len(camposImportacaoFuncionario))
+	for _, c := range camposImportacaoFuncionario {
+		conhecidos[c.nome] = true
+	}
+	var desconhecidos []string
+	for campo := range mapeamento {
+		if !conhecidos[campo] {
+			desconhecidos = append(desconhecidos, campo)
+		}
+	}
+	sort.Strings(desconhecidos)
+	for _, campo := range desconhecidos {
+		verr.Add("mapeamento."+campo, "campo desconhecido")
+	}
+
+	colunas := map[string]int{}
+	efetivo := map[string]string{}
+	for _, c := range camposImportacaoFuncionario {
+		if h, ok := mapeamento[c.nome]; ok {
+			i, achou := indice[normalizarCabecalho(h)]
+			if !achou {
+				verr.Add("mapeamento."+c.nome, fmt.Sprintf("coluna %q não encontrada na planilha", h))
+				continue
+			}
+			colunas[c.nome] = i
+			efetivo[c.nome] = cabecalho[i]
+			continue
+		}
+		for _, nome := range append([]string{c.nome}, c.apelidos...) {
+			if i, achou := indice[normalizarCabecalho(nome)]; achou {
+				colunas[c.nome] = i
+				efetivo[c.nome] = cabecalho[i]
+				break
+			}
+		}
+		if _, achou := colunas[c.nome]; !achou && c.obrigatorio {
+			verr.Add(c.nome, "coluna obrigatória não encontrada na planilha")
+		}
+	}
+	_, cargo := colunas["cargo"]
+	_, cargoID := colunas["cargo_id"]
+	if !cargo && !cargoID {
+		verr.Add("cargo", "informe a coluna cargo ou cargo_id")
+	}
+	return colunas, efetivo, verr
+}
+
+// validarLinhaFuncionario monta e valida os registros de uma linha com as mesmas regras do cadastro
+// individual. cpfs e rgs guardam a linha onde cada documento já apareceu, para apontar repetições.
+// O erro de retorno é só falha de banco; problemas da linha vêm na lista.
+func validarLinhaFuncionario(campo func(string) string, numero int, cpfs, rgs map[string]int) (*repository.FuncionarioImportado, []entity.ErroImportacao, error) {
+	var erros []entity.ErroImportacao
+	add := func(c, msg string) {
+		erros = append(erros, entity.ErroImportacao{Campo: c, Mensagem: msg})
+	}
+
+	p := &entity.Pessoa{
+		Nome:       campo("nome"),
+		CPF:        campo("cpf"),
+		RG:         campo("rg"),
+		NomeSocial: campo("nome_social"),
+		Sexo:       campo("sexo"),
+	}
+	verrPessoa := validarPessoa(p)
+	for _, c := range verrPessoa.Campos {
+		add(c.Campo, c.Mensagem)
+	}
+
+	f := &entity.Funcionario{
+		PIS:           campo("pis"),
+		CTPF:          campo("ctps"),
+		TipoContrato:  campo("tipo_contrato"),
+		PrazoContrato: campo("prazo_contrato"),
+	}
+	nascimento, err := lerDataImportacao(campo("nascimento"))
+	if err != nil {
+		add("nascimento", err.Error())
+	}
+	admissao, err := lerDataImportacao(campo("admissao"))
+	if err != nil {
+		add("admissao", err.Error())
+	}
+	f.Nascimento = nascimento
+	f.Admissao = admissao
+
+	salario, err := lerValorImportacao(campo("salario"))
+	switch {
+	case err != nil:
+		add("salario", err.Error())
+	case salario <= 0:
+		add("salario", "salário deve ser maior que zero")
+	}
+	salarioReal := salario
+	if v := campo("salario_real"); v != "" {
+		if salarioReal, err = lerValorImportacao(v); err != nil {
+			add("salario_real", err.Error())
+		} else if salarioReal <= 0 {
+			add("salario_real", "salário real deve ser maior que zero")
+		}
+	}
+	f.SalarioInicial = salario
+
+	var cargo *entity.Cargo
+	if v := campo("cargo_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			add("cargo_id", "cargo_id deve ser um número")
+		} else if c, err := repository.GetCargoByID(id); err != nil {
+			return nil, nil, err
+		} else if c == nil || !c.Ativo {
+			add("cargo_id", "cargo não encontrado ou inativo")
+		} else {
+			cargo = c
+		}
+	} else if v := strings.TrimSpace(campo("cargo")); v != "" {
+		// cargo do catálogo quando o título existe; senão fica como texto livre, como no cadastro individual
+		c, err := repository.GetCargoByTitulo(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		if c != nil && c.Ativo {
+			cargo = c
+		} else {
+			f.Cargo = v
+		}
+	} else {
+		add("cargo", "cargo não pode ser vazio")
+	}
+	if cargo != nil {
+		f.CargoID = &cargo.ID
+		f.Cargo = cargo.Titulo
+	}
+
+	if err := validarDocumentosFuncionario(f); err != nil {
+		var verr *ErroValidacao
+		if errors.As(err, &verr) {
+			for _, c := range verr.Campos {
+				if c.Campo == "ctpf" {
+					c.Campo = "ctps"
+				}
+				add(c.Campo, c.Mensagem)
+			}
+		}
+	}
+	if !admissao.IsZero() {
+		if err := validarPrazoContrato(f); err != nil {
+			add("prazo_contrato", err.Error())
+		} else if err := validarTipoContrato(f); err != nil {
+			add("tipo_contrato", err.Error())
+		}
+		if !nascimento.IsZero() && !nascimento.Before(admissao) {
+			add("nascimento", "nascimento deve ser anterior à admissão")
+		}
+	}
+
+	// documentos repetidos na própria planilha
+	if len(verrPessoa.Campos) == 0 {
+		if anterior, ok := cpfs[p.CPF]; ok {
+			add("cpf", fmt.Sprintf("CPF repetido na linha %d", anterior))
+		} else {
+			cpfs[p.CPF] = numero
+		}
+		if anterior, ok := rgs[p.RG]; ok {
+			add("rg", fmt.Sprintf("RG repetido na linha %d", anterior))
+		} else {
+			rgs[p.RG] = numero
+		}
+	}
+
+	// CPF já cadastrado: readmissão da mesma pessoa; senão o RG não pode ser de outra pessoa
+	if len(verrPessoa.Campos) == 0 {
+		existente, err := repository.GetPessoaByCPF(p.CPF)
+		if err != nil {
+			return nil, nil, err
+		}
+		if existente != nil {
+			p = existente
+			f.PessoaID = existente.ID
+			if !admissao.IsZero() {
+				if err := validarReadmissao(f); err != nil {
+					add("cpf", err.Error())
+				}
+			}
+		} else {
+			existe, err := repository.ExistsPessoaByRG(p.RG)
+			if err != nil {
+				return nil, nil, err
+			}
+			if existe {
+				add("rg", "já existe uma pessoa com este RG")
+			}
+		}
+	}
+
+	item := &repository.FuncionarioImportado{
+		Pessoa:      p,
+		Funcionario: f,
+		Salario:     &entity.Salario{Inicio: admissao, Valor: salario},
+		SalarioReal: &entity.SalarioReal{Inicio: admissao, Valor: salarioReal},
+	}
+	if cargo != nil {
+		item.Cargo = entity.NewFuncionarioCargo(0, cargo.ID, truncateDate(admissao), "admissão")
+	}
+	return item, erros, nil
+}
+
+// lerDataImportacao aceita AAAA-MM-DD (e a data das células do XLSX) ou DD/MM/AAAA
+func lerDataImportacao(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, fmt.Errorf("data não informada")
+	}
+	for _, layout := range []string{"2006-01-02", "02/01/2006", "2/1/2006"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("data inválida %q: use DD/MM/AAAA ou AAAA-MM-DD", s)
+}
+
+// lerValorImportacao aceita "1.234,56", "1234,56", "1234.56" e "R$ 1.234,56"
+func lerValorImportacao(s string) (float64, error) {
+	v := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "R$"))
+	if v == "" {
+		return 0, fmt.Errorf("valor não informado")
+	}
+	if strings.Contains(v, ",") {
+		v = strings.ReplaceAll(strings.ReplaceAll(v, ".", ""), ",", ".")
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("valor inválido %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,489 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JornadaRepository define as operações de acesso às jornadas e às suas atribuições
+type JornadaRepository interface {
+	Create(j *entity.Jornada) error
+	GetByID(id int64) (*entity.Jornada, error)
+	Update(j *entity.Jornada) error
+	Delete(id int64) error
+	List() ([]*entity.Jornada, error)
+	EmUso(id int64) (bool, error)
+
+	Atribuir(a *entity.FuncionarioJornada) error
+	GetAtribuicaoByID(id int64) (*entity.FuncionarioJornada, error)
+	RemoverAtribuicao(id int64) error
+	ListByFuncionario(funcionarioID int64) ([]*entity.FuncionarioJornada, error)
+}
+
+// JornadaService mantém os modelos de jornada, atribui jornadas aos funcionários e responde
+// a jornada prevista de um funcionário em um dia
+type JornadaService struct {
+	authService *AuthService
+	logRepo     LogRepository
+	repo        JornadaRepository
+}
+
+func NewJornadaService(auth *AuthService, logRepo LogRepository, repo JornadaRepository) *JornadaService {
+	return &JornadaService{authService: auth, logRepo: logRepo, repo: repo}
+}
+
+// Modelos de jornada oferecidos no cadastro
+const (
+	ModeloJornadaSemanal44h = "SEMANAL_44H"
+	ModeloJornada12x36      = "12X36"
+	ModeloJornadaParcial30h = "PARCIAL_30H"
+)
+
+func diasUteisSemana(periodos ...entity.PeriodoJornada) []entity.DiaJornada {
+	var dias []entity.DiaJornada
+	for d := time.Monday; d <= time.Friday; d++ {
+		dias = append(dias, entity.DiaJornada{Dia: int(d), Periodos: periodos})
+	}
+	return dias
+}
+
+var modelosJornada = map[string]func() *entity.Jornada{
+	// 8h48 de segunda a sexta, com 1h de almoço
+	ModeloJornadaSemanal44h: func() *entity.Jornada {
+		j := entity.NewJornada("44h semanais (segunda a sexta)", entity.JornadaTipoSemanal, 7, false)
+		j.Dias = diasUteisSemana(entity.PeriodoJornada{Inicio: "08:00", Fim: "12:00"}, entity.PeriodoJornada{Inicio: "13:00", Fim: "17:48"})
+		return j
+	},
+	// 12h de plantão (11h de trabalho e 1h de intervalo) por 36h de descanso, inclusive em feriados
+	ModeloJornada12x36: func() *entity.Jornada {
+		j := entity.NewJornada("12x36", entity.JornadaTipo12x36, 2, true)
+		j.Dias = []entity.DiaJornada{{Dia: 0, Periodos: []entity.PeriodoJornada{{Inicio: "07:00", Fim: "12:00"}, {Inicio: "13:00", Fim: "19:00"}}}}
+		return j
+	},
+	// 6h de segunda a sexta, com 15 minutos de intervalo (CLT art. 71, §1º)
+	ModeloJornadaParcial30h: func() *entity.Jornada {
+		j := entity.NewJornada("Tempo parcial 30h", entity.JornadaTipoParcial, 7, false)
+		j.Dias = diasUteisSemana(entity.PeriodoJornada{Inicio: "08:00", Fim: "12:00"}, entity.PeriodoJornada{Inicio: "12:15", Fim: "14:15"})
+		return j
+	},
+}
+
+// jornadaPadrao vale para quem não tem jornada atribuída
+func jornadaPadrao() *entity.Jornada {
+	return modelosJornada[ModeloJornadaSemanal44h]()
+}
+
+// escala são as atribuições de jornada de um funcionário, em ordem de início
+type escala struct {
+	atribuicoes []*entity.FuncionarioJornada
+}
+
+// carregarEscala busca as atribuições de jornada do funcionário no banco
+func carregarEscala(funcionarioID int64) (*escala, error) {
+	atribuicoes, err := repository.ListJornadasByFuncionarioID(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	return &escala{atribuicoes: atribuicoes}, nil
+}
+
+// vigente devolve a atribuição em vigor no dia, ou nil antes da primeira
+func (e *escala) vigente(dia time.Time) *entity.FuncionarioJornada {
+	var atual *entity.FuncionarioJornada
+	for _, a := range e.atribuicoes {
+		if a.Jornada == nil || truncateDate(a.Inicio).After(dia) {
+			continue
+		}
+		if atual == nil || a.Inicio.After(atual.Inicio) {
+			atual = a
+		}
+	}
+	return atual
+}
+
+// periodosPrevistos devolve os períodos de trabalho do dia pela jornada vigente (ou a padrão) e
+// se o dia é folga por feriado. cal pode ser nil para ignorar feriados.
+func (e *escala) periodosPrevistos(dia time.Time, cal *calendario) (periodos []entity.PeriodoJornada, atribuicao *entity.FuncionarioJornada, feriado bool) {
+	dia = truncateDate(dia)
+	atribuicao = e.vigente(dia)
+	a := atribuicao
+	if a == nil {
+		a = &entity.FuncionarioJornada{Jornada: jornadaPadrao()}
+	}
+	periodos = a.Jornada.PeriodosDoDia(a.IndiceNoCiclo(dia))
+	if len(periodos) > 0 && !a.Jornada.TrabalhaFeriados && cal != nil && cal.ehFeriado(dia) {
+		return nil, atribuicao, true
+	}
+	return periodos, atribuicao, false
+}
+
+// minutosPrevistos devolve a jornada do dia em minutos (0 em folgas e feriados)
+func (e *escala) minutosPrevistos(dia time.Time, cal *calendario) int {
+	periodos, _, _ := e.periodosPrevistos(dia, cal)
+	total := 0
+	for _, p := range periodos {
+		total += p.Minutos()
+	}
+	return total
+}
+
+// JornadaPrevistaDTO é a jornada esperada de um funcionário em um dia
+type JornadaPrevistaDTO struct {
+	Data             time.Time               `json:"data"`
+	JornadaID        *int64                  `json:"jornada_id,omitempty"` // nulo quando vale a jornada padrão
+	Jornada          string                  `json:"jornada"`
+	Periodos         []entity.PeriodoJornada `json:"periodos"`
+	MinutosPrevistos int                     `json:"minutos_previstos"`
+	Folga            bool                    `json:"folga"`
+	Feriado          bool                    `json:"feriado"`
+}
+
+func jornadaPrevista(esc *escala, cal *calendario, dia time.Time) *JornadaPrevistaDTO {
+	periodos, a, feriado := esc.periodosPrevistos(dia, cal)
+	dto := &JornadaPrevistaDTO{
+		Data:     truncateDate(dia),
+		Jornada:  jornadaPadrao().Nome + " (padrão)",
+		Periodos: []entity.PeriodoJornada{},
+		Feriado:  feriado,
+	}
+	if a != nil {
+		id := a.JornadaID
+		dto.JornadaID = &id
+		dto.Jornada = a.Jornada.Nome
+	}
+	for _, p := range periodos {
+		dto.Periodos = append(dto.Periodos, p)
+		dto.MinutosPrevistos += p.Minutos()
+	}
+	dto.Folga = dto.MinutosPrevistos == 0
+	return dto
+}
+
+// HorasPrevistas devolve a jornada prevista do funcionário no dia, considerando a jornada vigente e os
+// feriados do calendário. Não exige credenciais: é a API de jornada para os cálculos de ponto e ausências.
+func (s *JornadaService) HorasPrevistas(funcionarioID int64, dia time.Time) (*JornadaPrevistaDTO, error) {
+	dia = truncateDate(dia)
+	esc, err := carregarEscala(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	cal, err := carregarCalendario(dia, dia)
+	if err != nil {
+		return nil, err
+	}
+	return jornadaPrevista(esc, cal, dia), nil
+}
+
+// ConsultarHorasPrevistas lista a jornada prevista do funcionário em cada dia de [inicio, fim] (até 366 dias)
+func (s *JornadaService) ConsultarHorasPrevistas(ctx context.Context, claims Claims, funcionarioID int64, inicio, fim time.Time) ([]*JornadaPrevistaDTO, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	inicio, fim = truncateDate(inicio), truncateDate(fim)
+	if fim.Before(inicio) {
+		return nil, fmt.Errorf("data final anterior à inicial")
+	}
+	if fim.After(inicio.AddDate(0, 0, 365)) {
+		return nil, fmt.Errorf("intervalo máximo de 366 dias")
+	}
+	esc, err := carregarEscala(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	cal, err := carregarCalendario(inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	var lista []*JornadaPrevistaDTO
+	for d := inicio; !d.After(fim); d = d.AddDate(0, 0, 1) {
+		lista = append(lista, jornadaPrevista(esc, cal, d))
+	}
+	return lista, nil
+}
+
+// ModeloJornadaDTO é um modelo de jornada pronto para cadastro
+type ModeloJornadaDTO struct {
+	Modelo  string          `json:"modelo"`
+	Jornada *entity.Jornada `json:"jornada"`
+}
+
+// ListarModelos lista os modelos de jornada disponíveis
+func (s *JornadaService) ListarModelos(ctx context.Context, claims Claims) ([]ModeloJornadaDTO, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	var lista []ModeloJornadaDTO
+	for modelo, novo := range modelosJornada {
+		lista = append(lista, ModeloJornadaDTO{Modelo: modelo, Jornada: novo()})
+	}
+	sort.Slice(lista, func(i, j int) bool { return lista[i].Modelo < lista[j].Modelo })
+	return lista, nil
+}
+
+// NovaJornadaDeModelo monta uma jornada a partir de um modelo, para ser ajustada e cadastrada
+func NovaJornadaDeModelo(modelo string) (*entity.Jornada, error) {
+	novo, ok := modelosJornada[strings.ToUpper(strings.TrimSpace(modelo))]
+	if !ok {
+		return nil, fmt.Errorf("modelo de jornada desconhecido: %s", modelo)
+	}
+	return novo(), nil
+}
+
+func validarJornada(j *entity.Jornada) error {
+	j.Nome = strings.TrimSpace(j.Nome)
+	j.Tipo = strings.ToUpper(strings.TrimSpace(j.Tipo))
+	if j.Nome == "" {
+		return fmt.Errorf("nome da jornada é obrigatório")
+	}
+
+	switch j.Tipo {
+	case entity.JornadaTipoSemanal, entity.JornadaTipoParcial:
+		j.CicloDias = 7
+	case entity.JornadaTipo12x36:
+		j.CicloDias = 2
+	case entity.JornadaTipoPersonalizada:
+		if j.CicloDias < 1 || j.CicloDias > 60 {
+			return fmt.Errorf("ciclo da jornada deve ter de 1 a 60 dias")
+		}
+	default:
+		return fmt.Errorf("tipo de jornada inválido: use SEMANAL, 12X36, PARCIAL ou PERSONALIZADA")
+	}
+
+	vistos := map[int]bool{}
+	var dias []entity.DiaJornada
+	for _, d := range j.Dias {
+		if len(d.Periodos) == 0 {
+			continue
+		}
+		if d.Dia < 0 || d.Dia >= j.CicloDias {
+			return fmt.Errorf("dia %d fora do ciclo de %d dias", d.Dia, j.CicloDias)
+		}
+		if vistos[d.Dia] {
+			return fmt.Errorf("dia %d informado mais de uma vez", d.Dia)
+		}
+		vistos[d.Dia] = true
+
+		// períodos em ordem cronológica; um período antes do fim do anterior é do dia seguinte (turno noturno)
+		primeiro, fimAnterior := 0, -1
+		for i, p := range d.Periodos {
+			ini, fim, err := p.Intervalo()
+			if err != nil {
+				return fmt.Errorf("dia %d: %v", d.Dia, err)
+			}
+			if i == 0 {
+				primeiro = ini
+			} else if ini < fimAnterior {
+				if ini >= primeiro {
+					return fmt.Errorf("dia %d: períodos sobrepostos", d.Dia)
+				}
+				ini, fim = ini+24*60, fim+24*60
+				if ini < fimAnterior {
+					return fmt.Errorf("dia %d: períodos sobrepostos ou fora de ordem", d.Dia)
+				}
+			}
+			fimAnterior = fim
+		}
+		if fimAnterior-primeiro > 24*60 {
+			return fmt.Errorf("dia %d: jornada maior que 24 horas", d.Dia)
+		}
+		dias = append(dias, d)
+	}
+	sort.Slice(dias, func(a, b int) bool { return dias[a].Dia < dias[b].Dia })
+	j.Dias = dias
+	if len(j.Dias) == 0 {
+		return fmt.Errorf("a jornada precisa de ao menos um período de trabalho")
+	}
+
+	total := j.MinutosCiclo()
+	switch j.Tipo {
+	case entity.JornadaTipoSemanal:
+		if total > 44*60 {
+			return fmt.Errorf("jornada semanal acima de 44 horas (CLT art. 58)")
+		}
+	case entity.JornadaTipoParcial:
+		if total > 30*60 {
+			return fmt.Errorf("jornada de tempo parcial acima de 30 horas semanais (CLT art. 58-A)")
+		}
+	case entity.JornadaTipo12x36:
+		if len(j.Dias) != 1 || j.Dias[0].Dia != 0 || total > 12*60 {
+			return fmt.Errorf("a escala 12x36 tem um único dia de trabalho (dia 0) de até 12 horas")
+		}
+	}
+	return nil
+}
+
+func (s *JornadaService) CriarJornada(ctx context.Context, claims Claims, j *entity.Jornada) error {
+	if err := s.authService.Authorize(ctx, claims, "jornada:update"); err != nil {
+		return err
+	}
+	if err := validarJornada(j); err != nil {
+		return err
+	}
+	if err := s.repo.Create(j); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Jornada criada ID=%d %s (%s)", j.ID, j.Nome, j.Tipo),
+	})
+	return nil
+}
+
+func (s *JornadaService) AtualizarJornada(ctx context.Context, claims Claims, j *entity.Jornada) error {
+	if err := s.authService.Authorize(ctx, claims, "jornada:update"); err != nil {
+		return err
+	}
+	atual, err := s.repo.GetByID(j.ID)
+	if err != nil {
+		return err
+	}
+	if atual == nil {
+		return fmt.Errorf("jornada não encontrada")
+	}
+	if err := validarJornada(j); err != nil {
+		return err
+	}
+	if err := s.repo.Update(j); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  4,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Jornada atualizada ID=%d %s (%s)", j.ID, j.Nome, j.Tipo),
+	})
+	return nil
+}
+
+// ExcluirJornada remove uma jornada que não esteja atribuída a nenhum funcionário
+func (s *JornadaService) ExcluirJornada(ctx context.Context, claims Claims, id int64) error {
+	if err := s.authService.Authorize(ctx, claims, "jornada:update"); err != nil {
+		return err
+	}
+	emUso, err := s.repo.EmUso(id)
+	if err != nil {
+		return err
+	}
+	if emUso {
+		return fmt.Errorf("jornada atribuída a funcionários não pode ser excluída")
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  5,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Jornada excluída ID=%d", id),
+	})
+	return nil
+}
+
+func (s *JornadaService) BuscarJornada(ctx context.Context, claims Claims, id int64) (*entity.Jornada, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *JornadaService) ListarJornadas(ctx context.Context, claims Claims) ([]*entity.Jornada, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	return s.repo.List()
+}
+
+// AtribuirJornada define a jornada do funcionário a partir de uma data; a anterior vale até a véspera
+func (s *JornadaService) AtribuirJornada(ctx context.Context, claims Claims, funcionarioID, jornadaID int64, inicio time.Time) (*entity.FuncionarioJornada, error) {
+	if err := s.authService.Authorize(ctx, claims, "jornada:update"); err != nil {
+		return nil, err
+	}
+	if inicio.IsZero() {
+		return nil, fmt.Errorf("data de início é obrigatória")
+	}
+	inicio = truncateDate(inicio)
+
+	f, err := repository.GetFuncionarioByID(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, fmt.Errorf("funcionário não encontrado")
+	}
+	j, err := s.repo.GetByID(jornadaID)
+	if err != nil {
+		return nil, err
+	}
+	if j == nil {
+		return nil, fmt.Errorf("jornada não encontrada")
+	}
+	atuais, err := s.repo.ListByFuncionario(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range atuais {
+		if truncateDate(a.Inicio).Equal(inicio) {
+			return nil, fmt.Errorf("já existe jornada atribuída a partir de %s", inicio.Format("02/01/2006"))
+		}
+	}
+
+	a := entity.NewFuncionarioJornada(funcionarioID, jornadaID, inicio)
+	if err := s.repo.Atribuir(a); err != nil {
+		return nil, err
+	}
+	a.Jornada = j
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Jornada %d atribuída ao funcionário %d a partir de %s", jornadaID, funcionarioID, inicio.Format("2006-01-02")),
+	})
+	return a, nil
+}
+
+// RemoverAtribuicao desfaz uma atribuição de jornada do funcionário
+func (s *JornadaService) RemoverAtribuicao(ctx context.Context, claims Claims, funcionarioID, atribuicaoID int64) error {
+	if err := s.authService.Authorize(ctx, claims, "jornada:update"); err != nil {
+		return err
+	}
+	a, err := s.repo.GetAtribuicaoByID(atribuicaoID)
+	if err != nil {
+		return err
+	}
+	if a == nil || a.FuncionarioID != funcionarioID {
+		return fmt.Errorf("atribuição de jornada não encontrada")
+	}
+	if err := s.repo.RemoverAtribuicao(atribuicaoID); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  5,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Atribuição de jornada ID=%d removida do funcionário %d", atribuicaoID, funcionarioID),
+	})
+	return nil
+}
+
+// ListarAtribuicoes lista o histórico de jornadas do funcionário
+func (s *JornadaService) ListarAtribuicoes(ctx context.Context, claims Claims, funcionarioID int64) ([]*entity.FuncionarioJornada, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	lista, err := s.repo.ListByFuncionario(funcionarioID)
+	if err != nil {
+		return nil, err
+	}
+	if lista == nil {
+		lista = []*entity.FuncionarioJornada{}
+	}
+	return lista, nil
+}
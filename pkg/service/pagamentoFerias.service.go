@@ -0,0 +1,287 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/documentos"
+	"AutoGRH/pkg/utils/textPDF"
+	"context"
+	"fmt"
+	"time"
+)
+
+// PagamentoFeriasRepository define as operações de acesso aos pagamentos de férias
+type PagamentoFeriasRepository interface {
+	GetByID(id int64) (*entity.PagamentoFerias, error)
+	GetByDescansoID(descansoID int64) (*entity.PagamentoFerias, error)
+	List() ([]*entity.PagamentoFerias, error)
+	ListByFuncionarioID(funcionarioID int64) ([]*entity.PagamentoFerias, error)
+	MarcarComoPago(id int64, data time.Time) error
+}
+
+// PagamentoFeriasService controla o pagamento das férias de cada descanso aprovado
+type PagamentoFeriasService struct {
+	authService *AuthService
+	logRepo     LogRepository
+	repo        PagamentoFeriasRepository
+}
+
+func NewPagamentoFeriasService(auth *AuthService, logRepo LogRepository, repo PagamentoFeriasRepository) *PagamentoFeriasService {
+	return &PagamentoFeriasService{authService: auth, logRepo: logRepo, repo: repo}
+}
+
+// gerarPagamentoFerias cria (ou recalcula, se ainda não pago) o pagamento de férias de um descanso aprovado.
+// Usado nas férias coletivas, na geração manual e pelo worker.
+func gerarPagamentoFerias(d *entity.Descanso) (*entity.PagamentoFerias, error) {
+	p, err := calcularPagamentoFerias(d)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.Pago:
+	case p.ID == 0:
+		err = repository.CreatePagamentoFerias(p)
+	default:
+		err = repository.UpdatePagamentoFerias(p)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// calcularPagamentoFerias monta, sem gravar, o pagamento de férias do descanso. Se o descanso já tem
+// pagamento, o novo leva o ID dele; se esse pagamento já foi efetuado, volta ele mesmo.
+func calcularPagamentoFerias(d *entity.Descanso) (*entity.PagamentoFerias, error) {
+	ferias, err := repository.GetFeriasByID(d.FeriasID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar férias do descanso: %w", err)
+	}
+	if ferias == nil {
+		return nil, fmt.Errorf("férias do descanso não encontradas")
+	}
+
+	funcionario, err := repository.GetFuncionarioByID(ferias.FuncionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar funcionário das férias: %w", err)
+	}
+
+	prevista, err := dataPrevistaPagamentoFerias(d.Inicio)
+	if err != nil {
+		return nil, err
+	}
+	novo := entity.NewPagamentoFerias(d, ferias.FuncionarioID, prevista)
+	inss := 0.0
+	if funcionario == nil || funcionario.RecolheINSS() {
+		inss = calcularINSS(novo.ValorBruto)
+	}
+	novo.AplicarDescontos(inss, calcularIRRF(novo.ValorBruto, inss))
+
+	existente, err := repository.GetPagamentoFeriasByDescansoID(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existente != nil {
+		if existente.Pago {
+			return existente, nil
+		}
+		novo.ID = existente.ID
+	}
+	return novo, nil
+}
+
+// dataPrevistaPagamentoFerias é o prazo do art. 145 da CLT: dois dias úteis antes do início do descanso
+func dataPrevistaPagamentoFerias(inicio time.Time) (time.Time, error) {
+	inicio = truncateDate(inicio)
+	cal, err := carregarCalendario(inicio.AddDate(0, 0, -30), inicio)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("erro ao carregar calendário: %w", err)
+	}
+	return cal.diaUtilAntes(inicio, entity.DiasAntecedenciaPagamentoFerias), nil
+}
+
+// GerarParaDescanso cria ou recalcula o pagamento de férias de um descanso aprovado
+func (s *PagamentoFeriasService) GerarParaDescanso(ctx context.Context, claims Claims, descansoID int64) (*entity.PagamentoFerias, error) {
+	if err := s.authService.Authorize(ctx, claims, "ferias:update"); err != nil {
+		return nil, err
+	}
+	d, err := repository.GetDescansoByID(descansoID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar descanso: %w", err)
+	}
+	if d == nil {
+		return nil, fmt.Errorf("descanso não encontrado")
+	}
+	if !d.Aprovado {
+		return nil, fmt.Errorf("descanso ainda não foi aprovado")
+	}
+
+	p, err := gerarPagamentoFerias(d)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe: fmt.Sprintf("Pagamento de férias gerado ID=%d DescansoID=%d Previsto=%s Liquido=%.2f",
+			p.ID, d.ID, p.DataPrevista.Format("2006-01-02"), p.ValorLiquido),
+	})
+	return p, nil
+}
+
+func (s *PagamentoFeriasService) GetByID(ctx context.Context, claims Claims, id int64) (*entity.PagamentoFerias, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *PagamentoFeriasService) GetByDescansoID(ctx context.Context, claims Claims, descansoID int64) (*entity.PagamentoFerias, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByDescansoID(descansoID)
+}
+
+func (s *PagamentoFeriasService) Listar(ctx context.Context, claims Claims) ([]*entity.PagamentoFerias, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	return s.repo.List()
+}
+
+func (s *PagamentoFeriasService) ListarPorFuncionario(ctx context.Context, claims Claims, funcionarioID int64) ([]*entity.PagamentoFerias, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByFuncionarioID(funcionarioID)
+}
+
+// MarcarComoPago registra o pagamento, marca o descanso como pago e fecha as férias se elegível
+func (s *PagamentoFeriasService) MarcarComoPago(ctx context.Context, claims Claims, id int64, data time.Time) error {
+	if err := s.authService.Authorize(ctx, claims, "ferias:update"); err != nil {
+		return err
+	}
+	p, err := s.repo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar pagamento de férias: %w", err)
+	}
+	if p == nil {
+		return fmt.Errorf("pagamento de férias não encontrado")
+	}
+	if p.Pago {
+		return fmt.Errorf("pagamento de férias já está pago")
+	}
+	if err := s.repo.MarcarComoPago(id, data); err != nil {
+		return err
+	}
+
+	if d, derr := repository.GetDescansoByID(p.DescansoID); derr == nil && d != nil && !d.Pago {
+		d.Pago = true
+		if err := repository.UpdateDescanso(d); err != nil {
+			return fmt.Errorf("erro ao marcar descanso como pago: %w", err)
+		}
+		if f, ferr := repository.GetFeriasByID(d.FeriasID); ferr == nil && f != nil {
+			if f.Dias == 0 && f.TercoPago && !f.Pago {
+				_ = repository.MarcarFeriasComoPagas(f.ID)
+			}
+		}
+	}
+	_ = repository.DeleteAvisoByTypeAndRef("PAGAMENTO_FERIAS_PENDENTE", id)
+
+	atraso := ""
+	if truncateDate(data).After(truncateDate(p.DataPrevista)) {
+		atraso = " (fora do prazo legal)"
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  4,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Pagamento de férias pago ID=%d em %s%s", id, data.Format("2006-01-02"), atraso),
+	})
+	return nil
+}
+
+// GerarAvisoRecibo monta o PDF de "aviso e recibo de férias" do pagamento
+func (s *PagamentoFeriasService) GerarAvisoRecibo(ctx context.Context, claims Claims, id int64) ([]byte, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	p, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar pagamento de férias: %w", err)
+	}
+	if p == nil {
+		return nil, nil
+	}
+	d, err := repository.GetDescansoByID(p.DescansoID)
+	if err != nil || d == nil {
+		return nil, fmt.Errorf("descanso do pagamento não encontrado")
+	}
+	ferias, err := repository.GetFeriasByID(d.FeriasID)
+	if err != nil || ferias == nil {
+		return nil, fmt.Errorf("férias do pagamento não encontradas")
+	}
+	funcionario, err := repository.GetFuncionarioByID(p.FuncionarioID)
+	if err != nil || funcionario == nil {
+		return nil, fmt.Errorf("funcionário do pagamento não encontrado")
+	}
+	pessoa, err := repository.GetPessoaByID(funcionario.PessoaID)
+	if err != nil || pessoa == nil {
+		return nil, fmt.Errorf("pessoa do funcionário não encontrada")
+	}
+
+	const data = "02/01/2006"
+	aquisitivoFim := ferias.Inicio.AddDate(0, 0, -1)
+	aquisitivoIni := ferias.Inicio.AddDate(-1, 0, 0)
+	retorno := d.Fim.AddDate(0, 0, 1)
+
+	doc := textPDF.New()
+	doc.Text("AVISO E RECIBO DE FÉRIAS", 16, true)
+	doc.Space(6)
+	doc.Text(fmt.Sprintf("Empregado: %s", pessoa.Nome), 11, false)
+	doc.Text(fmt.Sprintf("CPF: %s    PIS: %s    CTPS: %s", documentos.FormatarCPF(pessoa.CPF),
+		documentos.FormatarPIS(funcionario.PIS), documentos.FormatarCTPS(funcionario.CTPF)), 11, false)
+	doc.Text(fmt.Sprintf("Cargo: %s    Admissão: %s", funcionario.Cargo, funcionario.Admissao.Format(data)), 11, false)
+	doc.Space(8)
+
+	doc.Text("AVISO", 12, true)
+	doc.Text(fmt.Sprintf("Período aquisitivo: %s a %s", aquisitivoIni.Format(data), aquisitivoFim.Format(data)), 11, false)
+	doc.Text(fmt.Sprintf("Período de gozo: %s a %s (%d dias)", d.Inicio.Format(data), d.Fim.Format(data), p.Dias), 11, false)
+	doc.Text(fmt.Sprintf("Retorno ao trabalho: %s", retorno.Format(data)), 11, false)
+	doc.Space(8)
+
+	doc.Text("RECIBO", 12, true)
+	linhas := []struct {
+		rotulo string
+		valor  float64
+	}{
+		{"Férias", p.ValorFerias},
+		{"1/3 constitucional", p.ValorTerco},
+		{"Total bruto", p.ValorBruto},
+		{"(-) INSS", p.DescontoINSS},
+		{"(-) IRRF", p.DescontoIRRF},
+		{"Líquido a receber", p.ValorLiquido},
+	}
+	for _, l := range linhas {
+		doc.TextAt(300, fmt.Sprintf("R$ %.2f", l.valor), 11, false)
+		doc.Text(l.rotulo, 11, false)
+	}
+	doc.Space(8)
+
+	pagamento := "pendente"
+	if p.DataPagamento != nil {
+		pagamento = p.DataPagamento.Format(data)
+	}
+	doc.Text(fmt.Sprintf("Data limite para pagamento (CLT art. 145): %s", p.DataPrevista.Format(data)), 11, false)
+	doc.Text(fmt.Sprintf("Data do pagamento: %s", pagamento), 11, false)
+	doc.Space(30)
+	doc.Text("Recebi a importância líquida acima referente às minhas férias.", 11, false)
+	doc.Space(30)
+	doc.Text("________________________________________", 11, false)
+	doc.Text(pessoa.Nome, 11, false)
+
+	return doc.Bytes(), nil
+}
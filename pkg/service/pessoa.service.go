@@ -2,9 +2,15 @@ package service
 
 import (
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/consulta"
+	"AutoGRH/pkg/utils/documentos"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 )
 
 // PessoaRepository interface usada pelo service
@@ -19,6 +25,7 @@ type PessoaRepository interface {
 	ExistsByRG(ctx context.Context, rg string) (bool, error)
 	SearchByNome(ctx context.Context, nome string) ([]*entity.Pessoa, error)
 	ListAll(ctx context.Context) ([]*entity.Pessoa, error)
+	Buscar(ctx context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Pessoa], error)
 }
 
 type PessoaService struct {
@@ -37,35 +44,27 @@ func NewPessoaService(auth *AuthService, logRepo LogRepository, repo PessoaRepos
 
 // CreatePessoa cria uma nova pessoa
 func (s *PessoaService) CreatePessoa(ctx context.Context, claims Claims, p *entity.Pessoa) error {
-	p.Nome = strings.TrimSpace(p.Nome)
-	p.CPF = strings.TrimSpace(p.CPF)
-	p.RG = strings.TrimSpace(p.RG)
+	verr := validarPessoa(p)
+	if len(verr.Campos) == 0 {
+		exists, err := s.repo.ExistsByCPF(ctx, p.CPF)
+		if err != nil {
+			return err
+		}
+		if exists {
+			verr.Add("cpf", "já existe uma pessoa com este CPF")
+		}
 
-	if p.Nome == "" {
-		return fmt.Errorf("nome não pode ser vazio")
-	}
-	if p.CPF == "" {
-		return fmt.Errorf("CPF não pode ser vazio")
-	}
-	if p.RG == "" {
-		return fmt.Errorf("RG não pode ser vazio")
+		exists, err = s.repo.ExistsByRG(ctx, p.RG)
+		if err != nil {
+			return err
+		}
+		if exists {
+			verr.Add("rg", "já existe uma pessoa com este RG")
+		}
 	}
-
-	exists, err := s.repo.ExistsByCPF(ctx, p.CPF)
-	if err != nil {
-		return err
-	}
-	if exists {
-		return fmt.Errorf("já existe uma pessoa com este CPF")
-	}
-
-	exists, err = s.repo.ExistsByRG(ctx, p.RG)
-	if err != nil {
+	if err := verr.Err(); err != nil {
 		return err
 	}
-	if exists {
-		return fmt.Errorf("já existe uma pessoa com este RG")
-	}
 
 	if err := s.repo.Create(ctx, p); err != nil {
 		return err
@@ -81,6 +80,53 @@ func (s *PessoaService) CreatePessoa(ctx context.Context, claims Claims, p *enti
 	return nil
 }
 
+// validarPessoa apara os campos e grava o CPF só com dígitos, conferindo os verificadores
+func validarPessoa(p *entity.Pessoa) *ErroValidacao {
+	verr := &ErroValidacao{}
+	p.Nome = strings.TrimSpace(p.Nome)
+	p.CPF = strings.TrimSpace(p.CPF)
+	p.RG = strings.TrimSpace(p.RG)
+
+	if p.Nome == "" {
+		verr.Add("nome", "nome não pode ser vazio")
+	}
+	if p.CPF == "" {
+		verr.Add("cpf", "CPF não pode ser vazio")
+	} else if cpf, err := documentos.NormalizarCPF(p.CPF); err != nil {
+		verr.Add("cpf", err.Error())
+	} else {
+		p.CPF = cpf
+	}
+	if p.RG == "" {
+		verr.Add("rg", "RG não pode ser vazio")
+	}
+	validarDadosPessoais(p, verr)
+	return verr
+}
+
+// validarDadosPessoais confere os códigos do eSocial; zero ou vazio significa não informado
+func validarDadosPessoais(p *entity.Pessoa, verr *ErroValidacao) {
+	p.NomeSocial = strings.TrimSpace(p.NomeSocial)
+	p.Sexo = strings.ToUpper(strings.TrimSpace(p.Sexo))
+	p.IdentidadeGenero = strings.ToUpper(strings.TrimSpace(p.IdentidadeGenero))
+
+	if p.Sexo != "" && p.Sexo != entity.SexoMasculino && p.Sexo != entity.SexoFeminino {
+		verr.Add("sexo", "sexo deve ser M ou F")
+	}
+	if p.IdentidadeGenero != "" && !slices.Contains(entity.IdentidadesGenero, p.IdentidadeGenero) {
+		verr.Add("identidade_genero", "identidade de gênero deve ser "+strings.Join(entity.IdentidadesGenero, ", "))
+	}
+	if _, ok := entity.EstadosCivis[p.EstadoCivil]; p.EstadoCivil != 0 && !ok {
+		verr.Add("estado_civil", "estado civil inválido")
+	}
+	if _, ok := entity.GrausInstrucao[p.GrauInstrucao]; p.GrauInstrucao != 0 && !ok {
+		verr.Add("grau_instrucao", "grau de instrução inválido")
+	}
+	if _, ok := entity.RacasCor[p.RacaCor]; p.RacaCor != 0 && !ok {
+		verr.Add("raca_cor", "raça/cor inválida")
+	}
+}
+
 // GetPessoaByID retorna uma pessoa pelo ID
 func (s *PessoaService) GetPessoaByID(ctx context.Context, claims Claims, id int64) (*entity.Pessoa, error) {
 	if id <= 0 {
@@ -94,12 +140,27 @@ func (s *PessoaService) UpdatePessoa(ctx context.Context, claims Claims, p *enti
 	if p.ID <= 0 {
 		return fmt.Errorf("ID inválido")
 	}
-	p.Nome = strings.TrimSpace(p.Nome)
-	p.CPF = strings.TrimSpace(p.CPF)
-	p.RG = strings.TrimSpace(p.RG)
-
+	verr := validarPessoa(p)
 	if p.Nome == "" || p.CPF == "" || p.RG == "" {
-		return fmt.Errorf("nome, CPF e RG não podem ser vazios")
+		verr.Mensagem = "nome, CPF e RG não podem ser vazios"
+	}
+	if len(verr.Campos) == 0 {
+		outra, err := s.repo.GetByCPF(ctx, p.CPF)
+		if err != nil {
+			return err
+		}
+		if outra != nil && outra.ID != p.ID {
+			verr.Add("cpf", "já existe uma pessoa com este CPF")
+		}
+	}
+	if err := verr.Err(); err != nil {
+		return err
+	}
+	// titular anonimizado não volta a ser identificado
+	if atual, err := s.repo.GetByID(ctx, p.ID); err != nil {
+		return err
+	} else if atual != nil && atual.AnonimizadoEm != nil {
+		return ErrPessoaAnonimizada
 	}
 
 	if err := s.repo.Update(ctx, p); err != nil {
@@ -116,11 +177,22 @@ func (s *PessoaService) UpdatePessoa(ctx context.Context, claims Claims, p *enti
 	return nil
 }
 
-// DeletePessoa remove uma pessoa
+// ErrPessoaComVinculos impede apagar quem já foi funcionário: o histórico de vínculos e pagamentos
+// precisa ficar; para apagar os dados pessoais, use a anonimização
+var ErrPessoaComVinculos = errors.New("a pessoa tem vínculos de trabalho registrados; use a anonimização para apagar seus dados pessoais")
+
+// DeletePessoa remove uma pessoa que nunca teve vínculo de trabalho
 func (s *PessoaService) DeletePessoa(ctx context.Context, claims Claims, id int64) error {
 	if id <= 0 {
 		return fmt.Errorf("ID inválido")
 	}
+	vinculos, err := repository.ListFuncionariosByPessoaID(id)
+	if err != nil {
+		return err
+	}
+	if len(vinculos) > 0 {
+		return ErrPessoaComVinculos
+	}
 
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return err
@@ -140,3 +212,67 @@ func (s *PessoaService) DeletePessoa(ctx context.Context, claims Claims, id int6
 func (s *PessoaService) ListPessoas(ctx context.Context, claims Claims) ([]*entity.Pessoa, error) {
 	return s.repo.ListAll(ctx)
 }
+
+// BuscarPessoas lista uma página das pessoas conforme filtros e ordenação
+func (s *PessoaService) BuscarPessoas(ctx context.Context, claims Claims, q consulta.Consulta) (consulta.Pagina[*entity.Pessoa], error) {
+	return s.repo.Buscar(ctx, q)
+}
+
+// VinculoDTO é um contrato de trabalho da pessoa na linha do tempo de vínculos
+type VinculoDTO struct {
+	FuncionarioID  int64      `json:"funcionario_id"`
+	Admissao       time.Time  `json:"admissao"`
+	Demissao       *time.Time `json:"demissao,omitempty"`
+	Ativo          bool       `json:"ativo"`
+	Readmissao     bool       `json:"readmissao"` // há vínculo anterior da mesma pessoa
+	Cargo          string     `json:"cargo"`
+	TipoContrato   string     `json:"tipo_contrato"`
+	SalarioInicial float64    `json:"salario_inicial"`
+	UltimoSalario  float64    `json:"ultimo_salario"` // salário registrado vigente, ou o último do vínculo
+	PeriodosFerias int        `json:"periodos_ferias"`
+}
+
+// ListarVinculos devolve os contratos da pessoa em ordem de admissão
+func (s *PessoaService) ListarVinculos(ctx context.Context, claims Claims, pessoaID int64) ([]VinculoDTO, error) {
+	if err := s.exigirPessoa(ctx, pessoaID); err != nil {
+		return nil, err
+	}
+	funcionarios, err := repository.ListFuncionariosByPessoaID(pessoaID)
+	if err != nil {
+		return nil, err
+	}
+	lista := make([]VinculoDTO, 0, len(funcionarios))
+	for i, f := range funcionarios {
+		v := VinculoDTO{
+			FuncionarioID:  f.ID,
+			Admissao:       f.Admissao,
+			Demissao:       f.Demissao,
+			Ativo:          f.Ativo,
+			Readmissao:     i > 0,
+			Cargo:          f.Cargo,
+			TipoContrato:   f.TipoContrato,
+			SalarioInicial: f.SalarioInicial,
+			UltimoSalario:  f.SalarioInicial,
+		}
+		salarios, err := repository.GetSalariosByFuncionarioID(f.ID)
+		if err != nil {
+			return nil, err
+		}
+		var ultimo *entity.Salario
+		for _, sal := range salarios {
+			if ultimo == nil || sal.Inicio.After(ultimo.Inicio) {
+				ultimo = sal
+			}
+		}
+		if ultimo != nil {
+			v.UltimoSalario = ultimo.Valor
+		}
+		ferias, err := repository.GetFeriasByFuncionarioID(f.ID)
+		if err != nil {
+			return nil, err
+		}
+		v.PeriodosFerias = len(ferias)
+		lista = append(lista, v)
+	}
+	return lista, nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/cadastro"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrPessoaNaoEncontrada é devolvido ao gravar dados de uma pessoa inexistente
+var ErrPessoaNaoEncontrada = errors.New("pessoa não encontrada")
+
+// exigirPessoa confere se a pessoa existe antes de gravar seus dados estruturados
+func (s *PessoaService) exigirPessoa(ctx context.Context, pessoaID int64) error {
+	if pessoaID <= 0 {
+		return fmt.Errorf("ID inválido")
+	}
+	p, err := s.repo.GetByID(ctx, pessoaID)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return ErrPessoaNaoEncontrada
+	}
+	return nil
+}
+
+func (s *PessoaService) registrarAlteracao(ctx context.Context, claims Claims, detalhe string) {
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  4, // ATUALIZAR
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   detalhe,
+	})
+}
+
+// GetEndereco retorna o endereço da pessoa, ou nil se não houver
+func (s *PessoaService) GetEndereco(ctx context.Context, claims Claims, pessoaID int64) (*entity.Endereco, error) {
+	if err := s.exigirPessoa(ctx, pessoaID); err != nil {
+		return nil, err
+	}
+	return repository.GetEnderecoByPessoaID(pessoaID)
+}
+
+// SalvarEndereco grava o endereço da pessoa, substituindo o anterior
+func (s *PessoaService) SalvarEndereco(ctx context.Context, claims Claims, pessoaID int64, e *entity.Endereco) error {
+	if err := s.exigirPessoa(ctx, pessoaID); err != nil {
+		return err
+	}
+	if err := validarEndereco(e).Err(); err != nil {
+		return err
+	}
+	e.PessoaID = pessoaID
+	if err := repository.SaveEndereco(e); err != nil {
+		return err
+	}
+	s.registrarAlteracao(ctx, claims, fmt.Sprintf("Atualizou endereço da pessoa ID=%d", pessoaID))
+	return nil
+}
+
+// RemoverEndereco apaga o endereço da pessoa
+func (s *PessoaService) RemoverEndereco(ctx context.Context, claims Claims, pessoaID int64) error {
+	if err := s.exigirPessoa(ctx, pessoaID); err != nil {
+		return err
+	}
+	if err := repository.DeleteEndereco(pessoaID); err != nil {
+		return err
+	}
+	s.registrarAlteracao(ctx, claims, fmt.Sprintf("Removeu endereço da pessoa ID=%d", pessoaID))
+	return nil
+}
+
+// validarEndereco apara os campos e grava CEP e código do IBGE só com dígitos
+func validarEndereco(e *entity.Endereco) *ErroValidacao {
+	verr := &ErroValidacao{}
+	e.Logradouro = strings.TrimSpace(e.Logradouro)
+	e.Numero = strings.ToUpper(strings.TrimSpace(e.Numero))
+	e.Complemento = strings.TrimSpace(e.Complemento)
+	e.Bairro = strings.TrimSpace(e.Bairro)
+	e.Cidade = strings.TrimSpace(e.Cidade)
+	e.UF = strings.ToUpper(strings.TrimSpace(e.UF))
+
+	if cep, err := cadastro.NormalizarCEP(e.CEP); err != nil {
+		verr.Add("endereco.cep", err.Error())
+	} else {
+		e.CEP = cep
+	}
+	if e.Logradouro == "" {
+		verr.Add("endereco.logradouro", "logradouro não pode ser vazio")
+	}
+	if e.Numero == "" {
+		e.Numero = "S/N"
+	}
+	if e.Cidade == "" {
+		verr.Add("endereco.cidade", "cidade não pode ser vazia")
+	}
+	if !cadastro.UFValida(e.UF) {
+		verr.Add("endereco.uf", "UF inválida")
+	} else if strings.TrimSpace(e.CodigoIBGE) != "" {
+		if codigo, err := cadastro.NormalizarCodigoIBGE(e.CodigoIBGE, e.UF); err != nil {
+			verr.Add("endereco.codigo_ibge", err.Error())
+		} else {
+			e.CodigoIBGE = codigo
+		}
+	} else {
+		e.CodigoIBGE = ""
+	}
+	return verr
+}
+
+// ListarContatos lista os contatos da pessoa
+func (s *PessoaService) ListarContatos(ctx context.Context, claims Claims, pessoaID int64) ([]entity.Contato, error) {
+	if err := s.exigirPessoa(ctx, pessoaID); err != nil {
+		return nil, err
+	}
+	return repository.ListContatosByPessoaID(pessoaID)
+}
+
+// SalvarContatos substitui os contatos da pessoa. Telefones ficam só com dígitos e, quando nenhum
+// contato de um tipo é marcado como principal, o primeiro dele passa a ser.
+func (s *PessoaService) SalvarContatos(ctx context.Context, claims Claims, pessoaID int64, contatos []entity.Contato) error {
+	if err := s.exigirPessoa(ctx, pessoaID); err != nil {
+		return err
+	}
+	if err := validarContatos(contatos).Err(); err != nil {
+		return err
+	}
+	if err := repository.SaveContatos(pessoaID, contatos); err != nil {
+		return err
+	}
+	s.registrarAlteracao(ctx, claims, fmt.Sprintf("Atualizou contatos da pessoa ID=%d", pessoaID))
+	return nil
+}
+
+func validarContatos(contatos []entity.Contato) *ErroValidacao {
+	verr := &ErroValidacao{}
+	principais := map[string]int{}
+	for i := range contatos {
+		c := &contatos[i]
+		campo := fmt.Sprintf("contatos[%d]", i)
+		c.Tipo = strings.ToUpper(strings.TrimSpace(c.Tipo))
+		switch c.Tipo {
+		case entity.ContatoEmail:
+			if email, err := cadastro.NormalizarEmail(c.Valor); err != nil {
+				verr.Add(campo+".valor", err.Error())
+			} else {
+				c.Valor = email
+			}
+		case entity.ContatoTelefone, entity.ContatoCelular, entity.ContatoWhatsApp:
+			tel, err := cadastro.NormalizarTelefone(c.Valor)
+			if err != nil {
+				verr.Add(campo+".valor", err.Error())
+				continue
+			}
+			if c.Tipo != entity.ContatoTelefone && !cadastro.Celular(tel) {
+				verr.Add(campo+".valor", "número de celular deve ter 11 dígitos com 9 após o DDD")
+			}
+			c.Valor = tel
+		default:
+			verr.Add(campo+".tipo", "tipo deve ser TELEFONE, CELULAR, EMAIL ou WHATSAPP")
+			continue
+		}
+		if c.Principal {
+			principais[c.Tipo]++
+			if principais[c.Tipo] > 1 {
+				verr.Add(campo+".principal", "só pode haver um contato principal do tipo "+c.Tipo)
+			}
+		}
+	}
+	if len(verr.Campos) > 0 {
+		return verr
+	}
+	for i := range contatos {
+		if principais[contatos[i].Tipo] == 0 {
+			contatos[i].Principal = true
+			principais[contatos[i].Tipo] = 1
+		}
+	}
+	return verr
+}
+
+// ListarContatosEmergencia lista os contatos de emergência da pessoa
+func (s *PessoaService) ListarContatosEmergencia(ctx context.Context, claims Claims, pessoaID int64) ([]entity.ContatoEmergencia, error) {
+	if err := s.exigirPessoa(ctx, pessoaID); err != nil {
+		return nil, err
+	}
+	return repository.ListContatosEmergenciaByPessoaID(pessoaID)
+}
+
+// SalvarContatosEmergencia substitui os contatos de emergência da pessoa
+func (s *PessoaService) SalvarContatosEmergencia(ctx context.Context, claims Claims, pessoaID int64, contatos []entity.ContatoEmergencia) error {
+	if err := s.exigirPessoa(ctx, pessoaID); err != nil {
+		return err
+	}
+	verr := &ErroValidacao{}
+	for i := range contatos {
+		c := &contatos[i]
+		campo := fmt.Sprintf("contatos_emergencia[%d]", i)
+		c.Nome = strings.TrimSpace(c.Nome)
+		c.Parentesco = strings.ToUpper(strings.TrimSpace(c.Parentesco))
+		if c.Nome == "" {
+			verr.Add(campo+".nome", "nome não pode ser vazio")
+		}
+		if !slices.Contains(entity.Parentescos, c.Parentesco) {
+			verr.Add(campo+".parentesco", "parentesco deve ser "+strings.Join(entity.Parentescos, ", "))
+		}
+		if tel, err := cadastro.NormalizarTelefone(c.Telefone); err != nil {
+			verr.Add(campo+".telefone", err.Error())
+		} else {
+			c.Telefone = tel
+		}
+	}
+	if err := verr.Err(); err != nil {
+		return err
+	}
+	if err := repository.SaveContatosEmergencia(pessoaID, contatos); err != nil {
+		return err
+	}
+	s.registrarAlteracao(ctx, claims, fmt.Sprintf("Atualizou contatos de emergência da pessoa ID=%d", pessoaID))
+	return nil
+}
@@ -0,0 +1,431 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/afd"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// PontoRepository define as operações de acesso às apurações diárias de ponto
+type PontoRepository interface {
+	Upsert(p *entity.PontoDia) error
+	GetDia(funcionarioID int64, dia time.Time) (*entity.PontoDia, error)
+	ListPeriodo(funcionarioID int64, inicio, fim time.Time) ([]*entity.PontoDia, error)
+}
+
+// PontoService importa marcações de relógio de ponto (AFD) e apura a jornada diária, lançando
+// faltas, atrasos e horas extras do mês
+type PontoService struct {
+	authService *AuthService
+	logRepo     LogRepository
+	repo        PontoRepository
+}
+
+func NewPontoService(auth *AuthService, logRepo LogRepository, repo PontoRepository) *PontoService {
+	return &PontoService{authService: auth, logRepo: logRepo, repo: repo}
+}
+
+// ImportacaoPontoDTO resume o resultado de uma importação de AFD
+type ImportacaoPontoDTO struct {
+	Registros        int      `json:"registros"`
+	Marcacoes        int      `json:"marcacoes"`
+	Funcionarios     int      `json:"funcionarios"`
+	Dias             int      `json:"dias"`
+	FaltasLancadas   int      `json:"faltas_lancadas"`
+	AtrasosLancados  int      `json:"atrasos_lancados"`
+	LancamentosBanco int      `json:"lancamentos_banco_horas"` // dias levados ao banco de horas
+	NaoEncontrados   []string `json:"nao_encontrados"`         // PIS/CPF sem funcionário cadastrado
+	Erros            []string `json:"erros"`
+}
+
+// indiceFuncionarios associa PIS e CPF (só dígitos) ao funcionário, preferindo vínculos ativos
+func indiceFuncionarios() (map[string]*entity.Funcionario, error) {
+	funcionarios, err := repository.ListTodosFuncionarios()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar funcionários: %w", err)
+	}
+	idx := map[string]*entity.Funcionario{}
+	add := func(chave string, f *entity.Funcionario) {
+		if len(chave) != 11 {
+			return
+		}
+		if atual, ok := idx[chave]; ok && atual.Ativo && !f.Ativo {
+			return
+		}
+		idx[chave] = f
+	}
+	for _, f := range funcionarios {
+		add(afd.SoDigitos(f.PIS), f)
+		p, err := repository.GetPessoaByID(f.PessoaID)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao buscar pessoa do funcionário %d: %w", f.ID, err)
+		}
+		if p != nil {
+			add(afd.SoDigitos(p.CPF), f)
+		}
+	}
+	return idx, nil
+}
+
+// Importar lê um AFD e apura o ponto dos dias cobertos pelo arquivo pela jornada de cada funcionário.
+// Dias com jornada prevista e sem marcação viram
+// faltas INJUSTIFICADA e jornada não cumprida vira ATRASO, salvo quando o dia já tem ausência
+// justificada (atestado, licença, abono) ou está em férias aprovadas; falta ou atraso lançado à mão
+// no dia é mantido e não é lançado de novo. O lançamento MENSAL dos meses
+// importados é zerado, pois passa a ser substituído pelos registros do ponto. Reimportar o mesmo
+// arquivo, ou um AFD acumulado, não duplica marcações nem faltas: as faltas e atrasos lançados por
+// uma importação anterior são refeitos com as marcações do dia. Para quem participa do banco de
+// horas, as horas extras do dia viram crédito no banco e o atraso vira débito, em vez de falta.
+func (s *PontoService) Importar(ctx context.Context, claims Claims, arquivo io.Reader) (*ImportacaoPontoDTO, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+
+	lido, err := afd.Parse(arquivo, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	if len(lido.Marcacoes) == 0 {
+		return nil, fmt.Errorf("nenhuma marcação de ponto encontrada no arquivo")
+	}
+
+	idx, err := indiceFuncionarios()
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &ImportacaoPontoDTO{
+		Registros:      lido.Registros,
+		Marcacoes:      len(lido.Marcacoes),
+		NaoEncontrados: []string{},
+		Erros:          lido.Erros,
+	}
+	if dto.Erros == nil {
+		dto.Erros = []string{}
+	}
+
+	// marcações por funcionário e dia
+	porFuncionario := map[int64]map[string][]string{}
+	funcs := map[int64]*entity.Funcionario{}
+	naoEncontrados := map[string]bool{}
+	var primeiro, ultimo time.Time
+	for _, m := range lido.Marcacoes {
+		f, ok := idx[m.Identificador]
+		if !ok {
+			if !naoEncontrados[m.Identificador] {
+				naoEncontrados[m.Identificador] = true
+				dto.NaoEncontrados = append(dto.NaoEncontrados, m.Identificador)
+			}
+			continue
+		}
+		dia := truncateDate(m.Momento)
+		if primeiro.IsZero() || dia.Before(primeiro) {
+			primeiro = dia
+		}
+		if dia.After(ultimo) {
+			ultimo = dia
+		}
+		if porFuncionario[f.ID] == nil {
+			porFuncionario[f.ID] = map[string][]string{}
+			funcs[f.ID] = f
+		}
+		chave := chaveDia(dia)
+		porFuncionario[f.ID][chave] = append(porFuncionario[f.ID][chave], m.Momento.Format("15:04"))
+	}
+	if len(porFuncionario) == 0 {
+		return dto, nil
+	}
+
+	hoje := truncateDate(s.authService.clock())
+	cal, err := carregarCalendario(primeiro.AddDate(0, 0, -1), ultimo)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar calendário: %w", err)
+	}
+
+	ids := make([]int64, 0, len(funcs))
+	for id := range funcs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		res, err := s.apurarFuncionario(funcs[id], porFuncionario[id], primeiro, ultimo, hoje, cal)
+		if err != nil {
+			return nil, err
+		}
+		dto.Funcionarios++
+		dto.Dias += res.Dias
+		dto.FaltasLancadas += res.FaltasLancadas
+		dto.AtrasosLancados += res.AtrasosLancados
+		dto.LancamentosBanco += res.LancamentosBanco
+		dto.Erros = append(dto.Erros, res.Erros...)
+	}
+
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe: fmt.Sprintf("Importou AFD: %d marcações, %d funcionários, %s a %s (%d faltas, %d atrasos)",
+			dto.Marcacoes, dto.Funcionarios, primeiro.Format("2006-01-02"), ultimo.Format("2006-01-02"),
+			dto.FaltasLancadas, dto.AtrasosLancados),
+	})
+
+	return dto, nil
+}
+
+// apurarFuncionario apura os dias de [inicio, fim] dentro do vínculo do funcionário. Dias a partir
+// de hoje ainda podem receber marcações: são gravados, mas não lançam faltas nem atrasos.
+func (s *PontoService) apurarFuncionario(
+	f *entity.Funcionario,
+	marcacoes map[string][]string,
+	inicio, fim, hoje time.Time,
+	cal *calendario,
+) (*ImportacaoPontoDTO, error) {
+	res := &ImportacaoPontoDTO{}
+
+	arquivoInicio, arquivoFim := inicio, fim
+	if adm := truncateDate(f.Admissao); adm.After(inicio) {
+		inicio = adm
+	}
+	if f.Demissao != nil && truncateDate(*f.Demissao).Before(fim) {
+		fim = truncateDate(*f.Demissao)
+	}
+	if fim.Before(inicio) {
+		return res, nil
+	}
+
+	// ausências de vários dias podem ter começado antes do período
+	faltas, err := repository.GetFaltasByFuncionarioPeriodo(f.ID, inicio.AddDate(0, -1, 0), fim)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar faltas: %w", err)
+	}
+	descansos, err := repository.GetDescansosByFuncionarioID(f.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar descansos: %w", err)
+	}
+	esc, err := carregarEscala(f.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar jornada: %w", err)
+	}
+	banco, err := configBancoHoras(f.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar banco de horas: %w", err)
+	}
+
+	// a madrugada fecha o turno noturno da véspera, que pode ser o dia anterior ao arquivo
+	if marcacoes, err = atribuirMarcacoesAosTurnos(marcacoes, esc, arquivoInicio, arquivoFim); err != nil {
+		return nil, err
+	}
+	de := inicio
+	if vespera := inicio.AddDate(0, 0, -1); len(marcacoes[chaveDia(vespera)]) > 0 && !vespera.Before(truncateDate(f.Admissao)) {
+		de = vespera
+	}
+
+	meses := map[[2]int]bool{}
+	for d := de; !d.After(fim); d = d.AddDate(0, 0, 1) {
+		novas := marcacoes[chaveDia(d)]
+		parcial := !d.Before(hoje)
+		if parcial && len(novas) == 0 {
+			continue
+		}
+		aus := ausenciasDoDia(faltas, d)
+		emFerias := emDescanso(descansos, d)
+
+		previsto := 0
+		if !aus.justificada && !emFerias {
+			previsto = esc.minutosPrevistos(d, cal)
+		}
+		if previsto == 0 && len(novas) == 0 {
+			// o dia deixou de ter jornada (atestado, férias): o que a importação anterior lançou cai
+			if _, err := refazerLancamentoPonto(aus.faltaPonto, nil); err != nil {
+				return nil, err
+			}
+			if _, err := refazerLancamentoPonto(aus.atrasoPonto, nil); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var atuais []string
+		if existente, err := s.repo.GetDia(f.ID, d); err != nil {
+			return nil, err
+		} else if existente != nil {
+			atuais = existente.Marcacoes
+		}
+
+		dia, err := apurarDia(f.ID, d, juntarMarcacoes(atuais, novas), previsto,
+			d.Weekday() == time.Sunday || cal.ehFeriado(d))
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.Upsert(dia); err != nil {
+			return nil, err
+		}
+		res.Dias++
+		if !d.Before(inicio) {
+			meses[[2]int{int(d.Month()), d.Year()}] = true
+		}
+
+		if parcial {
+			continue
+		}
+		var falta *entity.Falta
+		if dia.Falta && !aus.falta {
+			falta = entity.NewAusencia(entity.FaltaTipoInjustificada, d, 1, f.ID)
+		}
+		if criada, err := refazerLancamentoPonto(aus.faltaPonto, falta); err != nil {
+			return nil, err
+		} else if criada {
+			faltas = append(faltas, falta)
+			res.FaltasLancadas++
+		}
+		if banco.Ativo {
+			lancou, err := lancarPontoNoBanco(banco, dia, aus.atraso || aus.atrasoPonto != nil)
+			if err != nil {
+				return nil, err
+			}
+			if lancou {
+				res.LancamentosBanco++
+			}
+			continue
+		}
+		var atraso *entity.Falta
+		if dia.MinutosAtraso > 0 && !aus.atraso {
+			atraso = entity.NewAtraso(d, dia.MinutosAtraso, f.ID)
+		}
+		if criado, err := refazerLancamentoPonto(aus.atrasoPonto, atraso); err != nil {
+			return nil, err
+		} else if criado {
+			faltas = append(faltas, atraso)
+			res.AtrasosLancados++
+		}
+	}
+
+	// o lançamento MENSAL só é substituído pelo ponto quando o arquivo cobre o mês inteiro do vínculo;
+	// nos meses cobertos em parte ele é mantido, com aviso
+	for m := range meses {
+		primeiroDia := time.Date(m[1], time.Month(m[0]), 1, 0, 0, 0, 0, inicio.Location())
+		ultimoDia := primeiroDia.AddDate(0, 1, -1)
+		if adm := truncateDate(f.Admissao); adm.After(primeiroDia) {
+			primeiroDia = adm
+		}
+		if f.Demissao != nil && truncateDate(*f.Demissao).Before(ultimoDia) {
+			ultimoDia = truncateDate(*f.Demissao)
+		}
+		if !inicio.After(primeiroDia) && !fim.Before(ultimoDia) {
+			if err := repository.SetFaltasMensais(f.ID, m[0], m[1], 0); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		mensais, err := repository.GetFaltasMensais(f.ID, m[0], m[1])
+		if err != nil {
+			return nil, err
+		}
+		if mensais > 0 {
+			res.Erros = append(res.Erros, fmt.Sprintf(
+				"funcionário %d: o arquivo não cobre todo o mês %02d/%d; mantido o lançamento MENSAL de %d falta(s)",
+				f.ID, m[0], m[1], mensais))
+		}
+	}
+	return res, nil
+}
+
+// ausenciasDia resume as faltas lançadas que cobrem um dia
+type ausenciasDia struct {
+	justificada bool          // atestado, licença ou abono: o dia não tem jornada prevista
+	falta       bool          // falta injustificada lançada à mão
+	atraso      bool          // atraso lançado à mão
+	faltaPonto  *entity.Falta // falta lançada por uma importação anterior do ponto
+	atrasoPonto *entity.Falta // atraso lançado por uma importação anterior do ponto
+}
+
+// ausenciasDoDia separa as faltas que cobrem o dia entre justificadas, lançadas à mão e lançadas pelo ponto
+func ausenciasDoDia(faltas []*entity.Falta, dia time.Time) ausenciasDia {
+	var a ausenciasDia
+	for _, f := range faltas {
+		if !f.Individual() {
+			continue
+		}
+		ini := truncateDate(f.Mes)
+		if f.Tipo == entity.FaltaTipoAtraso {
+			if !ini.Equal(dia) {
+				continue
+			}
+			if f.Origem == entity.FaltaOrigemPonto {
+				a.atrasoPonto = f
+			} else {
+				a.atraso = true
+			}
+			continue
+		}
+		dias := f.Quantidade
+		if dias < 1 {
+			dias = 1
+		}
+		if dia.Before(ini) || !dia.Before(ini.AddDate(0, 0, dias)) {
+			continue
+		}
+		switch {
+		case f.Justificada():
+			a.justificada = true
+		case f.Origem == entity.FaltaOrigemPonto:
+			a.faltaPonto = f
+		default:
+			a.falta = true
+		}
+	}
+	return a
+}
+
+// refazerLancamentoPonto leva a falta ou o atraso lançado pela importação (atual, nil se não há) ao
+// apurado agora (novo, nil se não cabe mais): cria, ajusta os minutos ou apaga. Indica se criou.
+func refazerLancamentoPonto(atual, novo *entity.Falta) (bool, error) {
+	switch {
+	case novo == nil && atual == nil:
+		return false, nil
+	case novo == nil:
+		return false, repository.DeleteFalta(atual.ID)
+	case atual == nil:
+		novo.Origem = entity.FaltaOrigemPonto
+		return true, repository.CreateFalta(novo)
+	case atual.Minutos != novo.Minutos:
+		atual.Minutos = novo.Minutos
+		return false, repository.UpdateFalta(atual)
+	}
+	return false, nil
+}
+
+// emDescanso indica se o dia está em um período de férias aprovado
+func emDescanso(descansos []*entity.Descanso, dia time.Time) bool {
+	for _, d := range descansos {
+		if d.Aprovado && !dia.Before(truncateDate(d.Inicio)) && !dia.After(truncateDate(d.Fim)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListarMes lista a apuração diária do ponto de um funcionário no mês
+func (s *PontoService) ListarMes(ctx context.Context, claims Claims, funcionarioID int64, mes, ano int) ([]*entity.PontoDia, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	if mes < 1 || mes > 12 {
+		return nil, fmt.Errorf("mês inválido")
+	}
+	inicio := time.Date(ano, time.Month(mes), 1, 0, 0, 0, 0, time.Local)
+	lista, err := s.repo.ListPeriodo(funcionarioID, inicio, inicio.AddDate(0, 1, -1))
+	if err != nil {
+		return nil, err
+	}
+	if lista == nil {
+		lista = []*entity.PontoDia{}
+	}
+	return lista, nil
+}
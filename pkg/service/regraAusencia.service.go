@@ -0,0 +1,378 @@
+package service
+
+import (
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// RegraAusenciaRepository define as operações de acesso às regras de limite de ausência
+type RegraAusenciaRepository interface {
+	Create(r *entity.RegraAusencia) error
+	GetByID(id int64) (*entity.RegraAusencia, error)
+	Update(r *entity.RegraAusencia) error
+	Delete(id int64) error
+	List() ([]*entity.RegraAusencia, error)
+}
+
+// RegraAusenciaService mantém as regras que limitam ausências simultâneas por grupo
+type RegraAusenciaService struct {
+	authService *AuthService
+	logRepo     LogRepository
+	repo        RegraAusenciaRepository
+}
+
+func NewRegraAusenciaService(auth *AuthService, logRepo LogRepository, repo RegraAusenciaRepository) *RegraAusenciaService {
+	return &RegraAusenciaService{authService: auth, logRepo: logRepo, repo: repo}
+}
+
+func validarRegraAusencia(r *entity.RegraAusencia) error {
+	r.Escopo = strings.ToUpper(strings.TrimSpace(r.Escopo))
+	r.Modo = strings.ToUpper(strings.TrimSpace(r.Modo))
+	r.Referencia = strings.TrimSpace(r.Referencia)
+	if r.Modo == "" {
+		r.Modo = entity.RegraAusenciaModoAviso
+	}
+	if r.Escopo != entity.RegraAusenciaEscopoCargo && r.Escopo != entity.RegraAusenciaEscopoDepartamento {
+		return fmt.Errorf("escopo inválido: use CARGO ou DEPARTAMENTO")
+	}
+	if r.Referencia == "" {
+		return fmt.Errorf("referência da regra é obrigatória")
+	}
+	if r.Escopo == entity.RegraAusenciaEscopoDepartamento {
+		departamentos, err := repository.ListDepartamentos()
+		if err != nil {
+			return fmt.Errorf("erro ao listar departamentos: %w", err)
+		}
+		existe := false
+		for _, d := range departamentos {
+			existe = existe || strings.EqualFold(strings.TrimSpace(d.Nome), r.Referencia)
+		}
+		if !existe {
+			return fmt.Errorf("departamento %q não encontrado", r.Referencia)
+		}
+	}
+	if r.MaxAusentes < 1 {
+		return fmt.Errorf("max_ausentes deve ser maior que zero")
+	}
+	if r.Modo != entity.RegraAusenciaModoAviso && r.Modo != entity.RegraAusenciaModoBloqueio {
+		return fmt.Errorf("modo inválido: use AVISO ou BLOQUEIO")
+	}
+	return nil
+}
+
+func (s *RegraAusenciaService) Criar(ctx context.Context, claims Claims, r *entity.RegraAusencia) error {
+	if err := s.authService.Authorize(ctx, claims, "ferias:update"); err != nil {
+		return err
+	}
+	if err := validarRegraAusencia(r); err != nil {
+		return err
+	}
+	r.Ativo = true
+	if err := s.repo.Create(r); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  3,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Regra de ausência criada ID=%d %s=%s max=%d modo=%s", r.ID, r.Escopo, r.Referencia, r.MaxAusentes, r.Modo),
+	})
+	return nil
+}
+
+func (s *RegraAusenciaService) Atualizar(ctx context.Context, claims Claims, r *entity.RegraAusencia) error {
+	if err := s.authService.Authorize(ctx, claims, "ferias:update"); err != nil {
+		return err
+	}
+	atual, err := s.repo.GetByID(r.ID)
+	if err != nil {
+		return err
+	}
+	if atual == nil {
+		return fmt.Errorf("regra de ausência não encontrada")
+	}
+	if err := validarRegraAusencia(r); err != nil {
+		return err
+	}
+	if err := s.repo.Update(r); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  4,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Regra de ausência atualizada ID=%d max=%d modo=%s ativo=%t", r.ID, r.MaxAusentes, r.Modo, r.Ativo),
+	})
+	return nil
+}
+
+func (s *RegraAusenciaService) Excluir(ctx context.Context, claims Claims, id int64) error {
+	if err := s.authService.Authorize(ctx, claims, "ferias:update"); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	_, _ = s.logRepo.Create(ctx, LogEntry{
+		EventoID:  5,
+		UsuarioID: &claims.UserID,
+		Quando:    s.authService.clock(),
+		Detalhe:   fmt.Sprintf("Regra de ausência excluída ID=%d", id),
+	})
+	return nil
+}
+
+func (s *RegraAusenciaService) Listar(ctx context.Context, claims Claims) ([]*entity.RegraAusencia, error) {
+	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
+		return nil, err
+	}
+	return s.repo.List()
+}
+
+// gruposAusencia resolve a quais grupos um funcionário pertence num dia: o cargo vem do descanso e os
+// departamentos, das alocações vigentes no dia. As alocações só são carregadas se houver regra por departamento.
+type gruposAusencia struct {
+	alocacoes     map[int64][]*entity.AlocacaoFuncionario
+	departamentos map[int64]string // nome por ID
+}
+
+// carregarGruposAusencia lê departamentos e alocações dos funcionários, se alguma regra for por departamento
+func carregarGruposAusencia(regras []*entity.RegraAusencia, funcionarioIDs []int64) (*gruposAusencia, error) {
+	g := &gruposAusencia{alocacoes: map[int64][]*entity.AlocacaoFuncionario{}, departamentos: map[int64]string{}}
+	porDepartamento := false
+	for _, r := range regras {
+		porDepartamento = porDepartamento || (r.Ativo && r.Escopo == entity.RegraAusenciaEscopoDepartamento)
+	}
+	if !porDepartamento {
+		return g, nil
+	}
+
+	departamentos, err := repository.ListDepartamentos()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar departamentos: %w", err)
+	}
+	for _, d := range departamentos {
+		g.departamentos[d.ID] = strings.TrimSpace(d.Nome)
+	}
+	for _, id := range funcionarioIDs {
+		if _, ok := g.alocacoes[id]; ok {
+			continue
+		}
+		if g.alocacoes[id], err = repository.ListAlocacoesByFuncionarioID(id); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// regraAplica indica se a regra cobre o funcionário, com o cargo informado, no dia
+func (g *gruposAusencia) regraAplica(r *entity.RegraAusencia, funcionarioID int64, cargo string, dia time.Time) bool {
+	referencia := strings.TrimSpace(r.Referencia)
+	switch r.Escopo {
+	case entity.RegraAusenciaEscopoCargo:
+		return strings.EqualFold(referencia, strings.TrimSpace(cargo))
+	case entity.RegraAusenciaEscopoDepartamento:
+		for _, a := range alocacoesVigentes(g.alocacoes[funcionarioID], dia) {
+			if strings.EqualFold(referencia, g.departamentos[a.DepartamentoID]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// regraAplicaNoPeriodo indica se a regra cobre o funcionário em algum dia de [inicio, fim]
+func (g *gruposAusencia) regraAplicaNoPeriodo(r *entity.RegraAusencia, funcionarioID int64, cargo string, inicio, fim time.Time) bool {
+	for dia := truncateDate(inicio); !dia.After(truncateDate(fim)); dia = dia.AddDate(0, 0, 1) {
+		if g.regraAplica(r, funcionarioID, cargo, dia) {
+			return true
+		}
+	}
+	return false
+}
+
+// calcularConflitosAusencia percorre [inicio, fim] dia a dia contando funcionários distintos ausentes
+// por regra e devolve os intervalos em que o limite foi excedido
+func calcularConflitosAusencia(regras []*entity.RegraAusencia, grupos *gruposAusencia, descansos []*entity.DescansoCalendario, inicio, fim time.Time) []entity.ConflitoAusencia {
+	inicio, fim = truncateDate(inicio), truncateDate(fim)
+	var conflitos []entity.ConflitoAusencia
+
+	for _, r := range regras {
+		if !r.Ativo {
+			continue
+		}
+		var doGrupo []*entity.DescansoCalendario
+		for _, d := range descansos {
+			if grupos.regraAplicaNoPeriodo(r, d.FuncionarioID, d.Cargo, d.Inicio, d.Fim) {
+				doGrupo = append(doGrupo, d)
+			}
+		}
+		if len(doGrupo) <= r.MaxAusentes {
+			continue
+		}
+
+		var atual *entity.ConflitoAusencia
+		for dia := inicio; !dia.After(fim); dia = dia.AddDate(0, 0, 1) {
+			ausentes := map[int64]bool{}
+			for _, d := range doGrupo {
+				if !truncateDate(d.Inicio).After(dia) && !truncateDate(d.Fim).Before(dia) &&
+					grupos.regraAplica(r, d.FuncionarioID, d.Cargo, dia) {
+					ausentes[d.FuncionarioID] = true
+				}
+			}
+			if len(ausentes) > r.MaxAusentes {
+				if atual == nil {
+					atual = &entity.ConflitoAusencia{
+						RegraID:    r.ID,
+						Escopo:     r.Escopo,
+						Referencia: r.Referencia,
+						Modo:       r.Modo,
+						Inicio:     dia,
+						Limite:     r.MaxAusentes,
+					}
+				}
+				atual.Fim = dia
+				if len(ausentes) > atual.Ausentes {
+					atual.Ausentes = len(ausentes)
+				}
+				continue
+			}
+			if atual != nil {
+				conflitos = append(conflitos, *atual)
+				atual = nil
+			}
+		}
+		if atual != nil {
+			conflitos = append(conflitos, *atual)
+		}
+	}
+	return conflitos
+}
+
+// conflitosDosDescansos carrega os grupos dos funcionários dos descansos e calcula os conflitos
+func conflitosDosDescansos(regras []*entity.RegraAusencia, descansos []*entity.DescansoCalendario, inicio, fim time.Time) ([]entity.ConflitoAusencia, error) {
+	ids := make([]int64, 0, len(descansos))
+	for _, d := range descansos {
+		ids = append(ids, d.FuncionarioID)
+	}
+	grupos, err := carregarGruposAusencia(regras, ids)
+	if err != nil {
+		return nil, err
+	}
+	return calcularConflitosAusencia(regras, grupos, descansos, inicio, fim), nil
+}
+
+// verificarLimitesAusencia simula um novo descanso do funcionário em [inicio, fim] e retorna
+// os conflitos com as regras ativas do seu grupo
+func verificarLimitesAusencia(funcionarioID int64, inicio, fim time.Time) ([]entity.ConflitoAusencia, error) {
+	regras, err := repository.ListRegrasAusenciaAtivas()
+	if err != nil {
+		return nil, err
+	}
+	if len(regras) == 0 {
+		return nil, nil
+	}
+	funcionario, err := repository.GetFuncionarioByID(funcionarioID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar funcionário: %w", err)
+	}
+	if funcionario == nil {
+		return nil, fmt.Errorf("funcionário não encontrado")
+	}
+
+	grupos, err := carregarGruposAusencia(regras, []int64{funcionarioID})
+	if err != nil {
+		return nil, err
+	}
+	aplicaveis := make([]*entity.RegraAusencia, 0, len(regras))
+	for _, r := range regras {
+		if grupos.regraAplicaNoPeriodo(r, funcionarioID, funcionario.Cargo, inicio, fim) {
+			aplicaveis = append(aplicaveis, r)
+		}
+	}
+	if len(aplicaveis) == 0 {
+		return nil, nil
+	}
+
+	descansos, err := repository.ListDescansosCalendario(inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	descansos = append(descansos, &entity.DescansoCalendario{
+		FuncionarioID: funcionarioID,
+		Cargo:         funcionario.Cargo,
+		Inicio:        inicio,
+		Fim:           fim,
+	})
+	return conflitosDosDescansos(aplicaveis, descansos, inicio, fim)
+}
+
+// primeiroBloqueio retorna o primeiro conflito de uma regra em modo BLOQUEIO, se houver
+func primeiroBloqueio(conflitos []entity.ConflitoAusencia) *entity.ConflitoAusencia {
+	for i := range conflitos {
+		if conflitos[i].Modo == entity.RegraAusenciaModoBloqueio {
+			return &conflitos[i]
+		}
+	}
+	return nil
+}
+
+// aplicarLimitesAusencia bloqueia o descanso se alguma regra em BLOQUEIO for excedida;
+// nas regras em AVISO apenas devolve os conflitos para serem registrados
+func aplicarLimitesAusencia(funcionarioID int64, inicio, fim time.Time) ([]entity.ConflitoAusencia, error) {
+	conflitos, err := verificarLimitesAusencia(funcionarioID, inicio, fim)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao verificar limite de ausências: %w", err)
+	}
+	if b := primeiroBloqueio(conflitos); b != nil {
+		return conflitos, fmt.Errorf("limite de ausências excedido para %s %s: %d ausentes (limite %d) entre %s e %s",
+			strings.ToLower(b.Escopo), b.Referencia, b.Ausentes, b.Limite,
+			b.Inicio.Format("02/01/2006"), b.Fim.Format("02/01/2006"))
+	}
+	return conflitos, nil
+}
+
+// registrarAvisoConflito cria um aviso para o descanso criado acima do limite de ausências. Em modo
+// AVISO ele é a única saída da regra: o erro volta para o chamador registrar no log de auditoria.
+func registrarAvisoConflito(descansoID, funcionarioID int64, conflitos []entity.ConflitoAusencia) error {
+	if len(conflitos) == 0 {
+		return nil
+	}
+	nome, err := repository.GetFuncionarioNomeByID(funcionarioID)
+	if err != nil {
+		// sem o nome o aviso ainda sai, com o ID do funcionário
+		log.Printf("erro ao buscar nome do funcionário %d para o aviso de conflito: %v", funcionarioID, err)
+	}
+	c := conflitos[0]
+	ref := descansoID
+	err = repository.CreateAviso(&entity.Aviso{
+		Tipo: "CONFLITO_AUSENCIA",
+		Mensagem: fmt.Sprintf("Descanso de %s excede o limite de ausências de %s %s (%d ausentes, limite %d) entre %s e %s.",
+			firstOrID(nome, funcionarioID), strings.ToLower(c.Escopo), c.Referencia, c.Ausentes, c.Limite,
+			c.Inicio.Format("02/01/2006"), c.Fim.Format("02/01/2006")),
+		ReferenciaID: &ref,
+		CriadoEm:     time.Now(),
+		Ativo:        true,
+	})
+	if err != nil {
+		log.Printf("erro ao gravar aviso de conflito de ausência do descanso %d: %v", descansoID, err)
+		return fmt.Errorf("erro ao gravar aviso de conflito de ausência: %w", err)
+	}
+	return nil
+}
+
+// detalheConflitos complementa o log de auditoria do descanso com os conflitos e a falha do aviso
+func detalheConflitos(conflitos []entity.ConflitoAusencia, avisoErr error) string {
+	if len(conflitos) == 0 {
+		return ""
+	}
+	if avisoErr != nil {
+		return fmt.Sprintf(" Conflitos=%d (aviso não gravado: %v)", len(conflitos), avisoErr)
+	}
+	return fmt.Sprintf(" Conflitos=%d", len(conflitos))
+}
@@ -67,6 +67,11 @@ func (s *SalarioService) CriarSalario(ctx context.Context, claims Claims, funcio
 	return novo, nil
 }
 
+// AvisoFaixaSalarial indica se o valor está fora da faixa do cargo do funcionário ("" quando não está)
+func (s *SalarioService) AvisoFaixaSalarial(funcionarioID int64, valor float64) string {
+	return avisoFaixaSalarial(funcionarioID, "Salário registrado", valor)
+}
+
 // ListSalarios retorna todos os salários registrados de um funcionário
 func (s *SalarioService) ListSalarios(ctx context.Context, claims Claims, funcionarioID int64) ([]*entity.Salario, error) {
 	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
@@ -68,6 +68,11 @@ func (s *SalarioRealService) CriarSalarioReal(ctx context.Context, claims Claims
 	return novo, nil
 }
 
+// AvisoFaixaSalarial indica se o valor está fora da faixa do cargo do funcionário ("" quando não está)
+func (s *SalarioRealService) AvisoFaixaSalarial(funcionarioID int64, valor float64) string {
+	return avisoFaixaSalarial(funcionarioID, "Salário real", valor)
+}
+
 // GetSalarioRealAtual retorna o salário real atual de um funcionário
 func (s *SalarioRealService) GetSalarioRealAtual(ctx context.Context, claims Claims, funcionarioID int64) (*entity.SalarioReal, error) {
 	if err := s.authService.Authorize(ctx, claims, ""); err != nil {
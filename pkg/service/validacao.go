@@ -0,0 +1,40 @@
+package service
+
+import "strings"
+
+// CampoInvalido é o erro de validação de um campo da requisição
+type CampoInvalido struct {
+	Campo    string `json:"campo"`
+	Mensagem string `json:"mensagem"`
+}
+
+// ErroValidacao reúne os campos inválidos de um cadastro; os controllers o devolvem campo a campo.
+// Sem Mensagem, o texto do erro junta as mensagens dos campos.
+type ErroValidacao struct {
+	Mensagem string
+	Campos   []CampoInvalido
+}
+
+func (e *ErroValidacao) Error() string {
+	if e.Mensagem != "" {
+		return e.Mensagem
+	}
+	msgs := make([]string, 0, len(e.Campos))
+	for _, c := range e.Campos {
+		msgs = append(msgs, c.Mensagem)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Add registra o erro de um campo
+func (e *ErroValidacao) Add(campo, mensagem string) {
+	e.Campos = append(e.Campos, CampoInvalido{Campo: campo, Mensagem: mensagem})
+}
+
+// Err devolve o erro quando há campos inválidos, ou nil
+func (e *ErroValidacao) Err() error {
+	if len(e.Campos) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,117 @@
+package afd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Marcacao é um registro de marcação de ponto do AFD (Arquivo Fonte de Dados)
+type Marcacao struct {
+	NSR           int64     // número sequencial do registro no REP
+	Momento       time.Time // data e hora da marcação (horário de parede do relógio)
+	Identificador string    // PIS (layout da Portaria 1510) ou CPF (Portaria 671), 11 dígitos
+	Linha         int
+}
+
+// Resultado reúne as marcações lidas e as linhas de marcação que não puderam ser interpretadas
+type Resultado struct {
+	Marcacoes []Marcacao
+	Registros int // linhas lidas, de qualquer tipo
+	Erros     []string
+}
+
+// Parse lê um AFD. Aceita o layout da Portaria 671/2021 (registros tipo 3 do REP-C e tipo 7 do REP-P,
+// com data "AAAA-MM-DDThh:mm:00-0300" e CPF) e o antigo da Portaria 1510/2009 (tipo 3 com data
+// "ddmmaaaa", hora "hhmm" e PIS). Cabeçalho, trailer e registros de ajuste são ignorados.
+func Parse(r io.Reader, loc *time.Location) (*Resultado, error) {
+	res := &Resultado{}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	linha := 0
+	for sc.Scan() {
+		linha++
+		l := strings.TrimRight(sc.Text(), "\r\n ")
+		if l == "" {
+			continue
+		}
+		res.Registros++
+		if len(l) < 10 {
+			res.Erros = append(res.Erros, fmt.Sprintf("linha %d: registro curto demais", linha))
+			continue
+		}
+		tipo := l[9]
+		if tipo != '3' && tipo != '7' {
+			continue
+		}
+
+		m, err := parseMarcacao(l, loc)
+		if err != nil {
+			res.Erros = append(res.Erros, fmt.Sprintf("linha %d: %v", linha, err))
+			continue
+		}
+		m.Linha = linha
+		res.Marcacoes = append(res.Marcacoes, m)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler AFD: %w", err)
+	}
+	return res, nil
+}
+
+func parseMarcacao(l string, loc *time.Location) (Marcacao, error) {
+	var m Marcacao
+	nsr, err := strconv.ParseInt(l[:9], 10, 64)
+	if err != nil {
+		return m, fmt.Errorf("NSR inválido")
+	}
+	m.NSR = nsr
+
+	// Portaria 671: NSR(9) tipo(1) data/hora(24) CPF(12) ...
+	if len(l) >= 46 && l[14] == '-' {
+		t, err := time.Parse("2006-01-02T15:04:05-0700", l[10:34])
+		if err != nil {
+			return m, fmt.Errorf("data/hora inválida: %q", l[10:34])
+		}
+		// mantém o horário registrado pelo relógio, no fuso da empresa
+		m.Momento = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+		m.Identificador, err = identificador(l[34:46])
+		return m, err
+	}
+
+	// Portaria 1510: NSR(9) tipo(1) data ddmmaaaa(8) hora hhmm(4) PIS(12)
+	if l[9] == '3' && len(l) >= 34 {
+		t, err := time.ParseInLocation("020120061504", l[10:22], loc)
+		if err != nil {
+			return m, fmt.Errorf("data/hora inválida: %q", l[10:22])
+		}
+		m.Momento = t
+		m.Identificador, err = identificador(l[22:34])
+		return m, err
+	}
+	return m, fmt.Errorf("layout de marcação não reconhecido")
+}
+
+// identificador normaliza PIS/CPF de 12 posições (com zero à esquerda) para 11 dígitos
+func identificador(campo string) (string, error) {
+	d := SoDigitos(campo)
+	if len(d) != len(strings.TrimSpace(campo)) || len(d) < 11 {
+		return "", fmt.Errorf("PIS/CPF inválido: %q", campo)
+	}
+	return d[len(d)-11:], nil
+}
+
+// SoDigitos remove pontuação de PIS/CPF para comparação
+func SoDigitos(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
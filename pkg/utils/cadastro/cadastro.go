@@ -0,0 +1,95 @@
+// Package cadastro valida CEP, UF, código IBGE e telefones e interpreta os endereços e contatos
+// antigos, gravados como texto livre.
+package cadastro
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// codigosUF são os códigos IBGE das unidades da federação, prefixo dos códigos de município
+var codigosUF = map[string]string{
+	"RO": "11", "AC": "12", "AM": "13", "RR": "14", "PA": "15", "AP": "16", "TO": "17",
+	"MA": "21", "PI": "22", "CE": "23", "RN": "24", "PB": "25", "PE": "26", "AL": "27", "SE": "28", "BA": "29",
+	"MG": "31", "ES": "32", "RJ": "33", "SP": "35",
+	"PR": "41", "SC": "42", "RS": "43",
+	"MS": "50", "MT": "51", "GO": "52", "DF": "53",
+}
+
+func soDigitos(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// UFValida indica se a sigla é de uma unidade da federação
+func UFValida(uf string) bool {
+	_, ok := codigosUF[strings.ToUpper(strings.TrimSpace(uf))]
+	return ok
+}
+
+// NormalizarCEP devolve os 8 dígitos do CEP, com ou sem hífen
+func NormalizarCEP(cep string) (string, error) {
+	d := soDigitos(cep)
+	if len(d) != 8 || len(strings.Trim(cep, "0123456789-. ")) > 0 {
+		return "", fmt.Errorf("CEP deve ter 8 dígitos")
+	}
+	return d, nil
+}
+
+// NormalizarCodigoIBGE confere o código de município do IBGE (7 dígitos) contra a UF
+func NormalizarCodigoIBGE(codigo, uf string) (string, error) {
+	d := soDigitos(codigo)
+	if len(d) != 7 || d != strings.TrimSpace(codigo) {
+		return "", fmt.Errorf("código IBGE do município deve ter 7 dígitos")
+	}
+	if prefixo, ok := codigosUF[strings.ToUpper(strings.TrimSpace(uf))]; ok && d[:2] != prefixo {
+		return "", fmt.Errorf("código IBGE %s não é de município de %s", d, strings.ToUpper(uf))
+	}
+	return d, nil
+}
+
+// NormalizarTelefone devolve DDD + número só com dígitos: 10 dígitos (fixo) ou 11 (celular, começando
+// por 9). Aceita o prefixo +55 e o zero de longa distância.
+func NormalizarTelefone(tel string) (string, error) {
+	d := soDigitos(tel)
+	if len(strings.Trim(tel, "0123456789()+-. ")) > 0 {
+		return "", fmt.Errorf("telefone deve conter só números")
+	}
+	if strings.HasPrefix(strings.TrimSpace(tel), "+55") || (len(d) > 11 && strings.HasPrefix(d, "55")) {
+		d = d[2:]
+	}
+	if len(d) > 10 && strings.HasPrefix(d, "0") {
+		d = d[1:]
+	}
+	if len(d) != 10 && len(d) != 11 {
+		return "", fmt.Errorf("telefone deve ter DDD e número (10 ou 11 dígitos)")
+	}
+	if d[0] == '0' || d[1] == '0' {
+		return "", fmt.Errorf("DDD inválido")
+	}
+	if len(d) == 11 && d[2] != '9' {
+		return "", fmt.Errorf("celular deve começar por 9 depois do DDD")
+	}
+	return d, nil
+}
+
+// Celular indica se o telefone normalizado é de celular
+func Celular(tel string) bool {
+	return len(tel) == 11 && tel[2] == '9'
+}
+
+// NormalizarEmail confere o formato do e-mail e o devolve em minúsculas
+func NormalizarEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	a, err := mail.ParseAddress(email)
+	if err != nil || a.Address != email || !strings.Contains(email[strings.LastIndex(email, "@"):], ".") {
+		return "", fmt.Errorf("e-mail inválido")
+	}
+	return strings.ToLower(email), nil
+}
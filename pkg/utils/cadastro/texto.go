@@ -0,0 +1,185 @@
+package cadastro
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Endereco é o resultado da leitura de um endereço em texto livre
+type Endereco struct {
+	CEP         string
+	Logradouro  string
+	Numero      string
+	Complemento string
+	Bairro      string
+	Cidade      string
+	UF          string
+}
+
+// Contato é um telefone ou e-mail encontrado no texto; Tipo segue entity.Contato*
+type Contato struct {
+	Tipo  string
+	Valor string
+}
+
+// Emergencia é um contato de emergência encontrado no texto
+type Emergencia struct {
+	Nome       string
+	Parentesco string
+	Telefone   string
+}
+
+var (
+	reCEP          = regexp.MustCompile(`\b\d{5}-?\d{3}\b`)
+	reUFFinal      = regexp.MustCompile(`[\s,/-]([A-Z]{2})[\s.]*$`)
+	reNumero       = regexp.MustCompile(`(?i)^(n[º°o]?\.?\s*)?(\d+[a-z]?|s/?n)$`)
+	reNumeroFinal  = regexp.MustCompile(`(?i)^(.*\D)\s+(?:n[º°o]?\.?\s*)?(\d+[a-z]?)$`)
+	reComplemento  = regexp.MustCompile(`(?i)^(ap(to|artamento)?\.?|casa|bloco|bl\.?|sala|lote|qd\.?|quadra|fundos)\b`)
+	reEmail        = regexp.MustCompile(`[^\s@,;/]+@[^\s@,;/]+\.[^\s@,;/]+`)
+	reTelefone     = regexp.MustCompile(`(\+?55[\s.-]*)?\(?0?\d{2}\)?[\s.-]*9?[\s.-]?\d{4}[\s.-]?\d{4}`)
+	reParenteses   = regexp.MustCompile(`\([^)]*\)`)
+	reSeparadores  = regexp.MustCompile(`\s*[;|]\s*|\s+/\s+`)
+	reEspacos      = regexp.MustCompile(`\s+`)
+	reNaoNomeBorda = regexp.MustCompile(`^[\s,:;.()/-]+|[\s,:;.()/-]+$`)
+)
+
+// ParseEndereco lê, com melhor esforço, endereços no formato usual
+// "Rua X, 100, Apto 2 - Centro, Campo Grande - MS, 79000-000". O que não for reconhecido fica no
+// logradouro.
+func ParseEndereco(texto string) Endereco {
+	var e Endereco
+	s := strings.TrimSpace(texto)
+	if cep := reCEP.FindString(s); cep != "" {
+		e.CEP = soDigitos(cep)
+		s = strings.Replace(s, cep, "", 1)
+		s = strings.TrimSpace(strings.ReplaceAll(s, "CEP", ""))
+	}
+	s = strings.Trim(s, " ,.-/")
+	if m := reUFFinal.FindStringSubmatchIndex(s); m != nil && UFValida(s[m[2]:m[3]]) {
+		e.UF = s[m[2]:m[3]]
+		s = strings.Trim(s[:m[0]], " ,.-/")
+	}
+
+	var partes []string
+	for _, p := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' }) {
+		for _, q := range strings.Split(p, " - ") {
+			if q = strings.TrimSpace(q); q != "" {
+				partes = append(partes, q)
+			}
+		}
+	}
+	if len(partes) == 0 {
+		return e
+	}
+	e.Logradouro = partes[0]
+	partes = partes[1:]
+	if m := reNumeroFinal.FindStringSubmatch(e.Logradouro); m != nil {
+		e.Logradouro, e.Numero = strings.TrimSpace(m[1]), m[2]
+	} else if len(partes) > 0 && reNumero.MatchString(partes[0]) {
+		e.Numero = reNumero.FindStringSubmatch(partes[0])[2]
+		partes = partes[1:]
+	}
+	if strings.EqualFold(strings.ReplaceAll(e.Numero, "/", ""), "sn") {
+		e.Numero = "S/N"
+	}
+	if e.UF != "" && len(partes) > 0 {
+		e.Cidade = partes[len(partes)-1]
+		partes = partes[:len(partes)-1]
+	}
+	for _, p := range partes {
+		switch {
+		case e.Complemento == "" && reComplemento.MatchString(p):
+			e.Complemento = p
+		case e.Bairro == "":
+			e.Bairro = p
+		default:
+			e.Complemento = strings.TrimSpace(e.Complemento + " " + p)
+		}
+	}
+	return e
+}
+
+// ParseContatos extrai e-mails e telefones com DDD de um texto livre. Telefones perto de "whats"
+// ou "zap" viram WhatsApp; os demais, celular ou telefone fixo pelo número.
+func ParseContatos(texto string) []Contato {
+	var lista []Contato
+	vistos := map[string]bool{}
+	add := func(tipo, valor string) {
+		if !vistos[valor] {
+			vistos[valor] = true
+			lista = append(lista, Contato{Tipo: tipo, Valor: valor})
+		}
+	}
+	for _, email := range reEmail.FindAllString(texto, -1) {
+		if e, err := NormalizarEmail(email); err == nil {
+			add("EMAIL", e)
+		}
+	}
+	semEmail := reEmail.ReplaceAllString(texto, " ")
+	for _, trecho := range reSeparadores.Split(semEmail, -1) {
+		whats := strings.Contains(strings.ToLower(trecho), "whats") || strings.Contains(strings.ToLower(trecho), "zap")
+		for _, t := range reTelefone.FindAllString(trecho, -1) {
+			tel, err := NormalizarTelefone(t)
+			if err != nil {
+				continue
+			}
+			switch {
+			case whats:
+				add("WHATSAPP", tel)
+			case Celular(tel):
+				add("CELULAR", tel)
+			default:
+				add("TELEFONE", tel)
+			}
+		}
+	}
+	return lista
+}
+
+// palavrasParentesco associa as palavras usuais (sem acento) aos parentescos de entity.Parentescos
+var palavrasParentesco = map[string]string{
+	"esposa": "CONJUGE", "esposo": "CONJUGE", "marido": "CONJUGE", "mulher": "CONJUGE", "conjuge": "CONJUGE",
+	"companheiro": "COMPANHEIRO", "companheira": "COMPANHEIRO", "namorado": "COMPANHEIRO", "namorada": "COMPANHEIRO",
+	"pai": "PAI", "mae": "MAE", "filho": "FILHO", "filha": "FILHO", "irmao": "IRMAO", "irma": "IRMAO",
+	"avo": "AVO", "neto": "NETO", "neta": "NETO", "tio": "TIO", "tia": "TIO", "sobrinho": "SOBRINHO",
+	"sobrinha": "SOBRINHO", "primo": "PRIMO", "prima": "PRIMO", "amigo": "AMIGO", "amiga": "AMIGO",
+}
+
+var semAcento = strings.NewReplacer("á", "a", "à", "a", "â", "a", "ã", "a", "é", "e", "ê", "e", "í", "i",
+	"ó", "o", "ô", "o", "õ", "o", "ú", "u", "ç", "c", "Á", "a", "É", "e", "Í", "i", "Ó", "o", "Ú", "u")
+
+// ParseEmergencia extrai contatos de emergência como "Maria (mãe) 67 99999-0000; José - pai - 6733334444".
+// Só entram os trechos com telefone; sem parentesco reconhecido, vale OUTRO.
+func ParseEmergencia(texto string) []Emergencia {
+	var lista []Emergencia
+	for _, trecho := range reSeparadores.Split(texto, -1) {
+		tels := reTelefone.FindAllString(trecho, -1)
+		if len(tels) == 0 {
+			continue
+		}
+		tel, err := NormalizarTelefone(tels[0])
+		if err != nil {
+			continue
+		}
+		e := Emergencia{Telefone: tel, Parentesco: "OUTRO"}
+		resto := strings.Replace(trecho, tels[0], " ", 1)
+		var nome []string
+		for _, palavra := range strings.Fields(reParenteses.ReplaceAllStringFunc(resto, func(p string) string {
+			return " " + strings.Trim(p, "()") + " "
+		})) {
+			limpa := reNaoNomeBorda.ReplaceAllString(palavra, "")
+			if p, ok := palavrasParentesco[semAcento.Replace(strings.ToLower(limpa))]; ok {
+				if e.Parentesco == "OUTRO" {
+					e.Parentesco = p
+				}
+				continue
+			}
+			if limpa != "" && soDigitos(limpa) == "" && !strings.Contains(strings.ToLower(limpa), "tel") {
+				nome = append(nome, limpa)
+			}
+		}
+		e.Nome = reEspacos.ReplaceAllString(strings.Join(nome, " "), " ")
+		lista = append(lista, e)
+	}
+	return lista
+}
@@ -0,0 +1,115 @@
+// Package consulta descreve paginação, ordenação e filtros das listagens. As colunas aceitas em cada
+// listagem são definidas pelo repository; aqui ficam só os parâmetros vindos da requisição.
+package consulta
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	LimitePadrao = 50
+	LimiteMaximo = 500
+
+	// LimiteExportacao é o máximo de itens de uma exportação, que sai sem paginação
+	LimiteExportacao = 50000
+)
+
+// ErrConsultaInvalida indica parâmetro de paginação, ordenação ou filtro inválido (erro do cliente)
+var ErrConsultaInvalida = errors.New("consulta inválida")
+
+// Invalida cria um erro de consulta inválida com a mensagem informada
+func Invalida(formato string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrConsultaInvalida, fmt.Sprintf(formato, args...))
+}
+
+// Consulta é uma página de uma listagem. Com Cursor, a página começa depois do item com esse ID e
+// Pagina é ignorada; o cursor só vale com a ordenação pelo ID. Com Todos, a consulta devolve todos os
+// itens filtrados, até LimiteExportacao, e paginação e cursor são ignorados.
+type Consulta struct {
+	Pagina  int               // a partir de 1
+	Limite  int               // itens por página
+	Cursor  int64             // ID do último item da página anterior
+	Ordem   string            // campo de ordenação; "-" na frente para decrescente
+	Filtros map[string]string // campo → valor
+	Todos   bool              // sem paginação, para exportação
+}
+
+// Offset é o deslocamento da página
+func (c Consulta) Offset() int {
+	return (c.Pagina - 1) * c.Limite
+}
+
+// Filtro devolve o valor de um filtro, ou vazio
+func (c Consulta) Filtro(campo string) string {
+	return c.Filtros[campo]
+}
+
+// Normalizada aplica os padrões: página 1 e LimitePadrao itens, até LimiteMaximo
+func (c Consulta) Normalizada() Consulta {
+	if c.Pagina < 1 {
+		c.Pagina = 1
+	}
+	if c.Limite <= 0 {
+		c.Limite = LimitePadrao
+	}
+	if c.Limite > LimiteMaximo {
+		c.Limite = LimiteMaximo
+	}
+	return c
+}
+
+// Parse lê page, limit, cursor e sort da query string; os demais parâmetros viram filtros
+func Parse(valores url.Values) (Consulta, error) {
+	c := Consulta{Filtros: map[string]string{}}
+	inteiro := func(nome string) (int64, error) {
+		v := strings.TrimSpace(valores.Get(nome))
+		if v == "" {
+			return 0, nil
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return 0, Invalida("%s deve ser um inteiro positivo", nome)
+		}
+		return n, nil
+	}
+	pagina, err := inteiro("page")
+	if err != nil {
+		return c, err
+	}
+	limite, err := inteiro("limit")
+	if err != nil {
+		return c, err
+	}
+	if limite > LimiteMaximo {
+		return c, Invalida("limit deve ser no máximo %d", LimiteMaximo)
+	}
+	if c.Cursor, err = inteiro("cursor"); err != nil {
+		return c, err
+	}
+	c.Pagina, c.Limite = int(pagina), int(limite)
+	c.Ordem = strings.TrimSpace(valores.Get("sort"))
+
+	for campo, v := range valores {
+		switch campo {
+		case "page", "limit", "cursor", "sort":
+			continue
+		}
+		if len(v) > 0 && strings.TrimSpace(v[0]) != "" {
+			c.Filtros[campo] = strings.TrimSpace(v[0])
+		}
+	}
+	return c.Normalizada(), nil
+}
+
+// Pagina é o resultado de uma consulta: os itens da página e o total de itens que atendem aos filtros
+type Pagina[T any] struct {
+	Itens         []T
+	Total         int
+	Pagina        int
+	Limite        int
+	ProximoCursor int64 // ID do último item quando a página veio cheia na ordenação pelo ID
+}
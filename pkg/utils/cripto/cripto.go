@@ -0,0 +1,185 @@
+// Package cripto cifra campos sensíveis com AES-256-GCM e calcula índices cegos (HMAC-SHA256) que
+// permitem buscar por igualdade um campo cifrado sem decifrar a tabela.
+//
+// O valor cifrado é gravado como texto: "enc:v<versão>:<base64(nonce || cifra)>". A versão indica a
+// chave usada, o que permite trocar a chave atual e recifrar aos poucos. Valores sem o prefixo são
+// texto puro (registros ainda não migrados) e são devolvidos como estão.
+package cripto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Prefixo marca os valores cifrados
+const Prefixo = "enc:"
+
+// TamanhoChave é o tamanho, em bytes, das chaves AES-256 e da chave do índice cego
+const TamanhoChave = 32
+
+var (
+	ErrChaveDesconhecida = errors.New("valor cifrado com chave desconhecida")
+	ErrValorInvalido     = errors.New("valor cifrado inválido ou adulterado")
+)
+
+// Cifrador guarda as chaves por versão; cifra sempre com a atual e decifra com qualquer uma
+type Cifrador struct {
+	chaves map[int]cipher.AEAD
+	atual  int
+	indice []byte
+}
+
+// NovoCifrador monta o cifrador. chaves traz as chaves AES-256 por versão, atual é a versão usada
+// para cifrar e chaveIndice é a chave do HMAC dos índices cegos, que não muda na rotação.
+func NovoCifrador(chaves map[int][]byte, atual int, chaveIndice []byte) (*Cifrador, error) {
+	if _, ok := chaves[atual]; !ok {
+		return nil, fmt.Errorf("chave atual v%d não informada", atual)
+	}
+	if len(chaveIndice) < TamanhoChave {
+		return nil, fmt.Errorf("chave do índice cego deve ter ao menos %d bytes", TamanhoChave)
+	}
+	c := &Cifrador{chaves: make(map[int]cipher.AEAD, len(chaves)), atual: atual, indice: chaveIndice}
+	for versao, chave := range chaves {
+		if versao <= 0 {
+			return nil, fmt.Errorf("versão de chave inválida: %d", versao)
+		}
+		if len(chave) != TamanhoChave {
+			return nil, fmt.Errorf("chave v%d deve ter %d bytes", versao, TamanhoChave)
+		}
+		bloco, err := aes.NewCipher(chave)
+		if err != nil {
+			return nil, fmt.Errorf("chave v%d: %w", versao, err)
+		}
+		aead, err := cipher.NewGCM(bloco)
+		if err != nil {
+			return nil, fmt.Errorf("chave v%d: %w", versao, err)
+		}
+		c.chaves[versao] = aead
+	}
+	return c, nil
+}
+
+// LerChaves interpreta a lista "1:<base64>,2:<base64>" de chaves por versão
+func LerChaves(s string) (map[int][]byte, error) {
+	chaves := map[int][]byte{}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		v, b64, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("chave sem versão: use <versão>:<base64>")
+		}
+		versao, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(v), "v"))
+		if err != nil {
+			return nil, fmt.Errorf("versão de chave inválida %q", v)
+		}
+		chave, err := LerChave(b64)
+		if err != nil {
+			return nil, fmt.Errorf("chave v%d: %w", versao, err)
+		}
+		if _, dup := chaves[versao]; dup {
+			return nil, fmt.Errorf("chave v%d repetida", versao)
+		}
+		chaves[versao] = chave
+	}
+	return chaves, nil
+}
+
+// LerChave decodifica uma chave em base64
+func LerChave(b64 string) ([]byte, error) {
+	chave, err := base64.StdEncoding.DecodeString(strings.TrimSpace(b64))
+	if err != nil {
+		return nil, fmt.Errorf("base64 inválido: %w", err)
+	}
+	return chave, nil
+}
+
+// Atual devolve a versão da chave usada para cifrar
+func (c *Cifrador) Atual() int {
+	return c.atual
+}
+
+// Cifrado indica se o valor está no formato cifrado
+func Cifrado(valor string) bool {
+	return strings.HasPrefix(valor, Prefixo)
+}
+
+// Versao devolve a versão da chave de um valor cifrado
+func Versao(valor string) (int, bool) {
+	if !Cifrado(valor) {
+		return 0, false
+	}
+	v, _, ok := strings.Cut(strings.TrimPrefix(valor, Prefixo), ":")
+	if !ok || !strings.HasPrefix(v, "v") {
+		return 0, false
+	}
+	versao, err := strconv.Atoi(v[1:])
+	if err != nil {
+		return 0, false
+	}
+	return versao, true
+}
+
+// Cifrar cifra o texto com a chave atual. contexto (ex.: "pessoa.cpf") é autenticado junto, para que
+// o valor não possa ser copiado para outra coluna. Texto vazio continua vazio.
+func (c *Cifrador) Cifrar(texto, contexto string) (string, error) {
+	if texto == "" {
+		return "", nil
+	}
+	aead := c.chaves[c.atual]
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(texto)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("erro ao gerar nonce: %w", err)
+	}
+	selado := aead.Seal(nonce, nonce, []byte(texto), []byte(contexto))
+	return fmt.Sprintf("%sv%d:%s", Prefixo, c.atual, base64.RawStdEncoding.EncodeToString(selado)), nil
+}
+
+// Decifrar devolve o texto de um valor cifrado com qualquer das chaves; texto puro volta como está
+func (c *Cifrador) Decifrar(valor, contexto string) (string, error) {
+	if !Cifrado(valor) {
+		return valor, nil
+	}
+	versao, ok := Versao(valor)
+	if !ok {
+		return "", ErrValorInvalido
+	}
+	aead, ok := c.chaves[versao]
+	if !ok {
+		return "", fmt.Errorf("%w (v%d)", ErrChaveDesconhecida, versao)
+	}
+	_, b64, _ := strings.Cut(strings.TrimPrefix(valor, Prefixo), ":")
+	selado, err := base64.RawStdEncoding.DecodeString(b64)
+	if err != nil || len(selado) < aead.NonceSize() {
+		return "", ErrValorInvalido
+	}
+	texto, err := aead.Open(nil, selado[:aead.NonceSize()], selado[aead.NonceSize():], []byte(contexto))
+	if err != nil {
+		return "", ErrValorInvalido
+	}
+	return string(texto), nil
+}
+
+// Indice calcula o índice cego do texto: o mesmo texto no mesmo contexto dá sempre o mesmo índice
+// (64 caracteres hexadecimais). Texto vazio não tem índice.
+func (c *Cifrador) Indice(texto, contexto string) string {
+	if texto == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, c.indice)
+	mac.Write([]byte(contexto))
+	mac.Write([]byte{0})
+	mac.Write([]byte(texto))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,164 @@
+// Package documentos valida CPF, PIS/PASEP/NIT e CTPS pelos dígitos verificadores e os normaliza
+// para a forma gravada no banco: somente dígitos.
+package documentos
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SoDigitos remove pontuação e espaços do documento
+func SoDigitos(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// apenasFormatacao indica se o texto só tem dígitos e a pontuação usual de documentos
+func apenasFormatacao(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && !strings.ContainsRune(".-/ ", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// repetido identifica sequências como 111.111.111-11, que passam no cálculo mas não são emitidas
+func repetido(d string) bool {
+	return strings.Count(d, d[:1]) == len(d)
+}
+
+// digitoMod11 calcula o dígito verificador módulo 11 com os pesos informados; restos 0 e 1 dão 0
+func digitoMod11(d string, pesos []int) byte {
+	soma := 0
+	for i, p := range pesos {
+		soma += int(d[i]-'0') * p
+	}
+	resto := soma % 11
+	if resto < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - resto)
+}
+
+// CPFValido confere o formato e os dois dígitos verificadores do CPF
+func CPFValido(cpf string) bool {
+	_, err := NormalizarCPF(cpf)
+	return err == nil
+}
+
+// NormalizarCPF valida o CPF, com ou sem máscara, e devolve os 11 dígitos
+func NormalizarCPF(cpf string) (string, error) {
+	cpf = strings.TrimSpace(cpf)
+	d := SoDigitos(cpf)
+	if !apenasFormatacao(cpf) || len(d) != 11 {
+		return "", fmt.Errorf("CPF deve ter 11 dígitos")
+	}
+	if repetido(d) {
+		return "", fmt.Errorf("CPF inválido")
+	}
+	if d[9] != digitoMod11(d, []int{10, 9, 8, 7, 6, 5, 4, 3, 2}) ||
+		d[10] != digitoMod11(d, []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}) {
+		return "", fmt.Errorf("dígito verificador do CPF inválido")
+	}
+	return d, nil
+}
+
+// NormalizarPIS valida o PIS/PASEP/NIT, com ou sem máscara, e devolve os 11 dígitos
+func NormalizarPIS(pis string) (string, error) {
+	pis = strings.TrimSpace(pis)
+	d := SoDigitos(pis)
+	if !apenasFormatacao(pis) || len(d) != 11 {
+		return "", fmt.Errorf("PIS deve ter 11 dígitos")
+	}
+	if repetido(d) {
+		return "", fmt.Errorf("PIS inválido")
+	}
+	if d[10] != digitoMod11(d, []int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) {
+		return "", fmt.Errorf("dígito verificador do PIS inválido")
+	}
+	return d, nil
+}
+
+// NormalizarCTPS valida a CTPS e devolve só os dígitos. A CTPS Digital é o próprio CPF (11 dígitos,
+// conferidos pelos verificadores). A CTPS em papel tem número (até 7 dígitos) e série (até 5) e é
+// gravada com 12 dígitos, número e série completados com zeros; informada com os 12 dígitos ou
+// separada por "/", "-" ou espaço ("1234567/0012"). A CTPS em papel não tem dígito verificador.
+func NormalizarCTPS(ctps string) (string, error) {
+	ctps = strings.TrimSpace(ctps)
+	if !apenasFormatacao(ctps) {
+		return "", fmt.Errorf("CTPS deve conter só números")
+	}
+	d := SoDigitos(ctps)
+	if d == ctps {
+		switch len(d) {
+		case 11:
+			if _, err := NormalizarCPF(d); err != nil {
+				return "", fmt.Errorf("CTPS Digital (CPF) inválida: %v", err)
+			}
+			return d, nil
+		case 12:
+			if repetido(d) {
+				return "", fmt.Errorf("CTPS inválida")
+			}
+			return d, nil
+		}
+	}
+
+	partes := strings.FieldsFunc(ctps, func(r rune) bool { return r == '/' || r == '-' || r == ' ' })
+	if len(partes) == 2 {
+		numero, serie := SoDigitos(partes[0]), SoDigitos(partes[1])
+		if len(numero) == 11 && len(serie) == 0 {
+			return NormalizarCTPS(numero)
+		}
+		if len(numero) >= 1 && len(numero) <= 7 && len(serie) >= 1 && len(serie) <= 5 {
+			d = strings.Repeat("0", 7-len(numero)) + numero + strings.Repeat("0", 5-len(serie)) + serie
+			if repetido(d) {
+				return "", fmt.Errorf("CTPS inválida")
+			}
+			return d, nil
+		}
+	}
+	// CPF com máscara usado como CTPS Digital
+	if len(d) == 11 {
+		if _, err := NormalizarCPF(ctps); err == nil {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("CTPS deve ser o CPF (CTPS Digital) ou número (até 7 dígitos) e série (até 5 dígitos)")
+}
+
+// FormatarCPF aplica a máscara 000.000.000-00 a um CPF normalizado
+func FormatarCPF(cpf string) string {
+	if len(cpf) != 11 || SoDigitos(cpf) != cpf {
+		return cpf
+	}
+	return cpf[:3] + "." + cpf[3:6] + "." + cpf[6:9] + "-" + cpf[9:]
+}
+
+// FormatarPIS aplica a máscara 000.00000.00-0 a um PIS normalizado
+func FormatarPIS(pis string) string {
+	if len(pis) != 11 || SoDigitos(pis) != pis {
+		return pis
+	}
+	return pis[:3] + "." + pis[3:8] + "." + pis[8:10] + "-" + pis[10:]
+}
+
+// FormatarCTPS mostra a CTPS em papel como número/série; a CTPS Digital sai como CPF
+func FormatarCTPS(ctps string) string {
+	if SoDigitos(ctps) != ctps {
+		return ctps
+	}
+	switch len(ctps) {
+	case 11:
+		return FormatarCPF(ctps)
+	case 12:
+		return ctps[:7] + "/" + ctps[7:]
+	}
+	return ctps
+}
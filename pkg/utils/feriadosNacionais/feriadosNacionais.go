@@ -0,0 +1,66 @@
+package feriadosNacionais
+
+import "time"
+
+// Feriado é um feriado nacional em uma data. Facultativo marca os pontos facultativos
+// (Carnaval, Corpus Christi), que não são feriados por lei federal.
+type Feriado struct {
+	Data        time.Time
+	Nome        string
+	Facultativo bool
+}
+
+// Pascoa calcula o domingo de Páscoa do ano (algoritmo de Meeus/Jones/Butcher, calendário gregoriano)
+func Pascoa(ano int, loc *time.Location) time.Time {
+	a := ano % 19
+	b := ano / 100
+	c := ano % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	mes := (h + l - 7*m + 114) / 31
+	dia := (h+l-7*m+114)%31 + 1
+	return time.Date(ano, time.Month(mes), dia, 0, 0, 0, 0, loc)
+}
+
+// FeriadosNacionais lista os feriados nacionais do ano (Leis 662/1949, 6.802/1980 e 14.759/2023),
+// incluindo a Sexta-feira Santa e os pontos facultativos móveis, em ordem de data
+func FeriadosNacionais(ano int, loc *time.Location) []Feriado {
+	data := func(mes time.Month, dia int) time.Time { return time.Date(ano, mes, dia, 0, 0, 0, 0, loc) }
+	pascoa := Pascoa(ano, loc)
+
+	lista := []Feriado{
+		{Data: data(time.January, 1), Nome: "Confraternização Universal"},
+		{Data: pascoa.AddDate(0, 0, -48), Nome: "Carnaval (segunda-feira)", Facultativo: true},
+		{Data: pascoa.AddDate(0, 0, -47), Nome: "Carnaval (terça-feira)", Facultativo: true},
+		{Data: pascoa.AddDate(0, 0, -2), Nome: "Sexta-feira Santa"},
+		{Data: data(time.April, 21), Nome: "Tiradentes"},
+		{Data: data(time.May, 1), Nome: "Dia do Trabalho"},
+		{Data: pascoa.AddDate(0, 0, 60), Nome: "Corpus Christi", Facultativo: true},
+		{Data: data(time.September, 7), Nome: "Independência do Brasil"},
+		{Data: data(time.October, 12), Nome: "Nossa Senhora Aparecida"},
+		{Data: data(time.November, 2), Nome: "Finados"},
+		{Data: data(time.November, 15), Nome: "Proclamação da República"},
+	}
+	if ano >= 2024 {
+		lista = append(lista, Feriado{Data: data(time.November, 20), Nome: "Dia Nacional de Zumbi e da Consciência Negra"})
+	}
+	lista = append(lista, Feriado{Data: data(time.December, 25), Nome: "Natal"})
+	return lista
+}
+
+// EhFeriadoNacional indica se o dia é feriado nacional (pontos facultativos não contam)
+func EhFeriadoNacional(dia time.Time) bool {
+	for _, f := range FeriadosNacionais(dia.Year(), dia.Location()) {
+		if !f.Facultativo && f.Data.Month() == dia.Month() && f.Data.Day() == dia.Day() {
+			return true
+		}
+	}
+	return false
+}
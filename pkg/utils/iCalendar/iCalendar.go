@@ -0,0 +1,88 @@
+package iCalendar
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+// Event é um evento de dia inteiro. UID deve ser estável para que clientes substituam versões anteriores.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Inicio      time.Time // primeiro dia
+	Fim         time.Time // último dia (inclusivo)
+	Categoria   string
+}
+
+// Calendar monta um VCALENDAR (RFC 5545) com eventos de dia inteiro
+type Calendar struct {
+	Nome    string
+	Eventos []Event
+	Gerado  time.Time // DTSTAMP de todos os eventos
+}
+
+// Bytes serializa o calendário com quebras CRLF e linhas dobradas em 75 octetos
+func (c *Calendar) Bytes() []byte {
+	var buf bytes.Buffer
+	write := func(s string) { buf.WriteString(fold(s)); buf.WriteString("\r\n") }
+
+	stamp := c.Gerado.UTC().Format("20060102T150405Z")
+
+	write("BEGIN:VCALENDAR")
+	write("VERSION:2.0")
+	write("PRODID:-//AutoGRH//Calendario RH//PT-BR")
+	write("CALSCALE:GREGORIAN")
+	write("METHOD:PUBLISH")
+	if c.Nome != "" {
+		write("X-WR-CALNAME:" + escape(c.Nome))
+	}
+	for _, e := range c.Eventos {
+		fim := e.Fim
+		if fim.Before(e.Inicio) {
+			fim = e.Inicio
+		}
+		write("BEGIN:VEVENT")
+		write("UID:" + e.UID)
+		write("DTSTAMP:" + stamp)
+		write("DTSTART;VALUE=DATE:" + e.Inicio.Format("20060102"))
+		write("DTEND;VALUE=DATE:" + fim.AddDate(0, 0, 1).Format("20060102")) // DTEND é exclusivo
+		write("SUMMARY:" + escape(e.Summary))
+		if e.Description != "" {
+			write("DESCRIPTION:" + escape(e.Description))
+		}
+		if e.Categoria != "" {
+			write("CATEGORIES:" + escape(e.Categoria))
+		}
+		write("TRANSP:TRANSPARENT")
+		write("END:VEVENT")
+	}
+	write("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// escape aplica o escape de TEXT da RFC 5545
+func escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// fold quebra linhas com mais de 75 octetos sem partir caracteres UTF-8
+func fold(s string) string {
+	if len(s) <= 75 {
+		return s
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		l := len(string(r))
+		if n+l > 75 {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += l
+	}
+	return b.String()
+}
@@ -0,0 +1,19 @@
+package nullStringToTimePtr
+
+import (
+	"AutoGRH/pkg/utils/dateStringToTime"
+	"database/sql"
+	"time"
+)
+
+// NullStringToTimePtr converte uma data anulável lida como texto (DSN sem parseTime) em *time.Time
+func NullStringToTimePtr(ns sql.NullString) (*time.Time, error) {
+	if !ns.Valid || ns.String == "" {
+		return nil, nil
+	}
+	t, err := dateStringToTime.DateStringToTime(ns.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,413 @@
+package planilha
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Tipos MIME das planilhas geradas
+const (
+	TipoCSV  = "text/csv; charset=utf-8"
+	TipoXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// Tipos de coluna: definem a formatação no CSV e o estilo da célula no XLSX
+const (
+	Texto    = iota
+	Inteiro  // int ou int64
+	Moeda    // float64, em reais
+	Data     // time.Time ou *time.Time; data zero ou nil fica vazia
+	DataHora // como Data, com hora e minuto
+	SimNao   // bool
+)
+
+// Coluna descreve o cabeçalho e o tipo de uma coluna exportada
+type Coluna struct {
+	Titulo string
+	Tipo   int
+}
+
+// Tabela é o conteúdo de uma exportação: uma aba com cabeçalho e linhas. Cada linha traz um valor
+// por coluna; nil deixa a célula vazia.
+type Tabela struct {
+	Nome    string // nome da aba (até 31 caracteres)
+	Colunas []Coluna
+	Linhas  [][]interface{}
+}
+
+// NovaTabela cria uma tabela vazia com as colunas informadas
+func NovaTabela(nome string, colunas ...Coluna) *Tabela {
+	return &Tabela{Nome: nome, Colunas: colunas}
+}
+
+// Adicionar inclui uma linha na tabela
+func (t *Tabela) Adicionar(valores ...interface{}) {
+	t.Linhas = append(t.Linhas, valores)
+}
+
+// TipoMIME devolve o Content-Type do formato
+func TipoMIME(formato string) string {
+	if formato == FormatoXLSX {
+		return TipoXLSX
+	}
+	return TipoCSV
+}
+
+// Escrever gera a tabela no formato pedido (FormatoCSV ou FormatoXLSX)
+func Escrever(t *Tabela, formato string) ([]byte, error) {
+	switch formato {
+	case FormatoCSV:
+		return EscreverCSV(t)
+	case FormatoXLSX:
+		return EscreverXLSX(t)
+	}
+	return nil, ErrFormato
+}
+
+// EscreverCSV gera CSV separado por ponto e vírgula, com BOM, para abrir direto no Excel em português.
+// Valores monetários saem como 1.234,56 e datas como DD/MM/AAAA.
+func EscreverCSV(t *Tabela) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\xef\xbb\xbf") // BOM: o Excel reconhece o UTF-8
+	w := csv.NewWriter(&buf)
+	w.Comma = ';'
+
+	cabecalho := make([]string, len(t.Colunas))
+	for i, c := range t.Colunas {
+		cabecalho[i] = c.Titulo
+	}
+	if err := w.Write(cabecalho); err != nil {
+		return nil, err
+	}
+	for _, l := range t.Linhas {
+		registro := make([]string, len(t.Colunas))
+		for i, c := range t.Colunas {
+			if i < len(l) {
+				registro[i] = textoCSV(c.Tipo, l[i])
+			}
+		}
+		if err := w.Write(registro); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// textoCSV formata a célula; texto que o Excel leria como fórmula ganha um apóstrofo na frente
+func textoCSV(tipo int, v interface{}) string {
+	s := textoCelula(tipo, v)
+	if tipo == Texto && s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// textoCelula formata o valor como é exibido na planilha
+func textoCelula(tipo int, v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	switch tipo {
+	case Moeda:
+		if n, ok := numero(v); ok {
+			return FormatarMoeda(n)
+		}
+	case Data, DataHora:
+		if d, ok := data(v); ok {
+			if d.IsZero() {
+				return ""
+			}
+			if tipo == DataHora {
+				return d.Format("02/01/2006 15:04")
+			}
+			return d.Format("02/01/2006")
+		}
+	case SimNao:
+		if b, ok := v.(bool); ok {
+			return simNao(b)
+		}
+	}
+	switch x := v.(type) {
+	case string:
+		return x
+	case bool:
+		return simNao(x)
+	case time.Time:
+		return textoCelula(Data, x)
+	case *time.Time:
+		return textoCelula(Data, x)
+	}
+	return fmt.Sprint(v)
+}
+
+// FormatarMoeda formata o valor com separador de milhar e duas casas: 1234.5 → "1.234,50"
+func FormatarMoeda(v float64) string {
+	s := strconv.FormatFloat(math.Abs(v), 'f', 2, 64)
+	inteiro, centavos := s[:len(s)-3], s[len(s)-2:]
+
+	var b strings.Builder
+	if v < 0 && s != "0.00" {
+		b.WriteByte('-')
+	}
+	for i, r := range inteiro {
+		if i > 0 && (len(inteiro)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteString(",")
+	b.WriteString(centavos)
+	return b.String()
+}
+
+func simNao(b bool) string {
+	if b {
+		return "Sim"
+	}
+	return "Não"
+}
+
+func numero(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case float32:
+		return float64(x), true
+	case int:
+		return float64(x), true
+	case int64:
+		return float64(x), true
+	}
+	return 0, false
+}
+
+func data(v interface{}) (time.Time, bool) {
+	switch x := v.(type) {
+	case time.Time:
+		return x, true
+	case *time.Time:
+		if x == nil {
+			return time.Time{}, true
+		}
+		return *x, true
+	}
+	return time.Time{}, false
+}
+
+// Estilos de célula do XLSX gerado, na ordem de cellXfs em estilosXLSX
+const (
+	estiloPadrao = iota
+	estiloCabecalho
+	estiloMoeda
+	estiloData
+	estiloDataHora
+)
+
+const estilosXLSX = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="&quot;R$&quot; #,##0.00"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="5">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="14" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="22" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
+
+const tiposConteudoXLSX = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const relacoesXLSX = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const relacoesPastaXLSX = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+// EscreverXLSX gera uma pasta de trabalho com uma aba: cabeçalho em negrito e congelado, valores
+// monetários como número no formato R$ e datas como data do Excel
+func EscreverXLSX(t *Tabela) ([]byte, error) {
+	var pasta strings.Builder
+	pasta.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="`)
+	escaparXML(&pasta, nomeAba(t.Nome))
+	pasta.WriteString(`" sheetId="1" r:id="rId1"/></sheets></workbook>`)
+
+	partes := []struct{ nome, conteudo string }{
+		{"[Content_Types].xml", tiposConteudoXLSX},
+		{"_rels/.rels", relacoesXLSX},
+		{"xl/workbook.xml", pasta.String()},
+		{"xl/_rels/workbook.xml.rels", relacoesPastaXLSX},
+		{"xl/styles.xml", estilosXLSX},
+		{"xl/worksheets/sheet1.xml", abaXLSX(t)},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, p := range partes {
+		f, err := zw.Create(p.nome)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write([]byte(p.conteudo)); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// abaXLSX monta o XML da aba com larguras ajustadas ao conteúdo
+func abaXLSX(t *Tabela) string {
+	larguras := make([]int, len(t.Colunas))
+	for i, c := range t.Colunas {
+		larguras[i] = utf8.RuneCountInString(c.Titulo)
+	}
+	for _, l := range t.Linhas {
+		for i, c := range t.Colunas {
+			if i < len(l) {
+				if n := utf8.RuneCountInString(textoCelula(c.Tipo, l[i])); n > larguras[i] {
+					larguras[i] = n
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0">` +
+		`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	if len(larguras) > 0 {
+		b.WriteString(`<cols>`)
+		for i, n := range larguras {
+			n = min(max(n+2, 8), 60)
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, n)
+		}
+		b.WriteString(`</cols>`)
+	}
+
+	b.WriteString(`<sheetData><row r="1">`)
+	for i, c := range t.Colunas {
+		celulaTexto(&b, referencia(i, 1), c.Titulo, estiloCabecalho)
+	}
+	b.WriteString(`</row>`)
+	for n, l := range t.Linhas {
+		fmt.Fprintf(&b, `<row r="%d">`, n+2)
+		for i, c := range t.Colunas {
+			if i < len(l) {
+				celulaXLSX(&b, referencia(i, n+2), c.Tipo, l[i])
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// celulaXLSX escreve a célula conforme o tipo da coluna; valores vazios não geram célula
+func celulaXLSX(b *strings.Builder, ref string, tipo int, v interface{}) {
+	if v == nil {
+		return
+	}
+	switch tipo {
+	case Moeda, Inteiro:
+		if n, ok := numero(v); ok {
+			estilo := estiloPadrao
+			if tipo == Moeda {
+				estilo = estiloMoeda
+			}
+			fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, estilo, strconv.FormatFloat(n, 'f', -1, 64))
+			return
+		}
+	case Data:
+		if d, ok := data(v); ok {
+			if !d.IsZero() {
+				fmt.Fprintf(b, `<c r="%s" s="%d"><v>%d</v></c>`, ref, estiloData, serialData(d))
+			}
+			return
+		}
+	case DataHora:
+		if d, ok := data(v); ok {
+			if !d.IsZero() {
+				fracao := float64(d.Hour()*3600+d.Minute()*60+d.Second()) / 86400
+				fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, estiloDataHora,
+					strconv.FormatFloat(float64(serialData(d))+fracao, 'f', -1, 64))
+			}
+			return
+		}
+	}
+	if s := textoCelula(tipo, v); s != "" {
+		celulaTexto(b, ref, s, estiloPadrao)
+	}
+}
+
+func celulaTexto(b *strings.Builder, ref, s string, estilo int) {
+	fmt.Fprintf(b, `<c r="%s" t="inlineStr" s="%d"><is><t xml:space="preserve">`, ref, estilo)
+	escaparXML(b, s)
+	b.WriteString(`</t></is></c>`)
+}
+
+// serialData converte a data no número de dias do Excel (inverso de dataSerial)
+func serialData(d time.Time) int {
+	dia := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	return int(dia.Sub(time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
+
+// referencia monta a referência da célula: coluna 0, linha 1 → "A1"
+func referencia(coluna, linha int) string {
+	var letras []byte
+	for n := coluna + 1; n > 0; n = (n - 1) / 26 {
+		letras = append([]byte{byte('A' + (n-1)%26)}, letras...)
+	}
+	return string(letras) + strconv.Itoa(linha)
+}
+
+// nomeAba remove os caracteres que o Excel não aceita no nome da aba e limita a 31 caracteres
+func nomeAba(nome string) string {
+	nome = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return ' '
+		}
+		return r
+	}, strings.TrimSpace(nome))
+	if r := []rune(nome); len(r) > 31 {
+		nome = string(r[:31])
+	}
+	if nome = strings.TrimSpace(nome); nome == "" {
+		return "Planilha"
+	}
+	return nome
+}
+
+func escaparXML(b *strings.Builder, s string) {
+	_ = xml.EscapeText(b, []byte(s))
+}
@@ -0,0 +1,88 @@
+// Package planilha lê planilhas CSV e XLSX como linhas de texto e as gera a partir de tabelas, sem
+// dependências externas. Do XLSX só é lida a primeira aba; datas formatadas como data saem no formato
+// AAAA-MM-DD.
+package planilha
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	FormatoCSV  = "CSV"
+	FormatoXLSX = "XLSX"
+)
+
+// ErrFormato indica arquivo que não é CSV nem XLSX
+var ErrFormato = errors.New("formato de planilha não suportado: envie CSV ou XLSX")
+
+// Formato identifica o tipo da planilha pelo conteúdo (XLSX é um zip) ou, em último caso, pela extensão
+func Formato(nome string, conteudo []byte) (string, error) {
+	if bytes.HasPrefix(conteudo, []byte("PK\x03\x04")) {
+		return FormatoXLSX, nil
+	}
+	switch strings.ToLower(filepath.Ext(nome)) {
+	case ".csv", ".txt", "":
+		if !utf8.Valid(conteudo) {
+			return "", fmt.Errorf("CSV deve estar em UTF-8")
+		}
+		return FormatoCSV, nil
+	}
+	return "", ErrFormato
+}
+
+// Ler devolve as linhas da planilha; a primeira é o cabeçalho. Espaços nas pontas são removidos.
+func Ler(nome string, conteudo []byte) ([][]string, error) {
+	formato, err := Formato(nome, conteudo)
+	if err != nil {
+		return nil, err
+	}
+	var linhas [][]string
+	if formato == FormatoXLSX {
+		linhas, err = lerXLSX(conteudo)
+	} else {
+		linhas, err = lerCSV(conteudo)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range linhas {
+		for i := range l {
+			l[i] = strings.TrimSpace(l[i])
+		}
+	}
+	return linhas, nil
+}
+
+// lerCSV aceita vírgula ou ponto e vírgula (padrão do Excel em português), conforme o cabeçalho
+func lerCSV(conteudo []byte) ([][]string, error) {
+	conteudo = bytes.TrimPrefix(conteudo, []byte("\xef\xbb\xbf"))
+	cabecalho := conteudo
+	if i := bytes.IndexByte(conteudo, '\n'); i >= 0 {
+		cabecalho = conteudo[:i]
+	}
+	r := csv.NewReader(bytes.NewReader(conteudo))
+	if bytes.Count(cabecalho, []byte(";")) > bytes.Count(cabecalho, []byte(",")) {
+		r.Comma = ';'
+	}
+	r.FieldsPerRecord = -1
+
+	var linhas [][]string
+	for {
+		l, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV inválido: %w", err)
+		}
+		linhas = append(linhas, l)
+	}
+	return linhas, nil
+}
@@ -0,0 +1,272 @@
+package planilha
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tamanhoMaximoParte limita cada XML descompactado, contra arquivos zip maliciosos
+const tamanhoMaximoParte = 50 << 20
+
+type xlsxArquivo struct {
+	partes map[string]*zip.File
+}
+
+func (a xlsxArquivo) ler(nome string, v interface{}) (bool, error) {
+	f, ok := a.partes[nome]
+	if !ok {
+		return false, nil
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return true, err
+	}
+	defer rc.Close()
+	dados, err := io.ReadAll(io.LimitReader(rc, tamanhoMaximoParte+1))
+	if err != nil {
+		return true, err
+	}
+	if len(dados) > tamanhoMaximoParte {
+		return true, fmt.Errorf("%s muito grande", nome)
+	}
+	return true, xml.Unmarshal(dados, v)
+}
+
+type xlsxTexto struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxTexto) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xlsxCelula struct {
+	Ref    string    `xml:"r,attr"`
+	Tipo   string    `xml:"t,attr"`
+	Estilo int       `xml:"s,attr"`
+	Valor  string    `xml:"v"`
+	Inline xlsxTexto `xml:"is"`
+}
+
+type xlsxAba struct {
+	Linhas []struct {
+		Numero  int          `xml:"r,attr"`
+		Celulas []xlsxCelula `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func lerXLSX(conteudo []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(conteudo), int64(len(conteudo)))
+	if err != nil {
+		return nil, fmt.Errorf("XLSX inválido: %w", err)
+	}
+	a := xlsxArquivo{partes: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		a.partes[f.Name] = f
+	}
+
+	aba, err := a.primeiraAba()
+	if err != nil {
+		return nil, err
+	}
+	textos, err := a.textosCompartilhados()
+	if err != nil {
+		return nil, err
+	}
+	datas, err := a.estilosDeData()
+	if err != nil {
+		return nil, err
+	}
+
+	var planilha xlsxAba
+	if ok, err := a.ler(aba, &planilha); err != nil || !ok {
+		return nil, fmt.Errorf("XLSX inválido: aba %s não encontrada ou ilegível", aba)
+	}
+
+	var linhas [][]string
+	for i, l := range planilha.Linhas {
+		numero := l.Numero
+		if numero == 0 {
+			numero = i + 1
+		}
+		// linhas vazias não vêm no XML; mantém a numeração da planilha
+		for len(linhas) < numero-1 {
+			linhas = append(linhas, nil)
+		}
+		var valores []string
+		for j, c := range l.Celulas {
+			col := j
+			if c.Ref != "" {
+				col = indiceColuna(c.Ref)
+			}
+			for len(valores) <= col {
+				valores = append(valores, "")
+			}
+			valores[col] = valorCelula(c, textos, datas)
+		}
+		linhas = append(linhas, valores)
+	}
+	return linhas, nil
+}
+
+// primeiraAba segue workbook.xml e suas relações até o arquivo da primeira aba
+func (a xlsxArquivo) primeiraAba() (string, error) {
+	var wb struct {
+		Abas []struct {
+			RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	var rels struct {
+		Rel []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	padrao := "xl/worksheets/sheet1.xml"
+	if ok, err := a.ler("xl/workbook.xml", &wb); err != nil || !ok || len(wb.Abas) == 0 {
+		return padrao, nil
+	}
+	if ok, err := a.ler("xl/_rels/workbook.xml.rels", &rels); err != nil || !ok {
+		return padrao, nil
+	}
+	for _, r := range rels.Rel {
+		if r.ID == wb.Abas[0].RID {
+			if strings.HasPrefix(r.Target, "/") {
+				return strings.TrimPrefix(r.Target, "/"), nil
+			}
+			return path.Join("xl", r.Target), nil
+		}
+	}
+	return padrao, nil
+}
+
+func (a xlsxArquivo) textosCompartilhados() ([]string, error) {
+	var sst struct {
+		Itens []xlsxTexto `xml:"si"`
+	}
+	if _, err := a.ler("xl/sharedStrings.xml", &sst); err != nil {
+		return nil, fmt.Errorf("XLSX inválido: %w", err)
+	}
+	textos := make([]string, len(sst.Itens))
+	for i, t := range sst.Itens {
+		textos[i] = t.String()
+	}
+	return textos, nil
+}
+
+// estilosDeData indica, por índice de estilo de célula, quais formatam números como data
+func (a xlsxArquivo) estilosDeData() (map[int]bool, error) {
+	var st struct {
+		Formatos []struct {
+			ID     int    `xml:"numFmtId,attr"`
+			Codigo string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		Estilos []struct {
+			Formato int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if _, err := a.ler("xl/styles.xml", &st); err != nil {
+		return nil, fmt.Errorf("XLSX inválido: %w", err)
+	}
+	proprios := map[int]bool{}
+	for _, f := range st.Formatos {
+		proprios[f.ID] = formatoDeData(f.Codigo)
+	}
+	datas := map[int]bool{}
+	for i, e := range st.Estilos {
+		if data, ok := proprios[e.Formato]; ok {
+			datas[i] = data
+			continue
+		}
+		// formatos embutidos de data: 14 a 22 e 45 a 47
+		datas[i] = (e.Formato >= 14 && e.Formato <= 22) || (e.Formato >= 45 && e.Formato <= 47)
+	}
+	return datas, nil
+}
+
+// formatoDeData reconhece códigos como "dd/mm/yyyy", ignorando trechos entre aspas e colchetes
+func formatoDeData(codigo string) bool {
+	var b strings.Builder
+	aspas, colchete := false, false
+	for _, r := range strings.ToLower(codigo) {
+		switch {
+		case r == '"':
+			aspas = !aspas
+		case r == '[' && !aspas:
+			colchete = true
+		case r == ']' && !aspas:
+			colchete = false
+		case !aspas && !colchete:
+			b.WriteRune(r)
+		}
+	}
+	s := b.String()
+	return strings.ContainsAny(s, "dy") || (strings.Contains(s, "m") && !strings.ContainsAny(s, "hs0#"))
+}
+
+func valorCelula(c xlsxCelula, textos []string, datas map[int]bool) string {
+	switch c.Tipo {
+	case "s":
+		i, err := strconv.Atoi(strings.TrimSpace(c.Valor))
+		if err != nil || i < 0 || i >= len(textos) {
+			return ""
+		}
+		return textos[i]
+	case "inlineStr":
+		return c.Inline.String()
+	case "b":
+		if c.Valor == "1" {
+			return "TRUE"
+		}
+		return "FALSE"
+	case "str", "e":
+		return c.Valor
+	}
+	if c.Valor == "" {
+		return ""
+	}
+	n, err := strconv.ParseFloat(c.Valor, 64)
+	if err != nil {
+		return c.Valor
+	}
+	if datas[c.Estilo] {
+		return dataSerial(n).Format("2006-01-02")
+	}
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// dataSerial converte o número de série do Excel (dias desde 30/12/1899) em data
+func dataSerial(n float64) time.Time {
+	dias := int(math.Floor(n))
+	return time.Date(1899, 12, 30, 0, 0, 0, 0, time.Local).AddDate(0, 0, dias)
+}
+
+// indiceColuna converte a referência "AB12" no índice 27 da coluna
+func indiceColuna(ref string) int {
+	col := 0
+	for _, r := range strings.ToUpper(ref) {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	return col - 1
+}
@@ -0,0 +1,117 @@
+package textPDF
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Dimensões de uma página A4 em pontos
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 50.0
+)
+
+type line struct {
+	x, y float64
+	size float64
+	bold bool
+	text string
+}
+
+// Document monta um PDF simples (A4, Helvetica) contendo apenas linhas de texto.
+// Suficiente para recibos e avisos, sem depender de bibliotecas externas.
+type Document struct {
+	pages [][]line
+	y     float64
+}
+
+// New cria um documento com uma página em branco
+func New() *Document {
+	return &Document{pages: [][]line{{}}, y: pageHeight - margin}
+}
+
+// Text escreve uma linha a partir da margem esquerda e avança o cursor
+func (d *Document) Text(text string, size float64, bold bool) {
+	d.TextAt(0, text, size, bold)
+	d.y -= size * 1.5
+}
+
+// TextAt escreve na linha atual deslocada x pontos da margem, sem avançar o cursor
+func (d *Document) TextAt(x float64, text string, size float64, bold bool) {
+	if d.y-size < margin {
+		d.pages = append(d.pages, []line{})
+		d.y = pageHeight - margin
+	}
+	p := len(d.pages) - 1
+	d.pages[p] = append(d.pages[p], line{x: margin + x, y: d.y - size, size: size, bold: bold, text: text})
+}
+
+// Space avança o cursor verticalmente
+func (d *Document) Space(h float64) {
+	d.y -= h
+}
+
+// Bytes serializa o documento no formato PDF 1.4
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	offsets := []int{}
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// 1 catálogo, 2 árvore de páginas, 3 e 4 fontes; depois pares (página, conteúdo)
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, lines := range d.pages {
+		var content bytes.Buffer
+		for _, l := range lines {
+			font := "F1"
+			if l.bold {
+				font = "F2"
+			}
+			fmt.Fprintf(&content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, l.size, l.x, l.y, escape(l.text))
+		}
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 6+i*2))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// escape converte o texto para WinAnsi (Latin-1) e escapa os caracteres especiais do PDF
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 256:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -12,6 +12,7 @@ import (
 type FeriasWorker struct {
 	feriasSvc      *service.FeriasService
 	descansoSvc    *service.DescansoService
+	pagamentoSvc   *service.PagamentoFeriasService
 	salarioRealSvc *service.SalarioRealService
 	funcionarioSvc *service.FuncionarioService
 	faltaSvc       *service.FaltaService
@@ -21,6 +22,7 @@ type FeriasWorker struct {
 func NewFeriasWorker(
 	feriasSvc *service.FeriasService,
 	descansoSvc *service.DescansoService,
+	pagamentoSvc *service.PagamentoFeriasService,
 	salarioRealSvc *service.SalarioRealService,
 	funcionarioSvc *service.FuncionarioService,
 	faltaSvc *service.FaltaService,
@@ -28,6 +30,7 @@ func NewFeriasWorker(
 	return &FeriasWorker{
 		feriasSvc:      feriasSvc,
 		descansoSvc:    descansoSvc,
+		pagamentoSvc:   pagamentoSvc,
 		salarioRealSvc: salarioRealSvc,
 		funcionarioSvc: funcionarioSvc,
 		faltaSvc:       faltaSvc,
@@ -72,9 +75,9 @@ func (w *FeriasWorker) run(ctx context.Context) {
 		fmt.Println("[Worker Férias] Erro ao garantir férias:", err)
 	}
 
-	// Marca descansos como pagos quando a data 'fim' já passou (e já aprovados)
-	if err := w.pagarDescansosComFimAnteriorAHoje(ctx); err != nil {
-		fmt.Println("[Worker Férias] Erro ao pagar descansos vencidos:", err)
+	// Gera o pagamento (vence 2 dias antes do início) dos descansos aprovados que ainda não têm
+	if err := w.gerarPagamentosDeFerias(ctx); err != nil {
+		fmt.Println("[Worker Férias] Erro ao gerar pagamentos de férias:", err)
 	}
 
 	fmt.Println("[Worker Férias] Ciclo concluído.")
@@ -93,27 +96,14 @@ func (w *FeriasWorker) garantirParaFuncionariosAtivos(ctx context.Context) error
 	return nil
 }
 
-func (w *FeriasWorker) pagarDescansosComFimAnteriorAHoje(ctx context.Context) error {
-	list, err := w.descansoSvc.ListarAprovados(ctx, w.claims)
+func (w *FeriasWorker) gerarPagamentosDeFerias(ctx context.Context) error {
+	list, err := repository.ListDescansosAprovadosSemPagamentoFerias()
 	if err != nil {
 		return err
 	}
-	hoje := time.Now()
 	for _, d := range list {
-		if !d.Pago && d.Fim.Before(hoje) {
-			// paga o descanso
-			if err := w.descansoSvc.MarcarComoPago(ctx, w.claims, d.ID); err != nil {
-				fmt.Printf("[Worker Férias] Falha ao pagar descanso ID=%d: %v\n", d.ID, err)
-				continue
-			}
-			// após pagar, verifica e fecha férias se elegível
-			if f, ferr := repository.GetFeriasByID(d.FeriasID); ferr == nil && f != nil {
-				if f.Dias == 0 && f.TercoPago && !f.Pago {
-					if err := repository.MarcarFeriasComoPagas(f.ID); err != nil {
-						fmt.Printf("[Worker Férias] Falha ao marcar férias %d como pagas: %v\n", f.ID, err)
-					}
-				}
-			}
+		if _, err := w.pagamentoSvc.GerarParaDescanso(ctx, w.claims, d.ID); err != nil {
+			fmt.Printf("[Worker Férias] Falha ao gerar pagamento do descanso ID=%d: %v\n", d.ID, err)
 		}
 	}
 	return nil
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"AutoGRH/pkg/controller/middleware"
+	"AutoGRH/pkg/service"
+	"context"
+	"fmt"
+	"time"
+)
+
+// RetencaoWorker anonimiza diariamente os ex-funcionários cujo prazo de guarda (LGPD) venceu
+type RetencaoWorker struct {
+	dadosPessoaisSvc *service.DadosPessoaisService
+	claims           service.Claims
+}
+
+func NewRetencaoWorker(dadosPessoaisSvc *service.DadosPessoaisService) *RetencaoWorker {
+	return &RetencaoWorker{
+		dadosPessoaisSvc: dadosPessoaisSvc,
+		claims:           middleware.SystemClaims(),
+	}
+}
+
+func (w *RetencaoWorker) Start() {
+	go func() {
+		// Executa imediatamente ao subir
+		w.executar()
+
+		// Agenda para 05:20 diariamente (depois dos avisos)
+		now := time.Now()
+		next := time.Date(now.Year(), now.Month(), now.Day(), 5, 20, 0, 0, now.Location())
+		if next.Before(now) {
+			next = next.Add(24 * time.Hour)
+		}
+		fmt.Printf("[Worker Retenção] Próxima execução agendada para: %v\n", next)
+
+		time.Sleep(time.Until(next))
+		w.executar()
+
+		// Ticker diário
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			w.executar()
+		}
+	}()
+}
+
+func (w *RetencaoWorker) executar() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	feitas, err := w.dadosPessoaisSvc.AplicarRetencao(ctx, w.claims, time.Now())
+	if err != nil {
+		fmt.Println("[Worker Retenção] Erro ao aplicar retenção:", err)
+	}
+	if len(feitas) > 0 {
+		fmt.Printf("[Worker Retenção] %d ex-funcionário(s) anonimizado(s)\n", len(feitas))
+	}
+}
@@ -0,0 +1,123 @@
+package testes
+
+import (
+	Adapter "AutoGRH/pkg/adapter"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+)
+
+func newBancoHorasServiceWithDB(lr *folhaFakeLogRepo) *service.BancoHorasService {
+	repo := Adapter.NewBancoHorasRepositoryAdapter(
+		repository.CreateLancamentoBancoHoras,
+		repository.GetLancamentoBancoHorasByID,
+		repository.DeleteLancamentoBancoHoras,
+		repository.ListLancamentosBancoHorasByFuncionario,
+		repository.GetBancoHorasConfig,
+		repository.SaveBancoHorasConfig,
+	)
+	return service.NewBancoHorasService(newAdminAuth(lr), lr, repo)
+}
+
+func TestBancoHoras_PontoFolgaEPagamentoDoVencido(t *testing.T) {
+	if err := truncateAll(); err != nil {
+		t.Fatalf("truncateAll inicio: %v", err)
+	}
+	t.Cleanup(func() { _ = truncateAll() })
+
+	lr := &folhaFakeLogRepo{}
+	bs := newBancoHorasServiceWithDB(lr)
+	ps := newPontoServiceWithDB(lr)
+	fs := newFolhaService(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 506, Perfil: "admin"}
+
+	funcID := seedPessoaFuncionarioBase(t, "Funcionario Banco")
+	f, _ := repository.GetFuncionarioByID(funcID)
+	f.PIS = "12345678901"
+	if err := repository.UpdateFuncionario(f); err != nil {
+		t.Fatalf("UpdateFuncionario erro: %v", err)
+	}
+	seedSalarioRealAtual(t, funcID, 2200)
+
+	// créditos vencem em 1 mês
+	if err := bs.Configurar(ctx, claims, &entity.BancoHorasConfig{FuncionarioID: funcID, Ativo: true, MesesValidade: 1}); err != nil {
+		t.Fatalf("Configurar erro: %v", err)
+	}
+
+	arquivo := afdPortaria1510("12345678901", map[string][]string{
+		"07042025": {"08:00", "12:00", "13:00", "17:48"},
+		"08042025": {"08:30", "12:00", "13:00", "17:48"}, // 30 min de atraso: débito
+		"10042025": {"08:00", "12:00", "13:00", "19:48"}, // 2h extras: crédito
+		"11042025": {"08:00", "12:00", "13:00", "17:48"},
+		"12042025": {"08:00", "12:00"}, // sábado: 4h de crédito
+	})
+	res, err := ps.Importar(ctx, claims, strings.NewReader(arquivo))
+	if err != nil {
+		t.Fatalf("Importar erro: %v", err)
+	}
+	// a falta do dia 09 continua sendo falta; o atraso vai para o banco
+	if res.FaltasLancadas != 1 || res.AtrasosLancados != 0 || res.LancamentosBanco != 3 {
+		t.Fatalf("resultado inesperado: %+v", res)
+	}
+	// reimportar refaz os lançamentos do ponto sem duplicar
+	if _, err := ps.Importar(ctx, claims, strings.NewReader(arquivo)); err != nil {
+		t.Fatalf("reimportar erro: %v", err)
+	}
+
+	fimAbril := time.Date(2025, 4, 30, 0, 0, 0, 0, time.Local)
+	ext, err := bs.Extrato(ctx, claims, funcID, fimAbril)
+	if err != nil || ext == nil {
+		t.Fatalf("Extrato erro: %v", err)
+	}
+	if ext.SaldoMinutos != 330 || ext.VencidosMinutos != 0 || len(ext.Lancamentos) != 3 {
+		t.Fatalf("extrato de abril inesperado: saldo=%d vencidos=%d lancamentos=%d", ext.SaldoMinutos, ext.VencidosMinutos, len(ext.Lancamentos))
+	}
+
+	// folga compensada na segunda 14/04 debita a jornada do dia (528 min); excluir a falta desfaz o débito
+	folga, err := bs.ConcederFolga(ctx, claims, funcID, time.Date(2025, 4, 14, 0, 0, 0, 0, time.Local), "")
+	if err != nil {
+		t.Fatalf("ConcederFolga erro: %v", err)
+	}
+	if folga.Minutos != 528 || folga.Referencia == nil {
+		t.Fatalf("folga inesperada: %+v", folga)
+	}
+	if ext, _ = bs.Extrato(ctx, claims, funcID, fimAbril); ext.SaldoMinutos != -198 {
+		t.Fatalf("saldo após folga esperado -198, veio %d", ext.SaldoMinutos)
+	}
+	if err := repository.DeleteFalta(*folga.Referencia); err != nil {
+		t.Fatalf("DeleteFalta erro: %v", err)
+	}
+
+	// horas extras que foram ao banco não são pagas na folha do mês
+	abril, err := fs.CriarFolhaSalario(ctx, claims, 4, 2025)
+	if err != nil {
+		t.Fatalf("CriarFolhaSalario abril erro: %v", err)
+	}
+	rows, err := repository.GetPagamentosByFolhaID(abril.ID)
+	if err != nil || len(rows) != 1 || rows[0].HorasExtras != 0 {
+		t.Fatalf("abril: esperava 1 pagamento sem horas extras, got=%+v err=%v", rows, err)
+	}
+
+	// os 330 minutos vencem em maio (10/05 e 12/05) e são pagos a 50%: 330 × (10/60) × 1,5 = 82,50
+	maio, err := fs.CriarFolhaSalario(ctx, claims, 5, 2025)
+	if err != nil {
+		t.Fatalf("CriarFolhaSalario maio erro: %v", err)
+	}
+	rows, err = repository.GetPagamentosByFolhaID(maio.ID)
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("maio: esperava 1 pagamento, got=%d err=%v", len(rows), err)
+	}
+	if rows[0].HorasExtras < 82.49 || rows[0].HorasExtras > 82.51 {
+		t.Fatalf("horasExtras de maio esperado ~82.50, veio %.2f", rows[0].HorasExtras)
+	}
+	ext, _ = bs.Extrato(ctx, claims, funcID, time.Date(2025, 5, 31, 0, 0, 0, 0, time.Local))
+	if ext.SaldoMinutos != 0 || ext.VencidosMinutos != 0 {
+		t.Fatalf("após a folha de maio o banco deveria zerar: saldo=%d vencidos=%d", ext.SaldoMinutos, ext.VencidosMinutos)
+	}
+}
@@ -0,0 +1,119 @@
+package testes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/service/jwt"
+)
+
+func newBuscaServiceSUT() *service.BuscaService {
+	cfg := service.AuthConfig{
+		Issuer:    "autogrh-test",
+		AccessTTL: 10 * time.Minute,
+		ClockSkew: 2 * time.Minute,
+		Timezone:  "America/Campo_Grande",
+	}
+	perms := service.PermissionMap{
+		"admin":   {"*": {}},
+		"usuario": {"funcionario:list": {}},
+	}
+	auth := service.NewAuthService(nil, &folhaFakeLogRepo{}, jwtm.NewHS256Manager([]byte("secret")), cfg, perms)
+	return service.NewBuscaService(auth)
+}
+
+func TestBusca_Global(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	ctx := context.Background()
+	admin := service.Claims{UserID: 1, Perfil: "admin"}
+	usuario := service.Claims{UserID: 2, Perfil: "usuario"}
+	svc := newBuscaServiceSUT()
+
+	joao := &entity.Pessoa{Nome: "João Silva", CPF: "39053344705", RG: "11"}
+	maria := &entity.Pessoa{Nome: "Maria Silvana Santos", CPF: "52998224725", RG: "22"}
+	for _, p := range []*entity.Pessoa{joao, maria} {
+		if err := repository.CreatePessoa(p); err != nil {
+			t.Fatalf("CreatePessoa erro: %v", err)
+		}
+	}
+	nasc := time.Date(1990, 5, 10, 0, 0, 0, 0, time.Local)
+	f := &entity.Funcionario{
+		PessoaID: joao.ID, Nascimento: nasc, Admissao: time.Date(2022, 1, 3, 0, 0, 0, 0, time.Local),
+		Cargo: "Analista de Sistemas", PIS: "12017386528", SalarioInicial: 4000,
+	}
+	if err := repository.CreateFuncionario(f); err != nil {
+		t.Fatalf("CreateFuncionario erro: %v", err)
+	}
+	doc := &entity.Documento{FuncionarioID: f.ID, Caminho: "uploads/contrato_silva.pdf"}
+	if err := repository.CreateDocumento(ctx, doc); err != nil {
+		t.Fatalf("CreateDocumento erro: %v", err)
+	}
+	v := entity.NewVale(f.ID, 300, time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local))
+	if err := repository.CreateVale(v); err != nil {
+		t.Fatalf("CreateVale erro: %v", err)
+	}
+	folha := &entity.FolhaPagamentos{Mes: 3, Ano: 2025, Tipo: "SALARIO", DataGeracao: time.Now()}
+	if err := repository.CreateFolhaPagamento(folha); err != nil {
+		t.Fatalf("CreateFolhaPagamento erro: %v", err)
+	}
+
+	achou := func(lista []entity.ResultadoBusca, tipo string, id int64) *entity.ResultadoBusca {
+		for i := range lista {
+			if lista[i].Tipo == tipo && lista[i].ID == id {
+				return &lista[i]
+			}
+		}
+		return nil
+	}
+
+	res, err := svc.Buscar(ctx, admin, "silva", 0)
+	if err != nil {
+		t.Fatalf("Buscar erro: %v", err)
+	}
+	rf := achou(res, entity.BuscaFuncionario, f.ID)
+	rp := achou(res, entity.BuscaPessoa, maria.ID)
+	rd := achou(res, entity.BuscaDocumento, doc.ID)
+	if rf == nil || rp == nil || rd == nil {
+		t.Fatalf("esperava funcionário, pessoa e documento, veio %+v", res)
+	}
+	if rf.Link != "/funcionarios/"+fmt.Sprint(f.ID) || rd.Titulo != "contrato_silva.pdf" {
+		t.Errorf("link ou título inesperado: %+v %+v", rf, rd)
+	}
+	// "João Silva" tem a palavra inteira; "Silvana" só começa com o termo
+	if rf.Relevancia <= rp.Relevancia || res[0].Relevancia < res[len(res)-1].Relevancia {
+		t.Errorf("ordenação por relevância inesperada: %+v", res)
+	}
+
+	res, err = svc.Buscar(ctx, usuario, "silva", 0)
+	if err != nil {
+		t.Fatalf("Buscar erro: %v", err)
+	}
+	if achou(res, entity.BuscaDocumento, doc.ID) != nil {
+		t.Errorf("usuário sem documento:list não deveria ver documentos: %+v", res)
+	}
+
+	if res, err = svc.Buscar(ctx, admin, "analista", 0); err != nil || achou(res, entity.BuscaFuncionario, f.ID) == nil {
+		t.Errorf("busca por cargo: %+v err=%v", res, err)
+	}
+	if res, err = svc.Buscar(ctx, admin, "390.533", 0); err != nil || achou(res, entity.BuscaPessoa, joao.ID) == nil {
+		t.Errorf("busca por trecho de CPF: %+v err=%v", res, err)
+	}
+	if res, err = svc.Buscar(ctx, admin, "173865", 0); err != nil || achou(res, entity.BuscaFuncionario, f.ID) == nil {
+		t.Errorf("busca por trecho de PIS: %+v err=%v", res, err)
+	}
+	if res, err = svc.Buscar(ctx, admin, "vale "+fmt.Sprint(v.ID), 0); err != nil || len(res) != 1 || res[0].Link != "/vales/"+fmt.Sprint(v.ID) {
+		t.Errorf("busca por vale: %+v err=%v", res, err)
+	}
+	if res, err = svc.Buscar(ctx, admin, "03/2025", 0); err != nil || achou(res, entity.BuscaFolha, folha.ID) == nil {
+		t.Errorf("busca por competência: %+v err=%v", res, err)
+	}
+	if _, err = svc.Buscar(ctx, admin, " a ", 0); err == nil {
+		t.Errorf("esperava erro para termo curto")
+	}
+}
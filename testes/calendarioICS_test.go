@@ -0,0 +1,296 @@
+package testes
+
+import (
+	Adapter "AutoGRH/pkg/adapter"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"AutoGRH/pkg/controller"
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/utils/iCalendar"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func newCalendarioICSServiceWithDB(lr *fdFakeLogRepo) *service.CalendarioICSService {
+	repo := Adapter.NewCalendarioTokenRepositoryAdapter(
+		repository.SaveCalendarioToken,
+		repository.GetCalendarioTokenByHash,
+		repository.DeleteCalendarioTokenByUsuarioID,
+		repository.TouchCalendarioToken,
+	)
+	return service.NewCalendarioICSService(newAdminAuthFD(lr), lr, repo)
+}
+
+// newCalendarioICSRouter expõe só a rota pública do feed, como no router da aplicação
+func newCalendarioICSRouter(svc *service.CalendarioICSService) http.Handler {
+	r := chi.NewRouter()
+	r.Get("/ics/{token}/{feed}.ics", controller.NewCalendarioICSController(svc).Feed)
+	return r
+}
+
+func getICS(h http.Handler, token, feed string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/ics/%s/%s.ics", token, feed), nil))
+	return rec
+}
+
+// desdobrar desfaz a dobra de linhas da RFC 5545 para comparar o conteúdo lógico
+func desdobrar(ics string) string {
+	return strings.ReplaceAll(ics, "\r\n ", "")
+}
+
+func seedUsuarioICS(t *testing.T, username string) *entity.Usuario {
+	t.Helper()
+	u := entity.NewUsuario(username, "hash", true)
+	if err := repository.CreateUsuario(u); err != nil {
+		t.Fatalf("CreateUsuario erro: %v", err)
+	}
+	return u
+}
+
+func TestICalendar_EscapeDobraECRLF(t *testing.T) {
+	descricao := "Cargo: Analista de Suporte Técnico Sênior; Status: aprovado, com observação\\ajuste\nSegunda linha com acentuação: ção ção ção"
+	cal := iCalendar.Calendar{
+		Nome: "AutoGRH - Férias",
+		Eventos: []iCalendar.Event{
+			{
+				UID:         "descanso-1@autogrh",
+				Summary:     "Férias: Ana; Bia, Cia",
+				Description: descricao,
+				Inicio:      time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local),
+				Fim:         time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local),
+				Categoria:   "Férias",
+			},
+			{
+				// fim antes do início vira evento de um dia
+				UID:     "ferias-vencimento-2@autogrh",
+				Summary: "Vencimento",
+				Inicio:  time.Date(2025, 12, 31, 0, 0, 0, 0, time.Local),
+				Fim:     time.Date(2025, 12, 30, 0, 0, 0, 0, time.Local),
+			},
+		},
+		Gerado: time.Date(2025, 2, 1, 12, 30, 0, 0, time.UTC),
+	}
+	ics := string(cal.Bytes())
+
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Fatalf("calendário sem BEGIN/END com CRLF: %q", ics)
+	}
+	linhas := strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n")
+	for _, l := range linhas {
+		if strings.ContainsAny(l, "\r\n") {
+			t.Fatalf("quebra de linha fora de CRLF: %q", l)
+		}
+		if len(l) > 75 {
+			t.Fatalf("linha com mais de 75 octetos (%d): %q", len(l), l)
+		}
+		if !utf8.ValidString(l) {
+			t.Fatalf("dobra partiu um caractere UTF-8: %q", l)
+		}
+	}
+
+	logico := desdobrar(ics)
+	for _, esperado := range []string{
+		"X-WR-CALNAME:AutoGRH - Férias\r\n",
+		"SUMMARY:Férias: Ana\\; Bia\\, Cia\r\n",
+		`DESCRIPTION:Cargo: Analista de Suporte Técnico Sênior\; Status: aprovado\, com observação\\ajuste\nSegunda linha com acentuação: ção ção ção` + "\r\n",
+		"DTSTAMP:20250201T123000Z\r\n",
+		// dia inteiro: DTEND é o dia seguinte ao último
+		"DTSTART;VALUE=DATE:20250301\r\nDTEND;VALUE=DATE:20250304\r\n",
+		"DTSTART;VALUE=DATE:20251231\r\nDTEND;VALUE=DATE:20260101\r\n",
+	} {
+		if !strings.Contains(logico, esperado) {
+			t.Fatalf("esperava %q em\n%s", esperado, logico)
+		}
+	}
+	if n := strings.Count(ics, "BEGIN:VEVENT\r\n"); n != 2 {
+		t.Fatalf("esperava 2 eventos, veio %d", n)
+	}
+}
+
+func TestCalendarioICS_TokenPorHashERevogacao(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &fdFakeLogRepo{}
+	svc := newCalendarioICSServiceWithDB(lr)
+	h := newCalendarioICSRouter(svc)
+	ctx := context.Background()
+
+	u := seedUsuarioICS(t, "ics-admin")
+	claims := service.Claims{UserID: u.ID, Perfil: "admin"}
+
+	token, err := svc.GerarToken(ctx, claims)
+	if err != nil || len(token) != 64 {
+		t.Fatalf("GerarToken inesperado: %q, %v", token, err)
+	}
+
+	// só o hash é gravado
+	sum := sha256.Sum256([]byte(token))
+	salvo, err := repository.GetCalendarioTokenByHash(hex.EncodeToString(sum[:]))
+	if err != nil || salvo == nil || salvo.UsuarioID != u.ID {
+		t.Fatalf("token não encontrado pelo hash: %+v, %v", salvo, err)
+	}
+	if puro, _ := repository.GetCalendarioTokenByHash(token); puro != nil {
+		t.Fatalf("o token não deveria ser gravado em texto puro")
+	}
+
+	c, err := svc.ClaimsPorToken(ctx, token)
+	if err != nil || c.UserID != u.ID || c.Perfil != "admin" {
+		t.Fatalf("ClaimsPorToken inesperado: %+v, %v", c, err)
+	}
+	if _, err := svc.ClaimsPorToken(ctx, "desconhecido"); !errors.Is(err, service.ErrTokenCalendarioInvalido) {
+		t.Fatalf("esperado ErrTokenCalendarioInvalido, veio %v", err)
+	}
+
+	rec := getICS(h, token, service.FeedCalendarioTodos)
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/calendar") ||
+		!strings.HasPrefix(rec.Body.String(), "BEGIN:VCALENDAR\r\n") {
+		t.Fatalf("feed válido deveria voltar 200 com text/calendar, veio %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if rec := getICS(h, token, "holerites"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("feed desconhecido deveria voltar 400, veio %d", rec.Code)
+	}
+	if rec := getICS(h, strings.Repeat("0", 64), service.FeedCalendarioTodos); rec.Code != http.StatusNotFound {
+		t.Fatalf("token desconhecido deveria voltar 404, veio %d", rec.Code)
+	}
+
+	// gerar de novo substitui o token anterior
+	novo, err := svc.GerarToken(ctx, claims)
+	if err != nil || novo == token {
+		t.Fatalf("GerarToken (novo) inesperado: %q, %v", novo, err)
+	}
+	if rec := getICS(h, token, service.FeedCalendarioTodos); rec.Code != http.StatusNotFound {
+		t.Fatalf("token substituído deveria voltar 404, veio %d", rec.Code)
+	}
+	if rec := getICS(h, novo, service.FeedCalendarioTodos); rec.Code != http.StatusOK {
+		t.Fatalf("token novo deveria voltar 200, veio %d", rec.Code)
+	}
+
+	if err := svc.RevogarToken(ctx, claims); err != nil {
+		t.Fatalf("RevogarToken erro: %v", err)
+	}
+	if rec := getICS(h, novo, service.FeedCalendarioTodos); rec.Code != http.StatusNotFound {
+		t.Fatalf("token revogado deveria voltar 404, veio %d", rec.Code)
+	}
+	if !hasLogPrefix(lr.entries, 5, u.ID, "Token de calendário revogado") {
+		t.Fatalf("esperava log de revogação")
+	}
+}
+
+func TestCalendarioICS_EventosDosFeeds(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &fdFakeLogRepo{}
+	svc := newCalendarioICSServiceWithDB(lr)
+	ctx := context.Background()
+	u := seedUsuarioICS(t, "ics-feeds")
+	claims := service.Claims{UserID: u.ID, Perfil: "admin"}
+
+	hoje := time.Now()
+	hoje = time.Date(hoje.Year(), hoje.Month(), hoje.Day(), 0, 0, 0, 0, time.Local)
+
+	funcID := seedPessoaFuncionarioFD(t)
+	// período aquisitivo que vence daqui a 6 meses e um já pago, fora do feed
+	ferias := entity.NewFerias(funcID, hoje.AddDate(-1, 6, 0), 30)
+	pago := entity.NewFerias(funcID, hoje.AddDate(-1, 3, 0), 30)
+	pago.Pago = true
+	for _, f := range []*entity.Ferias{ferias, pago} {
+		if err := repository.CreateFerias(f); err != nil {
+			t.Fatalf("CreateFerias erro: %v", err)
+		}
+	}
+	pendente := entity.NewDescanso(hoje.AddDate(0, 1, 0), hoje.AddDate(0, 1, 9), ferias.ID)
+	aprovado := entity.NewDescanso(hoje.AddDate(0, 2, 0), hoje.AddDate(0, 2, 4), ferias.ID)
+	aprovado.Aprovado = true
+	for _, d := range []*entity.Descanso{pendente, aprovado} {
+		if err := repository.CreateDescanso(d); err != nil {
+			t.Fatalf("CreateDescanso erro: %v", err)
+		}
+	}
+
+	expID := seedPessoaFuncionarioFD(t)
+	fimExp := hoje.AddDate(0, 0, 45)
+	if _, err := repository.DB.Exec(`UPDATE funcionario SET prazoContrato = ?, fimExperiencia = ? WHERE funcionarioID = ?`,
+		entity.ContratoExperiencia, fimExp.Format("2006-01-02"), expID); err != nil {
+		t.Fatalf("seed experiência erro: %v", err)
+	}
+
+	feed := func(nome string) string {
+		t.Helper()
+		ics, err := svc.Feed(ctx, claims, nome)
+		if err != nil {
+			t.Fatalf("Feed %s erro: %v", nome, err)
+		}
+		return desdobrar(string(ics))
+	}
+	uidDescansoPendente := fmt.Sprintf("UID:descanso-%d@autogrh\r\n", pendente.ID)
+	uidDescansoAprovado := fmt.Sprintf("UID:descanso-%d@autogrh\r\n", aprovado.ID)
+	uidVencimento := fmt.Sprintf("UID:ferias-vencimento-%d@autogrh\r\n", ferias.ID)
+	uidVencimentoPago := fmt.Sprintf("UID:ferias-vencimento-%d@autogrh\r\n", pago.ID)
+	uidExperiencia := fmt.Sprintf("UID:experiencia-%d@autogrh\r\n", expID)
+	comp := time.Date(hoje.Year(), hoje.Month(), 1, 0, 0, 0, 0, time.Local)
+	uidFolha := fmt.Sprintf("UID:folha-%d-%02d-SALARIO@autogrh\r\n", comp.Year(), int(comp.Month()))
+
+	descansos := feed(service.FeedCalendarioDescansos)
+	for _, esperado := range []string{
+		"X-WR-CALNAME:AutoGRH - Férias\r\n",
+		uidDescansoPendente, uidDescansoAprovado,
+		"SUMMARY:Férias: Teste Ferias/Descanso (pendente)\r\n",
+		"SUMMARY:Férias: Teste Ferias/Descanso\r\n",
+		`DESCRIPTION:Cargo: Analista\nStatus: aprovado` + "\r\n",
+		"DTSTART;VALUE=DATE:" + pendente.Inicio.Format("20060102") + "\r\nDTEND;VALUE=DATE:" + pendente.Fim.AddDate(0, 0, 1).Format("20060102") + "\r\n",
+	} {
+		if !strings.Contains(descansos, esperado) {
+			t.Fatalf("feed de descansos sem %q:\n%s", esperado, descansos)
+		}
+	}
+	if strings.Contains(descansos, "ferias-vencimento-") || strings.Contains(descansos, "folha-") {
+		t.Fatalf("feed de descansos com eventos de outros feeds:\n%s", descansos)
+	}
+
+	vencimentos := feed(service.FeedCalendarioVencimentos)
+	if !strings.Contains(vencimentos, uidVencimento) ||
+		!strings.Contains(vencimentos, "DTSTART;VALUE=DATE:"+ferias.Vencimento.Format("20060102")+"\r\n") {
+		t.Fatalf("feed de vencimentos sem o período em aberto:\n%s", vencimentos)
+	}
+	if strings.Contains(vencimentos, uidVencimentoPago) || strings.Contains(vencimentos, "descanso-") {
+		t.Fatalf("feed de vencimentos com período pago ou descansos:\n%s", vencimentos)
+	}
+
+	experiencia := feed(service.FeedCalendarioExperiencia)
+	if !strings.Contains(experiencia, uidExperiencia) ||
+		!strings.Contains(experiencia, "DTSTART;VALUE=DATE:"+fimExp.Format("20060102")+"\r\n") ||
+		strings.Contains(experiencia, fmt.Sprintf("UID:experiencia-%d@autogrh", funcID)) {
+		t.Fatalf("feed de experiência deveria trazer só o funcionário em experiência:\n%s", experiencia)
+	}
+
+	// 12 meses para trás e 2 para frente
+	folhas := feed(service.FeedCalendarioFolhas)
+	if n := strings.Count(folhas, "BEGIN:VEVENT\r\n"); n != 15 || !strings.Contains(folhas, uidFolha) ||
+		!strings.Contains(folhas, "Situação: não gerada") {
+		t.Fatalf("feed de folhas inesperado (%d eventos):\n%s", n, folhas)
+	}
+
+	todos := feed(service.FeedCalendarioTodos)
+	for _, uid := range []string{uidDescansoPendente, uidDescansoAprovado, uidVencimento, uidExperiencia, uidFolha} {
+		if !strings.Contains(todos, uid) {
+			t.Fatalf("feed todos sem %q", uid)
+		}
+	}
+
+	if _, err := svc.Feed(ctx, claims, "holerites"); !errors.Is(err, service.ErrFeedCalendarioInvalido) {
+		t.Fatalf("esperado ErrFeedCalendarioInvalido, veio %v", err)
+	}
+}
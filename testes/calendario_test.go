@@ -0,0 +1,89 @@
+package testes
+
+import (
+	Adapter "AutoGRH/pkg/adapter"
+	"context"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+)
+
+func newCalendarioServiceWithDB(lr *fdFakeLogRepo) *service.CalendarioService {
+	auth := newAdminAuthFD(lr)
+	repo := Adapter.NewFeriadoRepositoryAdapter(
+		repository.CreateFeriado,
+		repository.GetFeriadoByID,
+		repository.UpdateFeriado,
+		repository.DeleteFeriado,
+		repository.ListFeriadosEntre,
+	)
+	return service.NewCalendarioService(auth, lr, repo)
+}
+
+func TestCalendario_DiasUteis_FeriadosNacionaisECadastrados(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &fdFakeLogRepo{}
+	svc := newCalendarioServiceWithDB(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 92, Perfil: "admin"}
+
+	inicio := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.Local)
+	fim := time.Date(2025, time.April, 30, 0, 0, 0, 0, time.Local)
+
+	// Abril/2025: 22 dias de semana, menos Sexta-feira Santa (18) e Tiradentes (21)
+	n, err := svc.DiasUteis(inicio, fim)
+	if err != nil {
+		t.Fatalf("DiasUteis erro: %v", err)
+	}
+	if n != 20 {
+		t.Fatalf("esperado 20 dias úteis sem feriados cadastrados, obtido %d", n)
+	}
+
+	// Feriado estadual recorrente cadastrado em outro ano vale para 2025
+	sjorge := entity.NewFeriado(time.Date(2020, time.April, 23, 0, 0, 0, 0, time.Local), "São Jorge", entity.FeriadoTipoEstadual, true)
+	sjorge.Localidade = "RJ"
+	if err := svc.Criar(ctx, claims, sjorge); err != nil {
+		t.Fatalf("Criar feriado erro: %v", err)
+	}
+	// Folga da empresa só naquele dia
+	folga := entity.NewFeriado(time.Date(2025, time.April, 17, 0, 0, 0, 0, time.Local), "Ponte de Páscoa", "", false)
+	if err := svc.Criar(ctx, claims, folga); err != nil {
+		t.Fatalf("Criar folga erro: %v", err)
+	}
+	if folga.Tipo != entity.FeriadoTipoEmpresa {
+		t.Fatalf("tipo padrão deveria ser EMPRESA, obtido %s", folga.Tipo)
+	}
+
+	dto, err := svc.ConsultarDiasUteis(ctx, claims, inicio, fim)
+	if err != nil {
+		t.Fatalf("ConsultarDiasUteis erro: %v", err)
+	}
+	if dto.DiasUteis != 18 {
+		t.Fatalf("esperado 18 dias úteis, obtido %d", dto.DiasUteis)
+	}
+	if len(dto.Feriados) != 4 {
+		t.Fatalf("esperado 4 feriados no intervalo, obtido %d", len(dto.Feriados))
+	}
+
+	// Feriado nacional não pode ser cadastrado manualmente
+	nac := entity.NewFeriado(time.Date(2025, time.May, 1, 0, 0, 0, 0, time.Local), "Dia do Trabalho", entity.FeriadoTipoNacional, true)
+	if err := svc.Criar(ctx, claims, nac); err == nil {
+		t.Fatalf("esperava erro ao cadastrar feriado NACIONAL")
+	}
+
+	// Removendo a folga, o dia volta a ser útil
+	if err := svc.Excluir(ctx, claims, folga.ID); err != nil {
+		t.Fatalf("Excluir erro: %v", err)
+	}
+	util, err := svc.EhDiaUtil(folga.Data)
+	if err != nil {
+		t.Fatalf("EhDiaUtil erro: %v", err)
+	}
+	if !util {
+		t.Fatalf("17/04/2025 deveria voltar a ser dia útil após excluir a folga")
+	}
+}
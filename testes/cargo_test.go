@@ -0,0 +1,95 @@
+package testes
+
+import (
+	Adapter "AutoGRH/pkg/adapter"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+)
+
+func newCargoServiceWithDB(lr *salarioFakeLogRepo) *service.CargoService {
+	auth := newAdminAuthForSalary(lr)
+	repo := Adapter.NewCargoRepositoryAdapter(
+		repository.CreateCargo,
+		repository.GetCargoByID,
+		repository.GetCargoByTitulo,
+		repository.UpdateCargo,
+		repository.DeleteCargo,
+		repository.ListCargos,
+		repository.CargoEmUso,
+		repository.CreateFuncionarioCargo,
+		repository.ListCargosByFuncionarioID,
+	)
+	return service.NewCargoService(auth, lr, repo)
+}
+
+func TestCargo_Promocao_HistoricoSalarioEFaixa(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &salarioFakeLogRepo{}
+	svc := newCargoServiceWithDB(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 39, Perfil: "admin"}
+	funcID := seedPessoaFuncionarioForSalary(t)
+
+	pleno := entity.NewCargo(" Analista Pleno ", "2124-05", 4500, 7000, "Tecnologia")
+	if err := svc.CriarCargo(ctx, claims, pleno); err != nil {
+		t.Fatalf("CriarCargo erro: %v", err)
+	}
+	if pleno.CBO != "212405" || pleno.Titulo != "Analista Pleno" {
+		t.Fatalf("cargo não normalizado: %+v", pleno)
+	}
+	if err := svc.CriarCargo(ctx, claims, entity.NewCargo("analista pleno", "", 0, 0, "")); err == nil {
+		t.Fatalf("esperava erro de título duplicado")
+	}
+	if err := svc.CriarCargo(ctx, claims, entity.NewCargo("Faixa invertida", "", 5000, 4000, "")); err == nil {
+		t.Fatalf("esperava erro de faixa invertida")
+	}
+
+	data := time.Now().AddDate(0, 0, -10)
+	salario := 9000.0
+	res, err := svc.PromoverFuncionario(ctx, claims, funcID, service.PromocaoInput{
+		CargoID: pleno.ID, Data: data, Salario: &salario, Observacao: "promoção",
+	})
+	if err != nil {
+		t.Fatalf("PromoverFuncionario erro: %v", err)
+	}
+	if len(res.Avisos) != 1 || !strings.Contains(res.Avisos[0], "fora da faixa") {
+		t.Fatalf("esperava aviso de faixa salarial, veio %v", res.Avisos)
+	}
+
+	f, _ := repository.GetFuncionarioByID(funcID)
+	if f.CargoID == nil || *f.CargoID != pleno.ID || f.Cargo != "Analista Pleno" {
+		t.Fatalf("cargo do funcionário não atualizado: %+v", f)
+	}
+	atual, _ := repository.GetSalarioAtual(funcID)
+	if atual == nil || atual.Valor != 9000 || atual.Inicio.Format("2006-01-02") != data.Format("2006-01-02") {
+		t.Fatalf("salário da promoção não registrado: %+v", atual)
+	}
+	hist, err := svc.ListarHistorico(ctx, claims, funcID)
+	if err != nil || len(hist) != 1 || hist[0].Cargo == nil || hist[0].Cargo.Titulo != "Analista Pleno" {
+		t.Fatalf("histórico inválido: %+v err=%v", hist, err)
+	}
+
+	// mudança na mesma data ou para o mesmo cargo é recusada; cargo com histórico não é excluído
+	if _, err := svc.PromoverFuncionario(ctx, claims, funcID, service.PromocaoInput{CargoID: pleno.ID, Data: data}); err == nil {
+		t.Fatalf("esperava erro de mudança na mesma data")
+	}
+	if err := svc.ExcluirCargo(ctx, claims, pleno.ID); err == nil {
+		t.Fatalf("esperava erro ao excluir cargo em uso")
+	}
+
+	// renomear o cargo atualiza o título do funcionário
+	pleno.Titulo = "Analista de Sistemas Pleno"
+	if err := svc.AtualizarCargo(ctx, claims, pleno); err != nil {
+		t.Fatalf("AtualizarCargo erro: %v", err)
+	}
+	if f, _ = repository.GetFuncionarioByID(funcID); f.Cargo != "Analista de Sistemas Pleno" {
+		t.Fatalf("título do cargo não propagado: %q", f.Cargo)
+	}
+}
@@ -0,0 +1,139 @@
+package testes
+
+import (
+	Adapter "AutoGRH/pkg/adapter"
+	"context"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+)
+
+func newCentroCustoServiceWithDB(lr *folhaFakeLogRepo) *service.CentroCustoService {
+	auth := newAdminAuth(lr)
+	repo := Adapter.NewCentroCustoRepositoryAdapter(
+		repository.CreateCentroCusto,
+		repository.GetCentroCustoByID,
+		repository.ListCentrosCusto,
+		repository.UpdateCentroCusto,
+		repository.DeleteCentroCusto,
+		repository.CentroCustoEmUso,
+		repository.CreateDepartamento,
+		repository.GetDepartamentoByID,
+		repository.ListDepartamentos,
+		repository.UpdateDepartamento,
+		repository.DeleteDepartamento,
+		repository.DepartamentoEmUso,
+		repository.SaveAlocacoes,
+		repository.DeleteAlocacoes,
+		repository.ListAlocacoesByFuncionarioID,
+	)
+	return service.NewCentroCustoService(auth, lr, repo)
+}
+
+func TestCentroCusto_RateioFolhaEResumo(t *testing.T) {
+	if err := truncateAll(); err != nil {
+		t.Fatalf("truncateAll inicio: %v", err)
+	}
+	t.Cleanup(func() { _ = truncateAll() })
+
+	lr := &folhaFakeLogRepo{}
+	svc := newCentroCustoServiceWithDB(lr)
+	fs := newFolhaService(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 40, Perfil: "admin"}
+
+	producao := entity.NewCentroCusto(" cc-100 ", "Produção")
+	adm := entity.NewCentroCusto("CC-200", "Administrativo")
+	for _, c := range []*entity.CentroCusto{producao, adm} {
+		if err := svc.CriarCentroCusto(ctx, claims, c); err != nil {
+			t.Fatalf("CriarCentroCusto erro: %v", err)
+		}
+	}
+	if producao.Codigo != "CC-100" {
+		t.Fatalf("código não normalizado: %q", producao.Codigo)
+	}
+	if err := svc.CriarCentroCusto(ctx, claims, entity.NewCentroCusto("cc-100", "Outro")); err == nil {
+		t.Fatalf("esperava erro de código duplicado")
+	}
+	montagem := entity.NewDepartamento("Montagem", &producao.ID)
+	compras := entity.NewDepartamento("Compras", nil)
+	for _, d := range []*entity.Departamento{montagem, compras} {
+		if err := svc.CriarDepartamento(ctx, claims, d); err != nil {
+			t.Fatalf("CriarDepartamento erro: %v", err)
+		}
+	}
+
+	alocado := seedPessoaFuncionarioBase(t, "Funcionario Rateado")
+	seedSalarioRealAtual(t, alocado, 2000)
+	semAlocacao := seedPessoaFuncionarioBase(t, "Funcionario Sem Alocacao")
+	seedSalarioRealAtual(t, semAlocacao, 1500)
+
+	inicio := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+	// departamento sem centro de custo exige o centro no item; percentuais precisam somar 100
+	if _, err := svc.DefinirAlocacao(ctx, claims, alocado, inicio, []service.AlocacaoItem{
+		{DepartamentoID: montagem.ID, Percentual: 60}, {DepartamentoID: compras.ID, Percentual: 40},
+	}); err == nil {
+		t.Fatalf("esperava erro de departamento sem centro de custo")
+	}
+	if _, err := svc.DefinirAlocacao(ctx, claims, alocado, inicio, []service.AlocacaoItem{
+		{DepartamentoID: montagem.ID, Percentual: 60}, {DepartamentoID: compras.ID, CentroCustoID: &adm.ID, Percentual: 30},
+	}); err == nil {
+		t.Fatalf("esperava erro de percentuais que não somam 100")
+	}
+	if _, err := svc.DefinirAlocacao(ctx, claims, alocado, inicio, []service.AlocacaoItem{
+		{DepartamentoID: montagem.ID, Percentual: 60}, {DepartamentoID: compras.ID, CentroCustoID: &adm.ID, Percentual: 40},
+	}); err != nil {
+		t.Fatalf("DefinirAlocacao erro: %v", err)
+	}
+	// alocação posterior ao mês da folha não vale para ela
+	if _, err := svc.DefinirAlocacao(ctx, claims, alocado, time.Date(2025, 4, 1, 0, 0, 0, 0, time.Local),
+		[]service.AlocacaoItem{{DepartamentoID: compras.ID, CentroCustoID: &adm.ID}}); err != nil {
+		t.Fatalf("DefinirAlocacao (abril) erro: %v", err)
+	}
+
+	folha, err := fs.CriarFolhaSalario(ctx, claims, 3, 2025)
+	if err != nil {
+		t.Fatalf("CriarFolhaSalario erro: %v", err)
+	}
+	pags, err := repository.GetPagamentosByFolhaID(folha.ID)
+	if err != nil {
+		t.Fatalf("GetPagamentosByFolhaID erro: %v", err)
+	}
+	for _, p := range pags {
+		switch p.FuncionarioID {
+		case alocado:
+			if len(p.Rateio) != 2 || p.Rateio[0].Valor+p.Rateio[1].Valor != p.ValorFinal {
+				t.Fatalf("rateio inválido: %+v", p.Rateio)
+			}
+		case semAlocacao:
+			if len(p.Rateio) != 0 {
+				t.Fatalf("pagamento sem alocação não deveria ter rateio: %+v", p.Rateio)
+			}
+		}
+	}
+
+	resumo, err := svc.ResumoFolha(ctx, claims, folha.ID)
+	if err != nil {
+		t.Fatalf("ResumoFolha erro: %v", err)
+	}
+	if len(resumo) != 3 {
+		t.Fatalf("esperava 3 linhas no resumo, veio %d", len(resumo))
+	}
+	if resumo[0].Codigo != "CC-100" || resumo[0].Valor != 1200 || resumo[0].FGTS != 96 || resumo[0].Custo != 1296 {
+		t.Fatalf("resumo CC-100 inesperado: %+v", resumo[0])
+	}
+	if resumo[1].Codigo != "CC-200" || resumo[1].Valor != 800 || resumo[1].Funcionarios != 1 {
+		t.Fatalf("resumo CC-200 inesperado: %+v", resumo[1])
+	}
+	if resumo[2].CentroCustoID != nil || resumo[2].Valor != 1500 {
+		t.Fatalf("resumo sem centro de custo inesperado: %+v", resumo[2])
+	}
+
+	// centro de custo em uso não é excluído
+	if err := svc.ExcluirCentroCusto(ctx, claims, producao.ID); err == nil {
+		t.Fatalf("esperava erro ao excluir centro de custo em uso")
+	}
+}
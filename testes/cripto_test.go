@@ -0,0 +1,209 @@
+package testes
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/utils/consulta"
+	"AutoGRH/pkg/utils/cripto"
+)
+
+func chaveTeste(b byte) []byte {
+	return bytes.Repeat([]byte{b}, cripto.TamanhoChave)
+}
+
+func novoCifradorTeste(t *testing.T, chaves map[int][]byte, atual int) *cripto.Cifrador {
+	t.Helper()
+	c, err := cripto.NovoCifrador(chaves, atual, chaveTeste(9))
+	if err != nil {
+		t.Fatalf("NovoCifrador erro: %v", err)
+	}
+	return c
+}
+
+func TestCripto_CifraDecifraERotaciona(t *testing.T) {
+	v1 := novoCifradorTeste(t, map[int][]byte{1: chaveTeste(1)}, 1)
+
+	cifrado, err := v1.Cifrar("12345678901", "pessoa.cpf")
+	if err != nil || !cripto.Cifrado(cifrado) || strings.Contains(cifrado, "12345678901") {
+		t.Fatalf("Cifrar inesperado: %q, %v", cifrado, err)
+	}
+	if outro, _ := v1.Cifrar("12345678901", "pessoa.cpf"); outro == cifrado {
+		t.Fatalf("cifrar o mesmo texto duas vezes deveria dar valores diferentes")
+	}
+	if texto, err := v1.Decifrar(cifrado, "pessoa.cpf"); err != nil || texto != "12345678901" {
+		t.Fatalf("Decifrar inesperado: %q, %v", texto, err)
+	}
+	if _, err := v1.Decifrar(cifrado, "pessoa.rg"); !errors.Is(err, cripto.ErrValorInvalido) {
+		t.Fatalf("valor copiado para outra coluna deveria falhar, veio %v", err)
+	}
+	adulterado := cifrado[:len(cifrado)-2] + "AA"
+	if _, err := v1.Decifrar(adulterado, "pessoa.cpf"); !errors.Is(err, cripto.ErrValorInvalido) {
+		t.Fatalf("valor adulterado deveria falhar, veio %v", err)
+	}
+	if texto, _ := v1.Decifrar("texto puro", "pessoa.cpf"); texto != "texto puro" {
+		t.Fatalf("texto puro deveria voltar como está")
+	}
+
+	// rotação: a v2 cifra, mas a v1 continua decifrando o que já existe
+	v2 := novoCifradorTeste(t, map[int][]byte{1: chaveTeste(1), 2: chaveTeste(2)}, 2)
+	if texto, err := v2.Decifrar(cifrado, "pessoa.cpf"); err != nil || texto != "12345678901" {
+		t.Fatalf("chave antiga deveria continuar decifrando: %q, %v", texto, err)
+	}
+	novo, _ := v2.Cifrar("12345678901", "pessoa.cpf")
+	if versao, ok := cripto.Versao(novo); !ok || versao != 2 {
+		t.Fatalf("esperado valor na v2, veio %q", novo)
+	}
+	if _, err := v1.Decifrar(novo, "pessoa.cpf"); !errors.Is(err, cripto.ErrChaveDesconhecida) {
+		t.Fatalf("esperado ErrChaveDesconhecida, veio %v", err)
+	}
+
+	// o índice cego não depende da chave AES
+	if v1.Indice("12345678901", "pessoa.cpf") != v2.Indice("12345678901", "pessoa.cpf") {
+		t.Fatalf("índice cego deveria ser o mesmo após a rotação")
+	}
+	if v1.Indice("12345678901", "pessoa.cpf") == v1.Indice("12345678901", "funcionario.pis") {
+		t.Fatalf("índice cego deveria depender da coluna")
+	}
+}
+
+func TestCripto_RepositorioCifraEBuscaPorIndice(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+	defer func() { _ = repository.ConfigurarCriptografia(nil, nil) }()
+
+	// linha gravada antes de ligar a criptografia
+	antiga := &entity.Pessoa{Nome: "Ivo Antigo", CPF: "11122233344", RG: "998877"}
+	if err := repository.CreatePessoa(antiga); err != nil {
+		t.Fatalf("CreatePessoa erro: %v", err)
+	}
+
+	v1 := novoCifradorTeste(t, map[int][]byte{1: chaveTeste(1)}, 1)
+	if err := repository.ConfigurarCriptografia(v1, repository.ColunasCifraveis()); err != nil {
+		t.Fatalf("ConfigurarCriptografia erro: %v", err)
+	}
+
+	nova := &entity.Pessoa{Nome: "Júlia Nova", CPF: "55566677788", RG: "112233"}
+	if err := repository.CreatePessoa(nova); err != nil {
+		t.Fatalf("CreatePessoa erro: %v", err)
+	}
+	var bruto string
+	_ = repository.DB.QueryRow(`SELECT cpf FROM pessoa WHERE pessoaID = ?`, nova.ID).Scan(&bruto)
+	if !strings.HasPrefix(bruto, cripto.Prefixo) {
+		t.Fatalf("CPF deveria estar cifrado no banco, veio %q", bruto)
+	}
+	if p, err := repository.GetPessoaByCPF("55566677788"); err != nil || p == nil || p.ID != nova.ID || p.CPF != "55566677788" {
+		t.Fatalf("GetPessoaByCPF pelo índice inesperado: %+v, %v", p, err)
+	}
+	if ok, _ := repository.ExistsPessoaByRG("112233"); !ok {
+		t.Fatalf("ExistsPessoaByRG deveria achar o RG cifrado")
+	}
+	// a linha ainda não migrada continua sendo achada pelo texto puro
+	if ok, _ := repository.ExistsPessoaByCPF("11122233344"); !ok {
+		t.Fatalf("ExistsPessoaByCPF deveria achar a linha em texto puro")
+	}
+
+	resultado, err := repository.MigrarCriptografia()
+	if err != nil {
+		t.Fatalf("MigrarCriptografia erro: %v", err)
+	}
+	if m := migracaoDe(resultado, "pessoa.cpf"); m.Cifradas != 1 || m.Recifradas != 0 {
+		t.Fatalf("esperado só o CPF antigo cifrado, veio %+v", m)
+	}
+	_ = repository.DB.QueryRow(`SELECT cpf FROM pessoa WHERE pessoaID = ?`, antiga.ID).Scan(&bruto)
+	if !strings.HasPrefix(bruto, cripto.Prefixo) {
+		t.Fatalf("migração deveria cifrar o CPF antigo, veio %q", bruto)
+	}
+	if p, _ := repository.GetPessoaByCPF("11122233344"); p == nil || p.ID != antiga.ID {
+		t.Fatalf("CPF migrado deveria ser achado pelo índice")
+	}
+
+	// rotação para a v2: a migração recifra tudo com a chave nova
+	v2 := novoCifradorTeste(t, map[int][]byte{1: chaveTeste(1), 2: chaveTeste(2)}, 2)
+	if err := repository.ConfigurarCriptografia(v2, repository.ColunasCifraveis()); err != nil {
+		t.Fatalf("ConfigurarCriptografia erro: %v", err)
+	}
+	resultado, err = repository.MigrarCriptografia()
+	if err != nil {
+		t.Fatalf("MigrarCriptografia (rotação) erro: %v", err)
+	}
+	if m := migracaoDe(resultado, "pessoa.cpf"); m.Recifradas != 2 {
+		t.Fatalf("esperado 2 CPFs recifrados, veio %+v", m)
+	}
+	_ = repository.DB.QueryRow(`SELECT cpf FROM pessoa WHERE pessoaID = ?`, nova.ID).Scan(&bruto)
+	if versao, _ := cripto.Versao(bruto); versao != 2 {
+		t.Fatalf("CPF deveria estar na v2, veio %q", bruto)
+	}
+	if p, _ := repository.GetPessoaByID(nova.ID); p == nil || p.CPF != "55566677788" {
+		t.Fatalf("CPF recifrado não foi lido de volta: %+v", p)
+	}
+}
+
+func TestCripto_ImportacaoCifradaEAnonimizada(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+	defer func() { _ = repository.ConfigurarCriptografia(nil, nil) }()
+
+	v1 := novoCifradorTeste(t, map[int][]byte{1: chaveTeste(1)}, 1)
+	if err := repository.ConfigurarCriptografia(v1, repository.ColunasCifraveis()); err != nil {
+		t.Fatalf("ConfigurarCriptografia erro: %v", err)
+	}
+	p := &entity.Pessoa{Nome: "Lia Importada", CPF: "55566677788"}
+	if err := repository.CreatePessoa(p); err != nil {
+		t.Fatalf("CreatePessoa erro: %v", err)
+	}
+
+	planilha := []byte("nome;cpf\nLia Importada;555.666.777-88\n")
+	imp := &entity.Importacao{
+		Tipo: entity.ImportacaoFuncionarios, Arquivo: "lote.csv", Formato: "CSV",
+		Status: entity.ImportacaoValidada, Mapeamento: map[string]string{"cpf": "cpf"}, TotalLinhas: 1,
+		Linhas:    []entity.LinhaImportacao{{Linha: 2, Nome: p.Nome, CPF: p.CPF, PessoaExistente: true, PessoaID: p.ID}},
+		UsuarioID: 1, CriadoEm: time.Now(), Conteudo: planilha,
+	}
+	if err := repository.CreateImportacao(imp); err != nil {
+		t.Fatalf("CreateImportacao erro: %v", err)
+	}
+	var linhas, conteudo string
+	_ = repository.DB.QueryRow(`SELECT linhas, conteudo FROM importacao WHERE importacaoID = ?`, imp.ID).Scan(&linhas, &conteudo)
+	if !strings.HasPrefix(linhas, cripto.Prefixo) || !strings.HasPrefix(conteudo, cripto.Prefixo) {
+		t.Fatalf("linhas e planilha deveriam estar cifradas no banco, vieram %q e %q", linhas, conteudo)
+	}
+	if strings.Contains(linhas, p.CPF) || strings.Contains(conteudo, "555.666.777-88") {
+		t.Fatalf("CPF não deveria aparecer em texto puro na importação")
+	}
+	lida, err := repository.GetImportacaoByID(imp.ID)
+	if err != nil || lida == nil || !bytes.Equal(lida.Conteudo, planilha) || len(lida.Linhas) != 1 || lida.Linhas[0].CPF != p.CPF {
+		t.Fatalf("importação não foi decifrada na leitura: %+v, %v", lida, err)
+	}
+
+	// com o CPF cifrado a listagem não ordena por ele
+	if _, err := repository.BuscarPessoas(consulta.Consulta{Ordem: "-cpf"}); err == nil {
+		t.Fatalf("ordenação por cpf deveria ser recusada com o CPF cifrado")
+	}
+
+	// a anonimização acha o titular nas linhas cifradas e descarta a planilha
+	if _, err := repository.AnonimizarPessoa(p.ID, time.Now()); err != nil {
+		t.Fatalf("AnonimizarPessoa erro: %v", err)
+	}
+	lida, err = repository.GetImportacaoByID(imp.ID)
+	if err != nil || lida == nil || len(lida.Conteudo) != 0 || lida.Linhas[0].CPF != "" ||
+		lida.Linhas[0].Nome != repository.NomeAnonimizado(p.ID) {
+		t.Fatalf("importação deveria estar anonimizada: %+v, %v", lida, err)
+	}
+	_ = repository.DB.QueryRow(`SELECT linhas FROM importacao WHERE importacaoID = ?`, imp.ID).Scan(&linhas)
+	if !strings.HasPrefix(linhas, cripto.Prefixo) {
+		t.Fatalf("linhas anonimizadas deveriam continuar cifradas, veio %q", linhas)
+	}
+}
+
+func migracaoDe(resultado []repository.MigracaoCriptografia, coluna string) repository.MigracaoCriptografia {
+	for _, m := range resultado {
+		if m.Coluna == coluna {
+			return m
+		}
+	}
+	return repository.MigracaoCriptografia{Coluna: coluna}
+}
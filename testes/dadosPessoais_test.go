@@ -0,0 +1,258 @@
+package testes
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/service/jwt"
+)
+
+func newDadosPessoaisServiceSUT(retencao service.RetencaoConfig) *service.DadosPessoaisService {
+	cfg := service.AuthConfig{
+		Issuer:    "autogrh-test",
+		AccessTTL: 10 * time.Minute,
+		ClockSkew: 2 * time.Minute,
+		Timezone:  "America/Campo_Grande",
+	}
+	perms := service.PermissionMap{
+		"admin":   {"*": {}},
+		"usuario": {"pessoa:read": {}},
+	}
+	lr := &folhaFakeLogRepo{}
+	auth := service.NewAuthService(nil, lr, jwtm.NewHS256Manager([]byte("secret")), cfg, perms)
+	return service.NewDadosPessoaisService(auth, lr, retencao)
+}
+
+// seedExFuncionario cria um funcionário e o desliga na data informada
+func seedExFuncionario(t *testing.T, nome string, demissao time.Time) (int64, int64) {
+	t.Helper()
+	funcID := seedPessoaFuncionarioBase(t, nome)
+	if _, err := repository.DB.Exec(`UPDATE funcionario SET ativo = FALSE, demissao = ? WHERE funcionarioID = ?`,
+		demissao.Format("2006-01-02"), funcID); err != nil {
+		t.Fatalf("seed desligamento erro: %v", err)
+	}
+	f, err := repository.GetFuncionarioByID(funcID)
+	if err != nil || f == nil {
+		t.Fatalf("GetFuncionarioByID erro: %v", err)
+	}
+	return f.PessoaID, funcID
+}
+
+func TestDadosPessoais_AnonimizacaoMantemPagamentos(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+	t.Setenv("HOME", t.TempDir())
+
+	ctx := context.Background()
+	admin := service.Claims{UserID: 1, Perfil: "admin"}
+	svc := newDadosPessoaisServiceSUT(service.RetencaoConfig{})
+
+	pessoaID, funcID := seedExFuncionario(t, "Carla Antiga", time.Date(2018, 6, 30, 0, 0, 0, 0, time.Local))
+	original, _ := repository.GetPessoaByID(pessoaID)
+	if err := repository.SaveContatos(pessoaID, []entity.Contato{{Tipo: entity.ContatoEmail, Valor: "carla@exemplo.com", Principal: true}}); err != nil {
+		t.Fatalf("SaveContatos erro: %v", err)
+	}
+	folha := &entity.FolhaPagamentos{Mes: 5, Ano: 2018, Tipo: "SALARIO", DataGeracao: time.Now()}
+	if err := repository.CreateFolhaPagamento(folha); err != nil {
+		t.Fatalf("CreateFolhaPagamento erro: %v", err)
+	}
+	if err := repository.CreatePagamento(&entity.Pagamento{FuncionarioID: funcID, FolhaID: folha.ID, SalarioBase: 2500, ValorFinal: 2300, Pago: true}); err != nil {
+		t.Fatalf("CreatePagamento erro: %v", err)
+	}
+	rel := filepath.Join("documentos", "1", "1_atestado.pdf")
+	disco := filepath.Join(os.Getenv("HOME"), "Documents", "AutoGRH", rel)
+	_ = os.MkdirAll(filepath.Dir(disco), 0o755)
+	_ = os.WriteFile(disco, []byte("pdf"), 0o644)
+	if err := repository.CreateDocumento(ctx, &entity.Documento{FuncionarioID: funcID, Caminho: rel}); err != nil {
+		t.Fatalf("CreateDocumento erro: %v", err)
+	}
+
+	if _, err := svc.AnonimizarPessoa(ctx, service.Claims{UserID: 2, Perfil: "usuario"}, pessoaID); !errors.Is(err, service.ErrUnauthorized) {
+		t.Fatalf("esperado ErrUnauthorized sem lgpd:anonimizar, veio %v", err)
+	}
+	a, err := svc.AnonimizarPessoa(ctx, admin, pessoaID)
+	if err != nil {
+		t.Fatalf("AnonimizarPessoa erro: %v", err)
+	}
+	if a.Vinculos != 1 || a.DocumentosRemovidos != 1 {
+		t.Fatalf("resultado inesperado: %+v", a)
+	}
+	if _, err := os.Stat(disco); !os.IsNotExist(err) {
+		t.Fatalf("arquivo do documento deveria ter sido apagado: %v", err)
+	}
+
+	p, _ := repository.GetPessoaByID(pessoaID)
+	if p.AnonimizadoEm == nil || p.Nome != repository.NomeAnonimizado(pessoaID) || p.CPF == original.CPF || len(p.Contatos) != 0 {
+		t.Fatalf("pessoa não foi anonimizada: %+v", p)
+	}
+	if achada, _ := repository.GetPessoaByCPF(original.CPF); achada != nil {
+		t.Fatalf("CPF antigo ainda encontra a pessoa")
+	}
+	f, _ := repository.GetFuncionarioByID(funcID)
+	if f.PIS != "" || f.CTPF != "" || f.Nascimento.Month() != time.January || f.Nascimento.Day() != 1 {
+		t.Fatalf("dados do vínculo não foram anonimizados: %+v", f)
+	}
+	pagamentos, _ := repository.GetPagamentosByFolhaID(folha.ID)
+	if len(pagamentos) != 1 || pagamentos[0].ValorFinal != 2300 || pagamentos[0].SalarioBase != 2500 {
+		t.Fatalf("pagamentos deveriam ficar intactos: %+v", pagamentos)
+	}
+
+	if _, err := svc.AnonimizarPessoa(ctx, admin, pessoaID); !errors.Is(err, service.ErrPessoaAnonimizada) {
+		t.Fatalf("esperado ErrPessoaAnonimizada na segunda vez, veio %v", err)
+	}
+}
+
+func TestDadosPessoais_AnonimizacaoRemoveAvisoDeConflito(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	ctx := context.Background()
+	admin := service.Claims{UserID: 1, Perfil: "admin"}
+	svc := newDadosPessoaisServiceSUT(service.RetencaoConfig{})
+
+	avisoDeConflito := func(funcID int64, nome string) int64 {
+		t.Helper()
+		ferias := entity.NewFerias(funcID, time.Date(2017, 1, 10, 0, 0, 0, 0, time.Local), 30)
+		if err := repository.CreateFerias(ferias); err != nil {
+			t.Fatalf("CreateFerias erro: %v", err)
+		}
+		d := entity.NewDescanso(time.Date(2017, 7, 3, 0, 0, 0, 0, time.Local), time.Date(2017, 7, 12, 0, 0, 0, 0, time.Local), ferias.ID)
+		if err := repository.CreateDescanso(d); err != nil {
+			t.Fatalf("CreateDescanso erro: %v", err)
+		}
+		ref := d.ID
+		if err := repository.CreateAviso(&entity.Aviso{
+			Tipo: "CONFLITO_AUSENCIA", Mensagem: "Descanso de " + nome + " excede o limite de ausências.",
+			ReferenciaID: &ref, CriadoEm: time.Now(), Ativo: true,
+		}); err != nil {
+			t.Fatalf("CreateAviso erro: %v", err)
+		}
+		return d.ID
+	}
+
+	pessoaID, funcID := seedExFuncionario(t, "Fábio Conflito", time.Date(2018, 6, 30, 0, 0, 0, 0, time.Local))
+	avisoDeConflito(funcID, "Fábio Conflito")
+	outroID := seedPessoaFuncionarioBase(t, "Gina Colega")
+	outroDescanso := avisoDeConflito(outroID, "Gina Colega")
+
+	if _, err := svc.AnonimizarPessoa(ctx, admin, pessoaID); err != nil {
+		t.Fatalf("AnonimizarPessoa erro: %v", err)
+	}
+	var restantes int
+	var referencia int64
+	if err := repository.DB.QueryRow(`SELECT COUNT(*), MAX(referenciaID) FROM aviso WHERE tipo = 'CONFLITO_AUSENCIA'`).
+		Scan(&restantes, &referencia); err != nil {
+		t.Fatalf("contar avisos erro: %v", err)
+	}
+	if restantes != 1 || referencia != outroDescanso {
+		t.Fatalf("só o aviso de conflito da colega deveria restar: %d aviso(s), referência %d", restantes, referencia)
+	}
+}
+
+func TestDadosPessoais_RecusaVinculoAtivo(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	ctx := context.Background()
+	admin := service.Claims{UserID: 1, Perfil: "admin"}
+	svc := newDadosPessoaisServiceSUT(service.RetencaoConfig{})
+
+	funcID := seedPessoaFuncionarioBase(t, "Diego Ativo")
+	f, _ := repository.GetFuncionarioByID(funcID)
+	if _, err := svc.AnonimizarPessoa(ctx, admin, f.PessoaID); !errors.Is(err, service.ErrPessoaComVinculoAtivo) {
+		t.Fatalf("esperado ErrPessoaComVinculoAtivo, veio %v", err)
+	}
+}
+
+func TestDadosPessoais_ExportacaoZIP(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+	t.Setenv("HOME", t.TempDir())
+
+	ctx := context.Background()
+	admin := service.Claims{UserID: 1, Perfil: "admin"}
+	svc := newDadosPessoaisServiceSUT(service.RetencaoConfig{})
+
+	funcID := seedPessoaFuncionarioBase(t, "Elisa Titular")
+	f, _ := repository.GetFuncionarioByID(funcID)
+	seedValePago(t, funcID, 150, time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local))
+	rel := filepath.Join("documentos", "9", "9_rg.pdf")
+	disco := filepath.Join(os.Getenv("HOME"), "Documents", "AutoGRH", rel)
+	_ = os.MkdirAll(filepath.Dir(disco), 0o755)
+	_ = os.WriteFile(disco, []byte("conteudo do rg"), 0o644)
+	doc := &entity.Documento{FuncionarioID: funcID, Caminho: rel}
+	if err := repository.CreateDocumento(ctx, doc); err != nil {
+		t.Fatalf("CreateDocumento erro: %v", err)
+	}
+
+	conteudo, err := svc.ExportarDadosPessoais(ctx, admin, f.PessoaID)
+	if err != nil {
+		t.Fatalf("ExportarDadosPessoais erro: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(conteudo), int64(len(conteudo)))
+	if err != nil {
+		t.Fatalf("ZIP inválido: %v", err)
+	}
+	arquivos := map[string][]byte{}
+	for _, zf := range zr.File {
+		rc, _ := zf.Open()
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		arquivos[zf.Name] = b
+	}
+
+	var dados service.DadosPessoaisExportados
+	if err := json.Unmarshal(arquivos["dados-pessoais.json"], &dados); err != nil {
+		t.Fatalf("dados-pessoais.json inválido: %v", err)
+	}
+	if dados.Pessoa == nil || dados.Pessoa.Nome != "Elisa Titular" || len(dados.Vinculos) != 1 {
+		t.Fatalf("JSON inesperado: %+v", dados)
+	}
+	v := dados.Vinculos[0]
+	if len(v.Vales) != 1 || len(v.Documentos) != 1 || v.Documentos[0].Arquivo == "" {
+		t.Fatalf("vínculo inesperado: %+v", v)
+	}
+	if string(arquivos[v.Documentos[0].Arquivo]) != "conteudo do rg" {
+		t.Fatalf("documento não veio no ZIP: %v", v.Documentos[0])
+	}
+}
+
+func TestDadosPessoais_RetencaoVencida(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	ctx := context.Background()
+	admin := service.Claims{UserID: 1, Perfil: "admin"}
+	svc := newDadosPessoaisServiceSUT(service.RetencaoConfig{AnosAdicionais: 1})
+	hoje := time.Date(2026, 1, 15, 0, 0, 0, 0, time.Local)
+
+	antiga, _ := seedExFuncionario(t, "Fábio Antigo", time.Date(2019, 12, 31, 0, 0, 0, 0, time.Local))
+	recente, _ := seedExFuncionario(t, "Gina Recente", time.Date(2021, 6, 30, 0, 0, 0, 0, time.Local))
+	seedPessoaFuncionarioBase(t, "Hugo Ativo")
+
+	vencidas, err := svc.RetencoesVencidas(ctx, admin, hoje)
+	if err != nil {
+		t.Fatalf("RetencoesVencidas erro: %v", err)
+	}
+	// prazo de 6 anos: só quem saiu até 15/01/2020
+	if len(vencidas) != 1 || vencidas[0].PessoaID != antiga {
+		t.Fatalf("esperado só o ex-funcionário desligado há mais de 6 anos, veio %+v", vencidas)
+	}
+
+	feitas, err := svc.AplicarRetencao(ctx, admin, hoje)
+	if err != nil || len(feitas) != 1 || feitas[0].PessoaID != antiga {
+		t.Fatalf("AplicarRetencao inesperado: %+v, %v", feitas, err)
+	}
+	if p, _ := repository.GetPessoaByID(recente); p.AnonimizadoEm != nil {
+		t.Fatalf("pessoa dentro do prazo não deveria ser anonimizada")
+	}
+	if vencidas, _ = svc.RetencoesVencidas(ctx, admin, hoje); len(vencidas) != 0 {
+		t.Fatalf("anonimizados não deveriam voltar na lista: %+v", vencidas)
+	}
+}
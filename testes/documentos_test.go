@@ -100,6 +100,7 @@ func newDocumentoServiceWithDB(lr *docFakeLogRepo) *service.DocumentoService {
 		repository.GetByID,
 		repository.ListDocumentos,
 		repository.DeleteDocumento,
+		repository.BuscarDocumentos,
 	)
 	return service.NewDocumentoService(auth, lr, adapter)
 }
@@ -0,0 +1,114 @@
+package testes
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+)
+
+func TestDossie_SecoesIncluir(t *testing.T) {
+	secoes, err := service.SecoesDossie(" vales, FERIAS,vales ")
+	if err != nil || !reflect.DeepEqual(secoes, []string{entity.DossieFerias, entity.DossieVales}) {
+		t.Fatalf("seções inesperadas: %v, %v", secoes, err)
+	}
+	if todas, _ := service.SecoesDossie("todos"); len(todas) != len(entity.SecoesDossie) {
+		t.Fatalf("todos deveria trazer todas as seções, veio %v", todas)
+	}
+	if nenhuma, err := service.SecoesDossie(""); err != nil || len(nenhuma) != 0 {
+		t.Fatalf("lista vazia deveria trazer nenhuma seção, veio %v, %v", nenhuma, err)
+	}
+	if _, err := service.SecoesDossie("ferias,holerites"); !errors.Is(err, service.ErrSecaoDossieInvalida) {
+		t.Fatalf("esperado ErrSecaoDossieInvalida, veio %v", err)
+	}
+}
+
+func TestDossie_SecoesETotais(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	ctx := context.Background()
+	admin := service.Claims{UserID: 1, Perfil: "admin"}
+	lr := &folhaFakeLogRepo{}
+	// o dossiê lê direto do repository; o repo do service não é usado
+	svc := service.NewFuncionarioService(newAdminAuth(lr), lr, nil)
+
+	funcID := seedPessoaFuncionarioBase(t, "Lara Dossiê")
+	if _, err := repository.DB.Exec(`UPDATE funcionario SET admissao = '2022-01-31' WHERE funcionarioID = ?`, funcID); err != nil {
+		t.Fatalf("seed admissão erro: %v", err)
+	}
+
+	// férias: um período vencido com saldo, um a vencer e um já pago (fora do saldo)
+	vencido := entity.NewFerias(funcID, time.Date(2023, 1, 31, 0, 0, 0, 0, time.Local), 30)
+	vencido.Vencido = true
+	aVencer := entity.NewFerias(funcID, time.Date(2024, 1, 31, 0, 0, 0, 0, time.Local), 20)
+	pago := entity.NewFerias(funcID, time.Date(2022, 1, 31, 0, 0, 0, 0, time.Local), 30)
+	pago.Pago = true
+	for _, f := range []*entity.Ferias{vencido, aVencer, pago} {
+		if err := repository.CreateFerias(f); err != nil {
+			t.Fatalf("CreateFerias erro: %v", err)
+		}
+	}
+	for _, d := range []*entity.Descanso{
+		entity.NewDescanso(time.Date(2023, 7, 1, 0, 0, 0, 0, time.Local), time.Date(2023, 7, 10, 0, 0, 0, 0, time.Local), pago.ID),
+		entity.NewDescanso(time.Date(2023, 8, 1, 0, 0, 0, 0, time.Local), time.Date(2023, 8, 20, 0, 0, 0, 0, time.Local), pago.ID),
+	} {
+		if err := repository.CreateDescanso(d); err != nil {
+			t.Fatalf("CreateDescanso erro: %v", err)
+		}
+	}
+
+	// vales: aguardando aprovação, aprovado a descontar, já pago e excluído
+	dataVale := time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)
+	for _, v := range []*entity.Vale{
+		{FuncionarioID: funcID, Valor: 100, Data: dataVale, Ativo: true},
+		{FuncionarioID: funcID, Valor: 200, Data: dataVale, Aprovado: true, Ativo: true},
+		{FuncionarioID: funcID, Valor: 999, Data: dataVale, Ativo: false},
+	} {
+		if err := repository.CreateVale(v); err != nil {
+			t.Fatalf("CreateVale erro: %v", err)
+		}
+	}
+	seedValePago(t, funcID, 150, dataVale)
+
+	hoje := time.Date(2024, 3, 15, 10, 0, 0, 0, time.Local)
+	secoes, _ := service.SecoesDossie("ferias,vales")
+	d, err := svc.DossieFuncionario(ctx, admin, funcID, secoes, hoje)
+	if err != nil || d == nil {
+		t.Fatalf("DossieFuncionario erro: %v", err)
+	}
+
+	// 31/01/2022 + 2 anos e 1 mês = 29/02/2024 (fim do mês), mais 15 dias
+	if tc := d.TempoDeCasa; tc.Anos != 2 || tc.Meses != 1 || tc.Dias != 15 || tc.TotalDias != 774 {
+		t.Fatalf("tempo de casa inesperado: %+v", tc)
+	}
+	sf := d.SaldoFerias
+	if sf.Dias != 50 || sf.Periodos != 2 || sf.PeriodosVencidos != 1 || sf.ProximoVencimento == nil ||
+		!sf.ProximoVencimento.Equal(time.Date(2025, 1, 31, 0, 0, 0, 0, sf.ProximoVencimento.Location())) {
+		t.Fatalf("saldo de férias inesperado: %+v", sf)
+	}
+	if v := d.ValesEmAberto; v.Quantidade != 2 || v.Total != 300 || v.AguardandoAprovacao != 1 || v.AprovadosTotal != 200 {
+		t.Fatalf("vales em aberto inesperados: %+v", v)
+	}
+
+	f := d.Funcionario
+	if len(f.Ferias) != 3 || len(f.Vales) != 4 {
+		t.Fatalf("seções pedidas não vieram: %d férias, %d vales", len(f.Ferias), len(f.Vales))
+	}
+	for _, fr := range f.Ferias {
+		if esperado := map[int64]int{pago.ID: 2}[fr.ID]; len(fr.Descansos) != esperado {
+			t.Fatalf("férias %d com %d descansos, esperado %d", fr.ID, len(fr.Descansos), esperado)
+		}
+	}
+	if d.Pessoa != nil || f.Faltas != nil || f.Pagamentos != nil || f.SalariosRegistrados != nil {
+		t.Fatalf("seções não pedidas deveriam ficar de fora: %+v", d)
+	}
+
+	if d, err := svc.DossieFuncionario(ctx, admin, funcID+1000, nil, hoje); err != nil || d != nil {
+		t.Fatalf("funcionário inexistente deveria voltar nil, veio %+v, %v", d, err)
+	}
+}
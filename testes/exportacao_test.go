@@ -0,0 +1,100 @@
+package testes
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/utils/consulta"
+	"AutoGRH/pkg/utils/planilha"
+)
+
+func TestExportacao_ValesSemPaginacaoComNomes(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	funcID := seedPessoaFuncionarioBase(t, "Ana Exportada")
+	seedValePago(t, funcID, 1234.5, time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local))
+	seedValePago(t, funcID, 80, time.Date(2025, 3, 20, 0, 0, 0, 0, time.Local))
+
+	v, _ := url.ParseQuery("limit=1&sort=data")
+	q, err := consulta.Parse(v)
+	if err != nil {
+		t.Fatalf("Parse erro: %v", err)
+	}
+	q.Todos = true
+	pagina, err := repository.BuscarVales(q)
+	if err != nil {
+		t.Fatalf("BuscarVales erro: %v", err)
+	}
+	if pagina.Total != 2 || len(pagina.Itens) != 2 {
+		t.Fatalf("exportação deveria ignorar o limit: total=%d itens=%d", pagina.Total, len(pagina.Itens))
+	}
+
+	tabela, err := service.TabelaVales(pagina.Itens)
+	if err != nil {
+		t.Fatalf("TabelaVales erro: %v", err)
+	}
+
+	csv, err := planilha.EscreverCSV(tabela)
+	if err != nil {
+		t.Fatalf("EscreverCSV erro: %v", err)
+	}
+	texto := string(csv)
+	if !strings.HasPrefix(texto, "\ufeffFuncionário;CPF;Data;Valor;") {
+		t.Fatalf("cabeçalho do CSV inesperado: %q", texto)
+	}
+	if !strings.Contains(texto, "Ana Exportada;") || !strings.Contains(texto, ";10/03/2025;1.234,50;Sim;") {
+		t.Fatalf("linha do CSV deveria trazer nome, data e valor formatados: %q", texto)
+	}
+
+	xlsx, err := planilha.EscreverXLSX(tabela)
+	if err != nil {
+		t.Fatalf("EscreverXLSX erro: %v", err)
+	}
+	linhas, err := planilha.Ler("vales.xlsx", xlsx)
+	if err != nil {
+		t.Fatalf("Ler XLSX gerado erro: %v", err)
+	}
+	if len(linhas) != 3 || linhas[0][0] != "Funcionário" || linhas[1][0] != "Ana Exportada" {
+		t.Fatalf("XLSX inesperado: %v", linhas)
+	}
+	// valor sai como número e data como data do Excel
+	if linhas[1][2] != "2025-03-10" || linhas[1][3] != "1234.5" || linhas[2][3] != "80" {
+		t.Fatalf("tipos das células do XLSX inesperados: %v", linhas[1:])
+	}
+}
+
+func TestExportacao_PagamentosDaFolhaComTotais(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	ana := seedPessoaFuncionarioBase(t, "Ana Folha")
+	bia := seedPessoaFuncionarioBase(t, "Bia Folha")
+	folha := &entity.FolhaPagamentos{ID: 7, Mes: 3, Ano: 2025, Tipo: "SALARIO"}
+	pagamentos := []entity.Pagamento{
+		{FuncionarioID: ana, FolhaID: 7, SalarioBase: 3000, DescontoINSS: 250.5, FGTS: 240, ValorFinal: 2749.5},
+		{FuncionarioID: bia, FolhaID: 7, SalarioBase: 2000, Adicional: 100, DescontoVales: 80, FGTS: 168, ValorFinal: 2020, Pago: true},
+	}
+
+	tabela, err := service.TabelaPagamentos(folha, pagamentos)
+	if err != nil {
+		t.Fatalf("TabelaPagamentos erro: %v", err)
+	}
+	if tabela.Nome != "Folha 03-2025 SALARIO" || len(tabela.Linhas) != 3 {
+		t.Fatalf("tabela inesperada: nome=%q linhas=%d", tabela.Nome, len(tabela.Linhas))
+	}
+
+	csv, err := planilha.EscreverCSV(tabela)
+	if err != nil {
+		t.Fatalf("EscreverCSV erro: %v", err)
+	}
+	texto := string(csv)
+	for _, trecho := range []string{"Ana Folha;", "Bia Folha;", "Total;;5.000,00;100,00;", ";4.769,50;408,00;\n"} {
+		if !strings.Contains(texto, trecho) {
+			t.Fatalf("CSV da folha deveria conter %q: %q", trecho, texto)
+		}
+	}
+}
@@ -96,6 +96,7 @@ func newFaltaServiceWithDB(lr *faltaFakeLogRepo) *service.FaltaService {
 		repository.GetFaltaByID,
 		repository.GetFaltasByFuncionarioID,
 		repository.ListAllFaltas,
+		repository.BuscarFaltas,
 	)
 	return service.NewFaltaService(auth, lr, adp)
 }
@@ -244,3 +245,80 @@ func TestFalta_Create_ComQuantidadeZero_DeveErro(t *testing.T) {
 		t.Fatalf("esperava erro ao criar falta com quantidade 0")
 	}
 }
+
+func TestFalta_Tipos_SoInjustificadasEAtrasosDescontam(t *testing.T) {
+	// DB limpo no início e no fim do teste
+	if err := truncateAll(); err != nil {
+		t.Fatalf("truncateAll inicio: %v", err)
+	}
+	t.Cleanup(func() { _ = truncateAll() })
+
+	lr := &faltaFakeLogRepo{}
+	svc := newFaltaServiceWithDB(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 104, Perfil: "admin"}
+
+	funcID := seedPessoaFuncionarioFalta(t)
+	dia := func(d int) time.Time { return time.Date(2025, time.April, d, 0, 0, 0, 0, time.Local) }
+
+	registros := []*entity.Falta{
+		entity.NewAusencia(entity.FaltaTipoInjustificada, dia(7), 1, funcID),
+		entity.NewAusencia(entity.FaltaTipoAtestado, dia(8), 2, funcID),
+		entity.NewAusencia(entity.FaltaTipoAbonada, dia(14), 1, funcID),
+		entity.NewAtraso(dia(15), 30, funcID),
+		entity.NewAtraso(dia(16), 15, funcID),
+	}
+	for _, f := range registros {
+		if err := svc.CreateFalta(ctx, claims, f); err != nil {
+			t.Fatalf("CreateFalta %s erro: %v", f.Tipo, err)
+		}
+	}
+
+	// Só a injustificada entra na contagem de dias; atrasos somam minutos
+	dias, err := repository.GetTotalFaltasByFuncionarioMesAno(funcID, 4, 2025)
+	if err != nil || dias != 1 {
+		t.Fatalf("dias descontáveis esperado 1, veio %d (err=%v)", dias, err)
+	}
+	// a contagem da folha segue DescontaSalario: os dias dos registros que descontam, exceto atrasos
+	esperado := 0
+	for _, f := range registros {
+		if f.DescontaSalario() && f.Tipo != entity.FaltaTipoAtraso {
+			esperado += f.Quantidade
+		}
+	}
+	if dias != esperado {
+		t.Fatalf("contagem da folha (%d) diverge de DescontaSalario (%d)", dias, esperado)
+	}
+	minutos, err := repository.GetMinutosAtrasoByFuncionarioMesAno(funcID, 4, 2025)
+	if err != nil || minutos != 45 {
+		t.Fatalf("minutos de atraso esperado 45, veio %d (err=%v)", minutos, err)
+	}
+
+	// O total mensal consolidado não sobrescreve os registros individuais
+	if err := svc.UpsertMensal(ctx, claims, funcID, 4, 2025, 2); err != nil {
+		t.Fatalf("UpsertMensal erro: %v", err)
+	}
+	dias, _ = repository.GetTotalFaltasByFuncionarioMesAno(funcID, 4, 2025)
+	if dias != 3 {
+		t.Fatalf("dias descontáveis esperado 3 após lançamento mensal, veio %d", dias)
+	}
+
+	got, err := repository.GetFaltaByID(registros[1].ID)
+	if err != nil || got == nil || got.Tipo != entity.FaltaTipoAtestado || got.Quantidade != 2 || got.ReduzFerias() {
+		t.Fatalf("atestado inesperado: %+v (err=%v)", got, err)
+	}
+
+	// Validações por tipo
+	if err := svc.CreateFalta(ctx, claims, entity.NewAtraso(dia(17), 0, funcID)); err == nil {
+		t.Fatalf("esperava erro para atraso sem minutos")
+	}
+	if err := svc.CreateFalta(ctx, claims, entity.NewAusencia("FERIAS", dia(18), 1, funcID)); err == nil {
+		t.Fatalf("esperava erro para tipo inválido")
+	}
+	docInexistente := int64(999999)
+	comDoc := entity.NewAusencia(entity.FaltaTipoAtestado, dia(22), 1, funcID)
+	comDoc.DocumentoID = &docInexistente
+	if err := svc.CreateFalta(ctx, claims, comDoc); err == nil {
+		t.Fatalf("esperava erro para documento inexistente")
+	}
+}
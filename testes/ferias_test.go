@@ -102,6 +102,7 @@ func newFeriasServiceWithDB(lr *fdFakeLogRepo) *service.FeriasService {
 		repository.UpdateFerias,
 		repository.DeleteFerias,
 		repository.ListFerias,
+		repository.BuscarFerias,
 	)
 	return service.NewFeriasService(auth, lr, fRepo)
 }
@@ -281,3 +282,225 @@ func TestDescanso_FluxoCompleto_Create_Aprovar_Pagar_ComFechamentoDeFerias(t *te
 		t.Fatalf("ListarAprovados esperado 30 aprovados, got=%d err=%v", len(aprovados), err)
 	}
 }
+
+/************ TESTES: FÉRIAS COLETIVAS ************/
+
+func TestFerias_Coletivas_ConcedeEProporcionaliza(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &fdFakeLogRepo{}
+	fsvc := newFeriasServiceWithDB(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 81, Perfil: "admin"}
+
+	// Funcionário com 2 anos de casa (já possui período completo)
+	veteranoID := seedPessoaFuncionarioFD(t)
+
+	// Funcionário admitido há 6 meses (vai para férias proporcionais)
+	novatoID := seedPessoaFuncionarioFD(t)
+	novato, err := repository.GetFuncionarioByID(novatoID)
+	if err != nil || novato == nil {
+		t.Fatalf("GetFuncionarioByID erro: %v", err)
+	}
+	novato.Admissao = time.Now().AddDate(0, -6, 0)
+	if err := repository.UpdateFuncionario(novato); err != nil {
+		t.Fatalf("UpdateFuncionario erro: %v", err)
+	}
+
+	for _, id := range []int64{veteranoID, novatoID} {
+		if err := repository.CreateSalarioReal(entity.NewSalarioReal(id, time.Now().AddDate(-2, 0, 0), 3000)); err != nil {
+			t.Fatalf("CreateSalarioReal erro: %v", err)
+		}
+	}
+
+	inicio := truncateDay(time.Now().AddDate(0, 0, 7))
+	fim := inicio.AddDate(0, 0, 9) // 10 dias
+
+	resultados, err := fsvc.CriarFeriasColetivas(ctx, claims, service.FeriasColetivasInput{
+		Inicio:         inicio,
+		Fim:            fim,
+		Escopo:         service.ColetivasEscopoFuncionarios,
+		FuncionarioIDs: []int64{veteranoID, novatoID, 999999},
+	})
+	if err != nil {
+		t.Fatalf("CriarFeriasColetivas erro: %v", err)
+	}
+	if len(resultados) != 3 {
+		t.Fatalf("esperava 3 resultados, veio %d", len(resultados))
+	}
+
+	byID := map[int64]service.FeriasColetivasResultado{}
+	for _, r := range resultados {
+		byID[r.FuncionarioID] = r
+	}
+
+	// Veterano: saldo cobre os 10 dias, descansos já aprovados
+	vet := byID[veteranoID]
+	if vet.Status != service.ColetivasStatusConcedido || vet.DiasConcedidos != 10 || vet.DiasSemSaldo != 0 {
+		t.Fatalf("veterano inesperado: %+v", vet)
+	}
+	for _, d := range vet.Descansos {
+		if !d.Aprovado {
+			t.Fatalf("descanso de coletivas deveria nascer aprovado: %+v", d)
+		}
+	}
+
+	// Novato: 6 avos = 15 dias proporcionais; novo período aquisitivo começa nas coletivas
+	nov := byID[novatoID]
+	if nov.Status != service.ColetivasStatusProporcional || nov.DiasConcedidos != 10 {
+		t.Fatalf("novato inesperado: %+v", nov)
+	}
+	novAtual, _ := repository.GetFuncionarioByID(novatoID)
+	if novAtual == nil || novAtual.InicioAquisitivo == nil || novAtual.InicioAquisitivo.Format("2006-01-02") != inicio.Format("2006-01-02") {
+		t.Fatalf("esperava inicio_aquisitivo=%s, veio %+v", inicio.Format("2006-01-02"), novAtual)
+	}
+
+	// ID inexistente não interrompe o lote
+	if byID[999999].Status != service.ColetivasStatusErro {
+		t.Fatalf("esperava ERRO para funcionário inexistente, veio %+v", byID[999999])
+	}
+
+	if !hasLogPrefix(lr.entries, 3, claims.UserID, "Férias coletivas") {
+		t.Fatalf("esperava log de criação das férias coletivas")
+	}
+
+	// Relançar o mesmo período não duplica descansos, pagamentos nem férias proporcionais
+	feriasAntes, _ := repository.GetFeriasByFuncionarioID(novatoID)
+	descansosAntes, _ := repository.GetDescansosByFuncionarioID(novatoID)
+	again, err := fsvc.CriarFeriasColetivas(ctx, claims, service.FeriasColetivasInput{
+		Inicio:         inicio,
+		Fim:            fim,
+		Escopo:         service.ColetivasEscopoFuncionarios,
+		FuncionarioIDs: []int64{veteranoID, novatoID},
+	})
+	if err != nil {
+		t.Fatalf("CriarFeriasColetivas (relançamento) erro: %v", err)
+	}
+	for _, r := range again {
+		if r.Status != service.ColetivasStatusIgnorado || len(r.Descansos) != 0 {
+			t.Fatalf("relançamento deveria ignorar quem já tem descanso no período: %+v", r)
+		}
+	}
+	feriasDepois, _ := repository.GetFeriasByFuncionarioID(novatoID)
+	descansosDepois, _ := repository.GetDescansosByFuncionarioID(novatoID)
+	if len(feriasDepois) != len(feriasAntes) || len(descansosDepois) != len(descansosAntes) {
+		t.Fatalf("relançamento duplicou registros: férias %d→%d, descansos %d→%d",
+			len(feriasAntes), len(feriasDepois), len(descansosAntes), len(descansosDepois))
+	}
+}
+
+/************ TESTES: PROJEÇÃO DE FÉRIAS ************/
+
+func TestFerias_Projecao_PeriodoCompletoEEmCurso(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &fdFakeLogRepo{}
+	fsvc := newFeriasServiceWithDB(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 82, Perfil: "admin"}
+
+	funcID := seedPessoaFuncionarioFD(t)
+	f, err := repository.GetFuncionarioByID(funcID)
+	if err != nil || f == nil {
+		t.Fatalf("GetFuncionarioByID erro: %v", err)
+	}
+	f.Admissao = time.Date(2024, 1, 10, 0, 0, 0, 0, time.Local)
+	if err := repository.UpdateFuncionario(f); err != nil {
+		t.Fatalf("UpdateFuncionario erro: %v", err)
+	}
+	if err := repository.CreateSalarioReal(entity.NewSalarioReal(funcID, f.Admissao, 3000)); err != nil {
+		t.Fatalf("CreateSalarioReal erro: %v", err)
+	}
+	// 6 faltas no primeiro período aquisitivo: direito cai para 24 dias
+	if err := repository.CreateFalta(entity.NewFalta(6, time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), funcID)); err != nil {
+		t.Fatalf("CreateFalta erro: %v", err)
+	}
+
+	// 20/08/2025: período 2024-01-10..2025-01-10 completo; em curso com 7 meses e 10 dias (7 avos)
+	proj, err := fsvc.ProjecaoFerias(ctx, claims, funcID, time.Date(2025, 8, 20, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("ProjecaoFerias erro: %v", err)
+	}
+	if proj == nil || len(proj.Periodos) != 2 {
+		t.Fatalf("esperava 2 períodos, veio %+v", proj)
+	}
+
+	completo := proj.Periodos[0]
+	if !completo.Completo || completo.Avos != 12 || completo.Faltas != 6 || completo.DiasDireito != 24 || completo.DiasSaldo != 24 {
+		t.Fatalf("período completo inesperado: %+v", completo)
+	}
+
+	emCurso := proj.Periodos[1]
+	if emCurso.Completo || emCurso.Avos != 7 || emCurso.DiasDireito != 17 {
+		t.Fatalf("período em curso inesperado: %+v", emCurso)
+	}
+	if emCurso.Valor != 1700 || emCurso.Total != 2266.67 {
+		t.Fatalf("valor projetado inesperado: %+v", emCurso)
+	}
+	if proj.DiasSaldo != 41 {
+		t.Fatalf("esperava saldo total de 41 dias, veio %d", proj.DiasSaldo)
+	}
+
+	// Funcionário inexistente
+	if proj, err := fsvc.ProjecaoFerias(ctx, claims, 999999, time.Now()); err != nil || proj != nil {
+		t.Fatalf("esperava nil para funcionário inexistente, veio %+v err=%v", proj, err)
+	}
+}
+
+func TestFerias_Projecao_DescansoAprovadoNaoDescontaDuasVezes(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	fsvc := newFeriasServiceWithDB(&fdFakeLogRepo{})
+	ctx := context.Background()
+	claims := service.Claims{UserID: 82, Perfil: "admin"}
+
+	funcID := seedPessoaFuncionarioFD(t)
+	f, err := repository.GetFuncionarioByID(funcID)
+	if err != nil || f == nil {
+		t.Fatalf("GetFuncionarioByID erro: %v", err)
+	}
+	f.Admissao = time.Date(2024, 1, 10, 0, 0, 0, 0, time.Local)
+	if err := repository.UpdateFuncionario(f); err != nil {
+		t.Fatalf("UpdateFuncionario erro: %v", err)
+	}
+	if err := repository.CreateSalarioReal(entity.NewSalarioReal(funcID, f.Admissao, 3000)); err != nil {
+		t.Fatalf("CreateSalarioReal erro: %v", err)
+	}
+	ferias := entity.NewFerias(funcID, time.Date(2025, 1, 10, 0, 0, 0, 0, time.Local), 30)
+	if err := repository.CreateFerias(ferias); err != nil {
+		t.Fatalf("CreateFerias erro: %v", err)
+	}
+
+	// 10 dias aprovados (já descontados do período, como faz a aprovação) e 5 pendentes
+	aprovado := entity.NewDescanso(time.Date(2025, 2, 3, 0, 0, 0, 0, time.Local), time.Date(2025, 2, 12, 0, 0, 0, 0, time.Local), ferias.ID)
+	aprovado.Aprovado = true
+	if err := repository.CreateDescanso(aprovado); err != nil {
+		t.Fatalf("CreateDescanso erro: %v", err)
+	}
+	if err := repository.ConsumirDiasFerias(ferias.ID, aprovado.DuracaoEmDias()); err != nil {
+		t.Fatalf("ConsumirDiasFerias erro: %v", err)
+	}
+	pendente := entity.NewDescanso(time.Date(2025, 6, 2, 0, 0, 0, 0, time.Local), time.Date(2025, 6, 6, 0, 0, 0, 0, time.Local), ferias.ID)
+	if err := repository.CreateDescanso(pendente); err != nil {
+		t.Fatalf("CreateDescanso erro: %v", err)
+	}
+
+	proj, err := fsvc.ProjecaoFerias(ctx, claims, funcID, time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local))
+	if err != nil || proj == nil || len(proj.Periodos) != 2 {
+		t.Fatalf("ProjecaoFerias inesperada: %+v, %v", proj, err)
+	}
+	p := proj.Periodos[0]
+	if p.FeriasID == nil || *p.FeriasID != ferias.ID {
+		t.Fatalf("período completo deveria apontar para as férias %d: %+v", ferias.ID, p)
+	}
+	if p.DiasDireito != 30 || p.DiasGozados != 15 || p.DiasSaldo != 15 {
+		t.Fatalf("esperava direito 30, gozados 15 e saldo 15, veio %+v", p)
+	}
+	if p.Valor != 1500 {
+		t.Fatalf("valor deveria ser sobre os 15 dias de saldo, veio %+v", p)
+	}
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -1,15 +1,20 @@
 package testes
 
 import (
+	Adapter "AutoGRH/pkg/adapter"
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
 
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
 	"AutoGRH/pkg/service"
 	"AutoGRH/pkg/service/jwt"
+	"AutoGRH/pkg/utils/consulta"
 )
 
 /*** ---------------- Mocks (exclusivos p/ Funcionario) ---------------- ***/
@@ -119,6 +124,11 @@ func (r *funcionarioFakeRepo) ListTodos(ctx context.Context) ([]*entity.Funciona
 	return out, nil
 }
 
+func (r *funcionarioFakeRepo) Buscar(ctx context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Funcionario], error) {
+	todos, _ := r.ListTodos(ctx)
+	return consulta.Pagina[*entity.Funcionario]{Itens: todos, Total: len(todos), Pagina: 1, Limite: len(todos)}, nil
+}
+
 /*** -------------- SUT helpers -------------- ***/
 
 func newAuthForFuncionarioSuite(lr *funcionarioFakeLogRepo) *service.AuthService {
@@ -154,8 +164,8 @@ func TestFuncionario_CreateFuncionario_Sucesso(t *testing.T) {
 
 	f := &entity.Funcionario{
 		PessoaID:          1,
-		PIS:               "123",
-		CTPF:              "CT-001",
+		PIS:               "120.82304.88-6",
+		CTPF:              "1234567/0012",
 		Nascimento:        nasc,
 		Admissao:          adm,
 		Cargo:             "  Analista  ", // valida trim
@@ -172,6 +182,9 @@ func TestFuncionario_CreateFuncionario_Sucesso(t *testing.T) {
 	if got.Cargo != "Analista" {
 		t.Fatalf("esperava Cargo sem espaços após trim, veio %q", got.Cargo)
 	}
+	if got.PIS != "12082304886" || got.CTPF != "123456700012" {
+		t.Fatalf("esperava PIS e CTPS só com dígitos, veio %q e %q", got.PIS, got.CTPF)
+	}
 	if !got.Ativo {
 		t.Fatalf("esperava Ativo=true após criação")
 	}
@@ -213,6 +226,36 @@ func TestFuncionario_CreateFuncionario_Validacoes(t *testing.T) {
 	}
 }
 
+func TestFuncionario_CreateFuncionario_DocumentosInvalidos(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &funcionarioFakeLogRepo{}
+	repo := newFuncionarioFakeRepo()
+	svc := newFuncionarioServiceSUT(repo, lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 1}
+
+	f := &entity.Funcionario{
+		PessoaID: 1, Cargo: "A", SalarioInicial: 1, Admissao: time.Now().AddDate(0, 0, -10),
+		Nascimento: time.Now().AddDate(-20, 0, 0), PIS: "120.82304.88-1", CTPF: "123.456.789-00",
+	}
+	err := svc.CreateFuncionario(ctx, claims, f)
+	var verr *service.ErroValidacao
+	if !errors.As(err, &verr) {
+		t.Fatalf("esperava ErroValidacao, veio: %v", err)
+	}
+	campos := map[string]bool{}
+	for _, c := range verr.Campos {
+		campos[c.Campo] = true
+	}
+	if len(verr.Campos) != 2 || !campos["pis"] || !campos["ctpf"] {
+		t.Fatalf("esperava erros em pis e ctpf, veio %+v", verr.Campos)
+	}
+	if len(repo.m) != 0 {
+		t.Fatalf("funcionário com documentos inválidos não deveria ser criado")
+	}
+}
+
 /*** ---------------- Tests: GetByID ---------------- ***/
 
 func TestFuncionario_GetFuncionarioByID(t *testing.T) {
@@ -262,8 +305,8 @@ func TestFuncionario_UpdateFuncionario_Sucesso_E_Log(t *testing.T) {
 	up := &entity.Funcionario{
 		ID:                f.ID,
 		PessoaID:          1,
-		PIS:               "999",
-		CTPF:              "CT-999",
+		PIS:               "17012345673",
+		CTPF:              "529.982.247-25",
 		Nascimento:        nasc,
 		Admissao:          adm,
 		Cargo:             "  Pleno ",
@@ -275,7 +318,7 @@ func TestFuncionario_UpdateFuncionario_Sucesso_E_Log(t *testing.T) {
 	}
 
 	got, _ := repo.GetByID(ctx, f.ID)
-	if got.Cargo != "Pleno" || got.SalarioInicial != 4200.00 || got.PIS != "999" || got.CTPF != "CT-999" || got.FeriasDisponiveis != 20 {
+	if got.Cargo != "Pleno" || got.SalarioInicial != 4200.00 || got.PIS != "17012345673" || got.CTPF != "52998224725" || got.FeriasDisponiveis != 20 {
 		t.Fatalf("update não aplicou corretamente: %+v", got)
 	}
 	if !funcionarioHasLogPrefix(lr.entries, 4, 7, "Atualizou funcionário ID=") {
@@ -410,3 +453,249 @@ func TestFuncionario_ListasAtivosInativosETodos(t *testing.T) {
 		t.Fatalf("esperava 3 no total, veio %d", len(todos))
 	}
 }
+
+/*** ---------------- Tests: Contrato de experiência ---------------- ***/
+
+func TestFuncionario_ContratoExperiencia_ProrrogarEfetivarEncerrar(t *testing.T) {
+	lr := &funcionarioFakeLogRepo{}
+	repo := newFuncionarioFakeRepo()
+	svc := service.NewFuncionarioService(newAdminAuth(lr), lr, repo)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 8, Perfil: "admin"}
+
+	nasc := time.Now().AddDate(-25, 0, 0)
+	adm := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, -10)
+	novo := func() *entity.Funcionario {
+		return &entity.Funcionario{PessoaID: 1, Cargo: "A", SalarioInicial: 1, Admissao: adm, Nascimento: nasc, PrazoContrato: "experiencia"}
+	}
+
+	// sem término informado: 45 dias
+	f := novo()
+	if err := svc.CreateFuncionario(ctx, claims, f); err != nil {
+		t.Fatalf("create falhou: %v", err)
+	}
+	if f.PrazoContrato != entity.ContratoExperiencia || f.FimExperiencia == nil || !f.FimExperiencia.Equal(adm.AddDate(0, 0, 44)) {
+		t.Fatalf("experiência padrão inesperada: prazo=%s fim=%v", f.PrazoContrato, f.FimExperiencia)
+	}
+
+	longo := novo()
+	fimLongo := adm.AddDate(0, 0, 100)
+	longo.FimExperiencia = &fimLongo
+	if err := svc.CreateFuncionario(ctx, claims, longo); err == nil || !strings.Contains(err.Error(), "90 dias") {
+		t.Fatalf("esperava erro de limite de 90 dias, veio: %v", err)
+	}
+
+	// prorrogação padrão completa os 90 dias; só uma é admitida
+	got, err := svc.ProrrogarExperiencia(ctx, claims, f.ID, time.Time{})
+	if err != nil {
+		t.Fatalf("prorrogar falhou: %v", err)
+	}
+	if got.FimProrrogacao == nil || !got.FimProrrogacao.Equal(adm.AddDate(0, 0, 89)) {
+		t.Fatalf("prorrogação inesperada: %v", got.FimProrrogacao)
+	}
+	if _, err := svc.ProrrogarExperiencia(ctx, claims, f.ID, time.Time{}); err == nil || !strings.Contains(err.Error(), "já foi prorrogado") {
+		t.Fatalf("esperava erro de segunda prorrogação, veio: %v", err)
+	}
+
+	// atualização sem prazo mantém o contrato
+	up := &entity.Funcionario{ID: f.ID, PessoaID: 1, Cargo: "B", SalarioInicial: 1, Admissao: adm, Nascimento: nasc}
+	if err := svc.UpdateFuncionario(ctx, claims, up); err != nil {
+		t.Fatalf("update falhou: %v", err)
+	}
+	if got, _ = repo.GetByID(ctx, f.ID); !got.EmExperiencia() || got.FimProrrogacao == nil {
+		t.Fatalf("update não deveria alterar o contrato: %+v", got)
+	}
+
+	if got, err = svc.EfetivarContrato(ctx, claims, f.ID); err != nil || got.PrazoContrato != entity.ContratoPrazoIndeterminado {
+		t.Fatalf("efetivar falhou: %+v err=%v", got, err)
+	}
+	if _, err := svc.EncerrarExperiencia(ctx, claims, f.ID); err == nil || !strings.Contains(err.Error(), "não está em contrato de experiência") {
+		t.Fatalf("efetivado não pode encerrar experiência, veio: %v", err)
+	}
+
+	// encerramento no término: demissão na data do fim da experiência
+	g := novo()
+	if err := svc.CreateFuncionario(ctx, claims, g); err != nil {
+		t.Fatalf("create falhou: %v", err)
+	}
+	if _, err := svc.EncerrarExperiencia(ctx, claims, g.ID); err != nil {
+		t.Fatalf("encerrar falhou: %v", err)
+	}
+	got, _ = repo.GetByID(ctx, g.ID)
+	if got.Ativo || got.Demissao == nil || !got.Demissao.Equal(adm.AddDate(0, 0, 44)) {
+		t.Fatalf("encerramento inesperado: ativo=%v demissao=%v", got.Ativo, got.Demissao)
+	}
+}
+
+/*** ---------------- Tests: Readmissão ---------------- ***/
+
+func TestFuncionario_Readmissao_Vinculos(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &funcionarioFakeLogRepo{}
+	svc := newFuncionarioServiceSUT(Adapter.NewFuncionarioRepositoryAdapter(
+		repository.CreateFuncionario,
+		repository.GetFuncionarioByID,
+		repository.UpdateFuncionario,
+		repository.DeleteFuncionario,
+		repository.ListFuncionariosAtivos,
+		repository.ListFuncionariosInativos,
+		repository.ListTodosFuncionarios,
+		repository.BuscarFuncionarios,
+	), lr)
+	pessoas := service.NewPessoaService(newAuthForFuncionarioSuite(lr), lr, newPessoaRepoWithDB())
+	ctx := context.Background()
+	claims := service.Claims{UserID: 12}
+
+	p := &entity.Pessoa{Nome: "Bruno", CPF: "39053344705", RG: "77"}
+	if err := repository.CreatePessoa(p); err != nil {
+		t.Fatalf("CreatePessoa erro: %v", err)
+	}
+	nasc := time.Date(1990, 5, 10, 0, 0, 0, 0, time.Local)
+	primeiro := &entity.Funcionario{
+		PessoaID: p.ID, Nascimento: nasc, Admissao: time.Date(2020, 3, 2, 0, 0, 0, 0, time.Local),
+		Cargo: "Auxiliar", SalarioInicial: 1800,
+	}
+	if err := svc.CreateFuncionario(ctx, claims, primeiro); err != nil {
+		t.Fatalf("primeiro vínculo: %v", err)
+	}
+
+	novo := func(admissao time.Time) *entity.Funcionario {
+		return &entity.Funcionario{PessoaID: p.ID, Nascimento: nasc, Admissao: admissao, Cargo: "Analista", SalarioInicial: 3000}
+	}
+	if err := svc.CreateFuncionario(ctx, claims, novo(time.Date(2024, 1, 8, 0, 0, 0, 0, time.Local))); err == nil ||
+		!strings.Contains(err.Error(), "vínculo ativo") {
+		t.Fatalf("esperava erro de vínculo ativo, veio: %v", err)
+	}
+
+	demissao := time.Date(2023, 6, 30, 0, 0, 0, 0, time.Local)
+	primeiro.Demissao = &demissao
+	if err := svc.UpdateFuncionario(ctx, claims, primeiro); err != nil {
+		t.Fatalf("registrar demissão: %v", err)
+	}
+	if err := svc.DeleteFuncionario(ctx, claims, primeiro.ID); err != nil {
+		t.Fatalf("desligar: %v", err)
+	}
+	if err := svc.CreateFuncionario(ctx, claims, novo(demissao)); err == nil || !strings.Contains(err.Error(), "posterior ao fim") {
+		t.Fatalf("esperava erro de admissão antes do fim do vínculo anterior, veio: %v", err)
+	}
+	segundo := novo(time.Date(2024, 1, 8, 0, 0, 0, 0, time.Local))
+	if err := svc.CreateFuncionario(ctx, claims, segundo); err != nil {
+		t.Fatalf("readmissão: %v", err)
+	}
+	if segundo.ID == primeiro.ID {
+		t.Fatalf("readmissão deveria criar um novo vínculo")
+	}
+
+	vinculos, err := pessoas.ListarVinculos(ctx, claims, p.ID)
+	if err != nil {
+		t.Fatalf("ListarVinculos erro: %v", err)
+	}
+	if len(vinculos) != 2 || vinculos[0].FuncionarioID != primeiro.ID || vinculos[1].FuncionarioID != segundo.ID {
+		t.Fatalf("linha do tempo inesperada: %+v", vinculos)
+	}
+	if vinculos[0].Ativo || vinculos[0].Demissao == nil || vinculos[0].Readmissao {
+		t.Errorf("primeiro vínculo deveria estar encerrado: %+v", vinculos[0])
+	}
+	if !vinculos[1].Ativo || !vinculos[1].Readmissao || vinculos[1].UltimoSalario != 3000 {
+		t.Errorf("segundo vínculo inesperado: %+v", vinculos[1])
+	}
+
+	// a atualização ignora o próprio vínculo, mas não pode cruzar o período de outro
+	segundo.SalarioInicial = 3200
+	if err := svc.UpdateFuncionario(ctx, claims, segundo); err != nil {
+		t.Fatalf("atualizar o vínculo atual: %v", err)
+	}
+	segundo.Admissao = time.Date(2023, 6, 15, 0, 0, 0, 0, time.Local)
+	if err := svc.UpdateFuncionario(ctx, claims, segundo); err == nil || !strings.Contains(err.Error(), "sobrepõe") {
+		t.Fatalf("esperava erro de sobreposição ao antecipar a readmissão, veio: %v", err)
+	}
+	segundo.Admissao = time.Date(2024, 1, 8, 0, 0, 0, 0, time.Local)
+
+	adiada := time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local)
+	primeiro.Demissao = &adiada
+	if err := svc.UpdateFuncionario(ctx, claims, primeiro); err == nil || !strings.Contains(err.Error(), "sobrepõe") {
+		t.Fatalf("esperava erro de sobreposição ao adiar a demissão, veio: %v", err)
+	}
+	primeiro.Demissao = nil
+	if err := svc.UpdateFuncionario(ctx, claims, primeiro); err == nil || !strings.Contains(err.Error(), "vínculo ativo") {
+		t.Fatalf("esperava erro de vínculo ativo ao reabrir o vínculo anterior, veio: %v", err)
+	}
+}
+
+func TestFuncionario_BuscarPaginado(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	nasc := time.Date(1990, 5, 10, 0, 0, 0, 0, time.Local)
+	cpfs := []string{"39053344705", "52998224725", "11144477735"}
+	nomes := []string{"Ana Souza", "Bruno Lima", "Carla Souza"}
+	var ids []int64
+	for i, nome := range nomes {
+		p := &entity.Pessoa{Nome: nome, CPF: cpfs[i], RG: fmt.Sprintf("RG%d", i)}
+		if err := repository.CreatePessoa(p); err != nil {
+			t.Fatalf("CreatePessoa erro: %v", err)
+		}
+		f := &entity.Funcionario{
+			PessoaID: p.ID, Nascimento: nasc, Admissao: time.Date(2021+i, 2, 1, 0, 0, 0, 0, time.Local),
+			Cargo: "Analista", SalarioInicial: 3000,
+		}
+		if err := repository.CreateFuncionario(f); err != nil {
+			t.Fatalf("CreateFuncionario erro: %v", err)
+		}
+		ids = append(ids, f.ID)
+	}
+	if err := repository.DeleteFuncionario(ids[1]); err != nil {
+		t.Fatalf("DeleteFuncionario erro: %v", err)
+	}
+
+	parse := func(query string) consulta.Consulta {
+		v, _ := url.ParseQuery(query)
+		q, err := consulta.Parse(v)
+		if err != nil {
+			t.Fatalf("Parse(%q) erro: %v", query, err)
+		}
+		return q
+	}
+
+	p, err := repository.BuscarFuncionarios(parse("nome=souza&sort=-admissao"))
+	if err != nil {
+		t.Fatalf("BuscarFuncionarios erro: %v", err)
+	}
+	if p.Total != 2 || len(p.Itens) != 2 || p.Itens[0].ID != ids[2] || p.Itens[1].ID != ids[0] {
+		t.Fatalf("filtro por nome com ordenação inesperado: total=%d itens=%+v", p.Total, p.Itens)
+	}
+
+	p, err = repository.BuscarFuncionarios(parse("status=ativo&admissao_de=2022-01-01"))
+	if err != nil {
+		t.Fatalf("BuscarFuncionarios erro: %v", err)
+	}
+	if p.Total != 1 || p.Itens[0].ID != ids[2] {
+		t.Fatalf("filtro por status e admissão inesperado: %+v", p)
+	}
+
+	// página com 2 itens: total conta todos e o cursor leva à página seguinte
+	p, err = repository.BuscarFuncionarios(parse("limit=2"))
+	if err != nil {
+		t.Fatalf("BuscarFuncionarios erro: %v", err)
+	}
+	if p.Total != 3 || len(p.Itens) != 2 || p.ProximoCursor != ids[1] {
+		t.Fatalf("primeira página inesperada: total=%d itens=%d cursor=%d", p.Total, len(p.Itens), p.ProximoCursor)
+	}
+	p, err = repository.BuscarFuncionarios(parse(fmt.Sprintf("limit=2&cursor=%d", p.ProximoCursor)))
+	if err != nil {
+		t.Fatalf("BuscarFuncionarios erro: %v", err)
+	}
+	if p.Total != 3 || len(p.Itens) != 1 || p.Itens[0].ID != ids[2] || p.ProximoCursor != 0 {
+		t.Fatalf("página pelo cursor inesperada: %+v", p)
+	}
+	p, err = repository.BuscarFuncionarios(parse("limit=2&page=2"))
+	if err != nil || len(p.Itens) != 1 || p.Itens[0].ID != ids[2] {
+		t.Fatalf("segunda página inesperada: %+v err=%v", p, err)
+	}
+
+	for _, query := range []string{"salario=3000", "sort=salario", "status=todos", "sort=nome&cursor=1"} {
+		if _, err := repository.BuscarFuncionarios(parse(query)); !errors.Is(err, consulta.ErrConsultaInvalida) {
+			t.Errorf("%s: esperava consulta inválida, veio %v", query, err)
+		}
+	}
+}
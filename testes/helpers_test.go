@@ -8,6 +8,7 @@ import (
 var tables = []string{
 	// apague filhos antes dos pais, se houver FK
 	"documento",
+	"pagamento_ferias",
 	"descanso",
 	"ferias",
 	"pagamento",
@@ -21,6 +22,22 @@ var tables = []string{
 	"usuario",
 	"evento",
 	"aviso",
+	"regra_ausencia",
+	"calendario_token",
+	"feriado",
+	"ponto_dia",
+	"banco_horas",
+	"banco_horas_config",
+	"funcionario_jornada",
+	"jornada_periodo",
+	"jornada",
+	"funcionario_cargo",
+	"cargo",
+	"pagamento_rateio",
+	"funcionario_alocacao",
+	"departamento",
+	"centro_custo",
+	"importacao",
 }
 
 func truncateAll() error {
@@ -32,6 +49,7 @@ func truncateAll() error {
 		"SET FOREIGN_KEY_CHECKS = 0",
 
 		// Filhas primeiro:
+		"TRUNCATE TABLE pagamento_ferias",
 		"TRUNCATE TABLE descanso",
 		"TRUNCATE TABLE falta",
 		"TRUNCATE TABLE documento",
@@ -40,6 +58,25 @@ func truncateAll() error {
 		"TRUNCATE TABLE pagamento",
 		"TRUNCATE TABLE folha_pagamento",
 		"TRUNCATE TABLE vale", // se existir
+		"TRUNCATE TABLE regra_ausencia",
+		"TRUNCATE TABLE calendario_token",
+		"TRUNCATE TABLE feriado",
+		"TRUNCATE TABLE ponto_dia",
+		"TRUNCATE TABLE banco_horas",
+		"TRUNCATE TABLE banco_horas_config",
+		"TRUNCATE TABLE funcionario_jornada",
+		"TRUNCATE TABLE jornada_periodo",
+		"TRUNCATE TABLE jornada",
+		"TRUNCATE TABLE funcionario_cargo",
+		"TRUNCATE TABLE cargo",
+		"TRUNCATE TABLE pagamento_rateio",
+		"TRUNCATE TABLE funcionario_alocacao",
+		"TRUNCATE TABLE departamento",
+		"TRUNCATE TABLE centro_custo",
+		"TRUNCATE TABLE pessoa_endereco",
+		"TRUNCATE TABLE pessoa_contato",
+		"TRUNCATE TABLE pessoa_contato_emergencia",
+		"TRUNCATE TABLE importacao",
 
 		// Depois as pais:
 		"TRUNCATE TABLE ferias",
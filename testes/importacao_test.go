@@ -0,0 +1,259 @@
+package testes
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+	"AutoGRH/pkg/service/jwt"
+	"AutoGRH/pkg/utils/planilha"
+)
+
+func newImportacaoServiceSUT() *service.ImportacaoService {
+	cfg := service.AuthConfig{
+		Issuer:    "autogrh-test",
+		AccessTTL: 10 * time.Minute,
+		ClockSkew: 2 * time.Minute,
+		Timezone:  "America/Campo_Grande",
+	}
+	perms := service.PermissionMap{
+		"admin":   {"*": {}},
+		"usuario": {"funcionario:list": {}},
+	}
+	lr := &folhaFakeLogRepo{}
+	auth := service.NewAuthService(nil, lr, jwtm.NewHS256Manager([]byte("secret")), cfg, perms)
+	return service.NewImportacaoService(auth, lr)
+}
+
+func TestImportacao_CSVComErrosNaoGrava(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	ctx := context.Background()
+	admin := service.Claims{UserID: 1, Perfil: "admin"}
+	svc := newImportacaoServiceSUT()
+
+	csv := "Nome;CPF;RG;Data de Nascimento;Admissão;Cargo;Salário;PIS\n" +
+		"João Silva;390.533.447-05;11;10/05/1990;03/01/2022;Analista;4.000,00;120.1738.652-8\n" +
+		"Maria Souza;123.456.789-00;22;1991-02-01;2022-01-03;Analista;abc;\n" +
+		";;;;;;;\n" +
+		"José Lima;39053344705;33;01/01/1985;03/01/2022;Auxiliar;2000;\n"
+
+	if _, err := svc.ValidarFuncionarios(ctx, service.Claims{UserID: 2, Perfil: "usuario"}, "f.csv", []byte(csv), nil); !errors.Is(err, service.ErrUnauthorized) {
+		t.Fatalf("esperado ErrUnauthorized sem funcionario:create, veio %v", err)
+	}
+
+	imp, err := svc.ValidarFuncionarios(ctx, admin, "funcionarios.csv", []byte(csv), nil)
+	if err != nil {
+		t.Fatalf("ValidarFuncionarios erro: %v", err)
+	}
+	if imp.Status != entity.ImportacaoComErros || imp.Formato != planilha.FormatoCSV {
+		t.Fatalf("esperado CSV COM_ERROS, veio %s %s", imp.Formato, imp.Status)
+	}
+	if imp.TotalLinhas != 3 || imp.LinhasComErro != 2 {
+		t.Fatalf("esperado 3 linhas (a vazia é ignorada) e 2 com erro, veio %d e %d", imp.TotalLinhas, imp.LinhasComErro)
+	}
+	if l := imp.Linhas[0]; l.Linha != 2 || len(l.Erros) != 0 || l.CPF != "39053344705" {
+		t.Fatalf("linha 2 deveria ser válida com CPF normalizado: %+v", l)
+	}
+	campos := map[string]bool{}
+	for _, e := range imp.Linhas[1].Erros {
+		campos[e.Campo] = true
+	}
+	if !campos["cpf"] || !campos["salario"] {
+		t.Fatalf("linha 3 deveria acusar cpf e salario: %+v", imp.Linhas[1].Erros)
+	}
+	if l := imp.Linhas[2]; l.Linha != 5 || len(l.Erros) != 1 || !strings.Contains(l.Erros[0].Mensagem, "linha 2") {
+		t.Fatalf("linha 5 deveria acusar CPF repetido da linha 2: %+v", l)
+	}
+
+	if _, err := svc.ConfirmarImportacao(ctx, admin, imp.ID); !errors.Is(err, service.ErrImportacaoComErros) {
+		t.Fatalf("esperado ErrImportacaoComErros, veio %v", err)
+	}
+	if p, err := repository.GetPessoaByCPF("39053344705"); err != nil || p != nil {
+		t.Fatalf("nada deveria ser gravado: pessoa=%v err=%v", p, err)
+	}
+
+	rel, err := svc.RelatorioImportacao(ctx, admin, imp.ID)
+	if err != nil {
+		t.Fatalf("RelatorioImportacao erro: %v", err)
+	}
+	if !strings.Contains(string(rel), "5;José Lima;39053344705;ERRO") {
+		t.Fatalf("relatório sem a linha 5 com erro:\n%s", rel)
+	}
+}
+
+func TestImportacao_MapeamentoEConfirmacao(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	ctx := context.Background()
+	admin := service.Claims{UserID: 1, Perfil: "admin"}
+	svc := newImportacaoServiceSUT()
+
+	cargo := entity.NewCargo("Analista de Sistemas", "212405", 3000, 8000, "TI")
+	if err := repository.CreateCargo(cargo); err != nil {
+		t.Fatalf("CreateCargo erro: %v", err)
+	}
+
+	csv := "Funcionário,Documento,Identidade,Nasc,Entrada,Função,Remuneração,Salário Real\n" +
+		"João Silva,39053344705,11,1990-05-10,2022-01-03,analista de sistemas,\"4.500,00\",5000\n" +
+		"Maria Souza,52998224725,22,1991-02-01,2022-02-01,Recepcionista,2000.50,\n"
+	mapeamento := map[string]string{
+		"nome": "Funcionário", "cpf": "documento", "nascimento": "Nasc", "admissao": "Entrada", "salario": "Remuneração",
+	}
+
+	if _, err := svc.ValidarFuncionarios(ctx, admin, "f.csv", []byte(csv), map[string]string{"setor": "X"}); err == nil {
+		t.Fatalf("esperado erro de validação para campo desconhecido e colunas obrigatórias ausentes")
+	}
+
+	imp, err := svc.ValidarFuncionarios(ctx, admin, "funcionarios.csv", []byte(csv), mapeamento)
+	if err != nil {
+		t.Fatalf("ValidarFuncionarios erro: %v", err)
+	}
+	if imp.Status != entity.ImportacaoValidada || imp.LinhasComErro != 0 {
+		t.Fatalf("esperado VALIDADA sem erros, veio %s: %+v", imp.Status, imp.Linhas)
+	}
+	if imp.Mapeamento["rg"] != "Identidade" || imp.Mapeamento["cargo"] != "Função" {
+		t.Fatalf("mapeamento efetivo deveria reconhecer os apelidos: %v", imp.Mapeamento)
+	}
+	if p, _ := repository.GetPessoaByCPF("39053344705"); p != nil {
+		t.Fatalf("validação não deveria gravar pessoas")
+	}
+
+	imp, err = svc.ConfirmarImportacao(ctx, admin, imp.ID)
+	if err != nil {
+		t.Fatalf("ConfirmarImportacao erro: %v", err)
+	}
+	if imp.Status != entity.ImportacaoConcluida || imp.ConcluidoEm == nil {
+		t.Fatalf("esperado CONCLUIDA, veio %s", imp.Status)
+	}
+	joao := imp.Linhas[0]
+	if joao.PessoaID == 0 || joao.FuncionarioID == 0 {
+		t.Fatalf("linha sem IDs criados: %+v", joao)
+	}
+
+	f, err := repository.GetFuncionarioByID(joao.FuncionarioID)
+	if err != nil || f == nil {
+		t.Fatalf("GetFuncionarioByID erro: %v", err)
+	}
+	if f.CargoID == nil || *f.CargoID != cargo.ID || f.Cargo != "Analista de Sistemas" || f.SalarioInicial != 4500 {
+		t.Fatalf("funcionário importado inesperado: %+v", f)
+	}
+	sal, err := repository.GetSalarioAtual(f.ID)
+	if err != nil || sal == nil || sal.Valor != 4500 {
+		t.Fatalf("salário registrado esperado 4500: %+v err=%v", sal, err)
+	}
+	salReal, err := repository.GetSalarioRealAtual(f.ID)
+	if err != nil || salReal == nil || salReal.Valor != 5000 {
+		t.Fatalf("salário real esperado 5000: %+v err=%v", salReal, err)
+	}
+	hist, err := repository.ListCargosByFuncionarioID(f.ID)
+	if err != nil || len(hist) != 1 {
+		t.Fatalf("esperado 1 registro no histórico de cargos: %v err=%v", hist, err)
+	}
+
+	maria, err := repository.GetFuncionarioByID(imp.Linhas[1].FuncionarioID)
+	if err != nil || maria == nil || maria.CargoID != nil || maria.Cargo != "Recepcionista" {
+		t.Fatalf("cargo fora do catálogo deveria ficar como texto: %+v err=%v", maria, err)
+	}
+	if salReal, _ := repository.GetSalarioRealAtual(maria.ID); salReal == nil || salReal.Valor != 2000.50 {
+		t.Fatalf("salário real deveria repetir o registrado: %+v", salReal)
+	}
+
+	if _, err := svc.ConfirmarImportacao(ctx, admin, imp.ID); !errors.Is(err, service.ErrImportacaoConcluida) {
+		t.Fatalf("esperado ErrImportacaoConcluida, veio %v", err)
+	}
+
+	// nova planilha com o mesmo CPF: vínculo ativo impede a readmissão
+	imp2, err := svc.ValidarFuncionarios(ctx, admin, "f2.csv", []byte(csv), mapeamento)
+	if err != nil {
+		t.Fatalf("ValidarFuncionarios erro: %v", err)
+	}
+	if l := imp2.Linhas[0]; !l.PessoaExistente || l.PessoaID != joao.PessoaID || len(l.Erros) == 0 {
+		t.Fatalf("esperado CPF existente com erro de vínculo ativo: %+v", l)
+	}
+
+	lista, err := svc.ListImportacoes(ctx, admin)
+	if err != nil || len(lista) != 2 || lista[0].Linhas != nil || lista[0].Conteudo != nil {
+		t.Fatalf("listagem deveria trazer 2 importações sem linhas nem arquivo: %v err=%v", lista, err)
+	}
+	arq, err := svc.GetImportacao(ctx, admin, imp.ID)
+	if err != nil || string(arq.Conteudo) != csv {
+		t.Fatalf("arquivo original deveria ser guardado: err=%v", err)
+	}
+	if _, err := svc.GetImportacao(ctx, admin, 9999); !errors.Is(err, service.ErrImportacaoNaoEncontrada) {
+		t.Fatalf("esperado ErrImportacaoNaoEncontrada, veio %v", err)
+	}
+}
+
+// xlsxTeste monta uma planilha XLSX mínima: textos compartilhados, um texto inline e datas com formato 14
+func xlsxTeste(t *testing.T) []byte {
+	t.Helper()
+	partes := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+			xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Funcionários" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/planilha.xml"/>
+			</Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+			<si><t>nome</t></si><si><t>cpf</t></si><si><t>rg</t></si><si><t>nascimento</t></si>
+			<si><t>admissao</t></si><si><t>cargo</t></si><si><t>salario</t></si>
+			<si><r><t>João </t></r><r><t>Silva</t></r></si></sst>`,
+		"xl/styles.xml": `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+			<cellXfs count="2"><xf numFmtId="0"/><xf numFmtId="14"/></cellXfs></styleSheet>`,
+		"xl/worksheets/planilha.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c>
+				<c r="D1" t="s"><v>3</v></c><c r="E1" t="s"><v>4</v></c><c r="F1" t="s"><v>5</v></c><c r="G1" t="s"><v>6</v></c></row>
+			<row r="3"><c r="A3" t="s"><v>7</v></c><c r="B3" t="inlineStr"><is><t>390.533.447-05</t></is></c><c r="C3"><v>11</v></c>
+				<c r="D3" s="1"><v>32998</v></c><c r="E3" s="1"><v>44564</v></c><c r="F3" t="str"><v>Analista</v></c><c r="G3"><v>4000.5</v></c></row>
+			</sheetData></worksheet>`,
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for nome, conteudo := range partes {
+		w, err := zw.Create(nome)
+		if err != nil {
+			t.Fatalf("zip: %v", err)
+		}
+		if _, err := w.Write([]byte(conteudo)); err != nil {
+			t.Fatalf("zip: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestImportacao_XLSX(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	conteudo := xlsxTeste(t)
+	linhas, err := planilha.Ler("funcionarios.xlsx", conteudo)
+	if err != nil {
+		t.Fatalf("planilha.Ler erro: %v", err)
+	}
+	if len(linhas) != 3 || len(linhas[1]) != 0 {
+		t.Fatalf("esperadas 3 linhas com a 2ª vazia, veio %q", linhas)
+	}
+	esperado := []string{"João Silva", "390.533.447-05", "11", "1990-05-05", "2022-01-03", "Analista", "4000.5"}
+	if strings.Join(linhas[2], "|") != strings.Join(esperado, "|") {
+		t.Fatalf("linha 3 inesperada: %q", linhas[2])
+	}
+
+	imp, err := newImportacaoServiceSUT().ValidarFuncionarios(context.Background(), service.Claims{UserID: 1, Perfil: "admin"},
+		"funcionarios.xlsx", conteudo, nil)
+	if err != nil {
+		t.Fatalf("ValidarFuncionarios erro: %v", err)
+	}
+	if imp.Formato != planilha.FormatoXLSX || imp.Status != entity.ImportacaoValidada || imp.Linhas[0].Linha != 3 {
+		t.Fatalf("esperado XLSX VALIDADA com a linha 3: %+v", imp)
+	}
+}
@@ -0,0 +1,90 @@
+package testes
+
+import (
+	Adapter "AutoGRH/pkg/adapter"
+	"context"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+)
+
+func newJornadaServiceWithDB(lr *fdFakeLogRepo) *service.JornadaService {
+	auth := newAdminAuthFD(lr)
+	repo := Adapter.NewJornadaRepositoryAdapter(
+		repository.CreateJornada,
+		repository.GetJornadaByID,
+		repository.UpdateJornada,
+		repository.DeleteJornada,
+		repository.ListJornadas,
+		repository.JornadaEmUso,
+		repository.CreateFuncionarioJornada,
+		repository.GetFuncionarioJornadaByID,
+		repository.DeleteFuncionarioJornada,
+		repository.ListJornadasByFuncionarioID,
+	)
+	return service.NewJornadaService(auth, lr, repo)
+}
+
+func TestJornada_12x36_HorasPrevistasPorVigencia(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &fdFakeLogRepo{}
+	svc := newJornadaServiceWithDB(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 93, Perfil: "admin"}
+	funcID := seedPessoaFuncionarioFD(t)
+
+	j, err := service.NovaJornadaDeModelo(service.ModeloJornada12x36)
+	if err != nil {
+		t.Fatalf("NovaJornadaDeModelo erro: %v", err)
+	}
+	if err := svc.CriarJornada(ctx, claims, j); err != nil {
+		t.Fatalf("CriarJornada erro: %v", err)
+	}
+	salvo, err := svc.BuscarJornada(ctx, claims, j.ID)
+	if err != nil || salvo == nil || len(salvo.Dias) != 1 || len(salvo.Dias[0].Periodos) != 2 {
+		t.Fatalf("jornada gravada inválida: %+v err=%v", salvo, err)
+	}
+
+	dia := func(d int) time.Time { return time.Date(2025, time.April, d, 0, 0, 0, 0, time.Local) }
+	if _, err := svc.AtribuirJornada(ctx, claims, funcID, j.ID, dia(1)); err != nil {
+		t.Fatalf("AtribuirJornada erro: %v", err)
+	}
+
+	casos := []struct {
+		data     time.Time
+		previsto int
+	}{
+		{dia(1), 660},                   // plantão
+		{dia(2), 0},                     // descanso de 36h
+		{dia(21), 660},                  // Tiradentes: a 12x36 trabalha em feriados
+		{dia(1).AddDate(0, 0, -1), 528}, // antes da atribuição vale a jornada padrão
+		{dia(1).AddDate(0, 0, -2), 0},   // domingo na jornada padrão
+	}
+	for _, c := range casos {
+		p, err := svc.HorasPrevistas(funcID, c.data)
+		if err != nil {
+			t.Fatalf("HorasPrevistas erro: %v", err)
+		}
+		if p.MinutosPrevistos != c.previsto {
+			t.Fatalf("%s: esperado %d min, obtido %d (%s)", c.data.Format("2006-01-02"), c.previsto, p.MinutosPrevistos, p.Jornada)
+		}
+	}
+
+	// Tempo parcial acima de 30h semanais é recusado
+	parcial := entity.NewJornada("Parcial inválida", entity.JornadaTipoParcial, 7, false)
+	for d := 1; d <= 5; d++ {
+		parcial.Dias = append(parcial.Dias, entity.DiaJornada{Dia: d, Periodos: []entity.PeriodoJornada{{Inicio: "08:00", Fim: "15:00"}}})
+	}
+	if err := svc.CriarJornada(ctx, claims, parcial); err == nil {
+		t.Fatalf("esperava erro para jornada parcial de 35h")
+	}
+
+	// Jornada em uso não pode ser excluída
+	if err := svc.ExcluirJornada(ctx, claims, j.ID); err == nil {
+		t.Fatalf("esperava erro ao excluir jornada atribuída")
+	}
+}
@@ -0,0 +1,116 @@
+package testes
+
+import (
+	Adapter "AutoGRH/pkg/adapter"
+	"bytes"
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+)
+
+func newPagamentoFeriasServiceWithDB(lr *fdFakeLogRepo) *service.PagamentoFeriasService {
+	auth := newAdminAuthFD(lr)
+	repo := Adapter.NewPagamentoFeriasRepositoryAdapter(
+		repository.GetPagamentoFeriasByID,
+		repository.GetPagamentoFeriasByDescansoID,
+		repository.ListPagamentosFerias,
+		repository.ListPagamentosFeriasByFuncionarioID,
+		repository.MarcarPagamentoFeriasComoPago,
+	)
+	return service.NewPagamentoFeriasService(auth, lr, repo)
+}
+
+func TestPagamentoFerias_GeradoNaAprovacao_PagoERecibo(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &fdFakeLogRepo{}
+	fsvc := newFeriasServiceWithDB(lr)
+	dsvc := newDescansoServiceWithDB(lr)
+	psvc := newPagamentoFeriasServiceWithDB(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 90, Perfil: "admin"}
+
+	funcID := seedPessoaFuncionarioFD(t)
+
+	// Férias de 30 dias valendo 3000 (+ 1000 de terço)
+	f, err := fsvc.CriarFerias(ctx, claims, funcID, 30, 3000.00, time.Now().AddDate(0, -1, 0))
+	if err != nil {
+		t.Fatalf("CriarFerias erro: %v", err)
+	}
+
+	// começa numa segunda-feira: o prazo não pode cair no fim de semana anterior
+	inicio := time.Now().AddDate(0, 0, 20)
+	inicio = time.Date(inicio.Year(), inicio.Month(), inicio.Day(), 0, 0, 0, 0, time.Local)
+	for inicio.Weekday() != time.Monday {
+		inicio = inicio.AddDate(0, 0, 1)
+	}
+	d := entity.NewDescanso(inicio, inicio.AddDate(0, 0, 29), f.ID)
+	if err := dsvc.CreateDescanso(ctx, claims, d); err != nil {
+		t.Fatalf("CreateDescanso erro: %v", err)
+	}
+	if err := dsvc.AprovarDescanso(ctx, claims, d.ID); err != nil {
+		t.Fatalf("AprovarDescanso erro: %v", err)
+	}
+	// aprovar de novo falha sem consumir os dias outra vez
+	if err := dsvc.AprovarDescanso(ctx, claims, d.ID); err == nil {
+		t.Fatalf("segunda aprovação deveria falhar")
+	}
+	if fAtual, _ := repository.GetFeriasByID(f.ID); fAtual == nil || fAtual.Dias != 0 {
+		t.Fatalf("os 30 dias deveriam ter sido consumidos uma única vez: %+v", fAtual)
+	}
+
+	// Aprovação gera o pagamento, devido 2 dias úteis antes do início: quinta-feira, ou antes se houver feriado
+	p, err := psvc.GetByDescansoID(ctx, claims, d.ID)
+	if err != nil || p == nil {
+		t.Fatalf("pagamento de férias não gerado na aprovação: p=%v err=%v", p, err)
+	}
+	prevista := time.Date(p.DataPrevista.Year(), p.DataPrevista.Month(), p.DataPrevista.Day(), 0, 0, 0, 0, time.Local)
+	if wd := prevista.Weekday(); wd == time.Saturday || wd == time.Sunday ||
+		prevista.After(inicio.AddDate(0, 0, -4)) || prevista.Before(inicio.AddDate(0, 0, -10)) {
+		t.Fatalf("data prevista deveria ser a quinta-feira anterior ao início %s (ou antes, por feriado), veio %s",
+			inicio.Format("2006-01-02"), prevista.Format("2006-01-02"))
+	}
+
+	// 4000 bruto: INSS 373,41 e IRRF 114,76 (tabelas 2025)
+	approx := func(a, b float64) bool { return math.Abs(a-b) < 0.02 }
+	if !approx(p.ValorFerias, 3000) || !approx(p.ValorTerco, 1000) || !approx(p.ValorBruto, 4000) {
+		t.Fatalf("valores brutos inesperados: %+v", p)
+	}
+	if !approx(p.DescontoINSS, 373.41) || !approx(p.DescontoIRRF, 114.76) || !approx(p.ValorLiquido, 3511.83) {
+		t.Fatalf("descontos inesperados: %+v", p)
+	}
+
+	// Recibo em PDF
+	pdf, err := psvc.GerarAvisoRecibo(ctx, claims, p.ID)
+	if err != nil {
+		t.Fatalf("GerarAvisoRecibo erro: %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) {
+		t.Fatalf("recibo não é um PDF")
+	}
+
+	// Pagar no prazo marca o descanso como pago
+	if err := psvc.MarcarComoPago(ctx, claims, p.ID, p.DataPrevista); err != nil {
+		t.Fatalf("MarcarComoPago erro: %v", err)
+	}
+	dPago, _ := repository.GetDescansoByID(d.ID)
+	if dPago == nil || !dPago.Pago {
+		t.Fatalf("descanso deveria estar pago após o pagamento das férias")
+	}
+	pPago, _ := psvc.GetByID(ctx, claims, p.ID)
+	if pPago == nil || !pPago.Pago || pPago.DataPagamento == nil {
+		t.Fatalf("pagamento deveria estar pago com data registrada: %+v", pPago)
+	}
+	if err := psvc.MarcarComoPago(ctx, claims, p.ID, time.Now()); err == nil {
+		t.Fatalf("esperava erro ao pagar duas vezes")
+	}
+
+	if !hasLogPrefix(lr.entries, 4, claims.UserID, "Pagamento de férias pago") {
+		t.Fatalf("esperava log de pagamento de férias")
+	}
+}
@@ -113,6 +113,7 @@ func newFaltaServiceForSeed(lr *folhaFakeLogRepo) *service.FaltaService {
 		repository.GetFaltaByID,
 		repository.GetFaltasByFuncionarioID,
 		repository.ListAllFaltas,
+		repository.BuscarFaltas,
 	)
 	return service.NewFaltaService(auth, lr, adp)
 }
@@ -384,3 +385,133 @@ func TestPagamentoService_Get_Update_Pagar_ListarFuncionario(t *testing.T) {
 		t.Fatalf("Pagamento deveria estar pago: %+v", got3)
 	}
 }
+
+func TestFolhaSalario_FaltaInjustificada_DescontaDSR(t *testing.T) {
+	if err := truncateAll(); err != nil {
+		t.Fatalf("truncateAll inicio: %v", err)
+	}
+	t.Cleanup(func() { _ = truncateAll() })
+
+	lr := &folhaFakeLogRepo{}
+	fs := newFolhaService(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 504, Perfil: "admin"}
+
+	const mes, ano = 4, 2025
+	funcID := seedPessoaFuncionarioBase(t, "Funcionario DSR")
+	seedSalarioRealAtual(t, funcID, 3000)
+
+	// Segunda 14/04/2025: semana perde o domingo 20/04 e a Sexta-feira Santa 18/04.
+	// O atestado de 23/04 é justificado e não afeta o DSR.
+	fsvc := newFaltaServiceForSeed(lr)
+	for _, f := range []*entity.Falta{
+		entity.NewAusencia(entity.FaltaTipoInjustificada, time.Date(ano, mes, 14, 0, 0, 0, 0, time.Local), 1, funcID),
+		entity.NewAusencia(entity.FaltaTipoAtestado, time.Date(ano, mes, 23, 0, 0, 0, 0, time.Local), 1, funcID),
+	} {
+		if err := fsvc.CreateFalta(ctx, claims, f); err != nil {
+			t.Fatalf("CreateFalta erro: %v", err)
+		}
+	}
+
+	folha, err := fs.CriarFolhaSalario(ctx, claims, mes, ano)
+	if err != nil {
+		t.Fatalf("CriarFolhaSalario erro: %v", err)
+	}
+	rows, err := repository.GetPagamentosByFolhaID(folha.ID)
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("esperava 1 pagamento, got=%d err=%v", len(rows), err)
+	}
+	p := rows[0]
+	if p.DescontoDSR < 199.99 || p.DescontoDSR > 200.01 {
+		t.Fatalf("descontoDSR esperado ~200, veio %.2f", p.DescontoDSR)
+	}
+	// 3000 - 100 (falta) - 200 (DSR)
+	if p.ValorFinal < 2699.99 || p.ValorFinal > 2700.01 {
+		t.Fatalf("valorFinal esperado ~2700, veio %.2f", p.ValorFinal)
+	}
+
+	// Recalcular mantém o DSR como desconto próprio
+	if err := fs.RecalcularFolha(ctx, claims, folha.ID); err != nil {
+		t.Fatalf("RecalcularFolha erro: %v", err)
+	}
+	rows, _ = repository.GetPagamentosByFolhaID(folha.ID)
+	if len(rows) != 1 || rows[0].DescontoDSR < 199.99 || rows[0].DescontoDSR > 200.01 {
+		t.Fatalf("descontoDSR após recalcular esperado ~200, veio %+v", rows)
+	}
+}
+
+func TestFolhaSalario_TipoContrato_FGTSeSemPJ(t *testing.T) {
+	if err := truncateAll(); err != nil {
+		t.Fatalf("truncateAll inicio: %v", err)
+	}
+	t.Cleanup(func() { _ = truncateAll() })
+
+	lr := &folhaFakeLogRepo{}
+	fs := newFolhaService(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 505, Perfil: "admin"}
+
+	seedTipo := func(nome, tipo string, salario float64) int64 {
+		id := seedPessoaFuncionarioBase(t, nome)
+		seedSalarioRealAtual(t, id, salario)
+		f, err := repository.GetFuncionarioByID(id)
+		if err != nil || f == nil {
+			t.Fatalf("GetFuncionarioByID erro: %v", err)
+		}
+		f.TipoContrato = tipo
+		if err := repository.UpdateFuncionario(f); err != nil {
+			t.Fatalf("UpdateFuncionario erro: %v", err)
+		}
+		return id
+	}
+	clt := seedTipo("Funcionario CLT", entity.TipoContratoCLT, 2000)
+	aprendiz := seedTipo("Funcionario Aprendiz", entity.TipoContratoAprendiz, 1000)
+	estagio := seedTipo("Funcionario Estagio", entity.TipoContratoEstagio, 1000)
+	pj := seedTipo("Funcionario PJ", entity.TipoContratoPJ, 5000)
+
+	folha, err := fs.CriarFolhaSalario(ctx, claims, 3, 2025)
+	if err != nil {
+		t.Fatalf("CriarFolhaSalario erro: %v", err)
+	}
+	porFunc := func() map[int64]entity.Pagamento {
+		rows, err := repository.GetPagamentosByFolhaID(folha.ID)
+		if err != nil {
+			t.Fatalf("GetPagamentosByFolhaID erro: %v", err)
+		}
+		m := make(map[int64]entity.Pagamento)
+		for _, p := range rows {
+			m[p.FuncionarioID] = p
+		}
+		return m
+	}
+
+	pags := porFunc()
+	if _, ok := pags[pj]; ok || len(pags) != 3 {
+		t.Fatalf("prestador PJ não deveria entrar na folha: %+v", pags)
+	}
+	if pags[clt].FGTS != 160 || pags[aprendiz].FGTS != 20 || pags[estagio].FGTS != 0 {
+		t.Fatalf("FGTS esperado 160/20/0, veio %.2f/%.2f/%.2f",
+			pags[clt].FGTS, pags[aprendiz].FGTS, pags[estagio].FGTS)
+	}
+	// FGTS é do empregador: não desconta do líquido
+	if pags[clt].ValorFinal != 2000 {
+		t.Fatalf("valorFinal CLT esperado 2000, veio %.2f", pags[clt].ValorFinal)
+	}
+
+	// estagiário que passa a PJ sai da folha ao recalcular
+	f, _ := repository.GetFuncionarioByID(estagio)
+	f.TipoContrato = entity.TipoContratoPJ
+	if err := repository.UpdateFuncionario(f); err != nil {
+		t.Fatalf("UpdateFuncionario erro: %v", err)
+	}
+	if err := fs.RecalcularFolha(ctx, claims, folha.ID); err != nil {
+		t.Fatalf("RecalcularFolha erro: %v", err)
+	}
+	pags = porFunc()
+	if _, ok := pags[estagio]; ok || len(pags) != 2 {
+		t.Fatalf("pagamento do novo PJ deveria ser removido: %+v", pags)
+	}
+	if got, _ := repository.GetFolhaPagamentoByID(folha.ID); got == nil || got.ValorTotal != 3000 {
+		t.Fatalf("valorTotal esperado 3000, veio %+v", got)
+	}
+}
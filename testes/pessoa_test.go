@@ -1,6 +1,7 @@
 package testes
 
 import (
+	Adapter "AutoGRH/pkg/adapter"
 	"context"
 	"errors"
 	"strings"
@@ -8,8 +9,10 @@ import (
 	"time"
 
 	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
 	"AutoGRH/pkg/service"
 	"AutoGRH/pkg/service/jwt"
+	"AutoGRH/pkg/utils/consulta"
 )
 
 /*** ---------------- Mocks (nomes exclusivos p/ Pessoa) ---------------- ***/
@@ -192,6 +195,11 @@ func (r *pessoaFakePessoaRepo) ListAll(ctx context.Context) ([]*entity.Pessoa, e
 	return out, nil
 }
 
+func (r *pessoaFakePessoaRepo) Buscar(ctx context.Context, q consulta.Consulta) (consulta.Pagina[*entity.Pessoa], error) {
+	todas, _ := r.ListAll(ctx)
+	return consulta.Pagina[*entity.Pessoa]{Itens: todas, Total: len(todas), Pagina: 1, Limite: len(todas)}, nil
+}
+
 /*** -------------- SUT helpers (nomes exclusivos) -------------- ***/
 
 func newAuthForPessoaSuite(lr *pessoaFakeLogRepo) *service.AuthService {
@@ -211,6 +219,22 @@ func newPessoaServiceSUT(repo service.PessoaRepository, lr *pessoaFakeLogRepo) *
 	return service.NewPessoaService(auth, lr, repo)
 }
 
+// newPessoaRepoWithDB usa o repositório real, para os testes que gravam endereço, contatos e vínculos
+func newPessoaRepoWithDB() service.PessoaRepository {
+	return Adapter.NewPessoaRepositoryAdapter(
+		repository.CreatePessoa,
+		repository.GetPessoaByID,
+		repository.GetPessoaByCPF,
+		repository.UpdatePessoa,
+		repository.DeletePessoa,
+		repository.ExistsPessoaByCPF,
+		repository.ExistsPessoaByRG,
+		repository.SearchPessoaByNome,
+		repository.ListPessoas,
+		repository.BuscarPessoas,
+	)
+}
+
 /*** ---------------- Tests ---------------- ***/
 
 func TestPessoa_CreatePessoa_Sucesso(t *testing.T) {
@@ -224,12 +248,12 @@ func TestPessoa_CreatePessoa_Sucesso(t *testing.T) {
 
 	// dados com espaçamentos para validar Trim
 	p := &entity.Pessoa{
-		Nome:              "  Ana Maria  ",
-		CPF:               "  12345678901 ",
-		RG:                "  998877 ",
-		Endereco:          "Rua A, 100",
-		Contato:           "99999-0000",
-		ContatoEmergencia: "88888-0000",
+		Nome:        "  Ana Maria  ",
+		CPF:         "  529.982.247-25 ",
+		RG:          "  998877 ",
+		NomeSocial:  "  Ana ",
+		Sexo:        "f",
+		EstadoCivil: 2,
 	}
 	if err := svc.CreatePessoa(ctx, claims, p); err != nil {
 		t.Fatalf("CreatePessoa erro: %v", err)
@@ -240,7 +264,7 @@ func TestPessoa_CreatePessoa_Sucesso(t *testing.T) {
 
 	// verificação: persistiu TRIM
 	got, _ := repo.GetByID(ctx, p.ID)
-	if got.Nome != "Ana Maria" || got.CPF != "12345678901" || got.RG != "998877" {
+	if got.Nome != "Ana Maria" || got.CPF != "52998224725" || got.RG != "998877" || got.NomeSocial != "Ana" || got.Sexo != "F" {
 		t.Errorf("esperava campos TRIM: %+v", got)
 	}
 
@@ -261,14 +285,14 @@ func TestPessoa_CreatePessoa_DuplicadoCPF_RG(t *testing.T) {
 
 	// primeiro insert
 	if err := svc.CreatePessoa(ctx, claims, &entity.Pessoa{
-		Nome: "X", CPF: "111", RG: "222",
+		Nome: "X", CPF: "11144477735", RG: "222",
 	}); err != nil {
 		t.Fatalf("primeiro create falhou: %v", err)
 	}
 
 	// duplicado por CPF
 	err := svc.CreatePessoa(ctx, claims, &entity.Pessoa{
-		Nome: "Y", CPF: "111", RG: "333",
+		Nome: "Y", CPF: "111.444.777-35", RG: "333",
 	})
 	if err == nil || !strings.Contains(err.Error(), "já existe uma pessoa com este CPF") {
 		t.Fatalf("esperava erro de CPF duplicado, veio: %v", err)
@@ -276,7 +300,7 @@ func TestPessoa_CreatePessoa_DuplicadoCPF_RG(t *testing.T) {
 
 	// duplicado por RG
 	err = svc.CreatePessoa(ctx, claims, &entity.Pessoa{
-		Nome: "Z", CPF: "444", RG: "222",
+		Nome: "Z", CPF: "39053344705", RG: "222",
 	})
 	if err == nil || !strings.Contains(err.Error(), "já existe uma pessoa com este RG") {
 		t.Fatalf("esperava erro de RG duplicado, veio: %v", err)
@@ -297,9 +321,9 @@ func TestPessoa_CreatePessoa_CamposObrigatorios(t *testing.T) {
 		p    *entity.Pessoa
 		want string
 	}{
-		{"Nome vazio", &entity.Pessoa{Nome: " ", CPF: "1", RG: "2"}, "nome não pode ser vazio"},
+		{"Nome vazio", &entity.Pessoa{Nome: " ", CPF: "52998224725", RG: "2"}, "nome não pode ser vazio"},
 		{"CPF vazio", &entity.Pessoa{Nome: "A", CPF: " ", RG: "2"}, "CPF não pode ser vazio"},
-		{"RG vazio", &entity.Pessoa{Nome: "A", CPF: "1", RG: " "}, "RG não pode ser vazio"},
+		{"RG vazio", &entity.Pessoa{Nome: "A", CPF: "52998224725", RG: " "}, "RG não pode ser vazio"},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -311,6 +335,121 @@ func TestPessoa_CreatePessoa_CamposObrigatorios(t *testing.T) {
 	}
 }
 
+func TestPessoa_CreatePessoa_CPFInvalido(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &pessoaFakeLogRepo{}
+	repo := newPessoaFakeRepo()
+	svc := newPessoaServiceSUT(repo, lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 1}
+
+	for _, cpf := range []string{"529.982.247-24", "111.111.111-11", "5299822472", "529982247-2X"} {
+		err := svc.CreatePessoa(ctx, claims, &entity.Pessoa{Nome: "A", CPF: cpf, RG: "2"})
+		var verr *service.ErroValidacao
+		if !errors.As(err, &verr) || len(verr.Campos) != 1 || verr.Campos[0].Campo != "cpf" {
+			t.Fatalf("CPF %q: esperava erro no campo cpf, veio: %v", cpf, err)
+		}
+	}
+
+	// mesmo CPF com e sem máscara é duplicado
+	if err := svc.CreatePessoa(ctx, claims, &entity.Pessoa{Nome: "A", CPF: "52998224725", RG: "2"}); err != nil {
+		t.Fatalf("create falhou: %v", err)
+	}
+	err := svc.CreatePessoa(ctx, claims, &entity.Pessoa{Nome: "B", CPF: "529.982.247-25", RG: "3"})
+	if err == nil || !strings.Contains(err.Error(), "já existe uma pessoa com este CPF") {
+		t.Fatalf("esperava erro de CPF duplicado, veio: %v", err)
+	}
+}
+
+func TestPessoa_EnderecoEContatos(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &pessoaFakeLogRepo{}
+	svc := newPessoaServiceSUT(newPessoaRepoWithDB(), lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 8}
+
+	p := &entity.Pessoa{Nome: "Ana", CPF: "52998224725", RG: "2", GrauInstrucao: 7, RacaCor: 9}
+	var verr *service.ErroValidacao
+	if err := svc.CreatePessoa(ctx, claims, p); !errors.As(err, &verr) || verr.Campos[0].Campo != "raca_cor" {
+		t.Fatalf("esperava erro em raca_cor, veio: %v", err)
+	}
+	p.RacaCor = 3
+	if err := svc.CreatePessoa(ctx, claims, p); err != nil {
+		t.Fatalf("create falhou: %v", err)
+	}
+
+	// endereço: CEP e IBGE só com dígitos; código do município precisa ser da UF
+	e := &entity.Endereco{CEP: "01310-100", Logradouro: " Av. Paulista ", Cidade: "São Paulo", UF: "sp", CodigoIBGE: "3304557"}
+	if err := svc.SalvarEndereco(ctx, claims, p.ID, e); !errors.As(err, &verr) || verr.Campos[0].Campo != "endereco.codigo_ibge" {
+		t.Fatalf("esperava erro no código IBGE, veio: %v", err)
+	}
+	e.CodigoIBGE = "3550308"
+	if err := svc.SalvarEndereco(ctx, claims, p.ID, e); err != nil {
+		t.Fatalf("SalvarEndereco erro: %v", err)
+	}
+	if err := svc.SalvarEndereco(ctx, claims, 999999, &entity.Endereco{}); !errors.Is(err, service.ErrPessoaNaoEncontrada) {
+		t.Fatalf("esperava pessoa não encontrada, veio: %v", err)
+	}
+
+	err := svc.SalvarContatos(ctx, claims, p.ID, []entity.Contato{
+		{Tipo: "celular", Valor: "(11) 3333-4444"},
+		{Tipo: "EMAIL", Valor: "ana@"},
+	})
+	if !errors.As(err, &verr) || len(verr.Campos) != 2 || verr.Campos[0].Campo != "contatos[0].valor" {
+		t.Fatalf("esperava erros em contatos[0] e contatos[1], veio: %v", err)
+	}
+	if err := svc.SalvarContatos(ctx, claims, p.ID, []entity.Contato{
+		{Tipo: "celular", Valor: "+55 (11) 98765-4321"},
+		{Tipo: "email", Valor: " Ana@Empresa.com.br "},
+		{Tipo: "EMAIL", Valor: "ana@gmail.com", Principal: true},
+	}); err != nil {
+		t.Fatalf("SalvarContatos erro: %v", err)
+	}
+	if err := svc.SalvarContatosEmergencia(ctx, claims, p.ID, []entity.ContatoEmergencia{
+		{Nome: "José", Parentesco: "pai", Telefone: "(11) 3333-4444"},
+	}); err != nil {
+		t.Fatalf("SalvarContatosEmergencia erro: %v", err)
+	}
+
+	got, err := svc.GetPessoaByID(ctx, claims, p.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetPessoaByID erro: %v", err)
+	}
+	if got.GrauInstrucao != 7 || got.RacaCor != 3 {
+		t.Errorf("dados pessoais não gravados: %+v", got)
+	}
+	if got.Endereco == nil || got.Endereco.CEP != "01310100" || got.Endereco.UF != "SP" || got.Endereco.Numero != "S/N" || got.Endereco.Logradouro != "Av. Paulista" {
+		t.Errorf("endereço não normalizado: %+v", got.Endereco)
+	}
+	if len(got.Contatos) != 3 {
+		t.Fatalf("esperava 3 contatos, veio %+v", got.Contatos)
+	}
+	principal := map[string]string{}
+	for _, c := range got.Contatos {
+		if c.Principal {
+			principal[c.Tipo] = c.Valor
+		}
+	}
+	if principal[entity.ContatoCelular] != "11987654321" || principal[entity.ContatoEmail] != "ana@gmail.com" {
+		t.Errorf("principais inesperados: %+v", got.Contatos)
+	}
+	if len(got.ContatosEmergencia) != 1 || got.ContatosEmergencia[0].Parentesco != "PAI" || got.ContatosEmergencia[0].Telefone != "1133334444" {
+		t.Errorf("contato de emergência inesperado: %+v", got.ContatosEmergencia)
+	}
+
+	if err := svc.RemoverEndereco(ctx, claims, p.ID); err != nil {
+		t.Fatalf("RemoverEndereco erro: %v", err)
+	}
+	if e, _ := svc.GetEndereco(ctx, claims, p.ID); e != nil {
+		t.Errorf("esperava endereço removido, veio %+v", e)
+	}
+	if !pessoaHasLogPrefix(lr.entries, 4, 8, "Atualizou contatos da pessoa ID=") {
+		t.Errorf("não registrou log dos contatos")
+	}
+}
+
 func TestPessoa_GetPessoaByID(t *testing.T) {
 	defer func() { _ = truncateAll() }()
 
@@ -326,7 +465,7 @@ func TestPessoa_GetPessoaByID(t *testing.T) {
 	}
 
 	// cria e busca
-	p := &entity.Pessoa{Nome: "Ana", CPF: "1", RG: "2"}
+	p := &entity.Pessoa{Nome: "Ana", CPF: "52998224725", RG: "2"}
 	if err := svc.CreatePessoa(ctx, claims, p); err != nil {
 		t.Fatalf("create falhou: %v", err)
 	}
@@ -346,20 +485,21 @@ func TestPessoa_UpdatePessoa_Sucesso_E_Log(t *testing.T) {
 	claims := service.Claims{UserID: 7}
 
 	// cria base
-	p := &entity.Pessoa{Nome: "Ana", CPF: "1", RG: "2", Endereco: "R1", Contato: "C1", ContatoEmergencia: "E1"}
+	p := &entity.Pessoa{Nome: "Ana", CPF: "52998224725", RG: "2", EstadoCivil: 1}
 	if err := svc.CreatePessoa(ctx, claims, p); err != nil {
 		t.Fatalf("create falhou: %v", err)
 	}
 
 	// atualiza com TRIM e novos valores
 	up := &entity.Pessoa{
-		ID:                p.ID,
-		Nome:              "  Ana Maria ",
-		CPF:               "  111 ",
-		RG:                "  222 ",
-		Endereco:          "R2",
-		Contato:           "C2",
-		ContatoEmergencia: "E2",
+		ID:               p.ID,
+		Nome:             "  Ana Maria ",
+		CPF:              "  111.444.777-35 ",
+		RG:               "  222 ",
+		IdentidadeGenero: "cisgenero",
+		EstadoCivil:      2,
+		GrauInstrucao:    9,
+		RacaCor:          3,
 	}
 	if err := svc.UpdatePessoa(ctx, claims, up); err != nil {
 		t.Fatalf("update falhou: %v", err)
@@ -367,7 +507,8 @@ func TestPessoa_UpdatePessoa_Sucesso_E_Log(t *testing.T) {
 
 	// verificação persistida (TRIM aplicado)
 	got, _ := repo.GetByID(ctx, p.ID)
-	if got.Nome != "Ana Maria" || got.CPF != "111" || got.RG != "222" || got.Endereco != "R2" || got.Contato != "C2" || got.ContatoEmergencia != "E2" {
+	if got.Nome != "Ana Maria" || got.CPF != "11144477735" || got.RG != "222" ||
+		got.IdentidadeGenero != entity.GeneroCisgenero || got.EstadoCivil != 2 || got.GrauInstrucao != 9 || got.RacaCor != 3 {
 		t.Fatalf("update não aplicou corretamente: %+v", got)
 	}
 
@@ -416,7 +557,7 @@ func TestPessoa_DeletePessoa_Sucesso_E_Log(t *testing.T) {
 	claims := service.Claims{UserID: 55}
 
 	// cria
-	p := &entity.Pessoa{Nome: "Ana", CPF: "1", RG: "2"}
+	p := &entity.Pessoa{Nome: "Ana", CPF: "52998224725", RG: "2"}
 	if err := svc.CreatePessoa(ctx, claims, p); err != nil {
 		t.Fatalf("create falhou: %v", err)
 	}
@@ -457,9 +598,9 @@ func TestPessoa_ListPessoas_RetornaTudo(t *testing.T) {
 	ctx := context.Background()
 	claims := service.Claims{UserID: 1}
 
-	_ = svc.CreatePessoa(ctx, claims, &entity.Pessoa{Nome: "A", CPF: "1", RG: "2"})
-	_ = svc.CreatePessoa(ctx, claims, &entity.Pessoa{Nome: "B", CPF: "3", RG: "4"})
-	_ = svc.CreatePessoa(ctx, claims, &entity.Pessoa{Nome: "C", CPF: "5", RG: "6"})
+	_ = svc.CreatePessoa(ctx, claims, &entity.Pessoa{Nome: "A", CPF: "52998224725", RG: "2"})
+	_ = svc.CreatePessoa(ctx, claims, &entity.Pessoa{Nome: "B", CPF: "11144477735", RG: "4"})
+	_ = svc.CreatePessoa(ctx, claims, &entity.Pessoa{Nome: "C", CPF: "39053344705", RG: "6"})
 
 	list, err := svc.ListPessoas(ctx, claims)
 	if err != nil {
@@ -0,0 +1,331 @@
+package testes
+
+import (
+	Adapter "AutoGRH/pkg/adapter"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+)
+
+func newPontoServiceWithDB(lr *folhaFakeLogRepo) *service.PontoService {
+	auth := newAdminAuth(lr)
+	repo := Adapter.NewPontoRepositoryAdapter(
+		repository.UpsertPontoDia,
+		repository.GetPontoDia,
+		repository.ListPontoDiasByFuncionarioPeriodo,
+	)
+	return service.NewPontoService(auth, lr, repo)
+}
+
+// afdPortaria1510 monta um AFD no layout antigo: NSR, tipo 3, data ddmmaaaa, hora hhmm e PIS
+func afdPortaria1510(pis string, marcacoes map[string][]string) string {
+	var b strings.Builder
+	b.WriteString("0000000001100000000000100000000000000EMPRESA TESTE\r\n")
+	nsr := 1
+	for _, dia := range []string{"07042025", "08042025", "09042025", "10042025", "11042025", "12042025"} {
+		for _, h := range marcacoes[dia] {
+			nsr++
+			fmt.Fprintf(&b, "%09d3%s%s0%s\r\n", nsr, dia, strings.ReplaceAll(h, ":", ""), pis)
+		}
+	}
+	nsr++
+	fmt.Fprintf(&b, "%09d3%s%s0%s\r\n", nsr, "08042025", "0800", "99999999999")
+	b.WriteString("999999999000000000000000000000000009\r\n")
+	return b.String()
+}
+
+func TestPonto_ImportarAFD_LancaFaltasAtrasosEHorasExtras(t *testing.T) {
+	if err := truncateAll(); err != nil {
+		t.Fatalf("truncateAll inicio: %v", err)
+	}
+	t.Cleanup(func() { _ = truncateAll() })
+
+	lr := &folhaFakeLogRepo{}
+	ps := newPontoServiceWithDB(lr)
+	fs := newFolhaService(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 505, Perfil: "admin"}
+
+	funcID := seedPessoaFuncionarioBase(t, "Funcionario Ponto")
+	f, _ := repository.GetFuncionarioByID(funcID)
+	f.PIS = "123.45678.90-1"
+	if err := repository.UpdateFuncionario(f); err != nil {
+		t.Fatalf("UpdateFuncionario erro: %v", err)
+	}
+	seedSalarioRealAtual(t, funcID, 2200)
+	// lançamento manual do mês: o arquivo cobre só parte de abril, então ele é mantido
+	seedFaltasMes(t, funcID, 4, 2025, 3)
+
+	arquivo := afdPortaria1510("12345678901", map[string][]string{
+		"07042025": {"08:00", "12:00", "13:00", "17:48"}, // jornada exata (8h48)
+		"08042025": {"08:30", "12:00", "13:00", "17:48"}, // 30 min de atraso
+		// 09/04 sem marcação: falta
+		"10042025": {"08:00", "12:00", "13:00", "19:48"}, // 2h extras
+		"11042025": {"08:00", "12:00", "13:00", "17:50"}, // dentro da tolerância
+		"12042025": {"08:00", "12:00"},                   // sábado: 4h extras
+	})
+
+	res, err := ps.Importar(ctx, claims, strings.NewReader(arquivo))
+	if err != nil {
+		t.Fatalf("Importar erro: %v", err)
+	}
+	if res.Funcionarios != 1 || res.Dias != 6 || res.FaltasLancadas != 1 || res.AtrasosLancados != 1 {
+		t.Fatalf("resultado inesperado: %+v", res)
+	}
+	if len(res.NaoEncontrados) != 1 || res.NaoEncontrados[0] != "99999999999" {
+		t.Fatalf("PIS desconhecido deveria ser reportado: %+v", res.NaoEncontrados)
+	}
+	if len(res.Erros) != 1 || !strings.Contains(res.Erros[0], "mantido o lançamento MENSAL de 3 falta(s)") {
+		t.Fatalf("esperava aviso do lançamento mensal mantido: %+v", res.Erros)
+	}
+
+	dias, err := ps.ListarMes(ctx, claims, funcID, 4, 2025)
+	if err != nil || len(dias) != 6 {
+		t.Fatalf("ListarMes: len=%d err=%v", len(dias), err)
+	}
+	porDia := map[int]*entity.PontoDia{}
+	for _, d := range dias {
+		porDia[d.Data.Day()] = d
+	}
+	if porDia[8].MinutosAtraso != 30 || !porDia[9].Falta || porDia[10].MinutosExtra != 120 ||
+		porDia[11].MinutosExtra != 0 || porDia[12].MinutosExtra != 240 {
+		t.Fatalf("apuração inesperada: 08=%+v 09=%+v 10=%+v 11=%+v 12=%+v", porDia[8], porDia[9], porDia[10], porDia[11], porDia[12])
+	}
+
+	// Reimportar o mesmo arquivo não duplica faltas nem atrasos
+	res, err = ps.Importar(ctx, claims, strings.NewReader(arquivo))
+	if err != nil {
+		t.Fatalf("reimportar erro: %v", err)
+	}
+	if res.FaltasLancadas != 0 || res.AtrasosLancados != 0 {
+		t.Fatalf("reimportação não deveria lançar de novo: %+v", res)
+	}
+	total, _ := repository.GetTotalFaltasByFuncionarioMesAno(funcID, 4, 2025)
+	if total != 4 {
+		t.Fatalf("esperava 4 faltas no mês (3 do mensal mantido e 1 do ponto), obtido %d", total)
+	}
+
+	// conferido o mês, o RH zera o lançamento manual e a folha passa a vir só do ponto
+	if err := repository.SetFaltasMensais(funcID, 4, 2025, 0); err != nil {
+		t.Fatalf("SetFaltasMensais erro: %v", err)
+	}
+
+	folha, err := fs.CriarFolhaSalario(ctx, claims, 4, 2025)
+	if err != nil {
+		t.Fatalf("CriarFolhaSalario erro: %v", err)
+	}
+	rows, err := repository.GetPagamentosByFolhaID(folha.ID)
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("esperava 1 pagamento, got=%d err=%v", len(rows), err)
+	}
+	p := rows[0]
+	// salário-hora 10: 360 min a 50% = 90
+	if p.HorasExtras < 89.99 || p.HorasExtras > 90.01 {
+		t.Fatalf("horasExtras esperado ~90, veio %.2f", p.HorasExtras)
+	}
+	// 2200 + 90 - 78.33 (falta + atraso) - 73.33 (DSR do domingo 13/04)
+	if p.ValorFinal < 2138.32 || p.ValorFinal > 2138.36 {
+		t.Fatalf("valorFinal esperado ~2138.34, veio %.2f", p.ValorFinal)
+	}
+}
+
+func TestPonto_ImportarAFD_RefazFaltasDoPontoEMantemAsManuais(t *testing.T) {
+	if err := truncateAll(); err != nil {
+		t.Fatalf("truncateAll inicio: %v", err)
+	}
+	t.Cleanup(func() { _ = truncateAll() })
+
+	lr := &folhaFakeLogRepo{}
+	ps := newPontoServiceWithDB(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 505, Perfil: "admin"}
+
+	funcID := seedPessoaFuncionarioBase(t, "Funcionario Reimportado")
+	f, _ := repository.GetFuncionarioByID(funcID)
+	f.PIS = "123.45678.90-1"
+	if err := repository.UpdateFuncionario(f); err != nil {
+		t.Fatalf("UpdateFuncionario erro: %v", err)
+	}
+	// falta injustificada lançada à mão em 11/04: não tira a jornada do dia
+	manual := entity.NewAusencia(entity.FaltaTipoInjustificada, time.Date(2025, 4, 11, 0, 0, 0, 0, time.Local), 1, funcID)
+	if err := repository.CreateFalta(manual); err != nil {
+		t.Fatalf("CreateFalta erro: %v", err)
+	}
+
+	marcacoes := map[string][]string{
+		"07042025": {"08:00", "12:00", "13:00", "17:48"},
+		"08042025": {"08:00", "12:00", "13:00", "17:48"},
+		// 09/04 ainda sem marcação: falta
+		"10042025": {"08:00", "12:00", "13:00", "17:48"},
+		"11042025": {"08:00", "12:00", "13:00", "17:48"},
+	}
+	res, err := ps.Importar(ctx, claims, strings.NewReader(afdPortaria1510("12345678901", marcacoes)))
+	if err != nil {
+		t.Fatalf("Importar erro: %v", err)
+	}
+	if res.FaltasLancadas != 1 || res.AtrasosLancados != 0 {
+		t.Fatalf("esperava só a falta de 09/04: %+v", res)
+	}
+	if d, _ := repository.GetPontoDia(funcID, time.Date(2025, 4, 11, 0, 0, 0, 0, time.Local)); d == nil || d.MinutosExtra != 0 || d.Falta {
+		t.Fatalf("dia com falta lançada à mão deveria manter a jornada prevista: %+v", d)
+	}
+
+	// o AFD acumulado traz 09/04 com 30 min de atraso: a falta do ponto vira atraso
+	marcacoes["09042025"] = []string{"08:30", "12:00", "13:00", "17:48"}
+	acumulado := afdPortaria1510("12345678901", marcacoes)
+	res, err = ps.Importar(ctx, claims, strings.NewReader(acumulado))
+	if err != nil {
+		t.Fatalf("reimportar erro: %v", err)
+	}
+	if res.FaltasLancadas != 0 || res.AtrasosLancados != 1 {
+		t.Fatalf("esperava só o atraso de 09/04: %+v", res)
+	}
+	faltas, err := repository.GetFaltasByFuncionarioPeriodo(funcID,
+		time.Date(2025, 4, 1, 0, 0, 0, 0, time.Local), time.Date(2025, 4, 30, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("GetFaltasByFuncionarioPeriodo erro: %v", err)
+	}
+	porTipo := map[string]int{}
+	for _, fa := range faltas {
+		porTipo[fa.Tipo+"/"+fa.Origem]++
+	}
+	if len(faltas) != 2 || porTipo[entity.FaltaTipoInjustificada+"/"+entity.FaltaOrigemManual] != 1 ||
+		porTipo[entity.FaltaTipoAtraso+"/"+entity.FaltaOrigemPonto] != 1 {
+		t.Fatalf("esperava a falta manual e o atraso do ponto, veio %v", porTipo)
+	}
+	if d, _ := repository.GetPontoDia(funcID, time.Date(2025, 4, 9, 0, 0, 0, 0, time.Local)); d == nil || d.Falta || d.MinutosAtraso != 30 {
+		t.Fatalf("09/04 deveria ter 30 min de atraso e não falta: %+v", d)
+	}
+
+	// o mesmo arquivo de novo não muda nada
+	res, err = ps.Importar(ctx, claims, strings.NewReader(acumulado))
+	if err != nil {
+		t.Fatalf("reimportar erro: %v", err)
+	}
+	if res.FaltasLancadas != 0 || res.AtrasosLancados != 0 {
+		t.Fatalf("reimportação não deveria lançar de novo: %+v", res)
+	}
+}
+
+func TestPonto_ImportarAFD_ZeraMensalSoComMesInteiro(t *testing.T) {
+	if err := truncateAll(); err != nil {
+		t.Fatalf("truncateAll inicio: %v", err)
+	}
+	t.Cleanup(func() { _ = truncateAll() })
+
+	lr := &folhaFakeLogRepo{}
+	ps := newPontoServiceWithDB(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 505, Perfil: "admin"}
+
+	// vínculo de 07/04 a 12/04: o arquivo cobre todo o mês dentro do vínculo
+	funcID := seedPessoaFuncionarioBase(t, "Funcionario Temporario")
+	seedFaltasMes(t, funcID, 4, 2025, 2)
+	if _, err := repository.DB.Exec(`UPDATE funcionario SET pis = ?, admissao = '2025-04-07', demissao = '2025-04-12' WHERE funcionarioID = ?`,
+		"12345678901", funcID); err != nil {
+		t.Fatalf("seed vínculo erro: %v", err)
+	}
+
+	arquivo := afdPortaria1510("12345678901", map[string][]string{
+		"07042025": {"08:00", "12:00", "13:00", "17:48"},
+		"12042025": {"08:00", "12:00"},
+	})
+	res, err := ps.Importar(ctx, claims, strings.NewReader(arquivo))
+	if err != nil {
+		t.Fatalf("Importar erro: %v", err)
+	}
+	if len(res.Erros) != 0 {
+		t.Fatalf("não deveria haver aviso com o mês coberto: %+v", res.Erros)
+	}
+	// 08 a 11/04 sem marcação viram faltas; o mensal é zerado
+	total, _ := repository.GetTotalFaltasByFuncionarioMesAno(funcID, 4, 2025)
+	if mensais, _ := repository.GetFaltasMensais(funcID, 4, 2025); mensais != 0 || total != 4 {
+		t.Fatalf("esperava mensal zerado e 4 faltas do ponto, veio mensal=%d total=%d", mensais, total)
+	}
+}
+
+func TestPonto_ImportarAFD_TurnoNoturno(t *testing.T) {
+	if err := truncateAll(); err != nil {
+		t.Fatalf("truncateAll inicio: %v", err)
+	}
+	t.Cleanup(func() { _ = truncateAll() })
+
+	lr := &folhaFakeLogRepo{}
+	ps := newPontoServiceWithDB(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 505, Perfil: "admin"}
+
+	funcID := seedPessoaFuncionarioBase(t, "Vigia Noturno")
+	if _, err := repository.DB.Exec(`UPDATE funcionario SET pis = ? WHERE funcionarioID = ?`, "12345678901", funcID); err != nil {
+		t.Fatalf("seed PIS erro: %v", err)
+	}
+
+	// 22:00 às 06:00 de segunda a sexta
+	j := entity.NewJornada("Noturno", entity.JornadaTipoPersonalizada, 7, false)
+	for d := time.Monday; d <= time.Friday; d++ {
+		j.Dias = append(j.Dias, entity.DiaJornada{Dia: int(d), Periodos: []entity.PeriodoJornada{{Inicio: "22:00", Fim: "06:00"}}})
+	}
+	if err := repository.CreateJornada(j); err != nil {
+		t.Fatalf("CreateJornada erro: %v", err)
+	}
+	a := entity.NewFuncionarioJornada(funcID, j.ID, time.Date(2025, time.April, 1, 0, 0, 0, 0, time.Local))
+	if err := repository.CreateFuncionarioJornada(a); err != nil {
+		t.Fatalf("CreateFuncionarioJornada erro: %v", err)
+	}
+
+	// só a entrada de segunda: turno em aberto até chegar a saída
+	if _, err := ps.Importar(ctx, claims, strings.NewReader(afdPortaria1510("12345678901", map[string][]string{
+		"07042025": {"22:00"},
+	}))); err != nil {
+		t.Fatalf("Importar (segunda) erro: %v", err)
+	}
+	if d, _ := repository.GetPontoDia(funcID, time.Date(2025, time.April, 7, 0, 0, 0, 0, time.Local)); d == nil || !d.Inconsistente {
+		t.Fatalf("segunda deveria ficar inconsistente até a saída: %+v", d)
+	}
+
+	// o arquivo seguinte começa na terça, mas a saída das 06:00 fecha o turno de segunda
+	res, err := ps.Importar(ctx, claims, strings.NewReader(afdPortaria1510("12345678901", map[string][]string{
+		"08042025": {"06:00", "22:30"}, // fecha segunda; terça entra 30 min atrasada
+		"09042025": {"06:00"},          // fecha terça; quarta sem marcação: falta
+		"10042025": {"22:00"},
+		"11042025": {"08:00", "22:00"}, // fecha quinta com 2h extras
+		"12042025": {"06:00"},          // fecha sexta; sábado sem jornada
+	})))
+	if err != nil {
+		t.Fatalf("Importar erro: %v", err)
+	}
+	if res.Dias != 5 || res.FaltasLancadas != 1 || res.AtrasosLancados != 1 {
+		t.Fatalf("resultado inesperado: %+v", res)
+	}
+
+	dias, err := ps.ListarMes(ctx, claims, funcID, 4, 2025)
+	if err != nil || len(dias) != 5 {
+		t.Fatalf("ListarMes: len=%d err=%v", len(dias), err)
+	}
+	porDia := map[int]*entity.PontoDia{}
+	for _, d := range dias {
+		porDia[d.Data.Day()] = d
+	}
+	if d := porDia[7]; d.Inconsistente || d.MinutosTrabalhados != 480 || d.MinutosAtraso != 0 || d.MinutosExtra != 0 ||
+		strings.Join(d.Marcacoes, ",") != "22:00,06:00+1" {
+		t.Fatalf("segunda deveria fechar com a saída de terça: %+v", d)
+	}
+	if d := porDia[8]; d.MinutosTrabalhados != 450 || d.MinutosAtraso != 30 {
+		t.Fatalf("terça deveria ter 30 min de atraso: %+v", d)
+	}
+	if !porDia[9].Falta {
+		t.Fatalf("quarta sem marcação deveria ser falta: %+v", porDia[9])
+	}
+	if d := porDia[10]; d.MinutosTrabalhados != 600 || d.MinutosExtra != 120 {
+		t.Fatalf("quinta deveria ter 2h extras: %+v", d)
+	}
+	if d := porDia[11]; d.MinutosTrabalhados != 480 || d.Inconsistente || porDia[12] != nil {
+		t.Fatalf("sexta deveria fechar com a saída de sábado: sexta=%+v sábado=%+v", d, porDia[12])
+	}
+}
@@ -0,0 +1,191 @@
+package testes
+
+import (
+	Adapter "AutoGRH/pkg/adapter"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"AutoGRH/pkg/entity"
+	"AutoGRH/pkg/repository"
+	"AutoGRH/pkg/service"
+)
+
+func newRegraAusenciaServiceWithDB(lr *fdFakeLogRepo) *service.RegraAusenciaService {
+	auth := newAdminAuthFD(lr)
+	repo := Adapter.NewRegraAusenciaRepositoryAdapter(
+		repository.CreateRegraAusencia,
+		repository.GetRegraAusenciaByID,
+		repository.UpdateRegraAusencia,
+		repository.DeleteRegraAusencia,
+		repository.ListRegrasAusencia,
+	)
+	return service.NewRegraAusenciaService(auth, lr, repo)
+}
+
+func TestRegraAusencia_BloqueioAvisoECalendario(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &fdFakeLogRepo{}
+	fsvc := newFeriasServiceWithDB(lr)
+	dsvc := newDescansoServiceWithDB(lr)
+	rsvc := newRegraAusenciaServiceWithDB(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 91, Perfil: "admin"}
+
+	// Dois analistas (cargo do seed) com férias disponíveis
+	func1 := seedPessoaFuncionarioFD(t)
+	func2 := seedPessoaFuncionarioFD(t)
+	f1, err := fsvc.CriarFerias(ctx, claims, func1, 30, 3000, time.Now().AddDate(0, -1, 0))
+	if err != nil {
+		t.Fatalf("CriarFerias func1 erro: %v", err)
+	}
+	f2, err := fsvc.CriarFerias(ctx, claims, func2, 30, 3000, time.Now().AddDate(0, -1, 0))
+	if err != nil {
+		t.Fatalf("CriarFerias func2 erro: %v", err)
+	}
+
+	// No máximo 1 analista ausente por vez, bloqueando
+	regra := entity.NewRegraAusencia("cargo", "analista", 1, "bloqueio")
+	if err := rsvc.Criar(ctx, claims, regra); err != nil {
+		t.Fatalf("Criar regra erro: %v", err)
+	}
+
+	base := time.Now().AddDate(0, 1, 0)
+	base = time.Date(base.Year(), base.Month(), base.Day(), 0, 0, 0, 0, time.Local)
+
+	d1 := entity.NewDescanso(base, base.AddDate(0, 0, 9), f1.ID)
+	if err := dsvc.CreateDescanso(ctx, claims, d1); err != nil {
+		t.Fatalf("primeiro descanso deveria ser aceito: %v", err)
+	}
+
+	// Sobrepõe 5 dias → bloqueado
+	d2 := entity.NewDescanso(base.AddDate(0, 0, 5), base.AddDate(0, 0, 14), f2.ID)
+	if err := dsvc.CreateDescanso(ctx, claims, d2); err == nil {
+		t.Fatalf("esperava bloqueio pelo limite de ausências")
+	}
+
+	// Em modo AVISO o descanso é criado e os conflitos retornados
+	regra.Modo = entity.RegraAusenciaModoAviso
+	if err := rsvc.Atualizar(ctx, claims, regra); err != nil {
+		t.Fatalf("Atualizar regra erro: %v", err)
+	}
+	d2 = entity.NewDescanso(base.AddDate(0, 0, 5), base.AddDate(0, 0, 14), f2.ID)
+	if err := dsvc.CreateDescanso(ctx, claims, d2); err != nil {
+		t.Fatalf("em modo AVISO o descanso deveria ser criado: %v", err)
+	}
+	if len(d2.Conflitos) != 1 || d2.Conflitos[0].Ausentes != 2 {
+		t.Fatalf("esperava 1 conflito com 2 ausentes, veio %+v", d2.Conflitos)
+	}
+	if got := d2.Conflitos[0].Fim.Format("2006-01-02"); got != base.AddDate(0, 0, 9).Format("2006-01-02") {
+		t.Fatalf("conflito deveria terminar com o primeiro descanso, veio %s", got)
+	}
+	// o aviso é gravado e o log de auditoria do descanso registra o conflito
+	var avisos int
+	_ = repository.DB.QueryRow(`SELECT COUNT(*) FROM aviso WHERE tipo = 'CONFLITO_AUSENCIA' AND referenciaID = ?`, d2.ID).Scan(&avisos)
+	if avisos != 1 {
+		t.Fatalf("esperava 1 aviso de conflito para o descanso %d, veio %d", d2.ID, avisos)
+	}
+	if !hasLogPrefix(lr.entries, 3, claims.UserID, fmt.Sprintf("Descanso criado ID=%d ", d2.ID)) ||
+		!strings.HasSuffix(lr.entries[len(lr.entries)-1].Detalhe, " Conflitos=1") {
+		t.Fatalf("log de auditoria deveria registrar o conflito: %+v", lr.entries[len(lr.entries)-1])
+	}
+
+	// Calendário traz os dois descansos (pendentes) com cargo, e o intervalo em conflito
+	cal, err := fsvc.CalendarioFerias(ctx, claims, base, base.AddDate(0, 0, 30))
+	if err != nil {
+		t.Fatalf("CalendarioFerias erro: %v", err)
+	}
+	if len(cal.Descansos) != 2 {
+		t.Fatalf("esperava 2 descansos no calendário, veio %d", len(cal.Descansos))
+	}
+	for _, d := range cal.Descansos {
+		if d.Cargo != "Analista" || d.Nome == "" {
+			t.Fatalf("descanso do calendário sem funcionário/cargo: %+v", d)
+		}
+	}
+	if len(cal.Conflitos) != 1 {
+		t.Fatalf("esperava 1 conflito no calendário, veio %+v", cal.Conflitos)
+	}
+}
+
+func TestRegraAusencia_DepartamentoPelaAlocacaoVigente(t *testing.T) {
+	defer func() { _ = truncateAll() }()
+
+	lr := &fdFakeLogRepo{}
+	fsvc := newFeriasServiceWithDB(lr)
+	dsvc := newDescansoServiceWithDB(lr)
+	rsvc := newRegraAusenciaServiceWithDB(lr)
+	ctx := context.Background()
+	claims := service.Claims{UserID: 91, Perfil: "admin"}
+
+	cc := entity.NewCentroCusto("CC-SUP", "Suporte")
+	if err := repository.CreateCentroCusto(cc); err != nil {
+		t.Fatalf("CreateCentroCusto erro: %v", err)
+	}
+	suporte := entity.NewDepartamento("Suporte", &cc.ID)
+	vendas := entity.NewDepartamento("Vendas", &cc.ID)
+	for _, d := range []*entity.Departamento{suporte, vendas} {
+		if err := repository.CreateDepartamento(d); err != nil {
+			t.Fatalf("CreateDepartamento erro: %v", err)
+		}
+	}
+
+	if err := rsvc.Criar(ctx, claims, entity.NewRegraAusencia("departamento", "Financeiro", 1, "bloqueio")); err == nil {
+		t.Fatalf("regra para departamento inexistente deveria ser recusada")
+	}
+	if err := rsvc.Criar(ctx, claims, entity.NewRegraAusencia("departamento", "suporte", 1, "bloqueio")); err != nil {
+		t.Fatalf("Criar regra erro: %v", err)
+	}
+
+	base := time.Now().AddDate(0, 1, 0)
+	base = time.Date(base.Year(), base.Month(), base.Day(), 0, 0, 0, 0, time.Local)
+
+	// os dois no Suporte; o segundo passa para Vendas a partir de base+7
+	func1 := seedPessoaFuncionarioFD(t)
+	func2 := seedPessoaFuncionarioFD(t)
+	alocar := func(funcID, depID int64, inicio time.Time) {
+		a := &entity.AlocacaoFuncionario{DepartamentoID: depID, CentroCustoID: cc.ID, Percentual: 100}
+		if err := repository.SaveAlocacoes(funcID, inicio, []*entity.AlocacaoFuncionario{a}); err != nil {
+			t.Fatalf("SaveAlocacoes erro: %v", err)
+		}
+	}
+	alocar(func1, suporte.ID, base.AddDate(0, -2, 0))
+	alocar(func2, suporte.ID, base.AddDate(0, -2, 0))
+	alocar(func2, vendas.ID, base.AddDate(0, 0, 7))
+
+	f1, err := fsvc.CriarFerias(ctx, claims, func1, 30, 3000, time.Now().AddDate(0, -1, 0))
+	if err != nil {
+		t.Fatalf("CriarFerias func1 erro: %v", err)
+	}
+	f2, err := fsvc.CriarFerias(ctx, claims, func2, 30, 3000, time.Now().AddDate(0, -1, 0))
+	if err != nil {
+		t.Fatalf("CriarFerias func2 erro: %v", err)
+	}
+
+	if err := dsvc.CreateDescanso(ctx, claims, entity.NewDescanso(base, base.AddDate(0, 0, 9), f1.ID)); err != nil {
+		t.Fatalf("primeiro descanso deveria ser aceito: %v", err)
+	}
+	// ainda no Suporte entre base+5 e base+6 → bloqueado
+	if err := dsvc.CreateDescanso(ctx, claims, entity.NewDescanso(base.AddDate(0, 0, 5), base.AddDate(0, 0, 6), f2.ID)); err == nil {
+		t.Fatalf("esperava bloqueio pelo limite do departamento")
+	}
+	// a partir de base+7 já está em Vendas → aceito mesmo sobrepondo o primeiro descanso
+	d2 := entity.NewDescanso(base.AddDate(0, 0, 7), base.AddDate(0, 0, 14), f2.ID)
+	if err := dsvc.CreateDescanso(ctx, claims, d2); err != nil {
+		t.Fatalf("descanso após a mudança de departamento deveria ser aceito: %v", err)
+	}
+	if len(d2.Conflitos) != 0 {
+		t.Fatalf("não deveria haver conflito após a mudança de departamento, veio %+v", d2.Conflitos)
+	}
+
+	cal, err := fsvc.CalendarioFerias(ctx, claims, base, base.AddDate(0, 0, 30))
+	if err != nil {
+		t.Fatalf("CalendarioFerias erro: %v", err)
+	}
+	if len(cal.Descansos) != 2 || len(cal.Conflitos) != 0 {
+		t.Fatalf("esperava 2 descansos e nenhum conflito no calendário, veio %d descansos e %+v", len(cal.Descansos), cal.Conflitos)
+	}
+}
@@ -80,6 +80,7 @@ func newValeServiceWithDB(lr *valeFakeLogRepo) *service.ValeService {
 		repository.ListValesPendentes,
 		repository.ListValesAprovadosNaoPagos,
 		repository.ListAllVales,
+		repository.BuscarVales,
 	)
 	return service.NewValeService(adp, newAdminAuthVale(lr), lr)
 }